@@ -85,6 +85,24 @@ type config struct {
 
 	ProbeTimeout   string `split_words:"true" default:"300ms"`
 	ProbeFrequency string `split_words:"true" default:"200ms"`
+
+	// CertificateTrustOverlap is how long the CAs removed from the
+	// system-internal-tls trust bundles are still trusted, so that their
+	// rotation doesn't break the connections to the queue proxies. Zero
+	// stops trusting them right away.
+	CertificateTrustOverlap string `split_words:"true" default:"0s"`
+
+	// These cap the requests buffered while waiting for capacity, per revision
+	// and per namespace, in number and in body bytes. Zero means no limit.
+	// The requests of unknown length are exempt from the byte caps.
+	BufferRevisionMaxRequests  int   `split_words:"true"`
+	BufferRevisionMaxBytes     int64 `split_words:"true"`
+	BufferNamespaceMaxRequests int   `split_words:"true"`
+	BufferNamespaceMaxBytes    int64 `split_words:"true"`
+	// BufferOverflowResponse is the response to requests over the buffer
+	// limits: 503, 429 or redirect, to BufferOverflowRedirectURL.
+	BufferOverflowResponse    string `split_words:"true" default:"503"`
+	BufferOverflowRedirectURL string `split_words:"true"`
 }
 
 func main() {
@@ -185,7 +203,11 @@ func main() {
 	// See also https://github.com/knative/serving/issues/12808.
 	if tlsEnabled {
 		logger.Info("Knative system-internal-tls is enabled")
-		certCache, err = certificate.NewCertCache(ctx)
+		trustOverlap, err := time.ParseDuration(env.CertificateTrustOverlap)
+		if err != nil {
+			logger.Fatalw("Failed to parse CERTIFICATE_TRUST_OVERLAP", zap.String("value", env.CertificateTrustOverlap), zap.Error(err))
+		}
+		certCache, err = certificate.NewCertCache(ctx, trustOverlap, mp)
 		if err != nil {
 			logger.Fatalw("Failed to create certificate cache", zap.Error(err))
 		}
@@ -226,9 +248,21 @@ func main() {
 	concurrencyReporter := activatorhandler.NewConcurrencyReporter(ctx, env.PodName, statCh, mp)
 	go concurrencyReporter.Run(ctx.Done())
 
+	overflow, err := activatorhandler.ParseOverflowPolicy(env.BufferOverflowResponse, env.BufferOverflowRedirectURL)
+	if err != nil {
+		logger.Fatalw("Failed to parse BUFFER_OVERFLOW_RESPONSE", zap.String("value", env.BufferOverflowResponse), zap.Error(err))
+	}
+	bufferLimits := activatorhandler.BufferLimits{
+		RevisionRequests:  env.BufferRevisionMaxRequests,
+		RevisionBytes:     env.BufferRevisionMaxBytes,
+		NamespaceRequests: env.BufferNamespaceMaxRequests,
+		NamespaceBytes:    env.BufferNamespaceMaxBytes,
+		Overflow:          overflow,
+	}
+
 	// Create activation handler chain
 	// Note: innermost handlers are specified first, ie. the last handler in the chain will be executed first
-	ah := activatorhandler.New(ctx, throttler, transport, networkConfig.EnableMeshPodAddressability, logger, tlsEnabled, tp, mp)
+	ah := activatorhandler.New(ctx, throttler, transport, networkConfig.EnableMeshPodAddressability, logger, tlsEnabled, tp, mp, bufferLimits)
 	ah = handler.NewTimeoutHandler(ah, "activator request timeout", func(r *http.Request) (time.Duration, time.Duration, time.Duration) {
 		if rev := activatorhandler.RevisionFrom(r.Context()); rev != nil {
 			responseStartTimeout := 0 * time.Second
@@ -260,6 +294,10 @@ func main() {
 	// We need the context handler to run first so ctx gets the revision info.
 	ah = activatorhandler.WrapActivatorHandlerWithFullDuplex(ah, logger)
 	ah = activatorhandler.NewContextHandler(ctx, ah, configStore)
+	// The revision of the requests split by the activator is picked first.
+	ah = activatorhandler.NewSplitHandler(ah)
+	// The mirrored requests go through the whole chain, as requests to their revision.
+	ah = activatorhandler.NewMirrorHandler(ah, logger)
 
 	ah = otelhttp.NewHandler(ah, "handle",
 		otelhttp.WithTracerProvider(tp),
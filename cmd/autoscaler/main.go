@@ -41,6 +41,7 @@ import (
 	filteredinformerfactory "knative.dev/pkg/client/injection/kube/informers/factory/filtered"
 	configmap "knative.dev/pkg/configmap/informer"
 	"knative.dev/pkg/controller"
+	"knative.dev/pkg/hash"
 	"knative.dev/pkg/injection"
 	"knative.dev/pkg/injection/sharedmain"
 	"knative.dev/pkg/leaderelection"
@@ -52,6 +53,7 @@ import (
 	autoscalingv1alpha1 "knative.dev/serving/pkg/apis/autoscaling/v1alpha1"
 	"knative.dev/serving/pkg/apis/serving"
 	"knative.dev/serving/pkg/autoscaler/bucket"
+	"knative.dev/serving/pkg/autoscaler/checkpoint"
 	asmetrics "knative.dev/serving/pkg/autoscaler/metrics"
 	"knative.dev/serving/pkg/autoscaler/scaling"
 	"knative.dev/serving/pkg/autoscaler/statforwarder"
@@ -178,10 +180,14 @@ func main() {
 	// since they will be sharing an elector
 	var electorCtx context.Context
 
-	var f *statforwarder.Forwarder
-	if b, bs, err := leaderelection.NewStatefulSetBucketAndSet(int(cc.Buckets)); err == nil {
+	var (
+		f  *statforwarder.Forwarder
+		bs *hash.BucketSet
+	)
+	if b, sbs, err := leaderelection.NewStatefulSetBucketAndSet(int(cc.Buckets)); err == nil {
 		logger.Info("Running with StatefulSet leader election")
 		electorCtx = leaderelection.WithStatefulSetElectorBuilder(ctx, cc, b)
+		bs = sbs
 		f = statforwarder.New(ctx, bs)
 		if err := statforwarder.StatefulSetBasedProcessor(ctx, f, accept); err != nil {
 			logger.Fatalw("Failed to set up statefulset processors", zap.Error(err))
@@ -189,7 +195,8 @@ func main() {
 	} else {
 		logger.Info("Running with Standard leader election")
 		electorCtx = leaderelection.WithStandardLeaderElectorBuilder(ctx, kubeClient, cc)
-		f = statforwarder.New(ctx, bucket.AutoscalerBucketSet(cc.Buckets))
+		bs = bucket.AutoscalerBucketSet(cc.Buckets)
+		f = statforwarder.New(ctx, bs)
 		if err := statforwarder.LeaseBasedProcessor(ctx, f, accept); err != nil {
 			logger.Fatalw("Failed to set up lease tracking", zap.Error(err))
 		}
@@ -200,6 +207,11 @@ func main() {
 		logger.Fatalw("Failed to setup elector", zap.Error(err))
 	}
 
+	// Checkpoint the metric history and scaling state of the owned buckets,
+	// so that their next owner resumes with warm windows.
+	checkpointer := checkpoint.New(logger, checkpoint.NewConfigMapStore(kubeClient, system.Namespace()),
+		bs, f.IsBucketOwner, collector, multiScaler)
+
 	// Set up a statserver.
 	statsServer := statserver.New(statsServerAddr, statsCh, logger, f.IsBucketOwner)
 	defer f.Cancel()
@@ -219,6 +231,10 @@ func main() {
 		elector.Run(egCtx)
 		return nil
 	})
+	eg.Go(func() error {
+		checkpointer.Run(egCtx)
+		return nil
+	})
 	eg.Go(statsServer.ListenAndServe)
 	eg.Go(pprof.ListenAndServe)
 	eg.Go(func() error {
@@ -286,7 +302,7 @@ func statsScraperFactoryFunc(podLister corev1listers.PodLister, usePassthroughLb
 		}
 
 		podAccessor := resources.NewPodAccessor(podLister, metric.Namespace, revisionName)
-		return asmetrics.NewStatsScraper(metric, revisionName, podAccessor, usePassthroughLb, meshMode, logger, mp), nil
+		return asmetrics.NewStatsScraper(metric, revisionName, podAccessor, usePassthroughLb, meshMode, logger, mp)
 	}
 }
 
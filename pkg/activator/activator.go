@@ -23,6 +23,28 @@ const (
 	RevisionHeaderName = "Knative-Serving-Revision"
 	// RevisionHeaderNamespace is the header key for revision's namespace.
 	RevisionHeaderNamespace = "Knative-Serving-Namespace"
+	// MirrorHeaderName is the header key for the name of the revision the
+	// request is mirrored to.
+	MirrorHeaderName = "Knative-Serving-Mirror"
+	// MirrorPercentHeaderName is the header key for the percentage of the
+	// requests mirrored.
+	MirrorPercentHeaderName = "Knative-Serving-Mirror-Percent"
+	// SplitHeaderName is the header key for the traffic split the activator
+	// assigns the request to a revision of, as `<revision>=<percent>,...`.
+	SplitHeaderName = "Knative-Serving-Split"
+	// SplitAffinityHeaderName is the header key for the part of the request
+	// keying its assignment to a revision of the split, e.g. `cookie:<name>`.
+	SplitAffinityHeaderName = "Knative-Serving-Split-Affinity"
+	// SplitMatchHeaderName is the header key for the cookie rules the
+	// activator routes the request by ahead of the split, as
+	// `<revision>=<cookie>=<value>,...`.
+	SplitMatchHeaderName = "Knative-Serving-Split-Match"
+	// UnsetHeaderValue is the value the ingress sets the headers the
+	// activator acts on to when the Route doesn't use them, overwriting the
+	// values sent by the clients. It is not a valid revision name or split.
+	// It is also the revision of the requests the ingress routes through the
+	// activator for it to pick their revision of the split.
+	UnsetHeaderValue = "-"
 )
 
 // RevisionHeaders are the headers the activator uses to identify the
@@ -17,19 +17,23 @@ limitations under the License.
 package certificate
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
 	"sync"
+	"time"
 
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
 	v1 "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/clock"
 	"knative.dev/networking/pkg/apis/networking"
 	"knative.dev/pkg/reconciler"
 
@@ -47,6 +51,19 @@ type CertCache struct {
 	secretInformer    v1.SecretInformer
 	configmapInformer v1.ConfigMapInformer
 	logger            *zap.SugaredLogger
+	clock             clock.WithDelayedExecution
+	metrics           *cacheMetrics
+
+	// trustOverlap is how long the CAs removed from the trust bundles are
+	// still trusted, so that the connections to the queue proxies which
+	// haven't picked up their new certificate yet don't break.
+	trustOverlap time.Duration
+	// trustedCAs are the CAs of the trust bundles, keyed by their DER bytes.
+	trustedCAs map[string]*x509.Certificate
+	// retiredCAs are the CAs removed from the trust bundles which are still
+	// trusted during trustOverlap, keyed by their DER bytes.
+	retiredCAs map[string]retiredCA
+	trustMux   sync.Mutex
 
 	certificate *tls.Certificate
 	TLSConf     tls.Config
@@ -54,8 +71,14 @@ type CertCache struct {
 	certificatesMux sync.RWMutex
 }
 
+type retiredCA struct {
+	ca    *x509.Certificate
+	until time.Time
+}
+
 // NewCertCache creates and starts the certificate cache that watches Activators certificate.
-func NewCertCache(ctx context.Context) (*CertCache, error) {
+// The CAs removed from the trust bundles are still trusted for trustOverlap.
+func NewCertCache(ctx context.Context, trustOverlap time.Duration, mp metric.MeterProvider) (*CertCache, error) {
 	nsSecretInformer := nssecretinformer.Get(ctx)
 	nsConfigmapInformer := nsconfigmapinformer.Get(ctx)
 
@@ -63,7 +86,10 @@ func NewCertCache(ctx context.Context) (*CertCache, error) {
 		secretInformer:    nsSecretInformer,
 		configmapInformer: nsConfigmapInformer,
 		logger:            logging.FromContext(ctx),
+		clock:             clock.RealClock{},
+		trustOverlap:      trustOverlap,
 	}
+	cr.metrics = newCacheMetrics(mp, cr.notAfter)
 
 	secret, err := cr.secretInformer.Lister().Secrets(system.Namespace()).Get(netcfg.ServingRoutingCertName)
 	if err != nil {
@@ -115,16 +141,64 @@ func (cr *CertCache) updateCertificate(secret *corev1.Secret) {
 		cr.logger.Warnf("failed to parse certificate in secret %s/%s: %v", secret.Namespace, secret.Name, zap.Error(err))
 		return
 	}
+	if cr.certificate != nil && !bytes.Equal(cr.certificate.Certificate[0], cert.Certificate[0]) {
+		cr.logger.Infof("Certificate in secret %s/%s was rotated", secret.Namespace, secret.Name)
+		cr.metrics.recordRotation()
+	}
 	cr.certificate = &cert
 }
 
+// notAfter returns the expiry time of the cached certificate.
+func (cr *CertCache) notAfter() (time.Time, bool) {
+	cr.certificatesMux.RLock()
+	defer cr.certificatesMux.RUnlock()
+	if cr.certificate == nil {
+		return time.Time{}, false
+	}
+	leaf, err := x509.ParseCertificate(cr.certificate.Certificate[0])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return leaf.NotAfter, true
+}
+
 // CA can optionally be in `ca.crt` in the `routing-serving-certs` secret
 // and/or configured using a trust-bundle via ConfigMap that has the defined label `knative-ca-trust-bundle`.
 func (cr *CertCache) updateTrustPool() {
-	pool := x509.NewCertPool()
+	cr.trustMux.Lock()
+	defer cr.trustMux.Unlock()
 
-	cr.addSecretCAIfPresent(pool)
-	cr.addTrustBundles(pool)
+	cas := make(map[string]*x509.Certificate)
+	cr.addSecretCAIfPresent(cas)
+	cr.addTrustBundles(cas)
+
+	// Keep trusting the CAs removed from the trust bundles during the
+	// overlap window, while the certificates they issued are being rotated.
+	now := cr.clock.Now()
+	if cr.retiredCAs == nil {
+		cr.retiredCAs = make(map[string]retiredCA)
+	}
+	for key, ca := range cr.trustedCAs {
+		if _, ok := cas[key]; !ok && cr.trustOverlap > 0 {
+			cr.logger.Infof("CA %q was removed from the trust bundles and is trusted until %s",
+				ca.Subject, now.Add(cr.trustOverlap).Format(time.RFC3339))
+			cr.retiredCAs[key] = retiredCA{ca: ca, until: now.Add(cr.trustOverlap)}
+			cr.clock.AfterFunc(cr.trustOverlap, cr.updateTrustPool)
+		}
+	}
+	cr.trustedCAs = cas
+
+	pool := x509.NewCertPool()
+	for _, ca := range cas {
+		pool.AddCert(ca)
+	}
+	for key, retired := range cr.retiredCAs {
+		if _, ok := cas[key]; ok || !now.Before(retired.until) {
+			delete(cr.retiredCAs, key)
+			continue
+		}
+		pool.AddCert(retired.ca)
+	}
 
 	// Use the trust pool in upstream TLS context
 	cr.certificatesMux.Lock()
@@ -135,25 +209,22 @@ func (cr *CertCache) updateTrustPool() {
 	cr.TLSConf.MinVersion = tls.VersionTLS13
 }
 
-func (cr *CertCache) addSecretCAIfPresent(pool *x509.CertPool) {
+func (cr *CertCache) addSecretCAIfPresent(cas map[string]*x509.Certificate) {
 	secret, err := cr.secretInformer.Lister().Secrets(system.Namespace()).Get(netcfg.ServingRoutingCertName)
 	if err != nil {
 		cr.logger.Warnf("Failed to get secret %s/%s: %v", system.Namespace(), netcfg.ServingRoutingCertName, zap.Error(err))
 		return
 	}
 	if len(secret.Data[certificates.CaCertName]) > 0 {
-		block, _ := pem.Decode(secret.Data[certificates.CaCertName])
-		ca, err := x509.ParseCertificate(block.Bytes)
-		if err != nil {
-			cr.logger.Warnf("CA from Secret %s/%s[%s] is invalid and will be ignored: %v",
-				system.Namespace(), netcfg.ServingRoutingCertName, certificates.CaCertName, err)
-		} else {
-			pool.AddCert(ca)
+		// During a CA rotation, the bundle may hold both the old and new CA.
+		if !addCertsFromPEM(cas, secret.Data[certificates.CaCertName]) {
+			cr.logger.Warnf("CA from Secret %s/%s[%s] is invalid and will be ignored",
+				system.Namespace(), netcfg.ServingRoutingCertName, certificates.CaCertName)
 		}
 	}
 }
 
-func (cr *CertCache) addTrustBundles(pool *x509.CertPool) {
+func (cr *CertCache) addTrustBundles(cas map[string]*x509.Certificate) {
 	selector, err := getLabelSelector(networking.TrustBundleLabelKey)
 	if err != nil {
 		cr.logger.Error("Failed to get label selector", zap.Error(err))
@@ -168,7 +239,7 @@ func (cr *CertCache) addTrustBundles(pool *x509.CertPool) {
 
 	for _, cm := range cms {
 		for _, bundle := range cm.Data {
-			ok := pool.AppendCertsFromPEM([]byte(bundle))
+			ok := addCertsFromPEM(cas, []byte(bundle))
 			if !ok {
 				cr.logger.Warnf("Failed to add CA bundle from ConfigMaps %s/%s as it contains invalid certificates. Bundle: %s", system.Namespace(),
 					cm.Name, bundle)
@@ -177,6 +248,29 @@ func (cr *CertCache) addTrustBundles(pool *x509.CertPool) {
 	}
 }
 
+// addCertsFromPEM adds the certificates of the PEM bundle to cas, skipping
+// the invalid ones like x509.CertPool.AppendCertsFromPEM. It returns whether
+// any certificate was added.
+func addCertsFromPEM(cas map[string]*x509.Certificate, bundle []byte) (ok bool) {
+	for len(bundle) > 0 {
+		var block *pem.Block
+		block, bundle = pem.Decode(bundle)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" || len(block.Headers) != 0 {
+			continue
+		}
+		ca, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			continue
+		}
+		cas[string(ca.Raw)] = ca
+		ok = true
+	}
+	return ok
+}
+
 // GetCertificate returns the cached certificates.
 func (cr *CertCache) GetCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
 	return cr.certificate, nil
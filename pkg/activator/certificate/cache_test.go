@@ -24,10 +24,14 @@ import (
 	"testing"
 	"time"
 
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/clock"
+	clocktesting "k8s.io/utils/clock/testing"
 	"knative.dev/networking/pkg/apis/networking"
 	"knative.dev/pkg/reconciler"
 
@@ -38,6 +42,7 @@ import (
 	fakesecretinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/secret/fake"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
+	"knative.dev/pkg/observability/metrics/metricstest"
 	rtesting "knative.dev/pkg/reconciler/testing"
 	"knative.dev/pkg/system"
 )
@@ -156,6 +161,103 @@ func TestReconcile(t *testing.T) {
 	}
 }
 
+func TestTrustOverlap(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	clock := asyncClock{clocktesting.NewFakeClock(time.Now())}
+	cr := fakeCertCache(ctx)
+	cr.clock = clock
+	cr.trustOverlap = time.Minute
+
+	secrets := fakesecretinformer.Get(ctx).Informer().GetIndexer()
+	secrets.Add(secret)
+	cr.updateTrustPool()
+	if !cr.TLSConf.RootCAs.Equal(getPoolWithCerts(secretCA)) {
+		t.Fatal("The initial CA is not trusted")
+	}
+
+	// Rotate the CA, both the old and new CA are trusted during the overlap.
+	rotated := secret.DeepCopy()
+	rotated.Data[certificates.CaCertName] = newCA
+	secrets.Update(rotated)
+	cr.updateTrustPool()
+	if !cr.TLSConf.RootCAs.Equal(getPoolWithCerts(secretCA, newCA)) {
+		t.Fatal("The old and new CA are not trusted during the overlap")
+	}
+
+	// Updates during the overlap keep trusting the old CA.
+	clock.Step(30 * time.Second)
+	cr.updateTrustPool()
+	if !cr.TLSConf.RootCAs.Equal(getPoolWithCerts(secretCA, newCA)) {
+		t.Fatal("The old CA is not trusted during the overlap")
+	}
+
+	// The old CA is no longer trusted after the overlap.
+	clock.Step(30 * time.Second)
+	if err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, 5*time.Second, true, func(context.Context) (bool, error) {
+		cr.certificatesMux.RLock()
+		defer cr.certificatesMux.RUnlock()
+		return cr.TLSConf.RootCAs.Equal(getPoolWithCerts(newCA)), nil
+	}); err != nil {
+		t.Fatal("The old CA is still trusted after the overlap:", err)
+	}
+}
+
+// asyncClock runs the AfterFunc callbacks in their own goroutine like the
+// real clock, as the fake clock runs them while holding its lock.
+type asyncClock struct {
+	*clocktesting.FakeClock
+}
+
+func (c asyncClock) AfterFunc(d time.Duration, f func()) clock.Timer {
+	return c.FakeClock.AfterFunc(d, func() { go f() })
+}
+
+func TestTrustWithoutOverlap(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	cr := fakeCertCache(ctx)
+
+	secrets := fakesecretinformer.Get(ctx).Informer().GetIndexer()
+	secrets.Add(secret)
+	cr.updateTrustPool()
+
+	rotated := secret.DeepCopy()
+	rotated.Data[certificates.CaCertName] = newCA
+	secrets.Update(rotated)
+	cr.updateTrustPool()
+	if !cr.TLSConf.RootCAs.Equal(getPoolWithCerts(newCA)) {
+		t.Fatal("The old CA is still trusted without overlap")
+	}
+}
+
+func TestCertificateRotationMetrics(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+	reader := metric.NewManualReader()
+	cr := fakeCertCache(ctx)
+	cr.metrics = newCacheMetrics(metric.NewMeterProvider(metric.WithReader(reader)), cr.notAfter)
+
+	cr.updateCertificate(secret)
+	cr.updateCertificate(secret)
+	rotated := secret.DeepCopy()
+	rotated.Data[certificates.CertName] = newTLSCrt
+	rotated.Data[certificates.PrivateKeyName] = newTLSKey
+	cr.updateCertificate(rotated)
+
+	metricstest.AssertMetrics(t, reader,
+		metricstest.MetricsPresent(scopeName, "kn.activator.certificate.rotations", "kn.activator.certificate.expiry"))
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &rm); err != nil {
+		t.Fatal("Failed to collect metrics:", err)
+	}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		if data, ok := m.Data.(metricdata.Sum[int64]); ok {
+			if got := data.DataPoints[0].Value; got != 1 {
+				t.Errorf("%s = %d, want: 1", m.Name, got)
+			}
+		}
+	}
+}
+
 func fakeCertCache(ctx context.Context) *CertCache {
 	secretInformer := fakesecretinformer.Get(ctx)
 	configmapInformer := fakeconfigmapinformer.Get(ctx)
@@ -166,6 +268,7 @@ func fakeCertCache(ctx context.Context) *CertCache {
 		certificate:       nil,
 		TLSConf:           tls.Config{},
 		logger:            logging.FromContext(ctx),
+		clock:             clocktesting.NewFakeClock(time.Now()),
 	}
 
 	secretInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
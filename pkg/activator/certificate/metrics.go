@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const scopeName = "knative.dev/serving/pkg/activator/certificate"
+
+type cacheMetrics struct {
+	rotations metric.Int64Counter
+}
+
+func newCacheMetrics(mp metric.MeterProvider, notAfter func() (time.Time, bool)) *cacheMetrics {
+	p := mp
+	if p == nil {
+		p = otel.GetMeterProvider()
+	}
+
+	meter := p.Meter(scopeName)
+
+	m := &cacheMetrics{
+		rotations: must(meter.Int64Counter(
+			"kn.activator.certificate.rotations",
+			metric.WithDescription("Number of times the activator certificate was replaced after a change of its secret"),
+			metric.WithUnit("{rotation}"),
+		)),
+	}
+	expiry := must(meter.Float64ObservableGauge(
+		"kn.activator.certificate.expiry",
+		metric.WithDescription("Time remaining until the activator certificate expires"),
+		metric.WithUnit("s"),
+	))
+	must(meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		if t, ok := notAfter(); ok {
+			o.ObserveFloat64(expiry, time.Until(t).Seconds())
+		}
+		return nil
+	}, expiry))
+	return m
+}
+
+func (m *cacheMetrics) recordRotation() {
+	if m == nil {
+		return
+	}
+	m.rotations.Add(context.Background(), 1)
+}
+
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// The responses the activator may send to requests that overflow the buffer.
+const (
+	OverflowServiceUnavailable = "503"
+	OverflowTooManyRequests    = "429"
+	OverflowRedirect           = "redirect"
+)
+
+// BufferLimits caps the requests the activator buffers while waiting for
+// capacity of a revision, e.g. while it is scaled from zero. Zero values
+// mean no limit. The requests of unknown length, e.g. chunked, are exempt
+// from the byte caps since their size can't be accounted up front.
+type BufferLimits struct {
+	// RevisionRequests and RevisionBytes cap the number of requests and
+	// their total body size buffered for a single revision.
+	RevisionRequests int
+	RevisionBytes    int64
+	// NamespaceRequests and NamespaceBytes cap the number of requests and
+	// their total body size buffered for all the revisions of a namespace.
+	NamespaceRequests int
+	NamespaceBytes    int64
+
+	// Overflow is the response to requests that would exceed the limits.
+	Overflow OverflowPolicy
+}
+
+// exceedsBytes returns whether a body of the given size is over the byte caps
+// by itself, so that it never fits regardless of the usage.
+func (l BufferLimits) exceedsBytes(size int64) bool {
+	return (l.RevisionBytes > 0 && size > l.RevisionBytes) ||
+		(l.NamespaceBytes > 0 && size > l.NamespaceBytes)
+}
+
+// OverflowPolicy is the response to requests which overflow the buffer.
+type OverflowPolicy struct {
+	// StatusCode is the status of the response.
+	StatusCode int
+	// RedirectURL is where the requests are redirected to, if StatusCode is
+	// a redirection.
+	RedirectURL string
+}
+
+// ParseOverflowPolicy parses the overflow response, one of the Overflow*
+// values. A redirectURL is required for OverflowRedirect.
+func ParseOverflowPolicy(response, redirectURL string) (OverflowPolicy, error) {
+	switch response {
+	case "", OverflowServiceUnavailable:
+		return OverflowPolicy{StatusCode: http.StatusServiceUnavailable}, nil
+	case OverflowTooManyRequests:
+		return OverflowPolicy{StatusCode: http.StatusTooManyRequests}, nil
+	case OverflowRedirect:
+		u, err := url.Parse(redirectURL)
+		if err != nil {
+			return OverflowPolicy{}, fmt.Errorf("invalid overflow redirect URL %q: %w", redirectURL, err)
+		}
+		if !u.IsAbs() {
+			return OverflowPolicy{}, fmt.Errorf("overflow redirect URL %q must be absolute", redirectURL)
+		}
+		return OverflowPolicy{StatusCode: http.StatusTemporaryRedirect, RedirectURL: redirectURL}, nil
+	}
+	return OverflowPolicy{}, fmt.Errorf("unknown overflow response %q", response)
+}
+
+// overflowRetryAfter is the value of the Retry-After header sent along the
+// overflow errors, in seconds.
+const overflowRetryAfter = 1
+
+// respond writes the overflow response.
+func (p OverflowPolicy) respond(w http.ResponseWriter, r *http.Request) {
+	if p.RedirectURL != "" {
+		http.Redirect(w, r, p.RedirectURL, p.StatusCode)
+		return
+	}
+	status := p.StatusCode
+	if status == 0 {
+		status = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(overflowRetryAfter))
+	http.Error(w, "activator buffer is full", status)
+}
+
+// bufferUsage is what is buffered for a revision or a namespace.
+type bufferUsage struct {
+	requests int
+	bytes    int64
+}
+
+// fits returns whether a request of the given size fits in the limits on
+// top of the usage.
+func (u bufferUsage) fits(size int64, maxRequests int, maxBytes int64) bool {
+	return (maxRequests <= 0 || u.requests < maxRequests) &&
+		(maxBytes <= 0 || u.bytes+size <= maxBytes)
+}
+
+// bufferLimiter accounts the requests that are buffered per revision and
+// per namespace.
+type bufferLimiter struct {
+	limits BufferLimits
+
+	mux        sync.Mutex
+	revisions  map[types.NamespacedName]bufferUsage
+	namespaces map[string]bufferUsage
+}
+
+func newBufferLimiter(limits BufferLimits) *bufferLimiter {
+	return &bufferLimiter{
+		limits:     limits,
+		revisions:  make(map[types.NamespacedName]bufferUsage),
+		namespaces: make(map[string]bufferUsage),
+	}
+}
+
+// reserve accounts a buffered request with a body of the given size for the
+// revision. It returns the function to call once the request leaves the
+// buffer, and false if the request does not fit.
+func (b *bufferLimiter) reserve(revID types.NamespacedName, size int64) (func(), bool) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	rev, ns := b.revisions[revID], b.namespaces[revID.Namespace]
+	if !rev.fits(size, b.limits.RevisionRequests, b.limits.RevisionBytes) ||
+		!ns.fits(size, b.limits.NamespaceRequests, b.limits.NamespaceBytes) {
+		return nil, false
+	}
+	b.revisions[revID] = bufferUsage{requests: rev.requests + 1, bytes: rev.bytes + size}
+	b.namespaces[revID.Namespace] = bufferUsage{requests: ns.requests + 1, bytes: ns.bytes + size}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { b.release(revID, size) })
+	}, true
+}
+
+func (b *bufferLimiter) release(revID types.NamespacedName, size int64) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	// Drop the entries once empty, as revisions come and go.
+	if rev := b.revisions[revID]; rev.requests > 1 {
+		b.revisions[revID] = bufferUsage{requests: rev.requests - 1, bytes: rev.bytes - size}
+	} else {
+		delete(b.revisions, revID)
+	}
+	if ns := b.namespaces[revID.Namespace]; ns.requests > 1 {
+		b.namespaces[revID.Namespace] = bufferUsage{requests: ns.requests - 1, bytes: ns.bytes - size}
+	} else {
+		delete(b.namespaces, revID.Namespace)
+	}
+}
@@ -0,0 +1,268 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/types"
+
+	"knative.dev/pkg/logging"
+	pkgnet "knative.dev/pkg/network"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func TestParseOverflowPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		response string
+		url      string
+		want     OverflowPolicy
+		wantErr  bool
+	}{{
+		name: "default",
+		want: OverflowPolicy{StatusCode: http.StatusServiceUnavailable},
+	}, {
+		name:     "too many requests",
+		response: OverflowTooManyRequests,
+		want:     OverflowPolicy{StatusCode: http.StatusTooManyRequests},
+	}, {
+		name:     "redirect",
+		response: OverflowRedirect,
+		url:      "https://example.com/busy",
+		want:     OverflowPolicy{StatusCode: http.StatusTemporaryRedirect, RedirectURL: "https://example.com/busy"},
+	}, {
+		name:     "relative redirect",
+		response: OverflowRedirect,
+		url:      "/busy",
+		wantErr:  true,
+	}, {
+		name:     "unknown",
+		response: "418",
+		wantErr:  true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseOverflowPolicy(tc.response, tc.url)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseOverflowPolicy() = %v, wantErr: %v", err, tc.wantErr)
+			}
+			if !cmp.Equal(got, tc.want) {
+				t.Error("ParseOverflowPolicy() (-want, +got):", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestBufferLimiter(t *testing.T) {
+	rev1 := types.NamespacedName{Namespace: "ns", Name: "rev1"}
+	rev2 := types.NamespacedName{Namespace: "ns", Name: "rev2"}
+	other := types.NamespacedName{Namespace: "other", Name: "rev1"}
+
+	b := newBufferLimiter(BufferLimits{
+		RevisionRequests:  2,
+		NamespaceRequests: 3,
+		NamespaceBytes:    100,
+	})
+
+	release, ok := b.reserve(rev1, 10)
+	if !ok {
+		t.Fatal("First request for rev1 didn't fit")
+	}
+	if _, ok := b.reserve(rev1, 10); !ok {
+		t.Fatal("Second request for rev1 didn't fit")
+	}
+	if _, ok := b.reserve(rev1, 10); ok {
+		t.Error("Third request for rev1 fit over the revision limit")
+	}
+	if _, ok := b.reserve(rev2, 90); ok {
+		t.Error("Request for rev2 fit over the namespace bytes limit")
+	}
+	if _, ok := b.reserve(rev2, 10); !ok {
+		t.Fatal("Request for rev2 didn't fit")
+	}
+	if _, ok := b.reserve(rev2, 0); ok {
+		t.Error("Request for rev2 fit over the namespace requests limit")
+	}
+	if _, ok := b.reserve(other, 100); !ok {
+		t.Error("Request for another namespace didn't fit")
+	}
+
+	// Releasing twice must not free up more than once.
+	release()
+	release()
+	if _, ok := b.reserve(rev1, 10); !ok {
+		t.Error("Request for rev1 didn't fit after a release")
+	}
+	if _, ok := b.reserve(rev1, 10); ok {
+		t.Error("Request for rev1 fit over the revision limit after a release")
+	}
+}
+
+func TestBufferLimiterCleanup(t *testing.T) {
+	rev := types.NamespacedName{Namespace: "ns", Name: "rev"}
+	b := newBufferLimiter(BufferLimits{})
+	release, ok := b.reserve(rev, 10)
+	if !ok {
+		t.Fatal("Request didn't fit without limits")
+	}
+	release()
+	if len(b.revisions) != 0 || len(b.namespaces) != 0 {
+		t.Errorf("Usage left after release: %v, %v", b.revisions, b.namespaces)
+	}
+}
+
+// blockingThrottler holds the requests until released.
+type blockingThrottler struct {
+	tried   chan struct{}
+	release chan struct{}
+}
+
+func (bt *blockingThrottler) Try(_ context.Context, _ types.NamespacedName, f func(string, bool) error) error {
+	bt.tried <- struct{}{}
+	<-bt.release
+	return f("10.10.10.10:1234", false)
+}
+
+func TestActivationHandlerBufferOverflow(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		overflow     OverflowPolicy
+		wantCode     int
+		wantLocation string
+	}{{
+		name:     "too many requests",
+		overflow: OverflowPolicy{StatusCode: http.StatusTooManyRequests},
+		wantCode: http.StatusTooManyRequests,
+	}, {
+		name:         "redirect",
+		overflow:     OverflowPolicy{StatusCode: http.StatusTemporaryRedirect, RedirectURL: "https://example.com/busy"},
+		wantCode:     http.StatusTemporaryRedirect,
+		wantLocation: "https://example.com/busy",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel, _ := rtesting.SetupFakeContextWithCancel(t)
+			defer cancel()
+
+			rt := pkgnet.RoundTripperFunc(func(*http.Request) (*http.Response, error) {
+				return httptest.NewRecorder().Result(), nil
+			})
+			throttler := &blockingThrottler{tried: make(chan struct{}), release: make(chan struct{})}
+			handler := New(ctx, throttler, rt, false /*usePassthroughLb*/, logging.FromContext(ctx), false /* TLS */, nil, nil,
+				BufferLimits{RevisionRequests: 1, Overflow: tc.overflow})
+
+			ctx = WithRevisionAndID(ctx, nil, types.NamespacedName{Namespace: testNamespace, Name: testRevName})
+			newRequest := func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "http://example.com", strings.NewReader("body")).WithContext(ctx)
+			}
+
+			buffered := httptest.NewRecorder()
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				handler.ServeHTTP(buffered, newRequest())
+			}()
+			<-throttler.tried
+
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, newRequest())
+			if resp.Code != tc.wantCode {
+				t.Errorf("Overflow status = %d, want: %d", resp.Code, tc.wantCode)
+			}
+			if got := resp.Header().Get("Location"); got != tc.wantLocation {
+				t.Errorf("Location = %q, want: %q", got, tc.wantLocation)
+			}
+
+			close(throttler.release)
+			<-done
+			if buffered.Code != http.StatusOK {
+				t.Errorf("Buffered request status = %d, want: %d", buffered.Code, http.StatusOK)
+			}
+
+			// Once the buffered request got through, there's room again.
+			go func() { <-throttler.tried }()
+			resp = httptest.NewRecorder()
+			handler.ServeHTTP(resp, newRequest())
+			if resp.Code != http.StatusOK {
+				t.Errorf("Status after the buffer drained = %d, want: %d", resp.Code, http.StatusOK)
+			}
+		})
+	}
+}
+
+func TestActivationHandlerBodyLength(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		limits        BufferLimits
+		contentLength int64
+		wantCode      int
+	}{{
+		name:          "unknown length, bytes not capped",
+		limits:        BufferLimits{RevisionRequests: 1},
+		contentLength: -1,
+		wantCode:      http.StatusOK,
+	}, {
+		name:          "unknown length, bytes capped",
+		limits:        BufferLimits{RevisionBytes: 2, NamespaceBytes: 2},
+		contentLength: -1,
+		wantCode:      http.StatusOK,
+	}, {
+		name:          "within the caps",
+		limits:        BufferLimits{RevisionBytes: 4, NamespaceBytes: 4},
+		contentLength: 4,
+		wantCode:      http.StatusOK,
+	}, {
+		name:          "over the revision cap",
+		limits:        BufferLimits{RevisionBytes: 2, Overflow: OverflowPolicy{StatusCode: http.StatusTooManyRequests}},
+		contentLength: 4,
+		wantCode:      http.StatusRequestEntityTooLarge,
+	}, {
+		name:          "over the namespace cap",
+		limits:        BufferLimits{NamespaceBytes: 2},
+		contentLength: 4,
+		wantCode:      http.StatusRequestEntityTooLarge,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel, _ := rtesting.SetupFakeContextWithCancel(t)
+			defer cancel()
+
+			rt := pkgnet.RoundTripperFunc(func(*http.Request) (*http.Response, error) {
+				return httptest.NewRecorder().Result(), nil
+			})
+			handler := New(ctx, fakeThrottler{}, rt, false /*usePassthroughLb*/, logging.FromContext(ctx), false /* TLS */, nil, nil, tc.limits)
+
+			ctx = WithRevisionAndID(ctx, nil, types.NamespacedName{Namespace: testNamespace, Name: testRevName})
+			req := httptest.NewRequest(http.MethodPost, "http://example.com", strings.NewReader("body")).WithContext(ctx)
+			req.ContentLength = tc.contentLength
+
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+			if resp.Code != tc.wantCode {
+				t.Errorf("Status = %d, want: %d", resp.Code, tc.wantCode)
+			}
+
+			limiter := handler.(*activationHandler).buffer
+			if len(limiter.revisions) != 0 || len(limiter.namespaces) != 0 {
+				t.Errorf("Buffer usage = %v, %v, want empty", limiter.revisions, limiter.namespaces)
+			}
+		})
+	}
+}
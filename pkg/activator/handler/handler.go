@@ -41,7 +41,9 @@ import (
 	"knative.dev/pkg/network"
 	pkghandler "knative.dev/pkg/network/handlers"
 	"knative.dev/serving/pkg/activator"
+	activatornet "knative.dev/serving/pkg/activator/net"
 	apiconfig "knative.dev/serving/pkg/apis/config"
+	"knative.dev/serving/pkg/apis/serving"
 	pkghttp "knative.dev/serving/pkg/http"
 	"knative.dev/serving/pkg/networking"
 	"knative.dev/serving/pkg/queue"
@@ -64,6 +66,8 @@ type activationHandler struct {
 	tls              bool
 	tracer           trace.Tracer
 	metrics          *requestMetrics
+	buffer           *bufferLimiter
+	overflow         OverflowPolicy
 }
 
 // New constructs a new http.Handler that deals with revision activation.
@@ -75,6 +79,7 @@ func New(_ context.Context,
 	tlsEnabled bool,
 	tp trace.TracerProvider,
 	mp metric.MeterProvider,
+	limits BufferLimits,
 ) http.Handler {
 	if tp == nil {
 		tp = otel.GetTracerProvider()
@@ -106,6 +111,8 @@ func New(_ context.Context,
 		logger:           logger,
 		tls:              tlsEnabled,
 		metrics:          newRequestMetrics(mp),
+		buffer:           newBufferLimiter(limits),
+		overflow:         limits.Overflow,
 	}
 }
 
@@ -113,12 +120,40 @@ func (a *activationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	tryContext, trySpan := a.tracer.Start(r.Context(), "throttler_try")
 
 	revID := RevIDFrom(r.Context())
+	if source := RevAnnotation(r.Context(), serving.LoadBalancingHashKeyAnnotationKey); source != "" {
+		if key := hashKey(r, source); key != "" {
+			tryContext = activatornet.WithHashKey(tryContext, key)
+		}
+	}
+	if RevAnnotation(r.Context(), serving.PriorityClassesAnnotationKey) != "" {
+		tryContext = activatornet.WithPriorityHeaders(tryContext, r.Header)
+	}
 
 	metrics := a.metrics.NewForRequest(revID)
+
+	// The declared length is accounted against the byte caps, while the
+	// bodies of unknown length, e.g. chunked or streamed, are exempt from
+	// them and only count against the request caps.
+	if a.buffer.limits.exceedsBytes(r.ContentLength) {
+		trySpan.SetStatus(codes.Error, "body too large")
+		trySpan.End()
+		http.Error(w, "request body is too large to buffer", http.StatusRequestEntityTooLarge)
+		return
+	}
+	unbuffer, ok := a.buffer.reserve(revID, max(r.ContentLength, 0))
+	if !ok {
+		trySpan.SetStatus(codes.Error, "buffer overflow")
+		trySpan.End()
+		metrics.OnRequestOverflow()
+		a.overflow.respond(w, r)
+		return
+	}
+	defer unbuffer()
 	metrics.OnRequestQueued()
 
 	if err := a.throttler.Try(tryContext, revID, func(dest string, isClusterIP bool) error {
 		// Request got capacity - decrement queued, increment active
+		unbuffer()
 		metrics.OnRequestDequeued()
 		metrics.OnRequestProcessing()
 		defer metrics.OnRequestComplete()
@@ -181,6 +216,23 @@ func (a *activationHandler) proxyRequest(revID types.NamespacedName, w http.Resp
 	proxy.ServeHTTP(w, r)
 }
 
+// hashKey extracts the consistent hashing key from the request.
+// The source is one of `header:<name>`, `cookie:<name>` or `query:<name>`.
+func hashKey(r *http.Request, source string) string {
+	kind, name, _ := strings.Cut(source, ":")
+	switch kind {
+	case "header":
+		return r.Header.Get(name)
+	case "cookie":
+		if c, err := r.Cookie(name); err == nil {
+			return c.Value
+		}
+	case "query":
+		return r.URL.Query().Get(name)
+	}
+	return ""
+}
+
 // useSecurePort replaces the default port with HTTPS port (8112).
 // TODO: endpointsToDests() should support HTTPS instead of this overwrite but it needs metadata request to be encrypted.
 // This code should be removed when https://github.com/knative/serving/issues/12821 was solved.
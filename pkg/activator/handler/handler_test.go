@@ -122,7 +122,7 @@ func TestActivationHandler(t *testing.T) {
 			ctx, cancel, _ := rtesting.SetupFakeContextWithCancel(t)
 			defer cancel()
 			handler := New(ctx, test.throttler, rt, false, /*usePassthroughLb*/
-				logging.FromContext(ctx), false /* TLS */, nil /* trace provider */, nil /* meter provider */)
+				logging.FromContext(ctx), false /* TLS */, nil /* trace provider */, nil /* meter provider */, BufferLimits{})
 
 			resp := httptest.NewRecorder()
 			req := httptest.NewRequest(http.MethodPost, "http://example.com", nil)
@@ -162,7 +162,7 @@ func TestActivationHandlerProxyHeader(t *testing.T) {
 	defer cancel()
 
 	handler := New(ctx, fakeThrottler{}, rt, false, /*usePassthroughLb*/
-		logging.FromContext(ctx), false /* TLS */, nil /* trace provider */, nil /* meter provider */)
+		logging.FromContext(ctx), false /* TLS */, nil /* trace provider */, nil /* meter provider */, BufferLimits{})
 
 	writer := httptest.NewRecorder()
 	req := httptest.NewRequest(http.MethodPost, "http://example.com", nil)
@@ -196,7 +196,7 @@ func TestActivationHandlerPassthroughLb(t *testing.T) {
 	defer cancel()
 
 	handler := New(ctx, fakeThrottler{}, rt, true, /*usePassthroughLb*/
-		logging.FromContext(ctx), false /* TLS */, nil /* trace provider */, nil /* meter provider */)
+		logging.FromContext(ctx), false /* TLS */, nil /* trace provider */, nil /* meter provider */, BufferLimits{})
 
 	writer := httptest.NewRecorder()
 	req := httptest.NewRequest(http.MethodPost, "http://example.com", nil)
@@ -242,7 +242,7 @@ func TestActivationHandlerTraceSpans(t *testing.T) {
 		cancel()
 	}()
 
-	handler := New(ctx, fakeThrottler{}, rt, false /*usePassthroughLb*/, logging.FromContext(ctx), false /* TLS */, tp, nil)
+	handler := New(ctx, fakeThrottler{}, rt, false /*usePassthroughLb*/, logging.FromContext(ctx), false /* TLS */, tp, nil, BufferLimits{})
 
 	// Set up config store to populate context.
 	configStore := setupConfigStore(t, logging.FromContext(ctx))
@@ -318,6 +318,33 @@ func TestUseSecurePort(t *testing.T) {
 	}
 }
 
+func TestHashKey(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/?tenant=query-tenant", nil)
+	req.Header.Set("X-Tenant", "header-tenant")
+	req.AddCookie(&http.Cookie{Name: "tenant", Value: "cookie-tenant"})
+
+	tests := map[string]struct {
+		source string
+		want   string
+	}{
+		"header":         {source: "header:X-Tenant", want: "header-tenant"},
+		"header lower":   {source: "header:x-tenant", want: "header-tenant"},
+		"cookie":         {source: "cookie:tenant", want: "cookie-tenant"},
+		"query":          {source: "query:tenant", want: "query-tenant"},
+		"missing header": {source: "header:X-Missing"},
+		"missing cookie": {source: "cookie:missing"},
+		"unknown kind":   {source: "path:tenant"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := hashKey(req, tc.source); got != tc.want {
+				t.Errorf("hashKey(%q) = %q, want %q", tc.source, got, tc.want)
+			}
+		})
+	}
+}
+
 func BenchmarkHandler(b *testing.B) {
 	ctx, cancel, _ := rtesting.SetupFakeContextWithCancel(b)
 	b.Cleanup(cancel)
@@ -334,7 +361,7 @@ func BenchmarkHandler(b *testing.B) {
 			}, nil
 		})
 
-		handler := New(ctx, fakeThrottler{}, rt, false /*usePassthroughLb*/, logging.FromContext(ctx), false /* TLS */, nil, nil)
+		handler := New(ctx, fakeThrottler{}, rt, false /*usePassthroughLb*/, logging.FromContext(ctx), false /* TLS */, nil, nil, BufferLimits{})
 
 		request := func() *http.Request {
 			req := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
@@ -18,6 +18,7 @@ package handler
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -60,14 +61,17 @@ func newMetrics(mp metric.MeterProvider) *ccMetrics {
 
 // requestMetrics holds metrics for tracking request states in the activator.
 type requestMetrics struct {
-	requestQueued metric.Int64UpDownCounter
-	requestActive metric.Int64UpDownCounter
+	requestQueued   metric.Int64UpDownCounter
+	requestActive   metric.Int64UpDownCounter
+	requestBuffered metric.Float64Histogram
+	requestOverflow metric.Int64Counter
 }
 
 type revisionRequestMetrics struct {
 	*requestMetrics
-	opts metric.MeasurementOption
-	ctx  context.Context
+	opts   metric.MeasurementOption
+	ctx    context.Context
+	queued time.Time
 }
 
 func newRequestMetrics(mp metric.MeterProvider) *requestMetrics {
@@ -101,6 +105,24 @@ func newRequestMetrics(mp metric.MeterProvider) *requestMetrics {
 		panic(err)
 	}
 
+	m.requestBuffered, err = meter.Float64Histogram(
+		"kn.revision.request.buffer.duration",
+		metric.WithDescription("The time requests spent in the activator waiting for capacity"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	m.requestOverflow, err = meter.Int64Counter(
+		"kn.revision.request.buffer.overflows",
+		metric.WithDescription("Number of requests rejected for exceeding the activator buffer limits"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
 	return &m
 }
 
@@ -116,13 +138,21 @@ func (m *requestMetrics) NewForRequest(revNN types.NamespacedName) revisionReque
 }
 
 // RecordRequestQueued increments the queued request count for a revision.
-func (m revisionRequestMetrics) OnRequestQueued() {
+func (m *revisionRequestMetrics) OnRequestQueued() {
+	m.queued = time.Now()
 	m.requestQueued.Add(m.ctx, 1, m.opts)
 }
 
-// RecordRequestDequeued decrements the queued request count for a revision.
+// RecordRequestDequeued decrements the queued request count for a revision
+// and records the time the request was queued for.
 func (m *revisionRequestMetrics) OnRequestDequeued() {
 	m.requestQueued.Add(m.ctx, -1, m.opts)
+	m.requestBuffered.Record(m.ctx, time.Since(m.queued).Seconds(), m.opts)
+}
+
+// OnRequestOverflow counts a request rejected for exceeding the buffer limits.
+func (m *revisionRequestMetrics) OnRequestOverflow() {
+	m.requestOverflow.Add(m.ctx, 1, m.opts)
 }
 
 // RecordRequestActive increments the active request count for a revision.
@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"knative.dev/serving/pkg/activator"
+)
+
+const (
+	// maxMirrorBodyBytes is the largest request body buffered to be mirrored.
+	// The requests with a larger or unknown length aren't mirrored.
+	maxMirrorBodyBytes = 1 << 20
+
+	// maxInFlightMirrors is the largest number of mirrored requests in flight.
+	// The requests over it aren't mirrored, so that a slow mirror revision
+	// doesn't pile up goroutines.
+	maxInFlightMirrors = 100
+
+	// mirrorTimeout bounds the time spent serving a mirrored request.
+	mirrorTimeout = 30 * time.Second
+)
+
+// NewMirrorHandler creates a handler duplicating the requests to the revision
+// they are mirrored to, as set by the Route in the mirror headers.
+// The duplicate is served by next as a request to the mirror revision, so it
+// stays out of the metrics of the original revision, and its response is
+// discarded.
+// Like the split headers, the mirror headers are only acted on for the
+// requests the ingress routes through the activator for their split, ahead
+// of the split.
+func NewMirrorHandler(next http.Handler, logger *zap.SugaredLogger) http.Handler {
+	return &mirrorHandler{
+		nextHandler: next,
+		logger:      logger,
+		sample: func(percent int) bool {
+			return rand.Intn(100) < percent //nolint:gosec // We don't need cryptographic randomness here.
+		},
+		inFlight: make(chan struct{}, maxInFlightMirrors),
+		timeout:  mirrorTimeout,
+	}
+}
+
+type mirrorHandler struct {
+	nextHandler http.Handler
+	logger      *zap.SugaredLogger
+	sample      func(percent int) bool
+	inFlight    chan struct{}
+	timeout     time.Duration
+}
+
+func (h *mirrorHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := r.Header.Get(activator.MirrorHeaderName)
+	percent, _ := strconv.Atoi(r.Header.Get(activator.MirrorPercentHeaderName))
+	r.Header.Del(activator.MirrorHeaderName)
+	r.Header.Del(activator.MirrorPercentHeaderName)
+
+	split := r.Header.Get(activator.RevisionHeaderName) == activator.UnsetHeaderValue
+	if split && name != "" && name != activator.UnsetHeaderValue && r.ContentLength >= 0 && r.ContentLength <= maxMirrorBodyBytes && h.sample(percent) {
+		h.mirror(r, name)
+	}
+
+	h.nextHandler.ServeHTTP(w, r)
+}
+
+// mirror serves the duplicate of the request in the background, unless too
+// many mirrored requests are in flight.
+func (h *mirrorHandler) mirror(r *http.Request, name string) {
+	select {
+	case h.inFlight <- struct{}{}:
+	default:
+		h.logger.Debug("Too many mirrored requests in flight, not mirroring the request to " + name)
+		return
+	}
+
+	mirror, err := mirrorRequest(r, name)
+	if err != nil {
+		<-h.inFlight
+		h.logger.Warnw("Failed to mirror the request to "+name, zap.Error(err))
+		return
+	}
+	go func() {
+		defer func() { <-h.inFlight }()
+		ctx, cancel := context.WithTimeout(mirror.Context(), h.timeout)
+		defer cancel()
+		h.nextHandler.ServeHTTP(&discardResponseWriter{header: make(http.Header)}, mirror.WithContext(ctx))
+	}()
+}
+
+// mirrorRequest buffers the body of the request to duplicate it, as a request
+// to the named revision, outliving the original one.
+func mirrorRequest(r *http.Request, name string) (*http.Request, error) {
+	var body []byte
+	if r.ContentLength > 0 {
+		body = make([]byte, r.ContentLength)
+		if _, err := io.ReadFull(r.Body, body); err != nil {
+			return nil, err
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	mirror := r.Clone(context.WithoutCancel(r.Context()))
+	mirror.Body = http.NoBody
+	if body != nil {
+		mirror.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	mirror.Header.Set(activator.RevisionHeaderName, name)
+	return mirror, nil
+}
+
+// discardResponseWriter discards the response of the mirrored requests.
+type discardResponseWriter struct {
+	header http.Header
+}
+
+func (w *discardResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *discardResponseWriter) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+func (w *discardResponseWriter) WriteHeader(int) {}
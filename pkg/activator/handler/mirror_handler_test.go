@@ -0,0 +1,215 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"knative.dev/pkg/logging"
+	"knative.dev/serving/pkg/activator"
+)
+
+func TestMirrorHandler(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		body    string
+		sampled bool
+		mirror  bool
+	}{{
+		name: "no mirror",
+		body: "hello",
+	}, {
+		name: "mirrored",
+		headers: map[string]string{
+			activator.MirrorHeaderName:        "shadow",
+			activator.MirrorPercentHeaderName: "10",
+		},
+		body:    "hello",
+		sampled: true,
+		mirror:  true,
+	}, {
+		name: "mirrored without body",
+		headers: map[string]string{
+			activator.MirrorHeaderName:        "shadow",
+			activator.MirrorPercentHeaderName: "10",
+		},
+		sampled: true,
+		mirror:  true,
+	}, {
+		name: "not sampled",
+		headers: map[string]string{
+			activator.MirrorHeaderName:        "shadow",
+			activator.MirrorPercentHeaderName: "10",
+		},
+		body: "hello",
+	}, {
+		name: "mirror unset by the ingress",
+		headers: map[string]string{
+			activator.MirrorHeaderName:        activator.UnsetHeaderValue,
+			activator.MirrorPercentHeaderName: "100",
+		},
+		body:    "hello",
+		sampled: true,
+	}, {
+		name: "mirror sent by a client to a revision",
+		headers: map[string]string{
+			activator.RevisionHeaderName:      "primary",
+			activator.MirrorHeaderName:        "shadow",
+			activator.MirrorPercentHeaderName: "10",
+		},
+		body:    "hello",
+		sampled: true,
+	}, {
+		name: "body too large",
+		headers: map[string]string{
+			activator.MirrorHeaderName:        "shadow",
+			activator.MirrorPercentHeaderName: "10",
+		},
+		body:    strings.Repeat("a", maxMirrorBodyBytes+1),
+		sampled: true,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			type served struct {
+				r    *http.Request
+				body string
+			}
+			servedCh := make(chan served, 2)
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				servedCh <- served{r: r, body: string(b)}
+				w.Write(b)
+			})
+			h := NewMirrorHandler(next, logging.FromContext(t.Context())).(*mirrorHandler)
+			h.sample = func(percent int) bool {
+				if percent != 10 {
+					t.Errorf("percent = %d, want: 10", percent)
+				}
+				return test.sampled
+			}
+
+			// The ingress routes the request through the activator for its split.
+			req := httptest.NewRequest(http.MethodPost, "http://example.com", strings.NewReader(test.body))
+			req.Header.Set(activator.RevisionHeaderName, activator.UnsetHeaderValue)
+			for k, v := range test.headers {
+				req.Header.Set(k, v)
+			}
+			rev := req.Header.Get(activator.RevisionHeaderName)
+			resp := httptest.NewRecorder()
+			h.ServeHTTP(resp, req)
+
+			if got := resp.Body.String(); got != test.body {
+				t.Errorf("Response body length = %d, want: %d", len(got), len(test.body))
+			}
+
+			want := map[string]bool{rev: true}
+			if test.mirror {
+				want["shadow"] = true
+			}
+			for range want {
+				select {
+				case s := <-servedCh:
+					rev := s.r.Header.Get(activator.RevisionHeaderName)
+					if !want[rev] {
+						t.Errorf("Unexpected request to %q", rev)
+					}
+					if s.r.Header.Get(activator.MirrorHeaderName) != "" || s.r.Header.Get(activator.MirrorPercentHeaderName) != "" {
+						t.Errorf("Mirror headers of the request to %q weren't removed", rev)
+					}
+					if got := s.body; got != test.body {
+						t.Errorf("Body length of the request to %q = %d, want: %d", rev, len(got), len(test.body))
+					}
+				case <-time.After(5 * time.Second):
+					t.Fatal("Timed out waiting for the requests")
+				}
+			}
+			select {
+			case s := <-servedCh:
+				t.Errorf("Unexpected request to %q", s.r.Header.Get(activator.RevisionHeaderName))
+			default:
+			}
+		})
+	}
+}
+
+func TestMirrorHandlerBounded(t *testing.T) {
+	release := make(chan struct{})
+	mirrored := make(chan error, maxInFlightMirrors+1)
+	next := http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(activator.RevisionHeaderName) != "shadow" {
+			return
+		}
+		// A slow mirror revision.
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+		mirrored <- r.Context().Err()
+	})
+	h := NewMirrorHandler(next, logging.FromContext(t.Context())).(*mirrorHandler)
+	h.sample = func(int) bool { return true }
+
+	serve := func() {
+		req := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+		req.Header.Set(activator.RevisionHeaderName, activator.UnsetHeaderValue)
+		req.Header.Set(activator.MirrorHeaderName, "shadow")
+		req.Header.Set(activator.MirrorPercentHeaderName, "100")
+		h.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	// The requests over the in-flight limit aren't mirrored.
+	for range maxInFlightMirrors + 1 {
+		serve()
+	}
+	close(release)
+	for range maxInFlightMirrors {
+		if err := <-mirrored; err != nil {
+			t.Error("Mirrored request failed:", err)
+		}
+	}
+	select {
+	case <-mirrored:
+		t.Error("Mirrored more than the in-flight limit")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// The mirrored requests time out.
+	h = NewMirrorHandler(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(activator.RevisionHeaderName) == "shadow" {
+			<-r.Context().Done()
+			mirrored <- r.Context().Err()
+		}
+	}), logging.FromContext(t.Context())).(*mirrorHandler)
+	h.sample = func(int) bool { return true }
+	h.timeout = 10 * time.Millisecond
+	serve()
+	select {
+	case err := <-mirrored:
+		if err == nil {
+			t.Error("Mirrored request didn't time out")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the mirrored request")
+	}
+}
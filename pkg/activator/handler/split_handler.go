@@ -0,0 +1,164 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"errors"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"knative.dev/serving/pkg/activator"
+)
+
+// NewSplitHandler creates a handler assigning the requests carrying a traffic
+// split, as set by the Route with a split affinity, to a revision of the
+// split by their affinity key, so the requests with the same key go to the
+// same revision. The requests carrying a cookie of the cookie rules of the
+// split go to the revision of the rule instead.
+// The split headers are only acted on for the requests the ingress routes
+// through the activator for their split, whose revision header it sets to
+// activator.UnsetHeaderValue, so that the clients can't split the requests
+// routed to a revision.
+func NewSplitHandler(next http.Handler) http.Handler {
+	return &splitHandler{nextHandler: next}
+}
+
+type splitHandler struct {
+	nextHandler http.Handler
+}
+
+func (h *splitHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	split := r.Header.Get(activator.SplitHeaderName)
+	affinity := r.Header.Get(activator.SplitAffinityHeaderName)
+	matches := r.Header.Get(activator.SplitMatchHeaderName)
+	r.Header.Del(activator.SplitHeaderName)
+	r.Header.Del(activator.SplitAffinityHeaderName)
+	r.Header.Del(activator.SplitMatchHeaderName)
+
+	if r.Header.Get(activator.RevisionHeaderName) == activator.UnsetHeaderValue {
+		weights, err := parseSplit(split)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		rev := ""
+		if matches != "" && matches != activator.UnsetHeaderValue {
+			if rev, err = matchCookie(r, matches); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		if rev == "" {
+			rev = pickRevision(weights, hashKey(r, affinity))
+		}
+		r.Header.Set(activator.RevisionHeaderName, rev)
+	}
+
+	h.nextHandler.ServeHTTP(w, r)
+}
+
+type revisionWeight struct {
+	name    string
+	percent int
+}
+
+// parseSplit parses a split of the form `<revision>=<percent>,...`.
+func parseSplit(v string) ([]revisionWeight, error) {
+	parts := strings.Split(v, ",")
+	weights := make([]revisionWeight, 0, len(parts))
+	for _, p := range parts {
+		name, pct, _ := strings.Cut(p, "=")
+		percent, err := strconv.Atoi(pct)
+		if name == "" || err != nil || percent < 0 {
+			return nil, errors.New("invalid traffic split " + strconv.Quote(v))
+		}
+		if percent > 0 {
+			weights = append(weights, revisionWeight{name: name, percent: percent})
+		}
+	}
+	if len(weights) == 0 {
+		return nil, errors.New("invalid traffic split " + strconv.Quote(v))
+	}
+	return weights, nil
+}
+
+// matchCookie returns the revision of the first of the cookie rules of the
+// form `<revision>=<cookie>=<value>,...` the request matches, if any.
+func matchCookie(r *http.Request, rules string) (string, error) {
+	for _, rule := range strings.Split(rules, ",") {
+		rev, cookie, _ := strings.Cut(rule, "=")
+		name, value, ok := strings.Cut(cookie, "=")
+		if rev == "" || name == "" || !ok {
+			return "", errors.New("invalid cookie rules " + strconv.Quote(rules))
+		}
+		if c, err := r.Cookie(name); err == nil && c.Value == value {
+			return rev, nil
+		}
+	}
+	return "", nil
+}
+
+// pickRevision picks the revision for the key with weighted rendezvous
+// hashing, so that the share of the keys of each revision follows its
+// percentage, and the keys assigned to a revision stay assigned to it while
+// its percentage is the only one growing, e.g. the latest revision during a
+// rollout. Keys only leave the revisions whose percentage doesn't grow for
+// the growing one, or when theirs shrinks. The requests without a key are
+// split randomly.
+func pickRevision(weights []revisionWeight, key string) string {
+	if key == "" {
+		n := rand.Intn(sumPercent(weights)) //nolint:gosec // We don't need cryptographic randomness here.
+		for _, w := range weights {
+			if n -= w.percent; n < 0 {
+				return w.name
+			}
+		}
+	}
+
+	best, bestScore := "", math.Inf(1)
+	for _, w := range weights {
+		h := fnv.New64a()
+		h.Write([]byte(key))
+		h.Write([]byte{0})
+		h.Write([]byte(w.name))
+		// A uniform value in (0, 1).
+		u := (float64(mix64(h.Sum64())>>11) + 0.5) / (1 << 53)
+		if score := -math.Log(u) / float64(w.percent); score < bestScore {
+			best, bestScore = w.name, score
+		}
+	}
+	return best
+}
+
+// mix64 is the splitmix64 finalizer, spreading the FNV hashes of similar
+// inputs over all the bits.
+func mix64(z uint64) uint64 {
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+func sumPercent(weights []revisionWeight) (sum int) {
+	for _, w := range weights {
+		sum += w.percent
+	}
+	return sum
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handler
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"knative.dev/serving/pkg/activator"
+)
+
+func TestSplitHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		headers    map[string]string
+		cookie     *http.Cookie
+		wantStatus int
+		wantRev    string
+	}{{
+		name:       "no split",
+		headers:    map[string]string{activator.RevisionHeaderName: "rev"},
+		wantStatus: http.StatusOK,
+		wantRev:    "rev",
+	}, {
+		name: "split sent by a client to a revision",
+		headers: map[string]string{
+			activator.RevisionHeaderName:      "rev",
+			activator.SplitHeaderName:         "other=100",
+			activator.SplitAffinityHeaderName: "header:X-User-Id",
+		},
+		wantStatus: http.StatusOK,
+		wantRev:    "rev",
+	}, {
+		name: "single revision",
+		headers: map[string]string{
+			activator.RevisionHeaderName:      activator.UnsetHeaderValue,
+			activator.SplitHeaderName:         "old=0,new=100",
+			activator.SplitAffinityHeaderName: "header:X-User-Id",
+			"X-User-Id":                       "alice",
+		},
+		wantStatus: http.StatusOK,
+		wantRev:    "new",
+	}, {
+		name: "by cookie",
+		headers: map[string]string{
+			activator.RevisionHeaderName:      activator.UnsetHeaderValue,
+			activator.SplitHeaderName:         "old=50,new=50",
+			activator.SplitAffinityHeaderName: "cookie:user",
+		},
+		cookie:     &http.Cookie{Name: "user", Value: "bob"},
+		wantStatus: http.StatusOK,
+		wantRev:    pickRevision([]revisionWeight{{"old", 50}, {"new", 50}}, "bob"),
+	}, {
+		name: "cookie rule matched",
+		headers: map[string]string{
+			activator.RevisionHeaderName:   activator.UnsetHeaderValue,
+			activator.SplitHeaderName:      "stable=100",
+			activator.SplitMatchHeaderName: "canary=beta=1,preview=beta=a=b",
+		},
+		cookie:     &http.Cookie{Name: "beta", Value: "a=b"},
+		wantStatus: http.StatusOK,
+		wantRev:    "preview",
+	}, {
+		name: "cookie rule not matched",
+		headers: map[string]string{
+			activator.RevisionHeaderName:   activator.UnsetHeaderValue,
+			activator.SplitHeaderName:      "stable=100",
+			activator.SplitMatchHeaderName: "canary=beta=1",
+		},
+		cookie:     &http.Cookie{Name: "beta", Value: "2"},
+		wantStatus: http.StatusOK,
+		wantRev:    "stable",
+	}, {
+		name: "cookie rules unset by the ingress",
+		headers: map[string]string{
+			activator.RevisionHeaderName:   activator.UnsetHeaderValue,
+			activator.SplitHeaderName:      "stable=100",
+			activator.SplitMatchHeaderName: activator.UnsetHeaderValue,
+		},
+		cookie:     &http.Cookie{Name: "beta", Value: "1"},
+		wantStatus: http.StatusOK,
+		wantRev:    "stable",
+	}, {
+		name: "invalid cookie rules",
+		headers: map[string]string{
+			activator.RevisionHeaderName:   activator.UnsetHeaderValue,
+			activator.SplitHeaderName:      "stable=100",
+			activator.SplitMatchHeaderName: "canary=beta",
+		},
+		wantStatus: http.StatusBadRequest,
+	}, {
+		name: "invalid split",
+		headers: map[string]string{
+			activator.RevisionHeaderName: activator.UnsetHeaderValue,
+			activator.SplitHeaderName:    "old=50,new",
+		},
+		wantStatus: http.StatusBadRequest,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got *http.Request
+			h := NewSplitHandler(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				got = r
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+			for k, v := range test.headers {
+				req.Header.Set(k, v)
+			}
+			if test.cookie != nil {
+				req.AddCookie(test.cookie)
+			}
+			resp := httptest.NewRecorder()
+			h.ServeHTTP(resp, req)
+
+			if resp.Code != test.wantStatus {
+				t.Fatalf("Status = %d, want: %d", resp.Code, test.wantStatus)
+			}
+			if got == nil {
+				return
+			}
+			if rev := got.Header.Get(activator.RevisionHeaderName); rev != test.wantRev {
+				t.Errorf("Revision = %q, want: %q", rev, test.wantRev)
+			}
+			if got.Header.Get(activator.SplitHeaderName) != "" || got.Header.Get(activator.SplitAffinityHeaderName) != "" ||
+				got.Header.Get(activator.SplitMatchHeaderName) != "" {
+				t.Error("Split headers weren't removed")
+			}
+		})
+	}
+}
+
+func TestPickRevision(t *testing.T) {
+	const keys = 10000
+
+	// The share of the keys follows the percentages.
+	weights := []revisionWeight{{"a", 70}, {"b", 20}, {"c", 10}}
+	counts := map[string]int{}
+	for i := range keys {
+		counts[pickRevision(weights, strconv.Itoa(i))]++
+	}
+	for _, w := range weights {
+		if got, want := float64(counts[w.name])/keys, float64(w.percent)/100; math.Abs(got-want) > 0.02 {
+			t.Errorf("Share of %s = %v, want: %v", w.name, got, want)
+		}
+	}
+
+	// The keys of the growing revision stay with it, and the keys of the
+	// unchanged revision only move to it.
+	before := []revisionWeight{{"old", 80}, {"mid", 15}, {"new", 5}}
+	after := []revisionWeight{{"old", 40}, {"mid", 15}, {"new", 45}}
+	for i := range keys {
+		key := strconv.Itoa(i)
+		if from, to := pickRevision(before, key), pickRevision(after, key); from != to && from != "old" && to != "new" {
+			t.Errorf("Key %s moved from %s to %s", key, from, to)
+		}
+	}
+
+	// The requests without a key are split randomly.
+	counts = map[string]int{}
+	for range keys {
+		counts[pickRevision(weights, "")]++
+	}
+	if len(counts) != len(weights) {
+		t.Errorf("Revisions of the requests without a key = %v, want all of %v", counts, weights)
+	}
+}
@@ -20,8 +20,24 @@ package net
 
 import (
 	"context"
+	"hash/fnv"
+	"math"
 	"math/rand"
+	"slices"
 	"sync"
+	"time"
+)
+
+const (
+	// peakEWMADecay is the time constant of the exponential decay of the
+	// latency observations in the peak-EWMA policy. Observations older than
+	// this have ~1/e of their original weight.
+	peakEWMADecay = 10 * time.Second
+
+	// peakEWMAPenalty is the cost assigned to a pod, which has requests
+	// in flight, but has not reported any latency yet. This makes sure
+	// brand new pods are probed, but are not flooded with requests.
+	peakEWMAPenalty = float64(math.MaxInt32)
 )
 
 // lbPolicy is a functor that selects a target pod from the list, or (noop, nil) if
@@ -114,3 +130,164 @@ func newRoundRobinPolicy() lbPolicy {
 		return noop, nil
 	}
 }
+
+// latencyEWMA keeps the peak exponentially weighted moving average of the
+// response latency of a pod.
+// "Peak" means that a latency spike is immediately reflected in the average,
+// while improvements are only picked up gradually.
+type latencyEWMA struct {
+	mu sync.Mutex
+	// value is the current average in nanoseconds.
+	value float64
+	// stamp is the time when the value was last updated.
+	stamp time.Time
+}
+
+// decayed returns the average decayed to `now`, assuming no observations
+// have been made since the last update.
+// Must be called with mu held.
+func (l *latencyEWMA) decayed(now time.Time) float64 {
+	elapsed := max(now.Sub(l.stamp), 0)
+	return l.value * math.Exp(-float64(elapsed)/float64(peakEWMADecay))
+}
+
+// observe records a new latency observation.
+func (l *latencyEWMA) observe(now time.Time, rtt time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	r := float64(rtt)
+	if r > l.value {
+		// Peak: jump to the new value right away.
+		l.value = r
+	} else {
+		w := math.Exp(-float64(max(now.Sub(l.stamp), 0)) / float64(peakEWMADecay))
+		l.value = l.value*w + r*(1-w)
+	}
+	l.stamp = now
+}
+
+// cost returns the expected cost of sending another request to the pod,
+// which currently has `pending` requests in flight.
+func (l *latencyEWMA) cost(now time.Time, pending int32) float64 {
+	l.mu.Lock()
+	v := l.decayed(now)
+	l.mu.Unlock()
+	if v == 0 && pending != 0 {
+		return peakEWMAPenalty + float64(pending)
+	}
+	return v * float64(pending+1)
+}
+
+// peakEWMAPolicy is a load balancer policy that picks the target with the lowest
+// expected cost, computed as the peak EWMA of the observed response latency
+// multiplied by the number of requests in flight to the target.
+// The latency is observed when the returned callback is invoked, that is when
+// the request has been served.
+func peakEWMAPolicy(ctx context.Context, targets []*podTracker) (func(), *podTracker) {
+	type candidate struct {
+		cost    float64
+		tracker *podTracker
+	}
+	now := time.Now()
+	candidates := make([]candidate, len(targets))
+	for i, t := range targets {
+		candidates[i] = candidate{cost: t.latency.cost(now, t.getWeight()), tracker: t}
+	}
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		switch {
+		case a.cost < b.cost:
+			return -1
+		case a.cost > b.cost:
+			return 1
+		}
+		return 0
+	})
+
+	for _, c := range candidates {
+		t := c.tracker
+		if cb, ok := t.Reserve(ctx); ok {
+			t.increaseWeight()
+			start := time.Now()
+			return func() {
+				end := time.Now()
+				t.latency.observe(end, end.Sub(start))
+				t.decreaseWeight()
+				cb()
+			}, t
+		}
+	}
+	return noop, nil
+}
+
+// hashKeyCtxKey is the context key for the consistent hashing policy key.
+type hashKeyCtxKey struct{}
+
+// WithHashKey attaches the key, which the consistent hashing policy uses
+// to pick the target for the request, to the context.
+func WithHashKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, hashKeyCtxKey{}, key)
+}
+
+// hashKeyFrom returns the consistent hashing key of the request, if any.
+func hashKeyFrom(ctx context.Context) string {
+	key, _ := ctx.Value(hashKeyCtxKey{}).(string)
+	return key
+}
+
+// rendezvousScore returns the weight of the dest for the given key.
+// The hash is deterministic, so that all the activators agree on the choice.
+func rendezvousScore(key, dest string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	h.Write([]byte{0})
+	h.Write([]byte(dest))
+	// FNV does not mix the trailing bytes well enough into the high bits,
+	// so finalize with the splitmix64 mixer.
+	x := h.Sum64()
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// newConsistentHashPolicy returns a load balancer policy that consistently maps
+// the request hash key (see WithHashKey) to the same target.
+// It uses rendezvous hashing, so when targets are added or removed only the keys
+// that mapped to the removed targets, or now map to the added ones, move.
+// The targets are all the pods of the revision, so that all the activators pick
+// the same one. Their container concurrency is split among the activators (see
+// sharePods), so that the activators don't overload a pod with the requests of
+// a hot key.
+// Requests without a key, or whose chosen target has no capacity, are handled
+// by the fallback policy.
+func newConsistentHashPolicy(fallback lbPolicy) lbPolicy {
+	return func(ctx context.Context, targets []*podTracker) (func(), *podTracker) {
+		key := hashKeyFrom(ctx)
+		if key == "" {
+			return fallback(ctx, targets)
+		}
+
+		var (
+			pick *podTracker
+			best uint64
+		)
+		for _, t := range targets {
+			if s := rendezvousScore(key, t.dest); pick == nil || s > best {
+				pick, best = t, s
+			}
+		}
+		if pick == nil {
+			return fallback(ctx, targets)
+		}
+		if cb, ok := pick.Reserve(ctx); ok {
+			pick.increaseWeight()
+			return func() {
+				pick.decreaseWeight()
+				cb()
+			}, pick
+		}
+		return fallback(ctx, targets)
+	}
+}
@@ -19,6 +19,7 @@ package net
 import (
 	"context"
 	"fmt"
+	"slices"
 	"testing"
 	"time"
 
@@ -260,6 +261,189 @@ func TestRoundRobin(t *testing.T) {
 	})
 }
 
+func TestPeakEWMA(t *testing.T) {
+	t.Run("prefers faster pod", func(t *testing.T) {
+		podTrackers := makeTrackers(3, 0)
+		now := time.Now()
+		podTrackers[0].latency.observe(now, 100*time.Millisecond)
+		podTrackers[1].latency.observe(now, 10*time.Millisecond)
+		podTrackers[2].latency.observe(now, 50*time.Millisecond)
+
+		cb, pt := peakEWMAPolicy(context.Background(), podTrackers)
+		t.Cleanup(cb)
+		if got, want := pt, podTrackers[1]; got != want {
+			t.Fatalf("Tracker = %v, want: %v", got, want)
+		}
+		if got, want := pt.getWeight(), int32(1); got != want {
+			t.Errorf("pt.weight = %d, want: %d", got, want)
+		}
+	})
+	t.Run("penalizes busy pods without latency data", func(t *testing.T) {
+		podTrackers := makeTrackers(2, 0)
+		podTrackers[0].increaseWeight()
+		podTrackers[1].latency.observe(time.Now(), time.Second)
+
+		cb, pt := peakEWMAPolicy(context.Background(), podTrackers)
+		t.Cleanup(cb)
+		if got, want := pt, podTrackers[1]; got != want {
+			t.Fatalf("Tracker = %v, want: %v", got, want)
+		}
+	})
+	t.Run("callback records latency", func(t *testing.T) {
+		podTrackers := makeTrackers(1, 0)
+		cb, pt := peakEWMAPolicy(context.Background(), podTrackers)
+		time.Sleep(5 * time.Millisecond)
+		cb()
+		if got, want := pt.getWeight(), int32(0); got != want {
+			t.Errorf("pt.weight = %d, want: %d", got, want)
+		}
+		pt.latency.mu.Lock()
+		defer pt.latency.mu.Unlock()
+		if got, want := time.Duration(pt.latency.value), 5*time.Millisecond; got < want {
+			t.Errorf("latency = %v, want at least: %v", got, want)
+		}
+	})
+	t.Run("peak is followed immediately, recovery decays", func(t *testing.T) {
+		var l latencyEWMA
+		now := time.Now()
+		l.observe(now, 10*time.Millisecond)
+		l.observe(now, 100*time.Millisecond)
+		if got, want := l.cost(now, 0), float64(100*time.Millisecond); got != want {
+			t.Errorf("cost = %v, want: %v", got, want)
+		}
+		now = now.Add(peakEWMADecay)
+		l.observe(now, 10*time.Millisecond)
+		if got := l.cost(now, 0); got <= float64(10*time.Millisecond) || got >= float64(100*time.Millisecond) {
+			t.Errorf("cost = %v, want strictly between 10ms and 100ms", time.Duration(got))
+		}
+		if got, want := l.cost(now, 1), 2*l.cost(now, 0); got != want {
+			t.Errorf("cost with 1 pending = %v, want: %v", got, want)
+		}
+	})
+	t.Run("with cc=1", func(t *testing.T) {
+		podTrackers := makeTrackers(2, 1)
+		now := time.Now()
+		podTrackers[0].latency.observe(now, 10*time.Millisecond)
+		podTrackers[1].latency.observe(now, 100*time.Millisecond)
+
+		cb, pt := peakEWMAPolicy(context.Background(), podTrackers)
+		t.Cleanup(cb)
+		if got, want := pt, podTrackers[0]; got != want {
+			t.Fatalf("Tracker = %v, want: %v", got, want)
+		}
+		// The faster pod is full, so the slower one must be picked.
+		cb, pt = peakEWMAPolicy(context.Background(), podTrackers)
+		t.Cleanup(cb)
+		if got, want := pt, podTrackers[1]; got != want {
+			t.Fatalf("Tracker = %v, want: %v", got, want)
+		}
+		// And now there's no capacity left.
+		if _, pt = peakEWMAPolicy(context.Background(), podTrackers); pt != nil {
+			t.Fatal("Wanted nil, got: ", pt)
+		}
+	})
+}
+
+func TestConsistentHash(t *testing.T) {
+	t.Run("no key uses fallback", func(t *testing.T) {
+		podTrackers := makeTrackers(3, 1)
+		chp := newConsistentHashPolicy(firstAvailableLBPolicy)
+		cb, pt := chp(context.Background(), podTrackers)
+		t.Cleanup(cb)
+		if got, want := pt, podTrackers[0]; got != want {
+			t.Fatalf("Tracker = %v, want: %v", got, want)
+		}
+	})
+	t.Run("same key same pod", func(t *testing.T) {
+		podTrackers := makeTrackers(10, 0)
+		chp := newConsistentHashPolicy(randomChoice2Policy)
+		ctx := WithHashKey(context.Background(), "tenant-a")
+		cb, want := chp(ctx, podTrackers)
+		cb()
+		for range 100 {
+			cb, got := chp(ctx, podTrackers)
+			cb()
+			if got != want {
+				t.Fatalf("Tracker = %v, want: %v", got, want)
+			}
+		}
+		if got := want.getWeight(); got != 0 {
+			t.Errorf("pt.weight = %d, want: 0", got)
+		}
+	})
+	t.Run("minimal disruption", func(t *testing.T) {
+		podTrackers := makeTrackers(10, 0)
+		chp := newConsistentHashPolicy(randomChoice2Policy)
+		keys := make([]string, 1000)
+		before := make(map[string]*podTracker, len(keys))
+		for i := range keys {
+			keys[i] = fmt.Sprint("key-", i)
+			cb, pt := chp(WithHashKey(context.Background(), keys[i]), podTrackers)
+			cb()
+			before[keys[i]] = pt
+		}
+
+		// Remove a pod. Only the keys mapped to it must move.
+		removed := podTrackers[3]
+		shrunk := append(append([]*podTracker{}, podTrackers[:3]...), podTrackers[4:]...)
+		for _, k := range keys {
+			cb, pt := chp(WithHashKey(context.Background(), k), shrunk)
+			cb()
+			if before[k] != removed && pt != before[k] {
+				t.Errorf("Key %s moved from %v to %v", k, before[k], pt)
+			}
+		}
+
+		// Add a pod. Keys must either stay or move to the new pod.
+		added := newPodTracker("new", nil)
+		grown := append(append([]*podTracker{}, podTrackers...), added)
+		moved := 0
+		for _, k := range keys {
+			cb, pt := chp(WithHashKey(context.Background(), k), grown)
+			cb()
+			if pt != before[k] {
+				moved++
+				if pt != added {
+					t.Errorf("Key %s moved from %v to %v", k, before[k], pt)
+				}
+			}
+		}
+		// We expect ~1/11 of the keys to move.
+		if moved == 0 || moved > len(keys)/5 {
+			t.Errorf("Moved %d keys, want roughly %d", moved, len(keys)/11)
+		}
+	})
+	t.Run("same pod across activators", func(t *testing.T) {
+		podTrackers := makeTrackers(10, 0)
+		chp := newConsistentHashPolicy(randomChoice2Policy)
+		// The activators may list the pods in a different order.
+		reversed := slices.Clone(podTrackers)
+		slices.Reverse(reversed)
+		for i := range 100 {
+			ctx := WithHashKey(context.Background(), fmt.Sprint("key-", i))
+			cb, a := chp(ctx, podTrackers)
+			cb()
+			cb, b := chp(ctx, reversed)
+			cb()
+			if a != b {
+				t.Fatalf("Key %d mapped to %v and %v by the activators", i, a, b)
+			}
+		}
+	})
+	t.Run("full pod uses fallback", func(t *testing.T) {
+		podTrackers := makeTrackers(3, 1)
+		chp := newConsistentHashPolicy(firstAvailableLBPolicy)
+		ctx := WithHashKey(context.Background(), "tenant-a")
+		cb, first := chp(ctx, podTrackers)
+		t.Cleanup(cb)
+		cb, pt := chp(ctx, podTrackers)
+		t.Cleanup(cb)
+		if pt == nil || pt == first {
+			t.Fatalf("Tracker = %v, want a tracker other than %v", pt, first)
+		}
+	})
+}
+
 func BenchmarkPolicy(b *testing.B) {
 	for _, test := range []struct {
 		name   string
@@ -276,6 +460,9 @@ func BenchmarkPolicy(b *testing.B) {
 	}, {
 		name:   "round-robin",
 		policy: newRoundRobinPolicy(),
+	}, {
+		name:   "peak-ewma",
+		policy: peakEWMAPolicy,
 	}} {
 		for _, n := range []int{1, 2, 3, 10, 100} {
 			b.Run(fmt.Sprintf("%s-%d-trackers-sequential", test.name, n), func(b *testing.B) {
@@ -19,6 +19,7 @@ package net
 import (
 	"context"
 	"net/http"
+	"slices"
 	"sort"
 	"sync"
 	"sync/atomic"
@@ -79,6 +80,9 @@ type podTracker struct {
 	weight atomic.Int32
 	// decreaseWeight is an allocation optimization for the randomChoice2 policy.
 	decreaseWeight func()
+
+	// latency tracks the response latency for the peakEWMA policy.
+	latency latencyEWMA
 }
 
 func (p *podTracker) increaseWeight() {
@@ -132,6 +136,10 @@ type revisionThrottler struct {
 	revID                types.NamespacedName
 	containerConcurrency int
 	lbPolicy             lbPolicy
+	// sharePods splits the container concurrency of every pod among the
+	// activators, rather than assigning each activator a slice of the pods,
+	// so that the consistent hash policy picks among all the pods.
+	sharePods bool
 
 	// These are used in slicing to infer which pods to assign
 	// to this activator.
@@ -150,6 +158,13 @@ type revisionThrottler struct {
 	// This is a breaker for the revision as a whole.
 	breaker breaker
 
+	// priorityClasses order the requests waiting for the capacity of the
+	// revision. Only the breaker of the revision queues requests by priority:
+	// the pod trackers are reserved without waiting once a request is
+	// admitted, and the requests finding them full are queued again in the
+	// breaker with their priority.
+	priorityClasses []serving.PriorityClass
+
 	// This will be non-empty when we're able to use pod addressing.
 	podTrackers []*podTracker
 
@@ -170,6 +185,7 @@ type revisionThrottler struct {
 
 func newRevisionThrottler(revID types.NamespacedName,
 	containerConcurrency int, proto string,
+	lbPolicyName string,
 	breakerParams queue.BreakerParams,
 	logger *zap.SugaredLogger,
 ) *revisionThrottler {
@@ -191,6 +207,14 @@ func newRevisionThrottler(revID types.NamespacedName,
 		revBreaker = queue.NewBreaker(breakerParams)
 		lbp = newRoundRobinPolicy()
 	}
+	// Explicitly requested policies override the defaults above.
+	switch lbPolicyName {
+	case serving.LoadBalancingPolicyPeakEWMA:
+		lbp = peakEWMAPolicy
+	case serving.LoadBalancingPolicyConsistentHash:
+		// The default policy is still used when the chosen pod is full.
+		lbp = newConsistentHashPolicy(lbp)
+	}
 	t := &revisionThrottler{
 		revID:                revID,
 		containerConcurrency: containerConcurrency,
@@ -198,6 +222,7 @@ func newRevisionThrottler(revID types.NamespacedName,
 		logger:               logger,
 		protocol:             proto,
 		lbPolicy:             lbp,
+		sharePods:            lbPolicyName == serving.LoadBalancingPolicyConsistentHash,
 	}
 
 	// Start with unknown
@@ -229,7 +254,7 @@ func (rt *revisionThrottler) try(ctx context.Context, function func(dest string,
 	reenqueue := true
 	for reenqueue {
 		reenqueue = false
-		if err := rt.breaker.Maybe(ctx, func() {
+		if err := rt.breaker.Maybe(rt.withPriority(ctx), func() {
 			cb, tracker, isClusterIP := rt.acquireDest(ctx)
 			if tracker == nil {
 				// This can happen if individual requests raced each other or if pod
@@ -247,6 +272,26 @@ func (rt *revisionThrottler) try(ctx context.Context, function func(dest string,
 	return ret
 }
 
+// withPriority attaches the priority level of the request to the context,
+// given the priority classes of the revision.
+func (rt *revisionThrottler) withPriority(ctx context.Context) context.Context {
+	if len(rt.priorityClasses) == 0 {
+		return ctx
+	}
+	h, _ := ctx.Value(priorityHeadersCtxKey{}).(http.Header)
+	return queue.WithPriority(ctx, queue.PriorityLevel(rt.priorityClasses, h))
+}
+
+// priorityHeadersCtxKey is the context key for the headers of the request
+// picking its priority class.
+type priorityHeadersCtxKey struct{}
+
+// WithPriorityHeaders attaches the headers of the request, which pick its
+// priority class among the ones of the revision, to the context.
+func WithPriorityHeaders(ctx context.Context, h http.Header) context.Context {
+	return context.WithValue(ctx, priorityHeadersCtxKey{}, h)
+}
+
 func (rt *revisionThrottler) calculateCapacity(backendCount, numTrackers, activatorCount int) int {
 	var targetCapacity int
 	if numTrackers > 0 {
@@ -296,6 +341,8 @@ func (rt *revisionThrottler) updateCapacity(backendCount int) {
 	// We have to make assignments on each updateCapacity, since if number
 	// of activators changes, then we need to rebalance the assignedTrackers.
 	ac, ai := int(rt.numActivators.Load()), int(rt.activatorIndex.Load())
+	// The capacity of the shared pods, if they are.
+	sharedCapacity := -1
 	numTrackers := func() int {
 		// We do not have to process the `podTrackers` under lock, since
 		// updateCapacity is guaranteed to be executed by a single goroutine.
@@ -314,7 +361,14 @@ func (rt *revisionThrottler) updateCapacity(backendCount int) {
 		assigned := rt.podTrackers
 		if rt.containerConcurrency > 0 {
 			rt.resetTrackers()
-			assigned = assignSlice(rt.podTrackers, ai, ac)
+			if rt.sharePods {
+				// Copied, since the trackers are sorted in place on the
+				// next update while the requests read them.
+				assigned = slices.Clone(rt.podTrackers)
+				sharedCapacity = sharePods(assigned, rt.containerConcurrency, ai, ac)
+			} else {
+				assigned = assignSlice(rt.podTrackers, ai, ac)
+			}
 		}
 		rt.logger.Debugf("Trackers %d/%d: assignment: %v", ai, ac, assigned)
 		// The actual write out of the assigned trackers has to be under lock.
@@ -325,6 +379,9 @@ func (rt *revisionThrottler) updateCapacity(backendCount int) {
 	}()
 
 	capacity := rt.calculateCapacity(backendCount, numTrackers, ac)
+	if sharedCapacity >= 0 && numTrackers > 0 {
+		capacity = min(sharedCapacity, revisionMaxConcurrency)
+	}
 	rt.logger.Infof("Set capacity to %d (backends: %d, index: %d/%d)",
 		capacity, backendCount, ai, ac)
 
@@ -408,6 +465,37 @@ func assignSlice(trackers []*podTracker, selfIndex, numActivators int) []*podTra
 	return x
 }
 
+// sharePods splits the container concurrency of each of the pods among the
+// activators, so that every activator may send requests to every pod while
+// the pods never get more than the container concurrency in total. The
+// remainder of the split goes to different activators for different pods.
+// It returns the capacity of the pods for this activator.
+// sharePods should receive podTrackers sorted by address.
+func sharePods(trackers []*podTracker, cc, selfIndex, numActivators int) int {
+	// When we're unassigned, or alone, the pods are all ours.
+	if selfIndex == -1 || numActivators <= 1 {
+		return cc * len(trackers)
+	}
+
+	capacity := 0
+	for i, t := range trackers {
+		share := cc / numActivators
+		if (i-selfIndex%numActivators+numActivators)%numActivators < cc%numActivators {
+			share++
+		}
+		t.UpdateConcurrency(share)
+		capacity += share
+	}
+	if capacity == 0 && len(trackers) > 0 {
+		// With fewer pods than activators and a lower container concurrency,
+		// some activators are left without a share, so they share a pod like
+		// assignSlice does.
+		trackers[selfIndex%len(trackers)].UpdateConcurrency(1)
+		capacity = 1
+	}
+	return capacity
+}
+
 // This function will never be called in parallel but `try` can be called in parallel to this so we need
 // to lock on updating concurrency / trackers
 func (rt *revisionThrottler) handleUpdate(update revisionDestsUpdate) {
@@ -546,13 +634,26 @@ func (t *Throttler) getOrCreateRevisionThrottler(revID types.NamespacedName) (*r
 		if err != nil {
 			return nil, err
 		}
+		_, lbPolicyName, _ := serving.LoadBalancingPolicyAnnotation.Get(rev.Annotations)
+		breakerParams := queue.BreakerParams{QueueDepth: breakerQueueDepth, MaxConcurrency: revisionMaxConcurrency}
+		// The priority classes are parsed once, the requests are then
+		// classified by the revision throttler.
+		var priorityClasses []serving.PriorityClass
+		if _, v, ok := serving.PriorityClassesAnnotation.Get(rev.Annotations); ok {
+			if classes, err := serving.ParsePriorityClasses(v); err == nil {
+				breakerParams.Priorities = queue.NewPriorityParams(classes, breakerQueueDepth)
+				priorityClasses = classes
+			}
+		}
 		revThrottler = newRevisionThrottler(
 			revID,
 			int(rev.Spec.GetContainerConcurrency()),
 			pkgnet.ServicePortName(rev.GetProtocol()),
-			queue.BreakerParams{QueueDepth: breakerQueueDepth, MaxConcurrency: revisionMaxConcurrency},
+			lbPolicyName,
+			breakerParams,
 			t.logger,
 		)
+		revThrottler.priorityClasses = priorityClasses
 		t.revisionThrottlers[revID] = revThrottler
 	}
 	return revThrottler, nil
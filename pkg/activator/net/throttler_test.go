@@ -19,6 +19,8 @@ package net
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
 	"slices"
 	"strconv"
 	"sync"
@@ -339,6 +341,78 @@ func TestThrottlerErrorNoRevision(t *testing.T) {
 	}
 }
 
+func TestThrottlerPriority(t *testing.T) {
+	ctx, cancel, _ := rtesting.SetupFakeContextWithCancel(t)
+	servfake := fakeservingclient.Get(ctx)
+	revisions := fakerevisioninformer.Get(ctx)
+	waitInformers, err := rtesting.RunAndSyncInformers(ctx, revisions.Informer())
+	if err != nil {
+		t.Fatal("Failed to start informers:", err)
+	}
+	defer func() {
+		cancel()
+		waitInformers()
+	}()
+
+	revID := types.NamespacedName{Namespace: testNamespace, Name: testRevision}
+	rev := revision(revID, pkgnet.ProtocolHTTP1, 1, func(r *v1.Revision) {
+		r.Annotations = map[string]string{serving.PriorityClassesAnnotationKey: "premium,default"}
+	})
+	servfake.ServingV1().Revisions(rev.Namespace).Create(ctx, rev, metav1.CreateOptions{})
+	revisions.Informer().GetIndexer().Add(rev)
+
+	throttler := newTestThrottler(ctx)
+	throttler.handleUpdate(revisionDestsUpdate{
+		Rev:   revID,
+		Dests: sets.New("128.0.0.1:1234"),
+	})
+	rt, err := throttler.getOrCreateRevisionThrottler(revID)
+	if err != nil {
+		t.Fatal("getOrCreateRevisionThrottler() =", err)
+	}
+	if got, want := len(rt.priorityClasses), 2; got != want {
+		t.Fatalf("len(priorityClasses) = %d, want: %d", got, want)
+	}
+
+	// Hold the capacity of the revision while the requests queue.
+	held, release := make(chan struct{}), make(chan struct{})
+	go throttler.Try(ctx, revID, func(string, bool) error {
+		close(held)
+		<-release
+		return nil
+	})
+	<-held
+
+	order := make(chan string, 2)
+	try := func(class string) {
+		h := http.Header{}
+		h.Set(serving.PriorityHeaderName, class)
+		throttler.Try(WithPriorityHeaders(ctx, h), revID, func(string, bool) error {
+			order <- class
+			return nil
+		})
+	}
+	waitQueued := func(n int) {
+		if err := wait.PollUntilContextTimeout(ctx, time.Millisecond, 5*time.Second, true, func(context.Context) (bool, error) {
+			return rt.breaker.(*queue.Breaker).Waiting() == n, nil
+		}); err != nil {
+			t.Fatalf("%d requests never queued", n)
+		}
+	}
+	go try("default")
+	waitQueued(1)
+	go try("premium")
+	waitQueued(2)
+	close(release)
+
+	if got := <-order; got != "premium" {
+		t.Errorf("First request served = %s, want: premium", got)
+	}
+	if got := <-order; got != "default" {
+		t.Errorf("Second request served = %s, want: default", got)
+	}
+}
+
 func TestThrottlerErrorOneTimesOut(t *testing.T) {
 	ctx, cancel, _ := rtesting.SetupFakeContextWithCancel(t)
 	servfake := fakeservingclient.Get(ctx)
@@ -475,6 +549,22 @@ func TestThrottlerSuccesses(t *testing.T) {
 		requests: 3,
 		// All three IP addresses should be used if cc>3.
 		wantDests: sets.New("128.0.0.1:1234", "128.0.0.2:1234", "211.212.213.214"),
+	}, {
+		name: "peak-ewma test",
+		revision: revision(types.NamespacedName{Namespace: testNamespace, Name: testRevision},
+			pkgnet.ProtocolHTTP1, 5, func(r *v1.Revision) {
+				r.Annotations = map[string]string{
+					serving.LoadBalancingPolicyAnnotationKey: serving.LoadBalancingPolicyPeakEWMA,
+				}
+			}),
+		initUpdates: []revisionDestsUpdate{{
+			Rev:   types.NamespacedName{Namespace: testNamespace, Name: testRevision},
+			Dests: sets.New("128.0.0.1:1234", "128.0.0.2:1234", "211.212.213.214"),
+		}},
+		requests: 3,
+		// Pods with requests in flight, but without latency data are penalized,
+		// so concurrent requests are spread across all the pods.
+		wantDests: sets.New("128.0.0.1:1234", "128.0.0.2:1234", "211.212.213.214"),
 	}, {
 		name:     "multiple ClusterIP requests",
 		revision: revisionCC1(types.NamespacedName{Namespace: testNamespace, Name: testRevision}, pkgnet.ProtocolHTTP1),
@@ -618,7 +708,7 @@ func TestPodAssignmentFinite(t *testing.T) {
 	defer cancel()
 
 	throttler := newTestThrottler(ctx)
-	rt := newRevisionThrottler(revName, 42 /*cc*/, pkgnet.ServicePortNameHTTP1, testBreakerParams, logger)
+	rt := newRevisionThrottler(revName, 42 /*cc*/, pkgnet.ServicePortNameHTTP1, "" /*lbPolicy*/, testBreakerParams, logger)
 	rt.numActivators.Store(4)
 	rt.activatorIndex.Store(0)
 	throttler.revisionThrottlers[revName] = rt
@@ -662,6 +752,90 @@ func TestPodAssignmentFinite(t *testing.T) {
 	}
 }
 
+func TestConsistentHashAcrossActivators(t *testing.T) {
+	logger := TestLogger(t)
+	revName := types.NamespacedName{Namespace: testNamespace, Name: testRevision}
+
+	ctx, cancel, _ := rtesting.SetupFakeContextWithCancel(t)
+	defer cancel()
+
+	// Three activators sharing the capacity of the pods.
+	makeThrottlers := func(cc int) []*revisionThrottler {
+		rts := make([]*revisionThrottler, 3)
+		for i := range rts {
+			throttler := newTestThrottler(ctx)
+			rts[i] = newRevisionThrottler(revName, cc, pkgnet.ServicePortNameHTTP1,
+				serving.LoadBalancingPolicyConsistentHash, testBreakerParams, logger)
+			rts[i].numActivators.Store(int32(len(rts)))
+			rts[i].activatorIndex.Store(int32(i))
+			throttler.revisionThrottlers[revName] = rts[i]
+			throttler.handleUpdate(revisionDestsUpdate{
+				Rev:   revName,
+				Dests: sets.New("ip5", "ip3", "ip1", "ip0", "ip2", "ip4"),
+			})
+		}
+		return rts
+	}
+
+	for _, cc := range []int{0, 10} {
+		t.Run(fmt.Sprint("concurrency ", cc, " picks the same pod"), func(t *testing.T) {
+			rts := makeThrottlers(cc)
+			for i := range 100 {
+				ctx := WithHashKey(context.Background(), fmt.Sprint("key-", i))
+				var dests []string
+				for _, rt := range rts {
+					cb, tracker, _ := rt.acquireDest(ctx)
+					if tracker == nil {
+						t.Fatal("acquireDest() = nil")
+					}
+					cb()
+					dests = append(dests, tracker.dest)
+				}
+				if dests[0] != dests[1] || dests[1] != dests[2] {
+					t.Fatalf("Key %d mapped to %v by the activators", i, dests)
+				}
+			}
+		})
+	}
+
+	t.Run("hot key respects the container concurrency", func(t *testing.T) {
+		const cc = 2
+		rts := makeThrottlers(cc)
+		ctx := WithHashKey(context.Background(), "hot")
+		inFlight := map[string]int{}
+		for _, rt := range rts {
+			// Acquire until the pods of the activator are full.
+			for range 100 {
+				cb, tracker, _ := rt.acquireDest(ctx)
+				if tracker == nil {
+					break
+				}
+				t.Cleanup(cb)
+				inFlight[tracker.dest]++
+			}
+		}
+		if len(inFlight) != 6 {
+			t.Errorf("Pods in use = %v, want all 6", inFlight)
+		}
+		// The hot pod takes the requests of all the activators up to its
+		// capacity before they fall back to the others.
+		var hot string
+		for dest := range inFlight {
+			if hot == "" || rendezvousScore("hot", dest) > rendezvousScore("hot", hot) {
+				hot = dest
+			}
+		}
+		if inFlight[hot] != cc {
+			t.Errorf("Requests in flight to the hot pod %s = %d, want: %d", hot, inFlight[hot], cc)
+		}
+		for dest, n := range inFlight {
+			if n > cc {
+				t.Errorf("Requests in flight to %s = %d, want at most %d", dest, n, cc)
+			}
+		}
+	})
+}
+
 func TestPodAssignmentInfinite(t *testing.T) {
 	logger := TestLogger(t)
 	revName := types.NamespacedName{Namespace: testNamespace, Name: testRevision}
@@ -670,7 +844,7 @@ func TestPodAssignmentInfinite(t *testing.T) {
 	defer cancel()
 
 	throttler := newTestThrottler(ctx)
-	rt := newRevisionThrottler(revName, 0 /*cc*/, pkgnet.ServicePortNameHTTP1, testBreakerParams, logger)
+	rt := newRevisionThrottler(revName, 0 /*cc*/, pkgnet.ServicePortNameHTTP1, "" /*lbPolicy*/, testBreakerParams, logger)
 	throttler.revisionThrottlers[revName] = rt
 
 	update := revisionDestsUpdate{
@@ -902,7 +1076,7 @@ func TestMultipleActivators(t *testing.T) {
 func TestInfiniteBreakerCreation(t *testing.T) {
 	// This test verifies that we use infiniteBreaker when CC==0.
 	tttl := newRevisionThrottler(types.NamespacedName{Namespace: "a", Name: "b"}, 0, /*cc*/
-		pkgnet.ServicePortNameHTTP1, queue.BreakerParams{}, TestLogger(t))
+		pkgnet.ServicePortNameHTTP1, "" /*lbPolicy*/, queue.BreakerParams{}, TestLogger(t))
 	if _, ok := tttl.breaker.(*infiniteBreaker); !ok {
 		t.Errorf("The type of revisionBreaker = %T, want %T", tttl, (*infiniteBreaker)(nil))
 	}
@@ -1217,3 +1391,56 @@ func TestAssignSlice(t *testing.T) {
 		}
 	})
 }
+
+func TestSharePods(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		pods, cc, na  int
+		wantCapacity  []int
+		wantPodShares []int
+	}{{
+		name:          "single activator",
+		pods:          3,
+		cc:            5,
+		na:            1,
+		wantCapacity:  []int{15},
+		wantPodShares: []int{5, 5, 5},
+	}, {
+		name:          "even split",
+		pods:          2,
+		cc:            6,
+		na:            3,
+		wantCapacity:  []int{4, 4, 4},
+		wantPodShares: []int{6, 6},
+	}, {
+		name:          "remainders",
+		pods:          3,
+		cc:            5,
+		na:            3,
+		wantCapacity:  []int{5, 5, 5},
+		wantPodShares: []int{5, 5, 5},
+	}, {
+		name:          "lower concurrency than activators",
+		pods:          3,
+		cc:            1,
+		na:            3,
+		wantCapacity:  []int{1, 1, 1},
+		wantPodShares: []int{1, 1, 1},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			podShares := make([]int, tc.pods)
+			for ai := range tc.na {
+				trackers := makeTrackers(tc.pods, tc.cc)
+				if got, want := sharePods(trackers, tc.cc, ai, tc.na), tc.wantCapacity[ai]; got != want {
+					t.Errorf("Capacity of activator %d = %d, want: %d", ai, got, want)
+				}
+				for i, tr := range trackers {
+					podShares[i] += tr.Capacity()
+				}
+			}
+			if !cmp.Equal(podShares, tc.wantPodShares) {
+				t.Error("Pod shares (-want, +got):", cmp.Diff(tc.wantPodShares, podShares))
+			}
+		})
+	}
+}
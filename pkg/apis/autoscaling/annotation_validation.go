@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -60,7 +61,8 @@ func ValidateAnnotations(ctx context.Context, config *autoscalerconfig.Config, a
 		Also(validateScaleDownDelay(anns)).
 		Also(validateMetric(config, anns)).
 		Also(validateAlgorithm(anns)).
-		Also(validateInitialScale(config, anns))
+		Also(validateInitialScale(config, anns)).
+		Also(validatePrescaling(config, anns))
 }
 
 func validateClass(m map[string]string) *apis.FieldError {
@@ -245,6 +247,10 @@ func validateMetric(c *autoscalerconfig.Config, m map[string]string) *apis.Field
 			switch metric {
 			case Concurrency, RPS:
 				return nil
+			case Custom:
+				return validateCustomMetric(m)
+			case Latency:
+				return validateLatencyMetric(m)
 			}
 		case HPA:
 			switch metric {
@@ -262,6 +268,45 @@ func validateMetric(c *autoscalerconfig.Config, m map[string]string) *apis.Field
 	return nil
 }
 
+// customMetricNameRegexp matches valid Prometheus metric names.
+var customMetricNameRegexp = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
+func validateCustomMetric(m map[string]string) (errs *apis.FieldError) {
+	if k, v, ok := CustomMetricNameAnnotation.Get(m); !ok {
+		errs = errs.Also(apis.ErrMissingField(CustomMetricNameAnnotationKey))
+	} else if !customMetricNameRegexp.MatchString(v) {
+		errs = errs.Also(apis.ErrInvalidValue(v, k))
+	}
+	if _, _, ok := TargetAnnotation.Get(m); !ok {
+		errs = errs.Also(apis.ErrMissingField(TargetAnnotationKey))
+	}
+	if k, v, ok := CustomMetricPortAnnotation.Get(m); ok {
+		if p, err := strconv.Atoi(v); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(v, k))
+		} else if p < 1 || p > math.MaxUint16 {
+			errs = errs.Also(apis.ErrOutOfBoundsValue(v, 1, math.MaxUint16, k))
+		}
+	}
+	if k, v, ok := CustomMetricPathAnnotation.Get(m); ok && !strings.HasPrefix(v, "/") {
+		errs = errs.Also(apis.ErrInvalidValue(v, k))
+	}
+	return errs
+}
+
+func validateLatencyMetric(m map[string]string) (errs *apis.FieldError) {
+	if _, _, ok := TargetAnnotation.Get(m); !ok {
+		errs = errs.Also(apis.ErrMissingField(TargetAnnotationKey))
+	}
+	if k, v, ok := LatencyPercentileAnnotation.Get(m); ok {
+		if p, err := strconv.ParseFloat(v, 64); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(v, k))
+		} else if p <= 0 || p > 100 {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("percentile %s should be in (0, 100]", v), k))
+		}
+	}
+	return errs
+}
+
 func validateInitialScale(config *autoscalerconfig.Config, m map[string]string) *apis.FieldError {
 	if k, v, ok := InitialScaleAnnotation.Get(m); ok {
 		initScaleInt, err := strconv.Atoi(v)
@@ -275,3 +320,27 @@ func validateInitialScale(config *autoscalerconfig.Config, m map[string]string)
 	}
 	return nil
 }
+
+func validatePrescaling(config *autoscalerconfig.Config, m map[string]string) (errs *apis.FieldError) {
+	class := config.PodAutoscalerClass
+	if _, c, ok := ClassAnnotation.Get(m); ok {
+		class = c
+	}
+	// Leave other classes of PodAutoscaler alone.
+	if class != KPA {
+		return nil
+	}
+	if k, v, ok := PredictiveScalingAnnotation.Get(m); ok {
+		switch v {
+		case PredictiveScalingDaily, PredictiveScalingWeekly:
+		default:
+			errs = errs.Also(apis.ErrInvalidValue(v, k))
+		}
+	}
+	if k, v, ok := MinScaleScheduleAnnotation.Get(m); ok {
+		if _, err := ParseMinScaleSchedule(v); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(v, k, err.Error()))
+		}
+	}
+	return errs
+}
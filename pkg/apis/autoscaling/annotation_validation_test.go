@@ -340,6 +340,86 @@ func TestValidateAnnotations(t *testing.T) {
 	}, {
 		name:        "valid class KPA with metric Concurrency",
 		annotations: map[string]string{MetricAnnotationKey: Concurrency},
+	}, {
+		name: "valid class KPA with metric Custom",
+		annotations: map[string]string{
+			MetricAnnotationKey:           Custom,
+			CustomMetricNameAnnotationKey: "work_queue_length",
+			CustomMetricPortAnnotationKey: "9091",
+			CustomMetricPathAnnotationKey: "/stats",
+			TargetAnnotationKey:           "10",
+		},
+	}, {
+		name:        "metric Custom without name or target",
+		annotations: map[string]string{MetricAnnotationKey: Custom},
+		expectErr:   "missing field(s): " + CustomMetricNameAnnotationKey + ", " + TargetAnnotationKey,
+	}, {
+		name: "metric Custom with invalid name",
+		annotations: map[string]string{
+			MetricAnnotationKey:           Custom,
+			CustomMetricNameAnnotationKey: "work-queue",
+			TargetAnnotationKey:           "10",
+		},
+		expectErr: "invalid value: work-queue: " + CustomMetricNameAnnotationKey,
+	}, {
+		name: "metric Custom with invalid port and path",
+		annotations: map[string]string{
+			MetricAnnotationKey:           Custom,
+			CustomMetricNameAnnotationKey: "work_queue_length",
+			CustomMetricPortAnnotationKey: "70000",
+			CustomMetricPathAnnotationKey: "stats",
+			TargetAnnotationKey:           "10",
+		},
+		expectErr: "expected 1 <= 70000 <= 65535: " + CustomMetricPortAnnotationKey +
+			"\ninvalid value: stats: " + CustomMetricPathAnnotationKey,
+	}, {
+		name: "valid predictive scaling and min-scale schedule",
+		annotations: map[string]string{
+			PredictiveScalingAnnotationKey: PredictiveScalingWeekly,
+			MinScaleScheduleAnnotationKey:  "0 8 * * 1-5 10h 5",
+		},
+	}, {
+		name:        "invalid predictive scaling",
+		annotations: map[string]string{PredictiveScalingAnnotationKey: "hourly"},
+		expectErr:   "invalid value: hourly: " + PredictiveScalingAnnotationKey,
+	}, {
+		name:        "invalid min-scale schedule",
+		annotations: map[string]string{MinScaleScheduleAnnotationKey: "0 8 * * * 1h 0"},
+		expectErr: "invalid value: 0 8 * * * 1h 0: " + MinScaleScheduleAnnotationKey +
+			"\nentry \"0 8 * * * 1h 0\": min-scale must be a positive integer",
+	}, {
+		name: "min-scale schedule ignored for HPA",
+		annotations: map[string]string{
+			ClassAnnotationKey:            HPA,
+			MinScaleScheduleAnnotationKey: "bogus",
+		},
+	}, {
+		name: "valid class KPA with metric Latency",
+		annotations: map[string]string{
+			MetricAnnotationKey:            Latency,
+			LatencyPercentileAnnotationKey: "99.9",
+			TargetAnnotationKey:            "200",
+		},
+	}, {
+		name:        "metric Latency without target",
+		annotations: map[string]string{MetricAnnotationKey: Latency},
+		expectErr:   "missing field(s): " + TargetAnnotationKey,
+	}, {
+		name: "metric Latency with invalid percentile",
+		annotations: map[string]string{
+			MetricAnnotationKey:            Latency,
+			LatencyPercentileAnnotationKey: "0",
+			TargetAnnotationKey:            "200",
+		},
+		expectErr: "percentile 0 should be in (0, 100]: " + LatencyPercentileAnnotationKey,
+	}, {
+		name: "metric Latency with unparseable percentile",
+		annotations: map[string]string{
+			MetricAnnotationKey:            Latency,
+			LatencyPercentileAnnotationKey: "p95",
+			TargetAnnotationKey:            "200",
+		},
+		expectErr: "invalid value: p95: " + LatencyPercentileAnnotationKey,
 	}, {
 		name:        "valid class HPA with metric CPU",
 		annotations: map[string]string{ClassAnnotationKey: HPA, MetricAnnotationKey: CPU},
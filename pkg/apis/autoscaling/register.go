@@ -71,6 +71,42 @@ const (
 	Memory = "memory"
 	// RPS is the requests per second reaching the Pod.
 	RPS = "rps"
+	// Custom is an application defined gauge scraped from the user container.
+	// The gauge is named by the CustomMetricNameAnnotationKey annotation.
+	Custom = "custom"
+
+	// CustomMetricNameAnnotationKey is the annotation to specify the name of the
+	// gauge the KPA should scale on when the metric is `custom`. For example,
+	//   autoscaling.knative.dev/metric: custom
+	//   autoscaling.knative.dev/custom-metric-name: work_queue_length
+	//   autoscaling.knative.dev/target: "10"   # target 10 queued items per pod
+	CustomMetricNameAnnotationKey = GroupName + "/custom-metric-name"
+	// CustomMetricPortAnnotationKey is the annotation to specify the port of the
+	// user container serving the Prometheus/OpenMetrics text endpoint.
+	// Defaults to the user container's serving port.
+	CustomMetricPortAnnotationKey = GroupName + "/custom-metric-port"
+	// CustomMetricPortDefault is the port scraped when neither the annotation
+	// nor the user container specify one.
+	CustomMetricPortDefault = 8080
+	// CustomMetricPathAnnotationKey is the annotation to specify the path of the
+	// Prometheus/OpenMetrics text endpoint. Defaults to CustomMetricPathDefault.
+	CustomMetricPathAnnotationKey = GroupName + "/custom-metric-path"
+	// CustomMetricPathDefault is the default path of the custom metric endpoint.
+	CustomMetricPathDefault = "/metrics"
+	// Latency is a percentile of the latency of the requests served by the
+	// Pods, in milliseconds. The percentile is set by the
+	// LatencyPercentileAnnotationKey annotation. For example,
+	//   autoscaling.knative.dev/metric: latency
+	//   autoscaling.knative.dev/latency-percentile: "95"
+	//   autoscaling.knative.dev/target: "200"   # target p95 < 200ms
+	Latency = "latency"
+
+	// LatencyPercentileAnnotationKey is the annotation to specify the percentile
+	// of the request latencies the KPA should keep under the target when the
+	// metric is `latency`. Defaults to LatencyPercentileDefault.
+	LatencyPercentileAnnotationKey = GroupName + "/latency-percentile"
+	// LatencyPercentileDefault is the percentile used when the annotation is not set.
+	LatencyPercentileDefault = 95.
 
 	// TargetAnnotationKey is the annotation to specify what metric value the
 	// PodAutoscaler should attempt to maintain. For example,
@@ -215,6 +251,31 @@ const (
 	// but bounding from above.
 	PanicThresholdPercentageMax = 1000.0
 
+	// PredictiveScalingAnnotationKey is the annotation to enable seasonal
+	// pre-scaling of a revision. The KPA remembers the desired scale of the
+	// revision over the last season and, looking a few minutes ahead, keeps
+	// at least the scale that was needed at the same time one season ago.
+	// Possible values are "daily" and "weekly". For example,
+	//   autoscaling.knative.dev/predictive-scaling: weekly
+	// Only the kpa.autoscaling.knative.dev class autoscaler supports
+	// the predictive-scaling annotation.
+	PredictiveScalingAnnotationKey = GroupName + "/predictive-scaling"
+	// PredictiveScalingDaily forecasts from the same time one day ago.
+	PredictiveScalingDaily = "daily"
+	// PredictiveScalingWeekly forecasts from the same time one week ago.
+	PredictiveScalingWeekly = "weekly"
+
+	// MinScaleScheduleAnnotationKey is the annotation to specify time based
+	// lower bounds on the scale of a revision. The value is a semicolon
+	// separated list of `<cron> <duration> <min-scale>` entries: every time
+	// the five field cron expression (evaluated in UTC) matches, a window
+	// of the given duration starts in which the revision is kept at
+	// min-scale pods or more. For example,
+	//   autoscaling.knative.dev/min-scale-schedule: "0 8 * * 1-5 10h 5; 0 18 * * * 2h 2"
+	// Only the kpa.autoscaling.knative.dev class autoscaler supports
+	// the min-scale-schedule annotation.
+	MinScaleScheduleAnnotationKey = GroupName + "/min-scale-schedule"
+
 	// ActivationScale is the minimum, non-zero value that a service should scale to.
 	// For example, if ActivationScale = 2, when a service scaled from zero it would
 	// scale up two replicas in this case. In essence, this allows one to set both a
@@ -239,6 +300,18 @@ var (
 	MetricAnnotation = kmap.KeyPriority{
 		MetricAnnotationKey,
 	}
+	CustomMetricNameAnnotation = kmap.KeyPriority{
+		CustomMetricNameAnnotationKey,
+	}
+	CustomMetricPortAnnotation = kmap.KeyPriority{
+		CustomMetricPortAnnotationKey,
+	}
+	CustomMetricPathAnnotation = kmap.KeyPriority{
+		CustomMetricPathAnnotationKey,
+	}
+	LatencyPercentileAnnotation = kmap.KeyPriority{
+		LatencyPercentileAnnotationKey,
+	}
 	MetricAggregationAlgorithmAnnotation = kmap.KeyPriority{
 		MetricAggregationAlgorithmKey,
 		GroupName + "/metricAggregationAlgorithm",
@@ -246,6 +319,9 @@ var (
 	ActivationScale = kmap.KeyPriority{
 		ActivationScaleKey,
 	}
+	MinScaleScheduleAnnotation = kmap.KeyPriority{
+		MinScaleScheduleAnnotationKey,
+	}
 	MinScaleAnnotation = kmap.KeyPriority{
 		MinScaleAnnotationKey,
 		GroupName + "/minScale",
@@ -258,6 +334,9 @@ var (
 		PanicWindowPercentageAnnotationKey,
 		GroupName + "/panicWindowPercentage",
 	}
+	PredictiveScalingAnnotation = kmap.KeyPriority{
+		PredictiveScalingAnnotationKey,
+	}
 	ScaleDownDelayAnnotation = kmap.KeyPriority{
 		ScaleDownDelayAnnotationKey,
 		GroupName + "/scaleDownDelay",
@@ -0,0 +1,212 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoscaling
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxMinScaleWindow bounds the duration of a single min-scale schedule window.
+const MaxMinScaleWindow = 7 * 24 * time.Hour
+
+// MinScaleWindow is a single entry of a min-scale schedule.
+type MinScaleWindow struct {
+	// Cron is the schedule at which windows start.
+	Cron CronSchedule
+	// Duration is how long each window lasts.
+	Duration time.Duration
+	// MinScale is the lower bound on the scale within a window.
+	MinScale int32
+}
+
+// MinScaleSchedule is a parsed MinScaleScheduleAnnotationKey value.
+type MinScaleSchedule []MinScaleWindow
+
+// ParseMinScaleSchedule parses the value of the min-scale-schedule annotation.
+func ParseMinScaleSchedule(v string) (MinScaleSchedule, error) {
+	var ret MinScaleSchedule
+	for entry := range strings.SplitSeq(v, ";") {
+		fields := strings.Fields(entry)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 7 {
+			return nil, fmt.Errorf("entry %q: want <cron> <duration> <min-scale>", strings.TrimSpace(entry))
+		}
+		cron, err := ParseCronSchedule(strings.Join(fields[:5], " "))
+		if err != nil {
+			return nil, fmt.Errorf("entry %q: %w", strings.TrimSpace(entry), err)
+		}
+		d, err := time.ParseDuration(fields[5])
+		if err != nil || d < time.Minute || d > MaxMinScaleWindow {
+			return nil, fmt.Errorf("entry %q: duration must be between %v and %v", strings.TrimSpace(entry), time.Minute, MaxMinScaleWindow)
+		}
+		ms, err := strconv.ParseInt(fields[6], 10, 32)
+		if err != nil || ms < 1 {
+			return nil, fmt.Errorf("entry %q: min-scale must be a positive integer", strings.TrimSpace(entry))
+		}
+		ret = append(ret, MinScaleWindow{Cron: cron, Duration: d, MinScale: int32(ms)})
+	}
+	if len(ret) == 0 {
+		return nil, errors.New("no schedule entries")
+	}
+	return ret, nil
+}
+
+// MinScale returns the largest min-scale of all the windows open at t,
+// or 0 if there is none.
+func (s MinScaleSchedule) MinScale(t time.Time) int32 {
+	var ret int32
+	for _, w := range s {
+		if w.MinScale > ret && w.Cron.MatchedWithin(t, w.Duration) {
+			ret = w.MinScale
+		}
+	}
+	return ret
+}
+
+// CronSchedule is a standard five field cron expression:
+// minute, hour, day of month, month and day of week.
+// Fields support `*`, lists, ranges and steps. Times are matched in UTC.
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// As in cron, if both day fields are restricted a day matches
+	// when either of them does.
+	domStar, dowStar bool
+}
+
+type cronField struct {
+	min, max int
+}
+
+var cronFields = [...]cronField{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+
+// ParseCronSchedule parses a five field cron expression.
+func ParseCronSchedule(v string) (CronSchedule, error) {
+	fields := strings.Fields(v)
+	if len(fields) != 5 {
+		return CronSchedule{}, fmt.Errorf("cron expression %q must have 5 fields", v)
+	}
+	var bits [5]uint64
+	for i, f := range fields {
+		b, err := parseCronField(f, cronFields[i])
+		if err != nil {
+			return CronSchedule{}, fmt.Errorf("cron field %q: %w", f, err)
+		}
+		bits[i] = b
+	}
+	// Sunday is both 0 and 7.
+	if bits[4]&(1<<7) != 0 {
+		bits[4] |= 1
+	}
+	return CronSchedule{
+		minute:  bits[0],
+		hour:    bits[1],
+		dom:     bits[2],
+		month:   bits[3],
+		dow:     bits[4],
+		domStar: fields[2] == "*",
+		dowStar: fields[4] == "*",
+	}, nil
+}
+
+func parseCronField(f string, r cronField) (uint64, error) {
+	var ret uint64
+	for part := range strings.SplitSeq(f, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			s, err := strconv.Atoi(stepStr)
+			if err != nil || s < 1 {
+				return 0, fmt.Errorf("invalid step %q", stepStr)
+			}
+			step = s
+		}
+		lo, hi := r.min, r.max
+		if rng != "*" {
+			loStr, hiStr, isRange := strings.Cut(rng, "-")
+			var err error
+			if lo, err = strconv.Atoi(loStr); err != nil {
+				return 0, fmt.Errorf("invalid value %q", loStr)
+			}
+			hi = lo
+			if isRange {
+				if hi, err = strconv.Atoi(hiStr); err != nil {
+					return 0, fmt.Errorf("invalid value %q", hiStr)
+				}
+			} else if hasStep {
+				hi = r.max
+			}
+			if lo < r.min || hi > r.max || lo > hi {
+				return 0, fmt.Errorf("%q out of range [%d, %d]", rng, r.min, r.max)
+			}
+		}
+		for i := lo; i <= hi; i += step {
+			ret |= 1 << i
+		}
+	}
+	return ret, nil
+}
+
+func (c CronSchedule) dayMatches(t time.Time) bool {
+	if c.month&(1<<int(t.Month())) == 0 {
+		return false
+	}
+	dom := c.dom&(1<<t.Day()) != 0
+	dow := c.dow&(1<<int(t.Weekday())) != 0
+	switch {
+	case c.domStar && c.dowStar:
+		return true
+	case c.domStar:
+		return dow
+	case c.dowStar:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+// Matches returns whether the schedule fires at the minute of t.
+func (c CronSchedule) Matches(t time.Time) bool {
+	t = t.UTC()
+	return c.minute&(1<<t.Minute()) != 0 && c.hour&(1<<t.Hour()) != 0 && c.dayMatches(t)
+}
+
+// MatchedWithin returns whether the schedule fired at any minute in (t-d, t].
+func (c CronSchedule) MatchedWithin(t time.Time, d time.Duration) bool {
+	t = t.UTC().Truncate(time.Minute)
+	earliest := t.Add(-d)
+	for cur := t; cur.After(earliest); {
+		switch {
+		case !c.dayMatches(cur):
+			// Skip to the last minute of the previous day.
+			cur = time.Date(cur.Year(), cur.Month(), cur.Day(), 0, 0, 0, 0, time.UTC).Add(-time.Minute)
+		case c.hour&(1<<cur.Hour()) == 0:
+			// Skip to the last minute of the previous hour.
+			cur = cur.Truncate(time.Hour).Add(-time.Minute)
+		case c.minute&(1<<cur.Minute()) == 0:
+			cur = cur.Add(-time.Minute)
+		default:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoscaling
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+	// A Monday.
+	base := time.Date(2026, time.March, 2, 8, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		cron    string
+		at      time.Time
+		want    bool
+		wantErr bool
+	}{{
+		name: "every minute",
+		cron: "* * * * *",
+		at:   base,
+		want: true,
+	}, {
+		name: "exact minute",
+		cron: "30 8 * * *",
+		at:   base,
+		want: true,
+	}, {
+		name: "wrong hour",
+		cron: "30 9 * * *",
+		at:   base,
+	}, {
+		name: "weekday range",
+		cron: "30 8 * * 1-5",
+		at:   base,
+		want: true,
+	}, {
+		name: "weekend",
+		cron: "30 8 * * 6,0",
+		at:   base,
+	}, {
+		name: "sunday as 7",
+		cron: "30 8 * * 7",
+		at:   base.AddDate(0, 0, 6),
+		want: true,
+	}, {
+		name: "steps",
+		cron: "*/15 */4 * * *",
+		at:   base,
+		want: true,
+	}, {
+		name: "step from offset",
+		cron: "10/20 * * * *",
+		at:   base,
+		want: true,
+	}, {
+		name: "day of month or day of week",
+		cron: "30 8 15 * 1",
+		at:   base,
+		want: true,
+	}, {
+		name: "month",
+		cron: "30 8 * 4 *",
+		at:   base,
+	}, {
+		name:    "too few fields",
+		cron:    "* * * *",
+		wantErr: true,
+	}, {
+		name:    "out of range",
+		cron:    "60 * * * *",
+		wantErr: true,
+	}, {
+		name:    "inverted range",
+		cron:    "* 5-3 * * *",
+		wantErr: true,
+	}, {
+		name:    "bad step",
+		cron:    "*/0 * * * *",
+		wantErr: true,
+	}, {
+		name:    "garbage",
+		cron:    "a * * * *",
+		wantErr: true,
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := ParseCronSchedule(tc.cron)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseCronSchedule() = %v, wantErr %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := c.Matches(tc.at); got != tc.want {
+				t.Errorf("Matches(%v) = %v, want %v", tc.at, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMinScaleSchedule(t *testing.T) {
+	s, err := ParseMinScaleSchedule("0 8 * * 1-5 11h 5; 0 18 * * * 2h 2 ;")
+	if err != nil {
+		t.Fatal("ParseMinScaleSchedule() =", err)
+	}
+
+	// 2026-03-02 is a Monday, 2026-03-07 a Saturday.
+	tests := []struct {
+		at   time.Time
+		want int32
+	}{
+		{time.Date(2026, time.March, 2, 7, 59, 0, 0, time.UTC), 0},
+		{time.Date(2026, time.March, 2, 8, 0, 0, 0, time.UTC), 5},
+		{time.Date(2026, time.March, 2, 17, 59, 59, 0, time.UTC), 5},
+		// Both windows are open, the larger min-scale wins.
+		{time.Date(2026, time.March, 2, 18, 0, 0, 0, time.UTC), 5},
+		{time.Date(2026, time.March, 2, 19, 0, 0, 0, time.UTC), 2},
+		{time.Date(2026, time.March, 2, 20, 0, 0, 0, time.UTC), 0},
+		{time.Date(2026, time.March, 7, 9, 0, 0, 0, time.UTC), 0},
+		{time.Date(2026, time.March, 7, 19, 0, 0, 0, time.UTC), 2},
+		// Other time zones are converted to UTC.
+		{time.Date(2026, time.March, 2, 9, 0, 0, 0, time.FixedZone("CET", 3600)), 5},
+	}
+	for _, tc := range tests {
+		if got := s.MinScale(tc.at); got != tc.want {
+			t.Errorf("MinScale(%v) = %d, want %d", tc.at, got, tc.want)
+		}
+	}
+}
+
+func TestParseMinScaleScheduleErrors(t *testing.T) {
+	for _, v := range []string{
+		"",
+		" ; ",
+		"0 8 * * * 10h",
+		"0 8 * * 10h 5",
+		"0 8 * * * 30s 5",
+		"0 8 * * * 200h 5",
+		"0 8 * * * 1h 0",
+		"0 8 * * * 1h many",
+		"0 25 * * * 1h 1",
+	} {
+		if _, err := ParseMinScaleSchedule(v); err == nil {
+			t.Errorf("ParseMinScaleSchedule(%q) = nil, wanted an error", v)
+		}
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"strconv"
+
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"knative.dev/pkg/apis"
 	"knative.dev/serving/pkg/apis/autoscaling"
@@ -86,3 +88,27 @@ func (m *Metric) AggregationAlgorithm() string {
 	}
 	return ""
 }
+
+// CustomMetric returns the name of the application defined gauge to scale on,
+// and the port and path of the user container endpoint serving it.
+// ok is false if the Metric does not scale on a custom metric.
+func (m *Metric) CustomMetric() (name string, port int, path string, ok bool) {
+	if _, v, _ := autoscaling.MetricAnnotation.Get(m.Annotations); v != autoscaling.Custom {
+		return "", 0, "", false
+	}
+	_, name, _ = autoscaling.CustomMetricNameAnnotation.Get(m.Annotations)
+	if name == "" {
+		return "", 0, "", false
+	}
+	port = autoscaling.CustomMetricPortDefault
+	if _, v, ok := autoscaling.CustomMetricPortAnnotation.Get(m.Annotations); ok {
+		if p, err := strconv.Atoi(v); err == nil {
+			port = p
+		}
+	}
+	path = autoscaling.CustomMetricPathDefault
+	if _, v, ok := autoscaling.CustomMetricPathAnnotation.Get(m.Annotations); ok && v != "" {
+		path = v
+	}
+	return name, port, path, true
+}
@@ -189,3 +189,54 @@ func TestMetricGetGroupVersionKind(t *testing.T) {
 		t.Errorf("got: %v, want: %v", got, want)
 	}
 }
+
+func TestMetricCustomMetric(t *testing.T) {
+	tests := []struct {
+		name     string
+		anns     map[string]string
+		wantName string
+		wantPort int
+		wantPath string
+		wantOK   bool
+	}{{
+		name: "not custom",
+		anns: map[string]string{autoscaling.MetricAnnotationKey: autoscaling.RPS},
+	}, {
+		name: "custom without a name",
+		anns: map[string]string{autoscaling.MetricAnnotationKey: autoscaling.Custom},
+	}, {
+		name: "defaults",
+		anns: map[string]string{
+			autoscaling.MetricAnnotationKey:           autoscaling.Custom,
+			autoscaling.CustomMetricNameAnnotationKey: "queue_length",
+		},
+		wantName: "queue_length",
+		wantPort: autoscaling.CustomMetricPortDefault,
+		wantPath: autoscaling.CustomMetricPathDefault,
+		wantOK:   true,
+	}, {
+		name: "explicit port and path",
+		anns: map[string]string{
+			autoscaling.MetricAnnotationKey:           autoscaling.Custom,
+			autoscaling.CustomMetricNameAnnotationKey: "queue_length",
+			autoscaling.CustomMetricPortAnnotationKey: "9091",
+			autoscaling.CustomMetricPathAnnotationKey: "/stats",
+		},
+		wantName: "queue_length",
+		wantPort: 9091,
+		wantPath: "/stats",
+		wantOK:   true,
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &Metric{}
+			m.Annotations = tc.anns
+			name, port, path, ok := m.CustomMetric()
+			if name != tc.wantName || port != tc.wantPort || path != tc.wantPath || ok != tc.wantOK {
+				t.Errorf("CustomMetric() = %q, %d, %q, %v; want %q, %d, %q, %v",
+					name, port, path, ok, tc.wantName, tc.wantPort, tc.wantPath, tc.wantOK)
+			}
+		})
+	}
+}
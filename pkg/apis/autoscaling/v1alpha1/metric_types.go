@@ -70,6 +70,31 @@ type MetricSpec struct {
 // MetricStatus reflects the status of metric collection for this specific entity.
 type MetricStatus struct {
 	duckv1.Status `json:",inline"`
+
+	// Requests summarizes the requests served over the stable window, as of
+	// the last time the metric collector published them. It is only set
+	// while requests are served.
+	// +optional
+	Requests *RequestSummary `json:"requests,omitempty"`
+}
+
+// RequestSummary summarizes the requests served over a window.
+type RequestSummary struct {
+	// ObservedTime is the end of the window.
+	ObservedTime metav1.Time `json:"observedTime"`
+
+	// Count is the number of requests served over the window.
+	Count int64 `json:"count"`
+
+	// ServerErrors is the number of those requests that failed with a 5xx
+	// status.
+	ServerErrors int64 `json:"serverErrors"`
+
+	// LatencyBuckets counts the requests by latency. The first bucket counts
+	// the requests served within 1ms, and the bound of each next bucket is
+	// 2^(1/4) times that of the previous one.
+	// +optional
+	LatencyBuckets []int64 `json:"latencyBuckets,omitempty"`
 }
 
 // MetricList is a list of Metric resources
@@ -113,6 +113,16 @@ func (pa *PodAutoscaler) Target() (float64, bool) {
 	return pa.annotationFloat64(autoscaling.TargetAnnotation)
 }
 
+// LatencyPercentile returns the percentile of the request latencies to scale
+// on as a fraction, when the metric is latency.
+func (pa *PodAutoscaler) LatencyPercentile() float64 {
+	// The value is validated in the webhook.
+	if p, ok := pa.annotationFloat64(autoscaling.LatencyPercentileAnnotation); ok {
+		return p / 100
+	}
+	return autoscaling.LatencyPercentileDefault / 100
+}
+
 // TargetUtilization returns the target utilization percentage as a fraction, if
 // the corresponding annotation is set.
 func (pa *PodAutoscaler) TargetUtilization() (float64, bool) {
@@ -179,6 +189,26 @@ func (pa *PodAutoscaler) InitialScale() (int32, bool) {
 	return pa.annotationInt32(autoscaling.InitialScaleAnnotation)
 }
 
+// PredictiveSeason returns the season the KPA forecasts the desired scale
+// over, or false if predictive scaling is not enabled.
+func (pa *PodAutoscaler) PredictiveSeason() (time.Duration, bool) {
+	// The value is validated in the webhook.
+	switch _, v, _ := autoscaling.PredictiveScalingAnnotation.Get(pa.Annotations); v {
+	case autoscaling.PredictiveScalingDaily:
+		return 24 * time.Hour, true
+	case autoscaling.PredictiveScalingWeekly:
+		return 7 * 24 * time.Hour, true
+	}
+	return 0, false
+}
+
+// MinScaleSchedule returns the min-scale schedule annotation value, or false if not present.
+func (pa *PodAutoscaler) MinScaleSchedule() (string, bool) {
+	// The value is validated in the webhook.
+	_, v, ok := autoscaling.MinScaleScheduleAnnotation.Get(pa.Annotations)
+	return v, ok
+}
+
 // IsReady returns true if the Status condition PodAutoscalerConditionReady
 // is true and the latest spec has been observed.
 func (pa *PodAutoscaler) IsReady() bool {
@@ -1268,6 +1268,75 @@ func TestInitialScale(t *testing.T) {
 	}
 }
 
+func TestPredictiveSeason(t *testing.T) {
+	cases := []struct {
+		name   string
+		pa     *PodAutoscaler
+		want   time.Duration
+		wantOK bool
+	}{{
+		name: "not present",
+		pa:   pa(map[string]string{}),
+	}, {
+		name: "daily",
+		pa: pa(map[string]string{
+			autoscaling.PredictiveScalingAnnotationKey: autoscaling.PredictiveScalingDaily,
+		}),
+		want:   24 * time.Hour,
+		wantOK: true,
+	}, {
+		name: "weekly",
+		pa: pa(map[string]string{
+			autoscaling.PredictiveScalingAnnotationKey: autoscaling.PredictiveScalingWeekly,
+		}),
+		want:   7 * 24 * time.Hour,
+		wantOK: true,
+	}, {
+		name: "invalid",
+		pa: pa(map[string]string{
+			autoscaling.PredictiveScalingAnnotationKey: "hourly",
+		}),
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, gotOK := tc.pa.PredictiveSeason()
+			if got != tc.want {
+				t.Errorf("PredictiveSeason = %v, want: %v", got, tc.want)
+			}
+			if gotOK != tc.wantOK {
+				t.Errorf("OK = %v, want: %v", gotOK, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestLatencyPercentile(t *testing.T) {
+	cases := []struct {
+		name string
+		pa   *PodAutoscaler
+		want float64
+	}{{
+		name: "not present",
+		pa:   pa(map[string]string{}),
+		want: 0.95,
+	}, {
+		name: "present",
+		pa: pa(map[string]string{
+			autoscaling.LatencyPercentileAnnotationKey: "99",
+		}),
+		want: 0.99,
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.pa.LatencyPercentile(); got != tc.want {
+				t.Errorf("LatencyPercentile = %v, want: %v", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestIsScaleTargetInitialized(t *testing.T) {
 	p := PodAutoscaler{}
 	if got, want := p.Status.IsScaleTargetInitialized(), false; got != want {
@@ -107,6 +107,11 @@ func (in *MetricSpec) DeepCopy() *MetricSpec {
 func (in *MetricStatus) DeepCopyInto(out *MetricStatus) {
 	*out = *in
 	in.Status.DeepCopyInto(&out.Status)
+	if in.Requests != nil {
+		in, out := &in.Requests, &out.Requests
+		*out = new(RequestSummary)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -327,3 +332,25 @@ func (in *PodScalableStatus) DeepCopy() *PodScalableStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequestSummary) DeepCopyInto(out *RequestSummary) {
+	*out = *in
+	in.ObservedTime.DeepCopyInto(&out.ObservedTime)
+	if in.LatencyBuckets != nil {
+		in, out := &in.LatencyBuckets, &out.LatencyBuckets
+		*out = make([]int64, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestSummary.
+func (in *RequestSummary) DeepCopy() *RequestSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(RequestSummary)
+	in.DeepCopyInto(out)
+	return out
+}
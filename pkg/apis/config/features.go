@@ -49,6 +49,10 @@ const (
 
 	// AllowHTTPFullDuplexFeatureKey gates the use of http1 full duplex per workload
 	AllowHTTPFullDuplexFeatureKey = "features.knative.dev/http-full-duplex"
+
+	// RequireImageDigestsFeatureKey gates requiring the images of a revision to
+	// be referenced by digest with the value 'enabled'
+	RequireImageDigestsFeatureKey = "features.knative.dev/require-image-digests"
 )
 
 // Feature config map keys that are used in schema-tweak
@@ -115,6 +119,7 @@ func defaultFeaturesConfig() *Features {
 		SecurePodDefaults:                Disabled,
 		TagHeaderBasedRouting:            Disabled,
 		AutoDetectHTTP2:                  Disabled,
+		RequireImageDigests:              Disabled,
 	}
 }
 
@@ -129,6 +134,7 @@ func NewFeaturesConfigFromMap(data map[string]string) (*Features, error) {
 		asFlag("multi-container-probing", &nc.MultiContainerProbing),
 		asFlag("queueproxy.mount-podinfo", &nc.QueueProxyMountPodInfo),
 		asFlag("queueproxy.resource-defaults", &nc.QueueProxyResourceDefaults),
+		asFlag("require-image-digests", &nc.RequireImageDigests),
 		asSecurePodDefaultsFlag("secure-pod-defaults", &nc.SecurePodDefaults),
 		asFlag("tag-header-based-routing", &nc.TagHeaderBasedRouting),
 		asFlag(FeatureContainerSpecAddCapabilities, &nc.ContainerSpecAddCapabilities),
@@ -202,6 +208,7 @@ type Features struct {
 	SecurePodDefaults                Flag
 	TagHeaderBasedRouting            Flag
 	AutoDetectHTTP2                  Flag
+	RequireImageDigests              Flag
 }
 
 // asFlag parses the value at key as a Flag into the target, if it exists.
@@ -408,6 +408,15 @@ func TestFeaturesConfiguration(t *testing.T) {
 		data: map[string]string{
 			"tag-header-based-routing": "Enabled",
 		},
+	}, {
+		name:    "require-image-digests Allowed",
+		wantErr: false,
+		wantFeatures: defaultWith(&Features{
+			RequireImageDigests: Allowed,
+		}),
+		data: map[string]string{
+			"require-image-digests": "Allowed",
+		},
 	}, {
 		name:    "kubernetes.podspec-volumes-emptyDir Disabled",
 		wantErr: false,
@@ -446,6 +446,29 @@ func ValidatePodSpec(ctx context.Context, ps corev1.PodSpec) *apis.FieldError {
 	return errs
 }
 
+// ValidateImageDigests validates that the images of the containers are
+// referenced by digest, rather than by tag.
+func ValidateImageDigests(ps corev1.PodSpec) (errs *apis.FieldError) {
+	for i := range ps.InitContainers {
+		errs = errs.Also(validateImageDigest(ps.InitContainers[i].Image).ViaFieldIndex("initContainers", i))
+	}
+	for i := range ps.Containers {
+		errs = errs.Also(validateImageDigest(ps.Containers[i].Image).ViaFieldIndex("containers", i))
+	}
+	return errs
+}
+
+func validateImageDigest(image string) *apis.FieldError {
+	if image == "" {
+		// Reported by the container validation.
+		return nil
+	}
+	if _, err := name.NewDigest(image, name.WeakValidation); err != nil {
+		return apis.ErrInvalidValue(image, "image", "image must be referenced by digest")
+	}
+	return nil
+}
+
 func validateInitContainers(ctx context.Context, containers, otherContainers []corev1.Container, volumes map[string]corev1.Volume) (errs *apis.FieldError) {
 	if len(containers) == 0 {
 		return nil
@@ -3593,6 +3593,43 @@ func TestVolumeValidation(t *testing.T) {
 	}
 }
 
+func TestValidateImageDigests(t *testing.T) {
+	const digest = "busybox@sha256:deadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef"
+	tests := []struct {
+		name string
+		ps   corev1.PodSpec
+		want *apis.FieldError
+	}{{
+		name: "digests",
+		ps: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Image: digest}},
+			Containers:     []corev1.Container{{Image: digest}},
+		},
+	}, {
+		name: "missing image",
+		ps: corev1.PodSpec{
+			Containers: []corev1.Container{{}},
+		},
+	}, {
+		name: "tags",
+		ps: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Image: "busybox"}},
+			Containers:     []corev1.Container{{Image: digest}, {Image: "busybox:latest"}},
+		},
+		want: apis.ErrInvalidValue("busybox", "initContainers[0].image", "image must be referenced by digest").Also(
+			apis.ErrInvalidValue("busybox:latest", "containers[1].image", "image must be referenced by digest")),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := ValidateImageDigests(test.ps)
+			if got, want := got.Error(), test.want.Error(); got != want {
+				t.Errorf("ValidateImageDigests (-want, +got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
+
 func TestObjectReferenceValidation(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -19,13 +19,16 @@ package serving
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	"knative.dev/pkg/apis"
+	"knative.dev/pkg/kmap"
 	"knative.dev/serving/pkg/apis/autoscaling"
 	"knative.dev/serving/pkg/apis/config"
 )
@@ -75,6 +78,98 @@ func ValidateRolloutDurationAnnotation(annos map[string]string) (errs *apis.Fiel
 	return errs
 }
 
+// ValidateRolloutAnalysisAnnotations validates the annotations gating the
+// rollout steps on the health of the latest revision.
+// These annotations can be set on either service or route objects.
+func ValidateRolloutAnalysisAnnotations(annos map[string]string) (errs *apis.FieldError) {
+	if k, v, _ := RolloutMaxErrorRateAnnotation.Get(annos); v != "" {
+		if rate, err := strconv.ParseFloat(v, 64); err != nil || rate < 0 || rate > 1 {
+			errs = errs.Also(apis.ErrOutOfBoundsValue(v, 0, 1, k))
+		}
+	}
+	if k, v, _ := RolloutMaxLatencyAnnotation.Get(annos); v != "" {
+		if d, err := time.ParseDuration(v); err != nil || d <= 0 {
+			errs = errs.Also(apis.ErrInvalidValue(v, k))
+		}
+	}
+	if k, v, _ := RolloutLatencyPercentileAnnotation.Get(annos); v != "" {
+		if p, err := strconv.ParseFloat(v, 64); err != nil || p <= 0 || p > 100 {
+			errs = errs.Also(&apis.FieldError{
+				Message: fmt.Sprintf("rollout-latency-percentile=%s should be in (0, 100]", v),
+				Paths:   []string{k},
+			})
+		}
+	}
+	if k, v, _ := RolloutMinRequestsAnnotation.Get(annos); v != "" {
+		if n, err := strconv.Atoi(v); err != nil || n < 1 {
+			errs = errs.Also(apis.ErrInvalidValue(v, k, "must be a positive integer"))
+		}
+	}
+	return errs
+}
+
+// ValidateRolloutPlanAnnotations validates the rollout plan annotation and its
+// approval. These annotations can be set on either service or route objects.
+func ValidateRolloutPlanAnnotations(annos map[string]string) (errs *apis.FieldError) {
+	if k, v, _ := RolloutPlanAnnotation.Get(annos); v != "" {
+		if _, err := ParseRolloutPlan(v); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(v, k, err.Error()))
+		}
+	}
+	if k, v, _ := RolloutApproveAnnotation.Get(annos); v != "" {
+		if _, _, err := ParseRolloutApproval(v); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(v, k, err.Error()))
+		}
+	}
+	return errs
+}
+
+// ValidateSplitAffinityAnnotation validates the traffic split affinity annotation.
+func ValidateSplitAffinityAnnotation(annos map[string]string) *apis.FieldError {
+	if k, v, ok := SplitAffinityAnnotation.Get(annos); ok {
+		kind, name, _ := strings.Cut(v, ":")
+		if (kind != "header" && kind != "cookie") || name == "" {
+			return apis.ErrInvalidValue(v, k)
+		}
+	}
+	return nil
+}
+
+// ValidateGCAnnotations validates the garbage collection annotations.
+// These annotations can be set on either service or configuration objects.
+func ValidateGCAnnotations(annos map[string]string) (errs *apis.FieldError) {
+	for _, kp := range []kmap.KeyPriority{GCRetainSinceCreateTimeAnnotation, GCRetainSinceLastActiveTimeAnnotation} {
+		if k, v, ok := kp.Get(annos); ok && !strings.EqualFold(v, "disabled") {
+			if d, err := time.ParseDuration(v); err != nil || d < 0 {
+				errs = errs.Also(apis.ErrInvalidValue(v, k, `must be a non-negative duration or "disabled"`))
+			}
+		}
+	}
+	if k, v, ok := GCMinNonActiveRevisionsAnnotation.Get(annos); ok {
+		if _, err := strconv.ParseUint(v, 10, 63); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(v, k, "must be a non-negative integer"))
+		}
+	}
+	if k, v, ok := GCMaxNonActiveRevisionsAnnotation.Get(annos); ok && !strings.EqualFold(v, "disabled") {
+		if _, err := strconv.ParseUint(v, 10, 63); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(v, k, `must be a non-negative integer or "disabled"`))
+		}
+	}
+	if k, v, ok := GCDryRunAnnotation.Get(annos); ok {
+		if _, err := strconv.ParseBool(v); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(v, k))
+		}
+	}
+	if k, v, ok := RestoreRevisionsAnnotation.Get(annos); ok {
+		for _, name := range strings.Split(v, ",") {
+			if msgs := validation.IsDNS1035Label(strings.TrimSpace(name)); len(msgs) > 0 {
+				errs = errs.Also(apis.ErrInvalidValue(v, k, msgs...))
+			}
+		}
+	}
+	return errs
+}
+
 // ValidateHasNoAutoscalingAnnotation validates that the respective entity does not have
 // annotations from the autoscaling group. It's to be used to validate Service and
 // Configuration.
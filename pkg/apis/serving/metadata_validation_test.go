@@ -491,3 +491,61 @@ func TestValidateRolloutDurationAnnotation(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateGCAnnotations(t *testing.T) {
+	tests := []struct {
+		name  string
+		annos map[string]string
+		want  string
+	}{{
+		name: "empty",
+	}, {
+		name: "valid",
+		annos: map[string]string{
+			GCRetainSinceCreateTimeKey:     "48h",
+			GCRetainSinceLastActiveTimeKey: "disabled",
+			GCMinNonActiveRevisionsKey:     "2",
+			GCMaxNonActiveRevisionsKey:     "Disabled",
+			GCDryRunKey:                    "true",
+			RestoreRevisionsKey:            "hello-00001, hello-00002",
+		},
+	}, {
+		name:  "invalid duration",
+		annos: map[string]string{GCRetainSinceCreateTimeKey: "two days"},
+		want: `invalid value: two days: serving.knative.dev/gc-retain-since-create-time
+must be a non-negative duration or "disabled"`,
+	}, {
+		name:  "negative duration",
+		annos: map[string]string{GCRetainSinceLastActiveTimeKey: "-1h"},
+		want: `invalid value: -1h: serving.knative.dev/gc-retain-since-last-active-time
+must be a non-negative duration or "disabled"`,
+	}, {
+		name:  "min disabled",
+		annos: map[string]string{GCMinNonActiveRevisionsKey: "disabled"},
+		want: `invalid value: disabled: serving.knative.dev/gc-min-non-active-revisions
+must be a non-negative integer`,
+	}, {
+		name:  "negative max",
+		annos: map[string]string{GCMaxNonActiveRevisionsKey: "-1"},
+		want: `invalid value: -1: serving.knative.dev/gc-max-non-active-revisions
+must be a non-negative integer or "disabled"`,
+	}, {
+		name:  "invalid dry-run",
+		annos: map[string]string{GCDryRunKey: "maybe"},
+		want:  "invalid value: maybe: serving.knative.dev/gc-dry-run",
+	}, {
+		name:  "invalid restore revision",
+		annos: map[string]string{RestoreRevisionsKey: "hello-00001,"},
+		want: `invalid value: hello-00001,: serving.knative.dev/restore-revisions
+a DNS-1035 label must consist of lower case alphanumeric characters or '-', start with an alphabetic character, and end with an alphanumeric character (e.g. 'my-name',  or 'abc-123', regex used for validation is '[a-z]([-a-z0-9]*[a-z0-9])?')`,
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateGCAnnotations(tc.annos)
+			if got, want := err.Error(), tc.want; got != want {
+				t.Errorf("APIErr mismatch, diff(-want,+got):\n%s", cmp.Diff(want, got))
+			}
+		})
+	}
+}
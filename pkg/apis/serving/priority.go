@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serving
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PriorityClass is a single request priority class declared by the
+// PriorityClassesAnnotationKey annotation.
+type PriorityClass struct {
+	// Name is matched against the priority header or the route tag of the request.
+	Name string
+	// QueueShare is the percentage of the queue depth reserved for this class.
+	QueueShare int
+	// Weight is the relative share of dequeues for this class when the
+	// queue is contended. Zero for all the classes means strict priority.
+	Weight int
+}
+
+// ParsePriorityClasses parses the value of the PriorityClassesAnnotationKey annotation.
+// The value is a comma separated list of classes, from the highest to the lowest
+// priority, in the form `<name>[:<queue-share-percent>[:<weight>]]`.
+// Classes without an explicit queue share split the remainder equally.
+// If any class has a weight, classes without one get weight 1 and dequeueing
+// is weighted-fair, otherwise it is strict priority.
+func ParsePriorityClasses(v string) ([]PriorityClass, error) {
+	parts := strings.Split(v, ",")
+	classes := make([]PriorityClass, 0, len(parts))
+	seen := make(map[string]struct{}, len(parts))
+	var (
+		totalShare, unshared int
+		weighted             bool
+	)
+	for _, p := range parts {
+		fields := strings.Split(strings.TrimSpace(p), ":")
+		if len(fields) > 3 {
+			return nil, fmt.Errorf("priority class %q has too many fields", p)
+		}
+		pc := PriorityClass{Name: fields[0]}
+		if pc.Name == "" {
+			return nil, fmt.Errorf("priority class %q has no name", p)
+		}
+		if _, ok := seen[pc.Name]; ok {
+			return nil, fmt.Errorf("priority class %q is declared more than once", pc.Name)
+		}
+		seen[pc.Name] = struct{}{}
+
+		if len(fields) > 1 && fields[1] != "" {
+			share, err := strconv.Atoi(fields[1])
+			if err != nil || share < 1 || share > 100 {
+				return nil, fmt.Errorf("priority class %q queue share must be an integer in [1, 100]", pc.Name)
+			}
+			pc.QueueShare = share
+			totalShare += share
+		} else {
+			unshared++
+		}
+		if len(fields) > 2 {
+			weight, err := strconv.Atoi(fields[2])
+			if err != nil || weight < 1 {
+				return nil, fmt.Errorf("priority class %q weight must be a positive integer", pc.Name)
+			}
+			pc.Weight = weight
+			weighted = true
+		}
+		classes = append(classes, pc)
+	}
+
+	if totalShare > 100 {
+		return nil, errors.New("priority class queue shares add up to more than 100")
+	}
+	if totalShare == 100 && unshared > 0 {
+		return nil, errors.New("priority class queue shares leave nothing for the classes without one")
+	}
+	for i := range classes {
+		if classes[i].QueueShare == 0 {
+			classes[i].QueueShare = max(1, (100-totalShare)/unshared)
+		}
+		if weighted && classes[i].Weight == 0 {
+			classes[i].Weight = 1
+		}
+	}
+	return classes, nil
+}
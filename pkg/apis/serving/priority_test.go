@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serving
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParsePriorityClasses(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []PriorityClass
+		wantErr bool
+	}{{
+		name:  "names only",
+		value: "premium,default,bulk",
+		want: []PriorityClass{
+			{Name: "premium", QueueShare: 33},
+			{Name: "default", QueueShare: 33},
+			{Name: "bulk", QueueShare: 33},
+		},
+	}, {
+		name:  "shares and weights",
+		value: "health:10, premium:30:8,bulk",
+		want: []PriorityClass{
+			{Name: "health", QueueShare: 10, Weight: 1},
+			{Name: "premium", QueueShare: 30, Weight: 8},
+			{Name: "bulk", QueueShare: 60, Weight: 1},
+		},
+	}, {
+		name:  "weight without share",
+		value: "premium::4,bulk::1",
+		want: []PriorityClass{
+			{Name: "premium", QueueShare: 50, Weight: 4},
+			{Name: "bulk", QueueShare: 50, Weight: 1},
+		},
+	}, {
+		name:    "empty name",
+		value:   "premium,,bulk",
+		wantErr: true,
+	}, {
+		name:    "duplicate name",
+		value:   "bulk,bulk",
+		wantErr: true,
+	}, {
+		name:    "too many fields",
+		value:   "premium:10:1:1",
+		wantErr: true,
+	}, {
+		name:    "invalid share",
+		value:   "premium:ten",
+		wantErr: true,
+	}, {
+		name:    "invalid weight",
+		value:   "premium:10:0",
+		wantErr: true,
+	}, {
+		name:    "shares over 100",
+		value:   "premium:60,bulk:50",
+		wantErr: true,
+	}, {
+		name:    "no share left",
+		value:   "premium:100,bulk",
+		wantErr: true,
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParsePriorityClasses(tc.value)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParsePriorityClasses() = %v, wantErr: %v", err, tc.wantErr)
+			}
+			if !cmp.Equal(got, tc.want) {
+				t.Error("ParsePriorityClasses (-want, +got):", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
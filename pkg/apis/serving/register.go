@@ -40,6 +40,42 @@ const (
 	// from automatically deleting the revision.
 	RevisionPreservedAnnotationKey = GroupName + "/no-gc"
 
+	// GCRetainSinceCreateTimeKey, GCRetainSinceLastActiveTimeKey,
+	// GCMinNonActiveRevisionsKey and GCMaxNonActiveRevisionsKey are annotations
+	// attached to a Configuration, or a Service, overriding the settings of
+	// the same name of the config-gc config map for its revisions.
+	GCRetainSinceCreateTimeKey     = GroupName + "/gc-retain-since-create-time"
+	GCRetainSinceLastActiveTimeKey = GroupName + "/gc-retain-since-last-active-time"
+	GCMinNonActiveRevisionsKey     = GroupName + "/gc-min-non-active-revisions"
+	GCMaxNonActiveRevisionsKey     = GroupName + "/gc-max-non-active-revisions"
+
+	// GCDryRunKey is an annotation attached to a Configuration, or a Service,
+	// which when "true" makes the garbage collector report the revisions it
+	// would delete, through events and the status of the Configuration,
+	// instead of deleting them.
+	GCDryRunKey = GroupName + "/gc-dry-run"
+
+	// RestoreRevisionsKey is an annotation attached to a Configuration, or a
+	// Service, holding a comma separated list of the names of its revisions
+	// to restore from their garbage collection archive. The listed revisions
+	// aren't garbage collected again while they are listed.
+	RestoreRevisionsKey = GroupName + "/restore-revisions"
+
+	// CertificateExpiryWarningKey is an annotation attached to a DomainMapping
+	// holding how long before the expiry of its externally provided TLS
+	// certificate to warn about it, e.g. "720h".
+	CertificateExpiryWarningKey = GroupName + "/certificate-expiry-warning"
+
+	// CertificateIssuerKey is an annotation, or a label, naming the entry of
+	// the cert-manager domain issuers issuing the certificates of a Route, a
+	// DomainMapping or a Namespace. It overrides the selection of the issuer
+	// by domain.
+	CertificateIssuerKey = GroupName + "/certificate-issuer"
+
+	// ArchivedRevisionLabelKey is the label attached to the garbage collection
+	// archive of a Revision, holding the name of the archived Revision.
+	ArchivedRevisionLabelKey = GroupName + "/archivedRevision"
+
 	// RouteLabelKey is the label key attached to a Configuration indicating by
 	// which Route it is configured as traffic target.
 	// The key is also attached to Revision resources to indicate they are directly
@@ -60,6 +96,62 @@ const (
 	// The value can be specified with at most with a second precision.
 	RolloutDurationKey = GroupName + "/rollout-duration"
 
+	// RolloutMaxErrorRateKey is an annotation attached to a Route to gate each
+	// step of the rollout of the latest revision on its error rate. The value
+	// is the highest fraction of requests, between 0 and 1, the revision may
+	// fail with a 5xx status before it is rolled back.
+	RolloutMaxErrorRateKey = GroupName + "/rollout-max-error-rate"
+
+	// RolloutMaxLatencyKey is an annotation attached to a Route to gate each
+	// step of the rollout of the latest revision on its latency. The value is
+	// the highest latency, at the RolloutLatencyPercentileKey percentile, the
+	// revision may respond with before it is rolled back, as a Golang
+	// time.Duration value serialized to string.
+	RolloutMaxLatencyKey = GroupName + "/rollout-max-latency"
+
+	// RolloutLatencyPercentileKey is the percentile of the latency gated by
+	// RolloutMaxLatencyKey, in (0, 100]. Defaults to RolloutLatencyPercentileDefault.
+	RolloutLatencyPercentileKey = GroupName + "/rollout-latency-percentile"
+
+	// RolloutLatencyPercentileDefault is the default latency percentile gated
+	// during rollouts.
+	RolloutLatencyPercentileDefault = 95.
+
+	// RolloutMinRequestsKey is an annotation attached to a Route setting the
+	// fewest requests the latest revision must serve during a step of its
+	// rollout for the step to be analyzed. The rollout is held at the step
+	// until then. Defaults to RolloutMinRequestsDefault.
+	RolloutMinRequestsKey = GroupName + "/rollout-min-requests"
+
+	// RolloutMinRequestsDefault is the default number of requests needed to
+	// analyze a rollout step.
+	RolloutMinRequestsDefault = 20
+
+	// RolloutPlanKey is an annotation attached to a Route to roll out the
+	// latest revisions in explicit stages, rather than in equal steps over the
+	// rollout duration. The plan is a comma separated list of stages, each
+	// either `<percent>%:<duration>`, holding the latest revision at the given
+	// share of its configuration traffic for the duration, or `pause`, holding
+	// it until approved with RolloutApproveKey, e.g. `1%:10m,5%:30m,pause,50%:10m`.
+	// The latest revision receives all the traffic after the last stage.
+	RolloutPlanKey = GroupName + "/rollout-plan"
+
+	// RolloutApproveKey is an annotation attached to a Route to resume the
+	// rollout of a revision paused at a `pause` stage of the rollout plan.
+	// The value is `<revision name>:<stage>`, where stage is the index of the
+	// pause in the plan, as reported in the Route status.
+	RolloutApproveKey = GroupName + "/rollout-approve"
+
+	// SplitAffinityKey is an annotation attached to a Route to consistently
+	// route the requests with the same key to the same revision of a traffic
+	// split, instead of splitting each request independently. The value has
+	// the form `header:<name>` or `cookie:<name>`. The requests assigned to a
+	// revision stay assigned to it while its percentage grows, e.g. during a
+	// rollout. The traffic splits of the Routes with this annotation go
+	// through the activator, which assigns the requests; those of the other
+	// Routes are split by the ingress directly.
+	SplitAffinityKey = GroupName + "/split-affinity"
+
 	// RoutingStateLabelKey is the label attached to a Revision indicating
 	// its state in relation to serving a Route.
 	RoutingStateLabelKey = GroupName + "/routingState"
@@ -103,6 +195,11 @@ const (
 	// DomainMapping was created in.
 	DomainMappingNamespaceLabelKey = GroupName + "/domainMappingNamespace"
 
+	// WildcardDomainAnnotationKey is the annotation key attached to the
+	// ClusterDomainClaims of the wildcard DomainMappings, holding the domain
+	// whose subdomains they claim.
+	WildcardDomainAnnotationKey = GroupName + "/wildcardDomain"
+
 	// ConfigurationGenerationLabelKey is the label key attached to a Revision indicating the
 	// metadata generation of the Configuration that created this revision
 	ConfigurationGenerationLabelKey = GroupName + "/configurationGeneration"
@@ -149,6 +246,54 @@ const (
 
 	// ProgressDeadlineAnnotationKey is the label key for the per revision progress deadline to set for the deployment
 	ProgressDeadlineAnnotationKey = GroupName + "/progress-deadline"
+
+	// LoadBalancingPolicyAnnotationKey is the annotation key attached to a Revision
+	// to select the policy the activator uses to pick a pod for a request.
+	// If unset, the activator picks the policy based on the container concurrency.
+	LoadBalancingPolicyAnnotationKey = GroupName + "/load-balancing-policy"
+
+	// LoadBalancingPolicyPeakEWMA is the LoadBalancingPolicyAnnotationKey value
+	// that selects the pod with the lowest peak-EWMA of the response latency
+	// weighted by the number of requests in flight.
+	LoadBalancingPolicyPeakEWMA = "peak-ewma"
+
+	// LoadBalancingPolicyConsistentHash is the LoadBalancingPolicyAnnotationKey value
+	// that consistently sends requests with the same hash key to the same pod,
+	// whichever activator receives them, as long as it has capacity. The key
+	// is configured by LoadBalancingHashKeyAnnotationKey.
+	LoadBalancingPolicyConsistentHash = "consistent-hash"
+
+	// LoadBalancingHashKeyAnnotationKey is the annotation key attached to a Revision
+	// to select the part of the request used as the consistent hashing key.
+	// The value has the form `header:<name>`, `cookie:<name>` or `query:<name>`.
+	LoadBalancingHashKeyAnnotationKey = GroupName + "/load-balancing-hash-key"
+
+	// PriorityClassesAnnotationKey is the annotation key attached to a Revision
+	// to declare request priority classes, see ParsePriorityClasses for the format.
+	// Requests are classified by the PriorityHeaderName header or their route tag
+	// and queued by the queue-proxy and the activator accordingly.
+	PriorityClassesAnnotationKey = GroupName + "/priority-classes"
+
+	// PriorityHeaderName is the name of the request header which selects the
+	// request priority class.
+	PriorityHeaderName = "Knative-Serving-Priority"
+
+	// AdaptiveConcurrencyAnnotationKey is the annotation key attached to a
+	// Revision to let the queue-proxy tune its concurrency limit from the
+	// observed request latencies, up to the container concurrency. Requests in
+	// excess of the limit are shed. The value selects the algorithm. It requires
+	// a non-zero container concurrency.
+	AdaptiveConcurrencyAnnotationKey = GroupName + "/adaptive-concurrency"
+
+	// AdaptiveConcurrencyGradient is the AdaptiveConcurrencyAnnotationKey value
+	// that moves the limit along the ratio of the long-term to the short-term
+	// request latency.
+	AdaptiveConcurrencyGradient = "gradient"
+
+	// AdaptiveConcurrencyAIMD is the AdaptiveConcurrencyAnnotationKey value that
+	// additively increases the limit while requests succeed and multiplicatively
+	// decreases it when they fail with an overload status.
+	AdaptiveConcurrencyAIMD = "aimd"
 )
 
 var (
@@ -182,6 +327,48 @@ var (
 		RolloutDurationKey,
 		GroupName + "/rolloutDuration",
 	}
+	RolloutMaxErrorRateAnnotation = kmap.KeyPriority{
+		RolloutMaxErrorRateKey,
+	}
+	RolloutMaxLatencyAnnotation = kmap.KeyPriority{
+		RolloutMaxLatencyKey,
+	}
+	RolloutLatencyPercentileAnnotation = kmap.KeyPriority{
+		RolloutLatencyPercentileKey,
+	}
+	RolloutMinRequestsAnnotation = kmap.KeyPriority{
+		RolloutMinRequestsKey,
+	}
+	RolloutPlanAnnotation = kmap.KeyPriority{
+		RolloutPlanKey,
+	}
+	RolloutApproveAnnotation = kmap.KeyPriority{
+		RolloutApproveKey,
+	}
+	SplitAffinityAnnotation = kmap.KeyPriority{
+		SplitAffinityKey,
+	}
+	GCRetainSinceCreateTimeAnnotation = kmap.KeyPriority{
+		GCRetainSinceCreateTimeKey,
+	}
+	GCRetainSinceLastActiveTimeAnnotation = kmap.KeyPriority{
+		GCRetainSinceLastActiveTimeKey,
+	}
+	GCMinNonActiveRevisionsAnnotation = kmap.KeyPriority{
+		GCMinNonActiveRevisionsKey,
+	}
+	GCMaxNonActiveRevisionsAnnotation = kmap.KeyPriority{
+		GCMaxNonActiveRevisionsKey,
+	}
+	GCDryRunAnnotation = kmap.KeyPriority{
+		GCDryRunKey,
+	}
+	RestoreRevisionsAnnotation = kmap.KeyPriority{
+		RestoreRevisionsKey,
+	}
+	CertificateExpiryWarningAnnotation = kmap.KeyPriority{
+		CertificateExpiryWarningKey,
+	}
 	QueueSidecarResourcePercentageAnnotation = kmap.KeyPriority{
 		QueueSidecarResourcePercentageAnnotationKey,
 		"queue.sidecar." + GroupName + "/resourcePercentage",
@@ -207,4 +394,16 @@ var (
 	ProgressDeadlineAnnotation = kmap.KeyPriority{
 		ProgressDeadlineAnnotationKey,
 	}
+	LoadBalancingPolicyAnnotation = kmap.KeyPriority{
+		LoadBalancingPolicyAnnotationKey,
+	}
+	LoadBalancingHashKeyAnnotation = kmap.KeyPriority{
+		LoadBalancingHashKeyAnnotationKey,
+	}
+	PriorityClassesAnnotation = kmap.KeyPriority{
+		PriorityClassesAnnotationKey,
+	}
+	AdaptiveConcurrencyAnnotation = kmap.KeyPriority{
+		AdaptiveConcurrencyAnnotationKey,
+	}
 )
@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serving
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// rolloutPause is the stage of a rollout plan waiting for approval.
+const rolloutPause = "pause"
+
+// RolloutStage is a single stage of a rollout plan.
+type RolloutStage struct {
+	// Percent is the share of the configuration traffic, in [1, 99], routed
+	// to the latest revision during the stage.
+	Percent int `json:"percent,omitempty"`
+
+	// Duration is how long the stage lasts.
+	Duration time.Duration `json:"duration,omitempty"`
+
+	// Pause holds the rollout at the previous stage until approved.
+	Pause bool `json:"pause,omitempty"`
+}
+
+// ParseRolloutPlan parses the rollout plan from the value of the
+// RolloutPlanKey annotation.
+func ParseRolloutPlan(v string) ([]RolloutStage, error) {
+	parts := strings.Split(v, ",")
+	plan := make([]RolloutStage, 0, len(parts))
+	last := 0
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		if p == rolloutPause {
+			plan = append(plan, RolloutStage{Pause: true})
+			continue
+		}
+		pct, dur, ok := strings.Cut(p, ":")
+		if !ok || !strings.HasSuffix(pct, "%") {
+			return nil, fmt.Errorf("stage %q is neither %q nor <percent>%%:<duration>", p, rolloutPause)
+		}
+		percent, err := strconv.Atoi(strings.TrimSuffix(pct, "%"))
+		if err != nil || percent <= last || percent >= 100 {
+			return nil, fmt.Errorf("stage %q percent must be above the previous stages and below 100", p)
+		}
+		d, err := time.ParseDuration(dur)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("stage %q duration must be positive", p)
+		}
+		last = percent
+		plan = append(plan, RolloutStage{Percent: percent, Duration: d})
+	}
+	return plan, nil
+}
+
+// ParseRolloutApproval parses the revision and the plan stage approved by
+// the value of the RolloutApproveKey annotation.
+func ParseRolloutApproval(v string) (string, int, error) {
+	rev, s, ok := strings.Cut(v, ":")
+	if !ok || rev == "" {
+		return "", 0, errors.New("approval must be <revision name>:<stage>")
+	}
+	stage, err := strconv.Atoi(s)
+	if err != nil || stage < 0 {
+		return "", 0, fmt.Errorf("stage %q must be a non-negative integer", s)
+	}
+	return rev, stage, nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serving
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseRolloutPlan(t *testing.T) {
+	tests := []struct {
+		name    string
+		plan    string
+		want    []RolloutStage
+		wantErr bool
+	}{{
+		name: "stages and pause",
+		plan: "1%:10m, 5%:30m,pause,50%:1h",
+		want: []RolloutStage{
+			{Percent: 1, Duration: 10 * time.Minute},
+			{Percent: 5, Duration: 30 * time.Minute},
+			{Pause: true},
+			{Percent: 50, Duration: time.Hour},
+		},
+	}, {
+		name: "pause only",
+		plan: "pause",
+		want: []RolloutStage{{Pause: true}},
+	}, {
+		name:    "empty",
+		plan:    "",
+		wantErr: true,
+	}, {
+		name:    "no duration",
+		plan:    "1%,50%:1m",
+		wantErr: true,
+	}, {
+		name:    "no percent sign",
+		plan:    "1:1m",
+		wantErr: true,
+	}, {
+		name:    "decreasing percent",
+		plan:    "10%:1m,5%:1m",
+		wantErr: true,
+	}, {
+		name:    "all the traffic",
+		plan:    "100%:1m",
+		wantErr: true,
+	}, {
+		name:    "zero duration",
+		plan:    "10%:0s",
+		wantErr: true,
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseRolloutPlan(tc.plan)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseRolloutPlan(%q) = %v, wantErr: %v", tc.plan, err, tc.wantErr)
+			}
+			if !cmp.Equal(got, tc.want) {
+				t.Error("ParseRolloutPlan (-want, +got):", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
+func TestParseRolloutApproval(t *testing.T) {
+	rev, stage, err := ParseRolloutApproval("config-00002:3")
+	if err != nil || rev != "config-00002" || stage != 3 {
+		t.Errorf("ParseRolloutApproval = %q, %d, %v; want config-00002, 3, nil", rev, stage, err)
+	}
+	for _, v := range []string{"config-00002", ":3", "config-00002:-1", "config-00002:three"} {
+		if _, _, err := ParseRolloutApproval(v); err == nil {
+			t.Errorf("ParseRolloutApproval(%q) succeeded, want error", v)
+		}
+	}
+}
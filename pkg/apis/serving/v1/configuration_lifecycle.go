@@ -17,9 +17,12 @@ limitations under the License.
 package v1
 
 import (
+	"strings"
+
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"knative.dev/pkg/apis"
+	"knative.dev/serving/pkg/apis/serving"
 )
 
 var configCondSet = apis.NewLivingConditionSet()
@@ -34,6 +37,20 @@ func (*Configuration) GetGroupVersionKind() schema.GroupVersionKind {
 	return SchemeGroupVersion.WithKind("Configuration")
 }
 
+// RestoreRevisions returns the names of the revisions to restore from their
+// garbage collection archive, as specified in an annotation.
+func (c *Configuration) RestoreRevisions() []string {
+	_, v, ok := serving.RestoreRevisionsAnnotation.Get(c.Annotations)
+	if !ok || v == "" {
+		return nil
+	}
+	names := strings.Split(v, ",")
+	for i := range names {
+		names[i] = strings.TrimSpace(names[i])
+	}
+	return names
+}
+
 // IsReady returns true if the Status condition ConfigurationConditionReady
 // is true and the latest spec has been observed.
 func (c *Configuration) IsReady() bool {
@@ -20,12 +20,15 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/apis/duck"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	apistest "knative.dev/pkg/apis/testing"
+	"knative.dev/serving/pkg/apis/serving"
 )
 
 func TestConfigurationDuckTypes(t *testing.T) {
@@ -67,6 +70,36 @@ func TestConfigurationGetGroupVersionKind(t *testing.T) {
 	}
 }
 
+func TestConfigurationRestoreRevisions(t *testing.T) {
+	tests := []struct {
+		name  string
+		annos map[string]string
+		want  []string
+	}{{
+		name: "no annotation",
+	}, {
+		name:  "empty",
+		annos: map[string]string{serving.RestoreRevisionsKey: ""},
+	}, {
+		name:  "single",
+		annos: map[string]string{serving.RestoreRevisionsKey: "hello-00001"},
+		want:  []string{"hello-00001"},
+	}, {
+		name:  "list",
+		annos: map[string]string{serving.RestoreRevisionsKey: "hello-00001, hello-00002"},
+		want:  []string{"hello-00001", "hello-00002"},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &Configuration{ObjectMeta: metav1.ObjectMeta{Annotations: test.annos}}
+			if got := c.RestoreRevisions(); !cmp.Equal(got, test.want) {
+				t.Error("RestoreRevisions (-want, +got):", cmp.Diff(test.want, got))
+			}
+		})
+	}
+}
+
 func TestConfigurationIsReady(t *testing.T) {
 	cases := []struct {
 		name    string
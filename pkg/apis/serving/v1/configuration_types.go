@@ -98,6 +98,11 @@ type ConfigurationStatus struct {
 	duckv1.Status `json:",inline"`
 
 	ConfigurationStatusFields `json:",inline"`
+
+	// RevisionsToCollect lists the names of the Revisions the garbage
+	// collector would delete, were it not in dry-run mode.
+	// +optional
+	RevisionsToCollect []string `json:"revisionsToCollect,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -33,6 +33,7 @@ func (c *Configuration) Validate(ctx context.Context) (errs *apis.FieldError) {
 	if !apis.IsInStatusUpdate(ctx) {
 		errs = errs.Also(serving.ValidateObjectMetadata(ctx, c.GetObjectMeta(), false))
 		errs = errs.Also(c.validateLabels().ViaField("labels"))
+		errs = errs.Also(serving.ValidateGCAnnotations(c.GetAnnotations()).ViaField("annotations"))
 		errs = errs.ViaField("metadata")
 
 		ctx = apis.WithinParent(ctx, c.ObjectMeta)
@@ -49,9 +49,29 @@ const (
 	// ReasonProgressDeadlineExceeded defines the reason for marking revision availability
 	// status as false if progress has exceeded the deadline.
 	ReasonProgressDeadlineExceeded = "ProgressDeadlineExceeded"
+
+	// ReasonDigestNotAllowed defines the reason for marking the image policy status
+	// as false if a resolved digest is not in the allow-list.
+	ReasonDigestNotAllowed = "DigestNotAllowed"
+
+	// ReasonDigestNotResolved defines the reason for marking the image policy status
+	// as false if an image is tagged in a registry skipping tag resolution, so its
+	// digest can't be checked.
+	ReasonDigestNotResolved = "DigestNotResolved"
+
+	// ReasonSignatureVerificationFailed defines the reason for marking the image policy
+	// status as false if a resolved digest isn't signed by any of the trusted keys.
+	ReasonSignatureVerificationFailed = "SignatureVerificationFailed"
+
+	// ReasonTagDrifted defines the reason for marking the image policy status as false
+	// if a tag now resolves to a digest other than the one the revision runs.
+	ReasonTagDrifted = "TagDrifted"
 )
 
 // RevisionConditionActive is not part of the RevisionConditionSet because we can have Inactive Ready Revisions (scale to zero)
+// RevisionConditionImagePolicySatisfied is not part of the RevisionConditionSet either,
+// as it is only set when an image policy is configured. Images violating the policy
+// fail the ContainerHealthy condition instead.
 var revisionCondSet = apis.NewLivingConditionSet(
 	RevisionConditionResourcesAvailable,
 	RevisionConditionContainerHealthy,
@@ -136,6 +156,23 @@ func (rs *RevisionStatus) MarkContainerHealthyUnknown(reason, message string) {
 	revisionCondSet.Manage(rs).MarkUnknown(RevisionConditionContainerHealthy, reason, "%s", message)
 }
 
+// MarkImagePolicySatisfiedTrue marks ImagePolicySatisfied status on revision as True
+func (rs *RevisionStatus) MarkImagePolicySatisfiedTrue() {
+	revisionCondSet.Manage(rs).MarkTrue(RevisionConditionImagePolicySatisfied)
+}
+
+// MarkImagePolicySatisfiedFalse marks ImagePolicySatisfied status on revision as False
+func (rs *RevisionStatus) MarkImagePolicySatisfiedFalse(reason, message string) {
+	revisionCondSet.Manage(rs).MarkFalse(RevisionConditionImagePolicySatisfied, reason, "%s", message)
+}
+
+// ClearImagePolicySatisfied removes the ImagePolicySatisfied condition once no
+// image policy applies to the revision anymore.
+func (rs *RevisionStatus) ClearImagePolicySatisfied() {
+	// Only terminal conditions fail to clear.
+	_ = revisionCondSet.Manage(rs).ClearCondition(RevisionConditionImagePolicySatisfied)
+}
+
 // MarkResourcesAvailableTrue marks ResourcesAvailable status on revision as True
 func (rs *RevisionStatus) MarkResourcesAvailableTrue() {
 	revisionCondSet.Manage(rs).MarkTrue(RevisionConditionResourcesAvailable)
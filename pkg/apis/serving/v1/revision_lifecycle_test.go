@@ -381,6 +381,25 @@ func TestTypicalFlowWithSuspendResume(t *testing.T) {
 	apistest.CheckConditionSucceeded(r, RevisionConditionReady, t)
 }
 
+func TestRevisionImagePolicySatisfied(t *testing.T) {
+	r := &RevisionStatus{}
+	r.InitializeConditions()
+	r.MarkResourcesAvailableTrue()
+	r.MarkContainerHealthyTrue()
+	apistest.CheckConditionSucceeded(r, RevisionConditionReady, t)
+
+	r.MarkImagePolicySatisfiedTrue()
+	apistest.CheckConditionSucceeded(r, RevisionConditionImagePolicySatisfied, t)
+
+	// The condition is informational, and doesn't affect readiness.
+	r.MarkImagePolicySatisfiedFalse(ReasonTagDrifted, "tag moved")
+	apistest.CheckConditionFailed(r, RevisionConditionImagePolicySatisfied, t)
+	apistest.CheckConditionSucceeded(r, RevisionConditionReady, t)
+	if got := r.GetCondition(RevisionConditionImagePolicySatisfied); got.Severity != apis.ConditionSeverityInfo {
+		t.Errorf("Severity = %q, want: %q", got.Severity, apis.ConditionSeverityInfo)
+	}
+}
+
 func TestRevisionNotOwnedStuff(t *testing.T) {
 	r := &RevisionStatus{}
 	r.InitializeConditions()
@@ -116,6 +116,10 @@ const (
 
 	// RevisionConditionActive is set when the revision is receiving traffic.
 	RevisionConditionActive apis.ConditionType = "Active"
+
+	// RevisionConditionImagePolicySatisfied is set when the image digests of
+	// the revision are checked against the image policy of the cluster.
+	RevisionConditionImagePolicySatisfied apis.ConditionType = "ImagePolicySatisfied"
 )
 
 // IsRevisionCondition returns true if the ConditionType is a revision condition type
@@ -125,7 +129,8 @@ func IsRevisionCondition(t apis.ConditionType) bool {
 		RevisionConditionReady,
 		RevisionConditionResourcesAvailable,
 		RevisionConditionContainerHealthy,
-		RevisionConditionActive:
+		RevisionConditionActive,
+		RevisionConditionImagePolicySatisfied:
 		return true
 	}
 	return false
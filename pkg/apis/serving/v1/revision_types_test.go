@@ -31,6 +31,10 @@ func TestIsRevisionCondition(t *testing.T) {
 		t.Error("Not expected to be a revision type")
 	}
 
+	if !IsRevisionCondition(RevisionConditionImagePolicySatisfied) {
+		t.Error("Expected ImagePolicySatisfied to be a revision condition")
+	}
+
 	if !IsRevisionCondition(RevisionConditionReady) {
 		t.Error("Expected to be a revision type")
 	}
@@ -74,9 +74,27 @@ func (rts *RevisionTemplateSpec) Validate(ctx context.Context) *apis.FieldError
 	errs = errs.Also(validateRevisionName(ctx, rts.Name, rts.GenerateName))
 	errs = errs.Also(validateQueueSidecarResourceAnnotations(rts.Annotations).ViaField("metadata.annotations"))
 	errs = errs.Also(validateProgressDeadlineAnnotation(rts.Annotations).ViaField("metadata.annotations"))
+	errs = errs.Also(validateLoadBalancingPolicyAnnotation(rts.Annotations).ViaField("metadata.annotations"))
+	errs = errs.Also(validatePriorityClassesAnnotation(rts.Annotations).ViaField("metadata.annotations"))
+	errs = errs.Also(validateAdaptiveConcurrencyAnnotation(ctx, rts.Annotations, rts.Spec.ContainerConcurrency).ViaField("metadata.annotations"))
+	if requireImageDigests(ctx, rts.Annotations) {
+		errs = errs.Also(serving.ValidateImageDigests(rts.Spec.PodSpec).ViaField("spec"))
+	}
 	return errs
 }
 
+// requireImageDigests returns whether the images of the revision must be
+// referenced by digest.
+func requireImageDigests(ctx context.Context, annos map[string]string) bool {
+	switch config.FromContextOrDefaults(ctx).Features.RequireImageDigests {
+	case config.Enabled:
+		return true
+	case config.Allowed:
+		return strings.EqualFold(annos[config.RequireImageDigestsFeatureKey], string(config.Enabled))
+	}
+	return false
+}
+
 // VerifyNameChange checks that if a user brought their own name previously that it
 // changes at the appropriate times.
 func (rts *RevisionTemplateSpec) VerifyNameChange(_ context.Context, og *RevisionTemplateSpec) *apis.FieldError {
@@ -245,3 +263,74 @@ func validateProgressDeadlineAnnotation(annos map[string]string) *apis.FieldErro
 	}
 	return nil
 }
+
+// validateLoadBalancingPolicyAnnotation validates the activator load balancing policy
+// and the consistent hashing key annotations.
+func validateLoadBalancingPolicyAnnotation(annos map[string]string) (errs *apis.FieldError) {
+	k, policy, ok := serving.LoadBalancingPolicyAnnotation.Get(annos)
+	if ok {
+		switch policy {
+		case serving.LoadBalancingPolicyPeakEWMA, serving.LoadBalancingPolicyConsistentHash:
+		default:
+			errs = errs.Also(apis.ErrInvalidValue(policy, k))
+		}
+	}
+
+	hk, hv, hok := serving.LoadBalancingHashKeyAnnotation.Get(annos)
+	switch {
+	case policy == serving.LoadBalancingPolicyConsistentHash && !hok:
+		errs = errs.Also(apis.ErrMissingField(serving.LoadBalancingHashKeyAnnotationKey))
+	case hok && policy != serving.LoadBalancingPolicyConsistentHash:
+		errs = errs.Also(&apis.FieldError{
+			Message: fmt.Sprintf("%s requires %s=%s", hk, serving.LoadBalancingPolicyAnnotationKey, serving.LoadBalancingPolicyConsistentHash),
+			Paths:   []string{hk},
+		})
+	case hok:
+		kind, name, _ := strings.Cut(hv, ":")
+		switch kind {
+		case "header", "cookie", "query":
+			if name == "" {
+				errs = errs.Also(apis.ErrInvalidValue(hv, hk))
+			}
+		default:
+			errs = errs.Also(apis.ErrInvalidValue(hv, hk))
+		}
+	}
+	return errs
+}
+
+// validatePriorityClassesAnnotation validates the request priority classes annotation.
+func validatePriorityClassesAnnotation(annos map[string]string) *apis.FieldError {
+	if k, v, ok := serving.PriorityClassesAnnotation.Get(annos); ok {
+		if _, err := serving.ParsePriorityClasses(v); err != nil {
+			return apis.ErrInvalidValue(v, k, err.Error())
+		}
+	}
+	return nil
+}
+
+// validateAdaptiveConcurrencyAnnotation validates the adaptive concurrency annotation.
+// The adaptive limit is capped by the container concurrency, so it's rejected
+// when the container concurrency is unlimited.
+func validateAdaptiveConcurrencyAnnotation(ctx context.Context, annos map[string]string, cc *int64) *apis.FieldError {
+	k, v, ok := serving.AdaptiveConcurrencyAnnotation.Get(annos)
+	if !ok {
+		return nil
+	}
+	switch v {
+	case serving.AdaptiveConcurrencyGradient, serving.AdaptiveConcurrencyAIMD:
+	default:
+		return apis.ErrInvalidValue(v, k)
+	}
+	containerConcurrency := config.FromContextOrDefaults(ctx).Defaults.ContainerConcurrency
+	if cc != nil {
+		containerConcurrency = *cc
+	}
+	if containerConcurrency == 0 {
+		return &apis.FieldError{
+			Message: k + " requires a non-zero containerConcurrency",
+			Paths:   []string{k},
+		}
+	}
+	return nil
+}
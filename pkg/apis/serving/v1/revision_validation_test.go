@@ -1096,6 +1096,206 @@ func TestRevisionTemplateSpecValidation(t *testing.T) {
 			Message: "progress-deadline=-1m3s must be positive",
 			Paths:   []string{serving.ProgressDeadlineAnnotationKey},
 		}).ViaField("metadata.annotations"),
+	}, {
+		name: "valid load-balancing-policy",
+		rts: &RevisionTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					serving.LoadBalancingPolicyAnnotationKey: serving.LoadBalancingPolicyPeakEWMA,
+				},
+			},
+			Spec: RevisionSpec{
+				PodSpec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Image: "helloworld",
+					}},
+				},
+			},
+		},
+		want: nil,
+	}, {
+		name: "invalid load-balancing-policy",
+		rts: &RevisionTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					serving.LoadBalancingPolicyAnnotationKey: "fastest",
+				},
+			},
+			Spec: RevisionSpec{
+				PodSpec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Image: "helloworld",
+					}},
+				},
+			},
+		},
+		want: apis.ErrInvalidValue("fastest", serving.LoadBalancingPolicyAnnotationKey).ViaField("metadata.annotations"),
+	}, {
+		name: "invalid priority-classes",
+		rts: &RevisionTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					serving.PriorityClassesAnnotationKey: "premium:60,bulk:50",
+				},
+			},
+			Spec: RevisionSpec{
+				PodSpec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Image: "helloworld",
+					}},
+				},
+			},
+		},
+		want: apis.ErrInvalidValue("premium:60,bulk:50", serving.PriorityClassesAnnotationKey,
+			"priority class queue shares add up to more than 100").ViaField("metadata.annotations"),
+	}, {
+		name: "valid adaptive-concurrency",
+		rts: &RevisionTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					serving.AdaptiveConcurrencyAnnotationKey: serving.AdaptiveConcurrencyGradient,
+				},
+			},
+			Spec: RevisionSpec{
+				PodSpec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Image: "helloworld",
+					}},
+				},
+				ContainerConcurrency: ptr.Int64(10),
+			},
+		},
+	}, {
+		name: "adaptive-concurrency with zero containerConcurrency",
+		rts: &RevisionTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					serving.AdaptiveConcurrencyAnnotationKey: serving.AdaptiveConcurrencyAIMD,
+				},
+			},
+			Spec: RevisionSpec{
+				PodSpec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Image: "helloworld",
+					}},
+				},
+				ContainerConcurrency: ptr.Int64(0),
+			},
+		},
+		want: (&apis.FieldError{
+			Message: serving.AdaptiveConcurrencyAnnotationKey + " requires a non-zero containerConcurrency",
+			Paths:   []string{serving.AdaptiveConcurrencyAnnotationKey},
+		}).ViaField("metadata.annotations"),
+	}, {
+		name: "adaptive-concurrency with default containerConcurrency",
+		rts: &RevisionTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					serving.AdaptiveConcurrencyAnnotationKey: serving.AdaptiveConcurrencyGradient,
+				},
+			},
+			Spec: RevisionSpec{
+				PodSpec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Image: "helloworld",
+					}},
+				},
+			},
+		},
+		want: (&apis.FieldError{
+			Message: serving.AdaptiveConcurrencyAnnotationKey + " requires a non-zero containerConcurrency",
+			Paths:   []string{serving.AdaptiveConcurrencyAnnotationKey},
+		}).ViaField("metadata.annotations"),
+	}, {
+		name: "invalid adaptive-concurrency",
+		rts: &RevisionTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					serving.AdaptiveConcurrencyAnnotationKey: "vegas",
+				},
+			},
+			Spec: RevisionSpec{
+				PodSpec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Image: "helloworld",
+					}},
+				},
+			},
+		},
+		want: apis.ErrInvalidValue("vegas", serving.AdaptiveConcurrencyAnnotationKey).ViaField("metadata.annotations"),
+	}, {
+		name: "valid consistent-hash",
+		rts: &RevisionTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					serving.LoadBalancingPolicyAnnotationKey:  serving.LoadBalancingPolicyConsistentHash,
+					serving.LoadBalancingHashKeyAnnotationKey: "cookie:session",
+				},
+			},
+			Spec: RevisionSpec{
+				PodSpec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Image: "helloworld",
+					}},
+				},
+			},
+		},
+		want: nil,
+	}, {
+		name: "consistent-hash without hash key",
+		rts: &RevisionTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					serving.LoadBalancingPolicyAnnotationKey: serving.LoadBalancingPolicyConsistentHash,
+				},
+			},
+			Spec: RevisionSpec{
+				PodSpec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Image: "helloworld",
+					}},
+				},
+			},
+		},
+		want: apis.ErrMissingField(serving.LoadBalancingHashKeyAnnotationKey).ViaField("metadata.annotations"),
+	}, {
+		name: "invalid hash key",
+		rts: &RevisionTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					serving.LoadBalancingPolicyAnnotationKey:  serving.LoadBalancingPolicyConsistentHash,
+					serving.LoadBalancingHashKeyAnnotationKey: "path:/foo",
+				},
+			},
+			Spec: RevisionSpec{
+				PodSpec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Image: "helloworld",
+					}},
+				},
+			},
+		},
+		want: apis.ErrInvalidValue("path:/foo", serving.LoadBalancingHashKeyAnnotationKey).ViaField("metadata.annotations"),
+	}, {
+		name: "hash key without consistent-hash",
+		rts: &RevisionTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					serving.LoadBalancingHashKeyAnnotationKey: "header:X-Tenant",
+				},
+			},
+			Spec: RevisionSpec{
+				PodSpec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Image: "helloworld",
+					}},
+				},
+			},
+		},
+		want: (&apis.FieldError{
+			Message: serving.LoadBalancingHashKeyAnnotationKey + " requires " + serving.LoadBalancingPolicyAnnotationKey + "=consistent-hash",
+			Paths:   []string{serving.LoadBalancingHashKeyAnnotationKey},
+		}).ViaField("metadata.annotations"),
 	}, {
 		name: "invalid networking.knative.dev/visibility annotation",
 		rts: &RevisionTemplateSpec{
@@ -1147,6 +1347,80 @@ func TestRevisionTemplateSpecValidation(t *testing.T) {
 			},
 		},
 		want: nil,
+	}, {
+		name: "image digests required",
+		ctx:  requireImageDigestsCtx(config.Enabled),
+		rts: &RevisionTemplateSpec{
+			Spec: RevisionSpec{
+				PodSpec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Image: "helloworld",
+					}},
+				},
+			},
+		},
+		want: apis.ErrInvalidValue("helloworld", "spec.containers[0].image", "image must be referenced by digest"),
+	}, {
+		name: "image digests required, by digest",
+		ctx:  requireImageDigestsCtx(config.Enabled),
+		rts: &RevisionTemplateSpec{
+			Spec: RevisionSpec{
+				PodSpec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Image: "helloworld@sha256:deadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+					}},
+				},
+			},
+		},
+		want: nil,
+	}, {
+		name: "image digests allowed, not required",
+		ctx:  requireImageDigestsCtx(config.Allowed),
+		rts: &RevisionTemplateSpec{
+			Spec: RevisionSpec{
+				PodSpec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Image: "helloworld",
+					}},
+				},
+			},
+		},
+		want: nil,
+	}, {
+		name: "image digests allowed, required by annotation",
+		ctx:  requireImageDigestsCtx(config.Allowed),
+		rts: &RevisionTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					config.RequireImageDigestsFeatureKey: "Enabled",
+				},
+			},
+			Spec: RevisionSpec{
+				PodSpec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Image: "helloworld",
+					}},
+				},
+			},
+		},
+		want: apis.ErrInvalidValue("helloworld", "spec.containers[0].image", "image must be referenced by digest"),
+	}, {
+		name: "image digests disabled, annotation ignored",
+		rts: &RevisionTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					config.RequireImageDigestsFeatureKey: "enabled",
+				},
+			},
+			Spec: RevisionSpec{
+				PodSpec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Image: "helloworld",
+					}},
+				},
+			},
+		},
+		want: nil,
 	}}
 
 	for _, test := range tests {
@@ -1177,6 +1451,14 @@ func autoscalerConfigCtx(allowInitialScaleZero bool, initialScale int) context.C
 	return config.ToContext(context.Background(), testConfigs)
 }
 
+func requireImageDigestsCtx(flag config.Flag) context.Context {
+	testConfigs := &config.Config{}
+	testConfigs.Features, _ = config.NewFeaturesConfigFromMap(map[string]string{
+		"require-image-digests": string(flag),
+	})
+	return config.ToContext(context.Background(), testConfigs)
+}
+
 func TestValidateQueueSidecarAnnotation(t *testing.T) {
 	//nolint
 	resourcePercentageDeprecationWarning := apis.ErrGeneric("Queue proxy resource percentage annotation is deprecated. Please use the available annotations to explicitly set resource values per service").
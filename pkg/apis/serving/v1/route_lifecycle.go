@@ -18,6 +18,7 @@ package v1
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -73,6 +74,83 @@ func (r *Route) RolloutDuration() time.Duration {
 	return 0
 }
 
+// RolloutMaxErrorRate returns the highest error rate the latest revision may
+// have during a rollout, as specified in an annotation.
+// false is returned if missing or cannot be parsed.
+func (r *Route) RolloutMaxErrorRate() (float64, bool) {
+	if _, v, ok := serving.RolloutMaxErrorRateAnnotation.Get(r.Annotations); ok && v != "" {
+		if rate, err := strconv.ParseFloat(v, 64); err == nil {
+			return rate, true
+		}
+	}
+	return 0, false
+}
+
+// RolloutMaxLatency returns the highest latency the latest revision may have
+// during a rollout, as specified in an annotation.
+// 0 is returned if missing or cannot be parsed.
+func (r *Route) RolloutMaxLatency() time.Duration {
+	if _, v, ok := serving.RolloutMaxLatencyAnnotation.Get(r.Annotations); ok && v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+	}
+	return 0
+}
+
+// RolloutLatencyPercentile returns the percentile of the latency gated during
+// rollouts as a fraction, e.g. 0.95 for the 95th percentile.
+func (r *Route) RolloutLatencyPercentile() float64 {
+	if _, v, ok := serving.RolloutLatencyPercentileAnnotation.Get(r.Annotations); ok && v != "" {
+		if p, err := strconv.ParseFloat(v, 64); err == nil && p > 0 && p <= 100 {
+			return p / 100
+		}
+	}
+	return serving.RolloutLatencyPercentileDefault / 100
+}
+
+// RolloutMinRequests returns the fewest requests the latest revision must
+// serve during a rollout step for the step to be analyzed.
+func (r *Route) RolloutMinRequests() int {
+	if _, v, ok := serving.RolloutMinRequestsAnnotation.Get(r.Annotations); ok && v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			return n
+		}
+	}
+	return serving.RolloutMinRequestsDefault
+}
+
+// RolloutPlan returns the rollout plan specified as an annotation.
+// nil is returned if missing or cannot be parsed.
+func (r *Route) RolloutPlan() []serving.RolloutStage {
+	if _, v, ok := serving.RolloutPlanAnnotation.Get(r.Annotations); ok && v != "" {
+		// WH should've declined all the invalid values for this annotation.
+		if plan, err := serving.ParseRolloutPlan(v); err == nil {
+			return plan
+		}
+	}
+	return nil
+}
+
+// RolloutApproval returns the revision and the rollout plan stage approved
+// by the annotation, if any.
+func (r *Route) RolloutApproval() (string, int, bool) {
+	if _, v, ok := serving.RolloutApproveAnnotation.Get(r.Annotations); ok && v != "" {
+		if rev, stage, err := serving.ParseRolloutApproval(v); err == nil {
+			return rev, stage, true
+		}
+	}
+	return "", 0, false
+}
+
+// SplitAffinity returns the part of the requests keying their assignment to
+// the revisions of the traffic splits, as specified in an annotation, e.g.
+// `header:X-User-Id`. "" is returned if missing.
+func (r *Route) SplitAffinity() string {
+	_, v, _ := serving.SplitAffinityAnnotation.Get(r.Annotations)
+	return v
+}
+
 // InitializeConditions sets the initial values to the conditions.
 func (rs *RouteStatus) InitializeConditions() {
 	routeCondSet.Manage(rs).InitializeConditions()
@@ -99,6 +177,20 @@ func (rs *RouteStatus) MarkIngressRolloutInProgress() {
 		"RolloutInProgress", "A gradual rollout of the latest revision(s) is in progress.")
 }
 
+// MarkRolledBack marks the RouteConditionRolloutHealthy condition false to
+// reflect that the rollout of the given revision was rolled back.
+func (rs *RouteStatus) MarkRolledBack(revision, reason string) {
+	routeCondSet.Manage(rs).MarkFalse(RouteConditionRolloutHealthy,
+		"RolledBack", "Revision %q was rolled back: %s", revision, reason)
+}
+
+// ClearRolledBack removes the RouteConditionRolloutHealthy condition once no
+// rollout is rolled back anymore.
+func (rs *RouteStatus) ClearRolledBack() {
+	// Only terminal conditions fail to clear.
+	_ = routeCondSet.Manage(rs).ClearCondition(RouteConditionRolloutHealthy)
+}
+
 // MarkIngressNotConfigured changes the IngressReady condition to be unknown to reflect
 // that the Ingress does not yet have a Status
 func (rs *RouteStatus) MarkIngressNotConfigured() {
@@ -21,6 +21,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -617,3 +618,100 @@ func TestRolloutDuration(t *testing.T) {
 		})
 	}
 }
+
+func TestRolloutAnalysisAnnotations(t *testing.T) {
+	r := &Route{}
+	if _, ok := r.RolloutMaxErrorRate(); ok {
+		t.Error("RolloutMaxErrorRate() is set without the annotation")
+	}
+	if got := r.RolloutMaxLatency(); got != 0 {
+		t.Errorf("RolloutMaxLatency() = %v, want: 0", got)
+	}
+	if got, want := r.RolloutLatencyPercentile(), 0.95; got != want {
+		t.Errorf("RolloutLatencyPercentile() = %v, want: %v", got, want)
+	}
+	if got, want := r.RolloutMinRequests(), serving.RolloutMinRequestsDefault; got != want {
+		t.Errorf("RolloutMinRequests() = %v, want: %v", got, want)
+	}
+
+	r.Annotations = map[string]string{
+		serving.RolloutMaxErrorRateKey:      "0",
+		serving.RolloutMaxLatencyKey:        "1s",
+		serving.RolloutLatencyPercentileKey: "50",
+		serving.RolloutMinRequestsKey:       "100",
+	}
+	if got, ok := r.RolloutMaxErrorRate(); !ok || got != 0 {
+		t.Errorf("RolloutMaxErrorRate() = %v, %v; want 0, true", got, ok)
+	}
+	if got, want := r.RolloutMaxLatency(), time.Second; got != want {
+		t.Errorf("RolloutMaxLatency() = %v, want: %v", got, want)
+	}
+	if got, want := r.RolloutLatencyPercentile(), 0.5; got != want {
+		t.Errorf("RolloutLatencyPercentile() = %v, want: %v", got, want)
+	}
+	if got, want := r.RolloutMinRequests(), 100; got != want {
+		t.Errorf("RolloutMinRequests() = %v, want: %v", got, want)
+	}
+}
+
+func TestRolloutPlanAnnotations(t *testing.T) {
+	r := &Route{}
+	if got := r.RolloutPlan(); got != nil {
+		t.Errorf("RolloutPlan() = %v, want: nil", got)
+	}
+	if _, _, ok := r.RolloutApproval(); ok {
+		t.Error("RolloutApproval() is set without the annotation")
+	}
+
+	r.Annotations = map[string]string{
+		serving.RolloutPlanKey:    "10%:1m,pause",
+		serving.RolloutApproveKey: "rev-2:1",
+	}
+	want := []serving.RolloutStage{{Percent: 10, Duration: time.Minute}, {Pause: true}}
+	if got := r.RolloutPlan(); !cmp.Equal(got, want) {
+		t.Error("RolloutPlan() (-want, +got):", cmp.Diff(want, got))
+	}
+	if rev, stage, ok := r.RolloutApproval(); !ok || rev != "rev-2" || stage != 1 {
+		t.Errorf("RolloutApproval() = %q, %d, %v; want rev-2, 1, true", rev, stage, ok)
+	}
+
+	// Invalid values are ignored.
+	r.Annotations = map[string]string{
+		serving.RolloutPlanKey:    "10%",
+		serving.RolloutApproveKey: "rev-2",
+	}
+	if got := r.RolloutPlan(); got != nil {
+		t.Errorf("RolloutPlan() = %v, want: nil", got)
+	}
+	if _, _, ok := r.RolloutApproval(); ok {
+		t.Error("RolloutApproval() is set with an invalid annotation")
+	}
+}
+
+func TestRolledBackCondition(t *testing.T) {
+	r := &RouteStatus{}
+	r.InitializeConditions()
+	r.MarkTrafficAssigned()
+	r.MarkCertificateReady("cert")
+	r.PropagateIngressStatus(netv1alpha1.IngressStatus{
+		Status: duckv1.Status{
+			Conditions: duckv1.Conditions{{
+				Type:   netv1alpha1.IngressConditionReady,
+				Status: corev1.ConditionTrue,
+			}},
+		},
+	})
+
+	r.MarkRolledBack("rev-2", "error rate 0.500 is above 0.050")
+	c := r.GetCondition(RouteConditionRolloutHealthy)
+	if c == nil || !c.IsFalse() || c.Reason != "RolledBack" {
+		t.Fatalf("RolloutHealthy = %#v, want False with reason RolledBack", c)
+	}
+	// Rolling back keeps serving the previous revision.
+	apistest.CheckConditionSucceeded(r, RouteConditionReady, t)
+
+	r.ClearRolledBack()
+	if c := r.GetCondition(RouteConditionRolloutHealthy); c != nil {
+		t.Errorf("RolloutHealthy = %#v, want it cleared", c)
+	}
+}
@@ -108,6 +108,38 @@ type TrafficTarget struct {
 	// a hostname, but may not contain anything else (e.g. basic auth, url path, etc.)
 	// +optional
 	URL *apis.URL `json:"url,omitempty"`
+
+	// Match optionally routes the requests matching any of these rules to
+	// the Revision of this target, ahead of the percentage based routing.
+	// +optional
+	Match []TrafficMatch `json:"match,omitempty"`
+
+	// Mirror marks this target as a shadow, to whose Revision the given
+	// percentage of the requests routed by percentage is duplicated. The
+	// responses of the shadow are discarded. A mirror target receives no
+	// traffic of its own, besides through its tag.
+	// The requests routed by percentage then go through the activator, which
+	// mirrors them.
+	// +optional
+	Mirror *int64 `json:"mirror,omitempty"`
+}
+
+// TrafficMatch is a rule matching the requests routed to a traffic target.
+// Exactly one of Header or Cookie must be specified.
+type TrafficMatch struct {
+	// Header is the name of the header the requests must carry with the value.
+	// +optional
+	Header string `json:"header,omitempty"`
+
+	// Cookie is the name of the cookie the requests must carry with the value.
+	// The ingress can't match a cookie among the others of the Cookie header,
+	// so the requests routed by percentage are sent through the activator,
+	// which routes the requests matching the cookie ahead of the split.
+	// +optional
+	Cookie string `json:"cookie,omitempty"`
+
+	// Value is the exact value of the header or cookie.
+	Value string `json:"value"`
 }
 
 // RouteSpec holds the desired state of the Route (from the client).
@@ -135,6 +167,12 @@ const (
 	// RouteConditionCertificateProvisioned is set to False when the
 	// Knative Certificates fail to be provisioned for the Route.
 	RouteConditionCertificateProvisioned apis.ConditionType = "CertificateProvisioned"
+
+	// RouteConditionRolloutHealthy is set to False when the rollout of the
+	// latest revision was rolled back for failing its analysis gates.
+	// It doesn't affect the readiness of the Route, which keeps serving the
+	// previous revision.
+	RouteConditionRolloutHealthy apis.ConditionType = "RolloutHealthy"
 )
 
 // IsRouteCondition returns true if the ConditionType is a route condition type
@@ -144,7 +182,8 @@ func IsRouteCondition(t apis.ConditionType) bool {
 		RouteConditionReady,
 		RouteConditionAllTrafficAssigned,
 		RouteConditionIngressReady,
-		RouteConditionCertificateProvisioned:
+		RouteConditionCertificateProvisioned,
+		RouteConditionRolloutHealthy:
 		return true
 	}
 	return false
@@ -169,6 +208,66 @@ type RouteStatusFields struct {
 	// LatestReadyRevisionName that we last observed.
 	// +optional
 	Traffic []TrafficTarget `json:"traffic,omitempty"`
+
+	// Rollouts holds the progress of the rollouts of the latest revisions
+	// of the configurations in the traffic distribution.
+	// +optional
+	Rollouts []RolloutStatus `json:"rollouts,omitempty"`
+}
+
+// RolloutStatus describes the progress of the rollout of the latest revision
+// of a configuration.
+type RolloutStatus struct {
+	// ConfigurationName is the name of the configuration being rolled out.
+	ConfigurationName string `json:"configurationName"`
+
+	// Tag is the tag of the traffic target being rolled out, if any.
+	// +optional
+	Tag string `json:"tag,omitempty"`
+
+	// RevisionName is the name of the revision being rolled out.
+	RevisionName string `json:"revisionName"`
+
+	// Revisions holds the revisions of the configuration receiving traffic,
+	// from the oldest to the one being rolled out, with their current share
+	// of the Route traffic.
+	// +optional
+	Revisions []RolloutRevisionStatus `json:"revisions,omitempty"`
+
+	// StartTime is when the rollout started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// NextStepTime is when the next step of the rollout is due.
+	// It is unset while the rollout is waiting on the ingress or
+	// awaiting approval.
+	// +optional
+	NextStepTime *metav1.Time `json:"nextStepTime,omitempty"`
+
+	// EstimatedCompletionTime is when the rollout is expected to complete,
+	// provided it is not paused along the way.
+	// +optional
+	EstimatedCompletionTime *metav1.Time `json:"estimatedCompletionTime,omitempty"`
+
+	// Stage is the index of the current stage of the rollout plan, if the
+	// rollout follows one.
+	// +optional
+	Stage *int32 `json:"stage,omitempty"`
+
+	// AwaitingApproval is true when the rollout is paused at a `pause` stage
+	// of the rollout plan, until approved.
+	// +optional
+	AwaitingApproval bool `json:"awaitingApproval,omitempty"`
+}
+
+// RolloutRevisionStatus is the share of the Route traffic of a revision
+// taking part in a rollout.
+type RolloutRevisionStatus struct {
+	// RevisionName is the name of the revision.
+	RevisionName string `json:"revisionName"`
+
+	// Percent is the percentage of the Route traffic routed to the revision.
+	Percent int64 `json:"percent"`
 }
 
 // RouteStatus communicates the observed state of the Route (from the controller).
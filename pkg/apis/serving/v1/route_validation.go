@@ -19,6 +19,8 @@ package v1
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
@@ -30,6 +32,9 @@ func (r *Route) Validate(ctx context.Context) *apis.FieldError {
 	errs := serving.ValidateObjectMetadata(ctx, r.GetObjectMeta(), false).Also(
 		r.validateLabels().ViaField("labels"))
 	errs = errs.Also(serving.ValidateRolloutDurationAnnotation(r.GetAnnotations()).ViaField("annotations"))
+	errs = errs.Also(serving.ValidateRolloutAnalysisAnnotations(r.GetAnnotations()).ViaField("annotations"))
+	errs = errs.Also(serving.ValidateRolloutPlanAnnotations(r.GetAnnotations()).ViaField("annotations"))
+	errs = errs.Also(serving.ValidateSplitAffinityAnnotation(r.GetAnnotations()).ViaField("annotations"))
 	errs = errs.ViaField("metadata")
 	errs = errs.Also(r.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
 
@@ -50,6 +55,10 @@ func validateTrafficList(ctx context.Context, traffic []TrafficTarget) *apis.Fie
 
 	// Track the targets of named TrafficTarget entries (to detect duplicates).
 	trafficMap := make(map[string]int)
+	// Track the match rules of the TrafficTarget entries (to detect duplicates).
+	matchMap := make(map[TrafficMatch]string)
+	// Track the mirror target (to detect duplicates).
+	mirror := -1
 
 	sum := int64(0)
 	for i, tt := range traffic {
@@ -59,6 +68,34 @@ func validateTrafficList(ctx context.Context, traffic []TrafficTarget) *apis.Fie
 			sum += *tt.Percent
 		}
 
+		if tt.Mirror != nil {
+			if mirror >= 0 {
+				errs = errs.Also(&apis.FieldError{
+					Message: "Multiple mirror targets",
+					Paths: []string{
+						fmt.Sprintf("[%d].mirror", i),
+						fmt.Sprintf("[%d].mirror", mirror),
+					},
+				})
+			} else {
+				mirror = i
+			}
+		}
+
+		for j, m := range tt.Match {
+			// Header names are case insensitive.
+			m.Header = http.CanonicalHeaderKey(m.Header)
+			path := fmt.Sprintf("[%d].match[%d]", i, j)
+			if prev, ok := matchMap[m]; ok {
+				errs = errs.Also(&apis.FieldError{
+					Message: "Multiple targets for the same match rule",
+					Paths:   []string{path, prev},
+				})
+			} else {
+				matchMap[m] = path
+			}
+		}
+
 		if tt.Tag == "" {
 			continue
 		}
@@ -101,9 +138,74 @@ func (tt *TrafficTarget) Validate(ctx context.Context) *apis.FieldError {
 	errs := tt.validateLatestRevision(ctx)
 	errs = tt.validateRevisionAndConfiguration(ctx, errs)
 	errs = tt.validateTrafficPercentage(errs)
+	errs = tt.validateMatch(errs)
+	errs = tt.validateMirror(errs)
 	return tt.validateURL(ctx, errs)
 }
 
+func (tt *TrafficTarget) validateMirror(errs *apis.FieldError) *apis.FieldError {
+	if tt.Mirror == nil {
+		return errs
+	}
+	if *tt.Mirror < 1 || *tt.Mirror > 100 {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(*tt.Mirror, 1, 100, "mirror"))
+	}
+	// The shadow only receives the duplicated requests.
+	if tt.Percent != nil && *tt.Percent != 0 {
+		errs = errs.Also(apis.ErrDisallowedFields("percent"))
+	}
+	if len(tt.Match) > 0 {
+		errs = errs.Also(apis.ErrDisallowedFields("match"))
+	}
+	return errs
+}
+
+func (tt *TrafficTarget) validateMatch(errs *apis.FieldError) *apis.FieldError {
+	for i := range tt.Match {
+		errs = errs.Also(tt.Match[i].Validate().ViaFieldIndex("match", i))
+	}
+	return errs
+}
+
+// Validate verifies that TrafficMatch is properly configured.
+func (m *TrafficMatch) Validate() (errs *apis.FieldError) {
+	switch {
+	case m.Header == "" && m.Cookie == "":
+		errs = apis.ErrMissingOneOf("header", "cookie")
+	case m.Header != "" && m.Cookie != "":
+		errs = apis.ErrMultipleOneOf("header", "cookie")
+	case m.Header != "":
+		if el := validation.IsHTTPHeaderName(m.Header); len(el) > 0 {
+			errs = apis.ErrInvalidKeyName(m.Header, "header", el...)
+		} else if strings.HasPrefix(http.CanonicalHeaderKey(m.Header), "Knative-") {
+			// Knative headers drive the routing itself.
+			errs = apis.ErrInvalidValue(m.Header, "header", "Knative headers are reserved")
+		}
+	default:
+		if el := validation.IsHTTPHeaderName(m.Cookie); len(el) > 0 {
+			errs = apis.ErrInvalidKeyName(m.Cookie, "cookie", el...)
+		}
+		if !isCookieValue(m.Value) {
+			errs = errs.Also(apis.ErrInvalidValue(m.Value, "value", "must be a valid cookie value"))
+		}
+	}
+	if m.Value == "" {
+		errs = errs.Also(apis.ErrMissingField("value"))
+	}
+	return errs
+}
+
+// isCookieValue reports whether v only consists of the cookie-octets of
+// RFC 6265, which also keeps the separators the activator rules use out.
+func isCookieValue(v string) bool {
+	for i := 0; i < len(v); i++ {
+		if c := v[i]; c < 0x21 || c > 0x7e || c == '"' || c == ',' || c == ';' || c == '\\' {
+			return false
+		}
+	}
+	return true
+}
+
 func (tt *TrafficTarget) validateRevisionAndConfiguration(ctx context.Context, errs *apis.FieldError) *apis.FieldError {
 	// We only validate the sense of latestRevision in the context of a Spec,
 	// and only when it is specified.
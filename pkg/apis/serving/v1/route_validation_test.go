@@ -23,6 +23,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	netapi "knative.dev/networking/pkg/apis/networking"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/ptr"
@@ -255,6 +256,105 @@ func TestTrafficTargetValidation(t *testing.T) {
 		},
 		wc:   apis.WithinSpec,
 		want: apis.ErrDisallowedFields("url"),
+	}, {
+		name: "valid match rules",
+		tt: &TrafficTarget{
+			RevisionName: "bar",
+			Match: []TrafficMatch{{
+				Header: "X-Canary",
+				Value:  "true",
+			}, {
+				Header: "X-Beta",
+				Value:  "1",
+			}, {
+				Cookie: "beta",
+				Value:  "1",
+			}},
+		},
+		wc: apis.WithinSpec,
+	}, {
+		name: "match rule without header nor value",
+		tt: &TrafficTarget{
+			RevisionName: "bar",
+			Match:        []TrafficMatch{{}},
+		},
+		wc: apis.WithinSpec,
+		want: apis.ErrMissingOneOf("match[0].header", "match[0].cookie").Also(
+			apis.ErrMissingField("match[0].value")),
+	}, {
+		name: "match rule with invalid header",
+		tt: &TrafficTarget{
+			RevisionName: "bar",
+			Match: []TrafficMatch{{
+				Header: "X Canary",
+				Value:  "true",
+			}},
+		},
+		wc: apis.WithinSpec,
+		want: apis.ErrInvalidKeyName("X Canary", "match[0].header",
+			validation.IsHTTPHeaderName("X Canary")...),
+	}, {
+		name: "match rule with Knative header",
+		tt: &TrafficTarget{
+			RevisionName: "bar",
+			Match: []TrafficMatch{{
+				Header: "knative-serving-tag",
+				Value:  "foo",
+			}},
+		},
+		wc:   apis.WithinSpec,
+		want: apis.ErrInvalidValue("knative-serving-tag", "match[0].header", "Knative headers are reserved"),
+	}, {
+		name: "match rule with header and cookie",
+		tt: &TrafficTarget{
+			RevisionName: "bar",
+			Match: []TrafficMatch{{
+				Header: "X-Canary",
+				Cookie: "beta",
+				Value:  "1",
+			}},
+		},
+		wc:   apis.WithinSpec,
+		want: apis.ErrMultipleOneOf("match[0].header", "match[0].cookie"),
+	}, {
+		name: "match rule with invalid cookie",
+		tt: &TrafficTarget{
+			RevisionName: "bar",
+			Match: []TrafficMatch{{
+				Cookie: "be;ta",
+				Value:  "a,b",
+			}},
+		},
+		wc: apis.WithinSpec,
+		want: apis.ErrInvalidKeyName("be;ta", "match[0].cookie",
+			validation.IsHTTPHeaderName("be;ta")...).Also(
+			apis.ErrInvalidValue("a,b", "match[0].value", "must be a valid cookie value")),
+	}, {
+		name: "valid mirror",
+		tt: &TrafficTarget{
+			Tag:          "shadow",
+			RevisionName: "bar",
+			Mirror:       ptr.Int64(10),
+		},
+		wc: apis.WithinSpec,
+	}, {
+		name: "mirror out of bounds",
+		tt: &TrafficTarget{
+			RevisionName: "bar",
+			Mirror:       ptr.Int64(0),
+		},
+		wc:   apis.WithinSpec,
+		want: apis.ErrOutOfBoundsValue(0, 1, 100, "mirror"),
+	}, {
+		name: "mirror with percent and match rules",
+		tt: &TrafficTarget{
+			RevisionName: "bar",
+			Percent:      ptr.Int64(10),
+			Mirror:       ptr.Int64(10),
+			Match:        []TrafficMatch{{Header: "X-Canary", Value: "true"}},
+		},
+		wc:   apis.WithinSpec,
+		want: apis.ErrDisallowedFields("percent", "match"),
 	}}
 
 	for _, test := range tests {
@@ -322,6 +422,50 @@ func TestRouteValidation(t *testing.T) {
 				}},
 			},
 		},
+	}, {
+		name: "duplicate match rules",
+		r: &Route{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: RouteSpec{
+				Traffic: []TrafficTarget{{
+					RevisionName: "foo",
+					Percent:      ptr.Int64(100),
+					Match:        []TrafficMatch{{Header: "x-canary", Value: "true"}},
+				}, {
+					ConfigurationName: "bar",
+					Match:             []TrafficMatch{{Header: "X-Beta", Value: "1"}, {Header: "X-Canary", Value: "true"}},
+				}},
+			},
+		},
+		want: &apis.FieldError{
+			Message: "Multiple targets for the same match rule",
+			Paths:   []string{"spec.traffic[1].match[1]", "spec.traffic[0].match[0]"},
+		},
+	}, {
+		name: "multiple mirror targets",
+		r: &Route{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+			},
+			Spec: RouteSpec{
+				Traffic: []TrafficTarget{{
+					RevisionName: "foo",
+					Percent:      ptr.Int64(100),
+				}, {
+					RevisionName: "bar",
+					Mirror:       ptr.Int64(10),
+				}, {
+					ConfigurationName: "baz",
+					Mirror:            ptr.Int64(20),
+				}},
+			},
+		},
+		want: &apis.FieldError{
+			Message: "Multiple mirror targets",
+			Paths:   []string{"spec.traffic[2].mirror", "spec.traffic[1].mirror"},
+		},
 	}, {
 		name: "valid split without tags",
 		r: &Route{
@@ -739,6 +883,90 @@ func TestRouteAnnotationUpdate(t *testing.T) {
 			Spec: getRouteSpec("new"),
 		},
 		wantErr: apis.ErrInvalidValue("three hours and seventeen seconds", serving.RolloutDurationKey).ViaField("metadata.annotations"),
+	}, {
+		name: "rollout analysis validation",
+		this: &Route{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+				Annotations: map[string]string{
+					serving.RolloutMaxErrorRateKey:      "0.01",
+					serving.RolloutMaxLatencyKey:        "250ms",
+					serving.RolloutLatencyPercentileKey: "99.9",
+					serving.RolloutMinRequestsKey:       "50",
+				},
+			},
+			Spec: getRouteSpec("new"),
+		},
+	}, {
+		name: "rollout analysis validation, fail",
+		this: &Route{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+				Annotations: map[string]string{
+					serving.RolloutMaxErrorRateKey:      "5",
+					serving.RolloutMaxLatencyKey:        "-1s",
+					serving.RolloutLatencyPercentileKey: "0",
+					serving.RolloutMinRequestsKey:       "0",
+				},
+			},
+			Spec: getRouteSpec("new"),
+		},
+		wantErr: apis.ErrOutOfBoundsValue("5", 0, 1, serving.RolloutMaxErrorRateKey).Also(
+			apis.ErrInvalidValue("-1s", serving.RolloutMaxLatencyKey)).Also(&apis.FieldError{
+			Message: "rollout-latency-percentile=0 should be in (0, 100]",
+			Paths:   []string{serving.RolloutLatencyPercentileKey},
+		}).Also(apis.ErrInvalidValue("0", serving.RolloutMinRequestsKey, "must be a positive integer")).ViaField("metadata.annotations"),
+	}, {
+		name: "rollout plan validation",
+		this: &Route{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+				Annotations: map[string]string{
+					serving.RolloutPlanKey:    "1%:10m,5%:30m,pause,50%:10m",
+					serving.RolloutApproveKey: "new-00002:2",
+				},
+			},
+			Spec: getRouteSpec("new"),
+		},
+	}, {
+		name: "rollout plan validation, fail",
+		this: &Route{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+				Annotations: map[string]string{
+					serving.RolloutPlanKey:    "50%:10m,5%:30m",
+					serving.RolloutApproveKey: "new-00002",
+				},
+			},
+			Spec: getRouteSpec("new"),
+		},
+		wantErr: apis.ErrInvalidValue("50%:10m,5%:30m", serving.RolloutPlanKey,
+			`stage "5%:30m" percent must be above the previous stages and below 100`).Also(
+			apis.ErrInvalidValue("new-00002", serving.RolloutApproveKey,
+				"approval must be <revision name>:<stage>")).ViaField("metadata.annotations"),
+	}, {
+		name: "split affinity validation",
+		this: &Route{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+				Annotations: map[string]string{
+					serving.SplitAffinityKey: "cookie:session",
+				},
+			},
+			Spec: getRouteSpec("new"),
+		},
+	}, {
+		name: "split affinity validation, fail",
+		this: &Route{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "valid",
+				Annotations: map[string]string{
+					serving.SplitAffinityKey: "query:user",
+				},
+			},
+			Spec: getRouteSpec("new"),
+		},
+		wantErr: apis.ErrInvalidValue("query:user", serving.SplitAffinityKey).ViaField("metadata.annotations"),
 	}, {
 		name: "no validation for lastModifier annotation even after update without spec changes as route owned by service",
 		this: &Route{
@@ -32,6 +32,10 @@ func (s *Service) Validate(ctx context.Context) (errs *apis.FieldError) {
 	if !apis.IsInStatusUpdate(ctx) {
 		errs = errs.Also(serving.ValidateObjectMetadata(ctx, s.GetObjectMeta(), false))
 		errs = errs.Also(serving.ValidateRolloutDurationAnnotation(s.GetAnnotations()).ViaField("annotations"))
+		errs = errs.Also(serving.ValidateRolloutAnalysisAnnotations(s.GetAnnotations()).ViaField("annotations"))
+		errs = errs.Also(serving.ValidateRolloutPlanAnnotations(s.GetAnnotations()).ViaField("annotations"))
+		errs = errs.Also(serving.ValidateSplitAffinityAnnotation(s.GetAnnotations()).ViaField("annotations"))
+		errs = errs.Also(serving.ValidateGCAnnotations(s.GetAnnotations()).ViaField("annotations"))
 		errs = errs.ViaField("metadata")
 
 		ctx = apis.WithinParent(ctx, s.ObjectMeta)
@@ -110,6 +110,11 @@ func (in *ConfigurationStatus) DeepCopyInto(out *ConfigurationStatus) {
 	*out = *in
 	in.Status.DeepCopyInto(&out.Status)
 	out.ConfigurationStatusFields = in.ConfigurationStatusFields
+	if in.RevisionsToCollect != nil {
+		in, out := &in.RevisionsToCollect, &out.RevisionsToCollect
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -308,6 +313,60 @@ func (in *RevisionTemplateSpec) DeepCopy() *RevisionTemplateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutRevisionStatus) DeepCopyInto(out *RolloutRevisionStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutRevisionStatus.
+func (in *RolloutRevisionStatus) DeepCopy() *RolloutRevisionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutRevisionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStatus) DeepCopyInto(out *RolloutStatus) {
+	*out = *in
+	if in.Revisions != nil {
+		in, out := &in.Revisions, &out.Revisions
+		*out = make([]RolloutRevisionStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextStepTime != nil {
+		in, out := &in.NextStepTime, &out.NextStepTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EstimatedCompletionTime != nil {
+		in, out := &in.EstimatedCompletionTime, &out.EstimatedCompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Stage != nil {
+		in, out := &in.Stage, &out.Stage
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStatus.
+func (in *RolloutStatus) DeepCopy() *RolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Route) DeepCopyInto(out *Route) {
 	*out = *in
@@ -430,6 +489,13 @@ func (in *RouteStatusFields) DeepCopyInto(out *RouteStatusFields) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Rollouts != nil {
+		in, out := &in.Rollouts, &out.Rollouts
+		*out = make([]RolloutStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -541,6 +607,22 @@ func (in *ServiceStatus) DeepCopy() *ServiceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficMatch) DeepCopyInto(out *TrafficMatch) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficMatch.
+func (in *TrafficMatch) DeepCopy() *TrafficMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TrafficTarget) DeepCopyInto(out *TrafficTarget) {
 	*out = *in
@@ -559,6 +641,16 @@ func (in *TrafficTarget) DeepCopyInto(out *TrafficTarget) {
 		*out = new(apis.URL)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Match != nil {
+		in, out := &in.Match, &out.Match
+		*out = make([]TrafficMatch, len(*in))
+		copy(*out, *in)
+	}
+	if in.Mirror != nil {
+		in, out := &in.Mirror, &out.Mirror
+		*out = new(int64)
+		**out = **in
+	}
 	return
 }
 
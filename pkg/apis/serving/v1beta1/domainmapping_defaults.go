@@ -27,6 +27,9 @@ import (
 func (dm *DomainMapping) SetDefaults(ctx context.Context) {
 	ctx = apis.WithinParent(ctx, dm.ObjectMeta)
 	dm.Spec.Ref.SetDefaults(apis.WithinSpec(ctx))
+	for i := range dm.Spec.Paths {
+		dm.Spec.Paths[i].Ref.SetDefaults(apis.WithinSpec(ctx))
+	}
 
 	if apis.IsInUpdate(ctx) {
 		serving.SetUserInfo(ctx, apis.GetBaseline(ctx).(*DomainMapping).Spec, dm.Spec, dm)
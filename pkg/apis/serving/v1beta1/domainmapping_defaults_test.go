@@ -75,6 +75,32 @@ func TestDomainMappingDefaulting(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		name: "empty path ref namespace",
+		in: &DomainMapping{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "some-namespace",
+			},
+			Spec: DomainMappingSpec{
+				Paths: []DomainMappingPath{{Path: "/api"}},
+			},
+		},
+		out: &DomainMapping{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "some-namespace",
+			},
+			Spec: DomainMappingSpec{
+				Ref: duckv1.KReference{
+					Namespace: "some-namespace",
+				},
+				Paths: []DomainMappingPath{{
+					Path: "/api",
+					Ref: duckv1.KReference{
+						Namespace: "some-namespace",
+					},
+				}},
+			},
+		},
 	}}
 
 	for _, test := range tests {
@@ -17,6 +17,8 @@ limitations under the License.
 package v1beta1
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	netv1alpha1 "knative.dev/networking/pkg/apis/networking/v1alpha1"
@@ -66,6 +68,10 @@ const (
 	// TLSCertificateProvidedExternally indicates that a TLS secret won't be created or managed
 	// instead a reference to an existing TLS secret should have been provided in the DomainMapping spec
 	TLSCertificateProvidedExternally = "TLS certificate was provided externally"
+	// CertificateExpiringSoonReason is the reason of the
+	// DomainMappingConditionCertificateProvisioned condition when the
+	// externally provided certificate expires soon.
+	CertificateExpiringSoonReason = "CertificateExpiringSoon"
 )
 
 // MarkTLSNotEnabled sets DomainMappingConditionCertificateProvisioned to true when
@@ -75,11 +81,27 @@ func (dms *DomainMappingStatus) MarkTLSNotEnabled(msg string) {
 		"TLSNotEnabled", msg)
 }
 
+// MarkCertificateNotRequired sets DomainMappingConditionCertificateProvisioned
+// to true when the certificate is provided externally.
 func (dms *DomainMappingStatus) MarkCertificateNotRequired(msg string) {
 	domainMappingCondSet.Manage(dms).MarkTrueWithReason(DomainMappingConditionCertificateProvisioned,
 		"CertificateExternallyProvided", msg)
 }
 
+// MarkCertificateExpiringSoon sets DomainMappingConditionCertificateProvisioned
+// to true, with a warning that the externally provided certificate expires soon.
+func (dms *DomainMappingStatus) MarkCertificateExpiringSoon(notAfter time.Time) {
+	domainMappingCondSet.Manage(dms).MarkTrueWithReason(DomainMappingConditionCertificateProvisioned,
+		CertificateExpiringSoonReason, "The TLS certificate expires at %s", notAfter.UTC().Format(time.RFC3339))
+}
+
+// MarkCertificateInvalid marks the DomainMappingConditionCertificateProvisioned
+// condition to indicate that the externally provided certificate cannot be
+// used, e.g. because it does not cover the domain or it expired.
+func (dms *DomainMappingStatus) MarkCertificateInvalid(reason, message string) {
+	domainMappingCondSet.Manage(dms).MarkFalse(DomainMappingConditionCertificateProvisioned, reason, message)
+}
+
 // MarkCertificateReady marks the DomainMappingConditionCertificateProvisioned
 // condition to indicate that the Certificate is ready.
 func (dms *DomainMappingStatus) MarkCertificateReady(name string) {
@@ -141,6 +163,14 @@ func (dms *DomainMappingStatus) MarkDomainClaimNotOwned() {
 		"The domain name is already in use by another DomainMapping")
 }
 
+// MarkDomainClaimOverlaps updates the DomainMappingConditionDomainClaimed
+// condition to indicate that the domain overlaps with the wildcard domain, or
+// the domain below the wildcard domain, claimed by another namespace.
+func (dms *DomainMappingStatus) MarkDomainClaimOverlaps(domain string) {
+	domainMappingCondSet.Manage(dms).MarkFalse(DomainMappingConditionDomainClaimed, "DomainClaimOverlaps",
+		"The domain name overlaps with %q, which is in use by another namespace", domain)
+}
+
 // MarkDomainClaimFailed updates the DomainMappingConditionDomainClaimed
 // condition to indicate that creating the ClusterDomainClaim failed.
 func (dms *DomainMappingStatus) MarkDomainClaimFailed(reason string) {
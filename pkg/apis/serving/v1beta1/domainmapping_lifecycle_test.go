@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -97,6 +98,16 @@ func TestDomainClaimConditions(t *testing.T) {
 	dms.MarkDomainClaimNotOwned()
 	apistest.CheckConditionFailed(dms, DomainMappingConditionDomainClaimed, t)
 	apistest.CheckConditionFailed(dms, DomainMappingConditionReady, t)
+
+	dms.MarkDomainClaimed()
+	apistest.CheckConditionSucceeded(dms, DomainMappingConditionReady, t)
+
+	dms.MarkDomainClaimOverlaps("*.example.com")
+	apistest.CheckConditionFailed(dms, DomainMappingConditionDomainClaimed, t)
+	apistest.CheckConditionFailed(dms, DomainMappingConditionReady, t)
+	if got, want := dms.GetCondition(DomainMappingConditionDomainClaimed).Reason, "DomainClaimOverlaps"; got != want {
+		t.Errorf("DomainClaimed reason = %q, want: %q", got, want)
+	}
 }
 
 func TestReferenceResolvedCondition(t *testing.T) {
@@ -165,6 +176,32 @@ func TestDomainMappingExternalDomainTLSNotEnabled(t *testing.T) {
 	apistest.CheckConditionSucceeded(dms, DomainMappingConditionCertificateProvisioned, t)
 }
 
+func TestDomainMappingCertificateInvalid(t *testing.T) {
+	dms := &DomainMappingStatus{}
+	dms.InitializeConditions()
+	dms.MarkCertificateInvalid("CertificateExpired", "the certificate has expired")
+
+	apistest.CheckConditionFailed(dms, DomainMappingConditionCertificateProvisioned, t)
+	if got, want := dms.GetCondition(DomainMappingConditionCertificateProvisioned).Reason, "CertificateExpired"; got != want {
+		t.Errorf("CertificateProvisioned reason = %q, want: %q", got, want)
+	}
+}
+
+func TestDomainMappingCertificateExpiringSoon(t *testing.T) {
+	dms := &DomainMappingStatus{}
+	dms.InitializeConditions()
+	dms.MarkCertificateExpiringSoon(time.Date(2026, time.May, 30, 0, 0, 0, 0, time.UTC))
+
+	apistest.CheckConditionSucceeded(dms, DomainMappingConditionCertificateProvisioned, t)
+	cond := dms.GetCondition(DomainMappingConditionCertificateProvisioned)
+	if got, want := cond.Reason, CertificateExpiringSoonReason; got != want {
+		t.Errorf("CertificateProvisioned reason = %q, want: %q", got, want)
+	}
+	if got, want := cond.Message, "The TLS certificate expires at 2026-05-30T00:00:00Z"; got != want {
+		t.Errorf("CertificateProvisioned message = %q, want: %q", got, want)
+	}
+}
+
 func TestDomainMappingHTTPDowngrade(t *testing.T) {
 	dms := &DomainMappingStatus{}
 	dms.InitializeConditions()
@@ -89,11 +89,55 @@ type DomainMappingSpec struct {
 	// Knative Routes, and by Kubernetes Services.
 	Ref duckv1.KReference `json:"ref"`
 
+	// Paths routes the requests to the given path prefixes to other targets
+	// than Ref, which receives the requests to the other paths.
+	// +optional
+	Paths []DomainMappingPath `json:"paths,omitempty"`
+
+	// Wildcard maps the subdomains of the name of the DomainMapping, at any
+	// depth, i.e. `*.{name}`, rather than the name itself. It is immutable.
+	// +optional
+	Wildcard bool `json:"wildcard,omitempty"`
+
+	// Headers manipulates the headers of the requests to Ref.
+	// +optional
+	Headers *DomainMappingHeaders `json:"headers,omitempty"`
+
 	// TLS allows the DomainMapping to terminate TLS traffic with an existing secret.
 	// +optional
 	TLS *SecretTLS `json:"tls,omitempty"`
 }
 
+// DomainMappingPath routes the requests to a path prefix of the domain.
+type DomainMappingPath struct {
+	// Path is the path prefix of the requests, which must start with "/".
+	// The longest matching prefix wins.
+	Path string `json:"path"`
+
+	// Ref specifies the target of the requests to the path prefix, with the
+	// same contract as the Ref of the DomainMappingSpec.
+	Ref duckv1.KReference `json:"ref"`
+
+	// Headers manipulates the headers of the requests to the path prefix.
+	// +optional
+	Headers *DomainMappingHeaders `json:"headers,omitempty"`
+}
+
+// DomainMappingHeaders manipulates the headers of the requests of a
+// DomainMapping.
+type DomainMappingHeaders struct {
+	// Request manipulates the headers of the requests.
+	// +optional
+	Request *HeaderOperations `json:"request,omitempty"`
+}
+
+// HeaderOperations sets HTTP headers.
+type HeaderOperations struct {
+	// Set sets the given headers, replacing their existing values.
+	// +optional
+	Set map[string]string `json:"set,omitempty"`
+}
+
 // DomainMappingStatus describes the current state of the DomainMapping.
 type DomainMappingStatus struct {
 	duckv1.Status `json:",inline"`
@@ -105,6 +149,11 @@ type DomainMappingStatus struct {
 	// Address holds the information needed for a DomainMapping to be the target of an event.
 	// +optional
 	Address *duckv1.Addressable `json:"address,omitempty"`
+
+	// CertificateNotAfter is the expiry time of the TLS certificate provided
+	// externally through spec.tls.
+	// +optional
+	CertificateNotAfter *metav1.Time `json:"certificateNotAfter,omitempty"`
 }
 
 const (
@@ -133,3 +182,12 @@ const (
 func (dm *DomainMapping) GetStatus() *duckv1.Status {
 	return &dm.Status.Status
 }
+
+// Host returns the host mapped by the DomainMapping, which is a wildcard
+// host, e.g. `*.example.com`, for a wildcard DomainMapping.
+func (dm *DomainMapping) Host() string {
+	if dm.Spec.Wildcard {
+		return "*." + dm.Name
+	}
+	return dm.Name
+}
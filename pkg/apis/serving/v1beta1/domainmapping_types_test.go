@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
 
@@ -35,3 +36,15 @@ func TestDomainMappingGetStatus(t *testing.T) {
 		t.Errorf("GetStatus did not retrieve status. Got=%v Want=%v", config.GetStatus(), status)
 	}
 }
+
+func TestDomainMappingHost(t *testing.T) {
+	dm := &DomainMapping{ObjectMeta: metav1.ObjectMeta{Name: "example.com"}}
+	if got, want := dm.Host(), "example.com"; got != want {
+		t.Errorf("Host() = %q, want: %q", got, want)
+	}
+
+	dm.Spec.Wildcard = true
+	if got, want := dm.Host(), "*.example.com"; got != want {
+		t.Errorf("Host() = %q, want: %q", got, want)
+	}
+}
@@ -19,15 +19,26 @@ package v1beta1
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
+	"time"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	netheader "knative.dev/networking/pkg/http/header"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/network"
 	"knative.dev/serving/pkg/apis/serving"
 )
 
+// reservedHeaders are the headers a DomainMapping may not set, as
+// they are needed to route its requests.
+var reservedHeaders = sets.New(
+	"Host",
+	netheader.OriginalHostKey,
+)
+
 // Validate makes sure that DomainMapping is properly configured.
 func (dm *DomainMapping) Validate(ctx context.Context) *apis.FieldError {
 	errs := dm.validateMetadata(ctx).ViaField("metadata")
@@ -35,6 +46,17 @@ func (dm *DomainMapping) Validate(ctx context.Context) *apis.FieldError {
 	ctx = apis.WithinParent(ctx, dm.ObjectMeta)
 	errs = errs.Also(dm.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
 
+	if apis.IsInUpdate(ctx) {
+		original := apis.GetBaseline(ctx).(*DomainMapping)
+		if original.Spec.Wildcard != dm.Spec.Wildcard {
+			errs = errs.Also(&apis.FieldError{
+				Message: "Immutable field changed",
+				Paths:   []string{"spec.wildcard"},
+				Details: "the DomainMapping must be recreated to map its subdomains instead of its name, or back",
+			})
+		}
+	}
+
 	return errs
 }
 
@@ -51,11 +73,17 @@ func (dm *DomainMapping) validateMetadata(ctx context.Context) (errs *apis.Field
 	}
 
 	clusterLocalDomain := network.GetClusterDomainName()
-	if strings.HasSuffix(dm.Name, "."+clusterLocalDomain) {
+	if strings.HasSuffix(dm.Host(), "."+clusterLocalDomain) {
 		errs = errs.Also(apis.ErrGeneric(
 			fmt.Sprintf("invalid name %q: must not be a subdomain of cluster local domain %q", dm.Name, clusterLocalDomain), "name"))
 	}
 
+	if k, v, ok := serving.CertificateExpiryWarningAnnotation.Get(dm.Annotations); ok {
+		if d, err := time.ParseDuration(v); err != nil || d <= 0 {
+			errs = errs.Also(apis.ErrInvalidValue(v, k, "must be a positive duration").ViaField("annotations"))
+		}
+	}
+
 	if apis.IsInUpdate(ctx) {
 		original := apis.GetBaseline(ctx).(*DomainMapping)
 		errs = errs.Also(
@@ -69,5 +97,70 @@ func (dm *DomainMapping) validateMetadata(ctx context.Context) (errs *apis.Field
 
 // Validate makes sure the DomainMappingSpec is properly configured.
 func (spec *DomainMappingSpec) Validate(ctx context.Context) *apis.FieldError {
-	return spec.Ref.Validate(ctx).ViaField("ref")
+	errs := spec.Ref.Validate(ctx).ViaField("ref")
+	errs = errs.Also(spec.Headers.Validate(ctx).ViaField("headers"))
+
+	paths := make(map[string]int, len(spec.Paths))
+	for i, p := range spec.Paths {
+		errs = errs.Also(p.Validate(ctx).ViaFieldIndex("paths", i))
+		if j, ok := paths[p.Path]; ok {
+			errs = errs.Also(&apis.FieldError{
+				Message: fmt.Sprintf("Multiple definitions for path %q", p.Path),
+				Paths:   []string{fmt.Sprintf("paths[%d].path", j), fmt.Sprintf("paths[%d].path", i)},
+			})
+		}
+		paths[p.Path] = i
+	}
+	return errs
+}
+
+// Validate makes sure the DomainMappingPath is properly configured.
+func (p *DomainMappingPath) Validate(ctx context.Context) (errs *apis.FieldError) {
+	switch {
+	case p.Path == "":
+		errs = apis.ErrMissingField("path")
+	case p.Path == "/":
+		errs = apis.ErrInvalidValue(p.Path, "path", "the requests to the root path are routed to spec.ref")
+	case !isURLPath(p.Path):
+		errs = apis.ErrInvalidValue(p.Path, "path", `must be a URL path starting with "/"`)
+	}
+	return errs.Also(p.Ref.Validate(ctx).ViaField("ref")).
+		Also(p.Headers.Validate(ctx).ViaField("headers"))
+}
+
+// Validate makes sure the DomainMappingHeaders are properly configured.
+func (h *DomainMappingHeaders) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if h == nil {
+		return nil
+	}
+	if h.Request != nil {
+		errs = h.Request.Validate(ctx).ViaField("request")
+	}
+	return errs
+}
+
+// Validate makes sure the HeaderOperations are properly configured.
+func (ops *HeaderOperations) Validate(context.Context) (errs *apis.FieldError) {
+	for name, value := range ops.Set {
+		errs = errs.Also(validateHeaderName(name).ViaField("set"))
+		if strings.ContainsAny(value, "\r\n\x00") {
+			errs = errs.Also(apis.ErrInvalidValue(value, apis.CurrentField).ViaKey(name).ViaField("set"))
+		}
+	}
+	return errs
+}
+
+func validateHeaderName(name string) *apis.FieldError {
+	if msgs := validation.IsHTTPHeaderName(name); len(msgs) > 0 {
+		return apis.ErrInvalidKeyName(name, apis.CurrentField, msgs...)
+	}
+	if reservedHeaders.Has(http.CanonicalHeaderKey(name)) {
+		return apis.ErrInvalidKeyName(name, apis.CurrentField, "the header is reserved")
+	}
+	return nil
+}
+
+// isURLPath returns whether p is an absolute URL path, without query or fragment.
+func isURLPath(p string) bool {
+	return strings.HasPrefix(p, "/") && !strings.ContainsAny(p, "?# \t\n")
 }
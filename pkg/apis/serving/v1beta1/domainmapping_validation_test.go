@@ -137,6 +137,106 @@ func TestDomainMappingValidation(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		name: "wildcard of the cluster local domain",
+		want: apis.ErrGeneric("invalid name \"cluster.local\": must not be a subdomain of cluster local domain \"cluster.local\"", "metadata.name"),
+		dm: &DomainMapping{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster.local",
+				Namespace: "ns",
+			},
+			Spec: DomainMappingSpec{
+				Ref: duckv1.KReference{
+					Name:       "some-name",
+					Namespace:  "ns",
+					Kind:       "Service",
+					APIVersion: "serving.knative.dev/v1",
+				},
+				Wildcard: true,
+			},
+		},
+	}, {
+		name: "invalid certificate expiry warning",
+		want: apis.ErrInvalidValue("30d", "metadata.annotations.serving.knative.dev/certificate-expiry-warning", "must be a positive duration"),
+		dm: &DomainMapping{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "expiry.example.com",
+				Namespace: "ns",
+				Annotations: map[string]string{
+					serving.CertificateExpiryWarningKey: "30d",
+				},
+			},
+			Spec: DomainMappingSpec{
+				Ref: pathRef("web"),
+			},
+		},
+	}, {
+		name: "wildcard with paths",
+		dm: &DomainMapping{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "tenant.example.com",
+				Namespace: "ns",
+			},
+			Spec: DomainMappingSpec{
+				Ref: duckv1.KReference{
+					Name:       "web",
+					Namespace:  "ns",
+					Kind:       "Service",
+					APIVersion: "serving.knative.dev/v1",
+				},
+				Paths: []DomainMappingPath{{
+					Path: "/api",
+					Ref: duckv1.KReference{
+						Name:       "api",
+						Namespace:  "ns",
+						Kind:       "Service",
+						APIVersion: "serving.knative.dev/v1",
+					},
+				}, {
+					Path: "/api/v2",
+					Ref: duckv1.KReference{
+						Name:       "api-v2",
+						Namespace:  "ns",
+						Kind:       "Service",
+						APIVersion: "serving.knative.dev/v1",
+					},
+				}},
+				Wildcard: true,
+			},
+		},
+	}, {
+		name: "invalid paths",
+		want: apis.ErrMissingField("spec.paths[0].path").Also(
+			apis.ErrInvalidValue("/", "spec.paths[1].path", "the requests to the root path are routed to spec.ref")).Also(
+			apis.ErrInvalidValue("api", "spec.paths[2].path", `must be a URL path starting with "/"`)).Also(
+			apis.ErrInvalidValue("/api?v=2", "spec.paths[3].path", `must be a URL path starting with "/"`)).Also(
+			apis.ErrMissingField("spec.paths[4].ref.kind")).Also(&apis.FieldError{
+			Message: `Multiple definitions for path "/api"`,
+			Paths:   []string{"spec.paths[5].path", "spec.paths[6].path"},
+		}),
+		dm: &DomainMapping{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "paths.example.com",
+				Namespace: "ns",
+			},
+			Spec: DomainMappingSpec{
+				Ref: duckv1.KReference{
+					Name:       "web",
+					Namespace:  "ns",
+					Kind:       "Service",
+					APIVersion: "serving.knative.dev/v1",
+				},
+				Paths: []DomainMappingPath{
+					{Ref: pathRef("api")},
+					{Path: "/", Ref: pathRef("api")},
+					{Path: "api", Ref: pathRef("api")},
+					{Path: "/api?v=2", Ref: pathRef("api")},
+					{Path: "/kindless", Ref: duckv1.KReference{Name: "api", Namespace: "ns", APIVersion: "serving.knative.dev/v1"}},
+					{Path: "/api", Ref: pathRef("api")},
+					{Path: "/api", Ref: pathRef("api-v2")},
+				},
+			},
+		},
 	}}
 
 	for _, test := range tests {
@@ -151,6 +251,67 @@ func TestDomainMappingValidation(t *testing.T) {
 	}
 }
 
+func TestDomainMappingHeadersValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		spec DomainMappingSpec
+		want *apis.FieldError
+	}{{
+		name: "request headers set",
+		spec: DomainMappingSpec{
+			Ref: pathRef("web"),
+			Headers: &DomainMappingHeaders{
+				Request: &HeaderOperations{
+					Set: map[string]string{"X-Forwarded-Prefix": "/api"},
+				},
+			},
+		},
+	}, {
+		name: "invalid operations",
+		spec: DomainMappingSpec{
+			Ref: pathRef("web"),
+			Headers: &DomainMappingHeaders{
+				Request: &HeaderOperations{
+					Set: map[string]string{
+						"X Forwarded Prefix": "/api",
+						"K-Original-Host":    "example.com",
+						"X-Tenant":           "a\r\nb",
+						"Host":               "example.com",
+					},
+				},
+			},
+		},
+		want: apis.ErrInvalidKeyName("X Forwarded Prefix", "spec.headers.request.set", "a valid HTTP header must consist of alphanumeric characters or '-' (e.g. 'X-Header-Name', regex used for validation is '[-A-Za-z0-9]+')").Also(
+			apis.ErrInvalidKeyName("K-Original-Host", "spec.headers.request.set", "the header is reserved")).Also(
+			apis.ErrInvalidKeyName("Host", "spec.headers.request.set", "the header is reserved")).Also(
+			apis.ErrInvalidValue("a\r\nb", "spec.headers.request.set[X-Tenant]")),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dm := &DomainMapping{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "headers.example.com",
+					Namespace: "ns",
+				},
+				Spec: test.spec,
+			}
+			if got := dm.Validate(context.Background()); !cmp.Equal(test.want.Error(), got.Error()) {
+				t.Errorf("Validate (-want, +got):\n%s", cmp.Diff(test.want.Error(), got.Error()))
+			}
+		})
+	}
+}
+
+func pathRef(name string) duckv1.KReference {
+	return duckv1.KReference{
+		Name:       name,
+		Namespace:  "ns",
+		Kind:       "Service",
+		APIVersion: "serving.knative.dev/v1",
+	}
+}
+
 func TestDomainMappingAnnotationUpdate(t *testing.T) {
 	const (
 		u1 = "oveja@knative.dev"
@@ -278,6 +439,27 @@ func TestDomainMappingAnnotationUpdate(t *testing.T) {
 			Spec: spec("old"),
 		},
 		want: nil,
+	}, {
+		name: "update wildcard",
+		this: &DomainMapping{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "valid.example.com",
+				Namespace: "ns",
+			},
+			Spec: DomainMappingSpec{Ref: spec("old").Ref, Wildcard: true},
+		},
+		prev: &DomainMapping{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "valid.example.com",
+				Namespace: "ns",
+			},
+			Spec: spec("old"),
+		},
+		want: &apis.FieldError{
+			Message: "Immutable field changed",
+			Paths:   []string{"spec.wildcard"},
+			Details: "the DomainMapping must be recreated to map its subdomains instead of its name, or back",
+		},
 	}}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -71,6 +71,27 @@ func (in *DomainMapping) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainMappingHeaders) DeepCopyInto(out *DomainMappingHeaders) {
+	*out = *in
+	if in.Request != nil {
+		in, out := &in.Request, &out.Request
+		*out = new(HeaderOperations)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainMappingHeaders.
+func (in *DomainMappingHeaders) DeepCopy() *DomainMappingHeaders {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainMappingHeaders)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DomainMappingList) DeepCopyInto(out *DomainMappingList) {
 	*out = *in
@@ -104,10 +125,44 @@ func (in *DomainMappingList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainMappingPath) DeepCopyInto(out *DomainMappingPath) {
+	*out = *in
+	in.Ref.DeepCopyInto(&out.Ref)
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = new(DomainMappingHeaders)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainMappingPath.
+func (in *DomainMappingPath) DeepCopy() *DomainMappingPath {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainMappingPath)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DomainMappingSpec) DeepCopyInto(out *DomainMappingSpec) {
 	*out = *in
 	in.Ref.DeepCopyInto(&out.Ref)
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]DomainMappingPath, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = new(DomainMappingHeaders)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.TLS != nil {
 		in, out := &in.TLS, &out.TLS
 		*out = new(SecretTLS)
@@ -140,6 +195,10 @@ func (in *DomainMappingStatus) DeepCopyInto(out *DomainMappingStatus) {
 		*out = new(v1.Addressable)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CertificateNotAfter != nil {
+		in, out := &in.CertificateNotAfter, &out.CertificateNotAfter
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -153,6 +212,29 @@ func (in *DomainMappingStatus) DeepCopy() *DomainMappingStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeaderOperations) DeepCopyInto(out *HeaderOperations) {
+	*out = *in
+	if in.Set != nil {
+		in, out := &in.Set, &out.Set
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeaderOperations.
+func (in *HeaderOperations) DeepCopy() *HeaderOperations {
+	if in == nil {
+		return nil
+	}
+	out := new(HeaderOperations)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretTLS) DeepCopyInto(out *SecretTLS) {
 	*out = *in
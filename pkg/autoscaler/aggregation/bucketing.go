@@ -296,6 +296,47 @@ func (t *TimedFloat64Buckets) Record(now time.Time, value float64) {
 	t.windowTotal += value
 }
 
+// BucketsSnapshot is a point in time copy of the data held by the buckets,
+// which can be used to restore it into another instance, e.g. after a restart.
+type BucketsSnapshot struct {
+	// LastWrite is the time of the last bucket in Buckets.
+	LastWrite time.Time `json:"lastWrite"`
+	// Granularity is the duration represented by each bucket.
+	Granularity time.Duration `json:"granularity"`
+	// Buckets holds the valid buckets of the window, oldest first.
+	Buckets []float64 `json:"buckets,omitempty"`
+}
+
+// Snapshot returns a copy of the valid buckets as of the last write.
+func (t *TimedFloat64Buckets) Snapshot() BucketsSnapshot {
+	t.bucketsMutex.RLock()
+	defer t.bucketsMutex.RUnlock()
+
+	ret := BucketsSnapshot{
+		LastWrite:   t.lastWrite,
+		Granularity: t.granularity,
+	}
+	if t.lastWrite.IsZero() {
+		return ret
+	}
+	numB := min(int(t.lastWrite.Sub(t.firstWrite)/t.granularity)+1, len(t.buckets))
+	ret.Buckets = make([]float64, numB)
+	lastIdx := t.timeToIndex(t.lastWrite) + len(t.buckets) // To ensure always positive % operation.
+	for i := range numB {
+		ret.Buckets[numB-1-i] = t.buckets[(lastIdx-i)%len(t.buckets)]
+	}
+	return ret
+}
+
+// Restore records the data from the snapshot, as if it were recorded at the
+// times it was originally recorded at. Data that has fallen out of the window
+// since is ignored.
+func (t *TimedFloat64Buckets) Restore(s BucketsSnapshot) {
+	for i, v := range s.Buckets {
+		t.Record(s.LastWrite.Add(-time.Duration(len(s.Buckets)-1-i)*s.Granularity), v)
+	}
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
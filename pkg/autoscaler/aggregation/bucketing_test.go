@@ -605,6 +605,64 @@ func TestTimedFloat64BucketsWindowUpdateNoOp(t *testing.T) {
 	}
 }
 
+func TestTimedFloat64BucketsSnapshotRestore(t *testing.T) {
+	now := time.Now().Truncate(granularity)
+	src := NewTimedFloat64Buckets(5*time.Second, granularity)
+
+	if got := src.Snapshot(); len(got.Buckets) != 0 {
+		t.Errorf("Snapshot of empty buckets = %v, want no buckets", got.Buckets)
+	}
+
+	// A partial window, with a hole in it.
+	src.Record(now, 1)
+	src.Record(now.Add(2*time.Second), 3)
+	src.Record(now.Add(2*time.Second), 2)
+	snap := src.Snapshot()
+	if got, want := snap.Buckets, []float64{1, 0, 5}; !cmp.Equal(got, want) {
+		t.Errorf("Snapshot buckets = %v, want: %v", got, want)
+	}
+
+	// A full window, which has wrapped around.
+	src.Record(now.Add(6*time.Second), 7)
+	snap = src.Snapshot()
+	if got, want := snap.Buckets, []float64{5, 0, 0, 0, 7}; !cmp.Equal(got, want) {
+		t.Errorf("Snapshot buckets = %v, want: %v", got, want)
+	}
+
+	for _, dst := range []interface {
+		Restore(BucketsSnapshot)
+		WindowAverage(time.Time) float64
+	}{
+		NewTimedFloat64Buckets(5*time.Second, granularity),
+		NewWeightedFloat64Buckets(5*time.Second, granularity),
+	} {
+		dst.Restore(snap)
+		for _, at := range []time.Duration{6 * time.Second, 8 * time.Second} {
+			if got, want := dst.WindowAverage(now.Add(at)), srcAverage(src, dst, now.Add(at)); got != want {
+				t.Errorf("%T WindowAverage at +%v = %v, want: %v", dst, at, got, want)
+			}
+		}
+	}
+
+	// Restoring a smaller window drops the older buckets.
+	small := NewTimedFloat64Buckets(2*time.Second, granularity)
+	small.Restore(snap)
+	if got, want := small.Snapshot().Buckets, []float64{0, 7}; !cmp.Equal(got, want) {
+		t.Errorf("Restored snapshot buckets = %v, want: %v", got, want)
+	}
+}
+
+// srcAverage returns the window average of src, computed the way dst would.
+func srcAverage(src *TimedFloat64Buckets, dst any, now time.Time) float64 {
+	if _, ok := dst.(*WeightedFloat64Buckets); ok {
+		return (&WeightedFloat64Buckets{
+			TimedFloat64Buckets: src,
+			smoothingCoeff:      computeSmoothingCoeff(5),
+		}).WindowAverage(now)
+	}
+	return src.WindowAverage(now)
+}
+
 func BenchmarkWindowAverage(b *testing.B) {
 	// Window lengths in secs.
 	for _, wl := range []int{30, 60, 120, 240, 600} {
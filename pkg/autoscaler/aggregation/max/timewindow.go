@@ -45,3 +45,25 @@ func (t *TimeWindow) Record(now time.Time, value int32) {
 func (t *TimeWindow) Current() int32 {
 	return t.window.Current()
 }
+
+// Sample is a value recorded at a point in time.
+type Sample struct {
+	Time  time.Time `json:"time"`
+	Value int32     `json:"value"`
+}
+
+// Samples returns the samples that are, or may yet become, the maximum
+// of the window, oldest first. Recording them into a new TimeWindow
+// restores the state of this one.
+func (t *TimeWindow) Samples() []Sample {
+	w := t.window
+	ret := make([]Sample, w.length)
+	for i := range w.length {
+		e := w.maxima[w.index(w.first+i)]
+		ret[i] = Sample{
+			Time:  time.Unix(int64(e.index)*int64(t.granularity.Seconds()), 0),
+			Value: e.value,
+		}
+	}
+	return ret
+}
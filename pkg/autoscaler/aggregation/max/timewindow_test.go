@@ -22,6 +22,8 @@ import (
 	"math/rand"
 	"testing"
 	"time"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestTimedWindowMax(t *testing.T) {
@@ -100,6 +102,36 @@ func BenchmarkLargeTimeWindowCreate(b *testing.B) {
 	}
 }
 
+func TestTimeWindowSamples(t *testing.T) {
+	now := time.Unix(1000, 0)
+	src := NewTimeWindow(10*time.Second, 2*time.Second)
+	if got := src.Samples(); len(got) != 0 {
+		t.Errorf("Samples = %v, want none", got)
+	}
+	for i, v := range []int32{3, 9, 4, 6, 2} {
+		src.Record(now.Add(time.Duration(i)*2*time.Second), v)
+	}
+
+	want := []Sample{{now.Add(2 * time.Second), 9}, {now.Add(6 * time.Second), 6}, {now.Add(8 * time.Second), 2}}
+	got := src.Samples()
+	if !cmp.Equal(got, want) {
+		t.Error("Samples mismatch (-want,+got):", cmp.Diff(want, got))
+	}
+
+	dst := NewTimeWindow(10*time.Second, 2*time.Second)
+	for _, s := range got {
+		dst.Record(s.Time, s.Value)
+	}
+	for i := range 5 {
+		at := now.Add(time.Duration(10+2*i) * time.Second)
+		src.Record(at, 1)
+		dst.Record(at, 1)
+		if got, want := dst.Current(), src.Current(); got != want {
+			t.Errorf("Current at %v = %d, want: %d", at, got, want)
+		}
+	}
+}
+
 func BenchmarkLargeTimeWindowRecord(b *testing.B) {
 	w := NewTimeWindow(45*time.Minute, 1*time.Second)
 	now := time.Now()
@@ -0,0 +1,190 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkpoint
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/hash"
+	"knative.dev/pkg/logging/logkey"
+	"knative.dev/serving/pkg/autoscaler/metrics"
+	"knative.dev/serving/pkg/autoscaler/scaling"
+)
+
+const (
+	// interval is how often the snapshots of the owned buckets are saved.
+	interval = 10 * time.Second
+
+	// restoreTimeout is how long after a bucket is acquired its snapshots
+	// are kept around, waiting for the respective collections and scalers
+	// to be created by the reconcilers.
+	restoreTimeout = time.Minute
+)
+
+// Collector is the part of the metric collector the Checkpointer needs.
+type Collector interface {
+	Keys() []types.NamespacedName
+	Snapshot(types.NamespacedName) (*metrics.CollectionSnapshot, error)
+	Restore(types.NamespacedName, *metrics.CollectionSnapshot) error
+}
+
+// Scaler is the part of the multiscaler the Checkpointer needs.
+type Scaler interface {
+	Snapshot(types.NamespacedName, time.Time) *scaling.ScalerSnapshot
+	Restore(types.NamespacedName, *scaling.ScalerSnapshot, time.Time) bool
+}
+
+// pendingRestore is a snapshot not restored yet. The fields are cleared as
+// the respective parts are restored.
+type pendingRestore struct {
+	Snapshot
+	deadline time.Time
+}
+
+// Checkpointer saves the snapshots of the buckets owned by this Autoscaler
+// pod and restores them once it acquires a bucket.
+type Checkpointer struct {
+	logger    *zap.SugaredLogger
+	store     Store
+	bs        *hash.BucketSet
+	isOwner   func(bkt string) bool
+	collector Collector
+	scaler    Scaler
+
+	// owned and pending are only accessed from Run.
+	owned   sets.Set[string]
+	pending map[types.NamespacedName]*pendingRestore
+}
+
+// New creates a new Checkpointer, which decides whether it owns a bucket
+// of the BucketSet with isOwner.
+func New(logger *zap.SugaredLogger, store Store, bs *hash.BucketSet, isOwner func(string) bool,
+	collector Collector, scaler Scaler,
+) *Checkpointer {
+	return &Checkpointer{
+		logger:    logger.Named("checkpoint"),
+		store:     store,
+		bs:        bs,
+		isOwner:   isOwner,
+		collector: collector,
+		scaler:    scaler,
+		owned:     sets.New[string](),
+		pending:   make(map[types.NamespacedName]*pendingRestore),
+	}
+}
+
+// Run checkpoints the owned buckets until the context is done.
+func (c *Checkpointer) Run(ctx context.Context) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			c.tick(ctx, now)
+		}
+	}
+}
+
+func (c *Checkpointer) tick(ctx context.Context, now time.Time) {
+	for _, bkt := range c.bs.BucketList() {
+		if !c.isOwner(bkt) {
+			c.owned.Delete(bkt)
+			continue
+		}
+		if !c.owned.Has(bkt) {
+			c.load(ctx, bkt, now)
+		}
+	}
+
+	c.restore(now)
+	c.save(ctx, now)
+}
+
+// load queues the snapshots of a newly acquired bucket for restoring.
+func (c *Checkpointer) load(ctx context.Context, bkt string, now time.Time) {
+	snaps, err := c.store.Load(ctx, bkt)
+	if err != nil {
+		// Retried on the next tick, since the bucket is not marked as owned.
+		c.logger.Errorw("Failed to load snapshots of bucket "+bkt, zap.Error(err))
+		return
+	}
+	c.owned.Insert(bkt)
+	c.logger.Infof("Acquired bucket %s with %d snapshots", bkt, len(snaps))
+	for key, snap := range snaps {
+		c.pending[key] = &pendingRestore{Snapshot: *snap, deadline: now.Add(restoreTimeout)}
+	}
+}
+
+// restore restores the pending snapshots whose collection or scaler exist by now.
+func (c *Checkpointer) restore(now time.Time) {
+	for key, p := range c.pending {
+		if p.Collection != nil && c.collector.Restore(key, p.Collection) == nil {
+			p.Collection = nil
+		}
+		if p.Scaler != nil && c.scaler.Restore(key, p.Scaler, now) {
+			p.Scaler = nil
+		}
+		if p.Collection == nil && p.Scaler == nil {
+			c.logger.Debugw("Restored snapshot", zap.String(logkey.Key, key.String()))
+			delete(c.pending, key)
+		} else if now.After(p.deadline) {
+			c.logger.Infow("Dropping snapshot not restored in time", zap.String(logkey.Key, key.String()))
+			delete(c.pending, key)
+		}
+	}
+}
+
+// save saves the snapshots of the owned buckets. Buckets with restores still
+// pending are skipped, so as not to overwrite their snapshots with the cold
+// state that has not been restored yet.
+func (c *Checkpointer) save(ctx context.Context, now time.Time) {
+	byBucket := make(map[string]Snapshots, len(c.owned))
+	for bkt := range c.owned {
+		byBucket[bkt] = Snapshots{}
+	}
+	for key := range c.pending {
+		delete(byBucket, c.bs.Owner(key.String()))
+	}
+
+	for _, key := range c.collector.Keys() {
+		snaps, ok := byBucket[c.bs.Owner(key.String())]
+		if !ok {
+			continue
+		}
+		coll, err := c.collector.Snapshot(key)
+		if err != nil {
+			// Deleted in the meantime.
+			continue
+		}
+		snaps[key] = &Snapshot{
+			Collection: coll,
+			Scaler:     c.scaler.Snapshot(key, now),
+		}
+	}
+
+	for bkt, snaps := range byBucket {
+		if err := c.store.Save(ctx, bkt, snaps); err != nil {
+			c.logger.Errorw("Failed to save snapshots of bucket "+bkt, zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkpoint
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/hash"
+	. "knative.dev/pkg/logging/testing"
+	"knative.dev/serving/pkg/autoscaler/metrics"
+	"knative.dev/serving/pkg/autoscaler/scaling"
+)
+
+type fakeStore struct {
+	loadErr error
+	loads   int
+	snaps   map[string]Snapshots
+}
+
+func (s *fakeStore) Load(_ context.Context, bkt string) (Snapshots, error) {
+	s.loads++
+	return s.snaps[bkt], s.loadErr
+}
+
+func (s *fakeStore) Save(_ context.Context, bkt string, snaps Snapshots) error {
+	s.snaps[bkt] = snaps
+	return nil
+}
+
+type fakeCollector struct {
+	snaps map[types.NamespacedName]*metrics.CollectionSnapshot
+}
+
+func (c *fakeCollector) Keys() []types.NamespacedName {
+	ret := make([]types.NamespacedName, 0, len(c.snaps))
+	for key := range c.snaps {
+		ret = append(ret, key)
+	}
+	return ret
+}
+
+func (c *fakeCollector) Snapshot(key types.NamespacedName) (*metrics.CollectionSnapshot, error) {
+	snap, ok := c.snaps[key]
+	if !ok {
+		return nil, metrics.ErrNotCollecting
+	}
+	return snap, nil
+}
+
+func (c *fakeCollector) Restore(key types.NamespacedName, snap *metrics.CollectionSnapshot) error {
+	if _, ok := c.snaps[key]; !ok {
+		return metrics.ErrNotCollecting
+	}
+	c.snaps[key] = snap
+	return nil
+}
+
+type fakeScaler struct {
+	snaps map[types.NamespacedName]*scaling.ScalerSnapshot
+}
+
+func (s *fakeScaler) Snapshot(key types.NamespacedName, _ time.Time) *scaling.ScalerSnapshot {
+	return s.snaps[key]
+}
+
+func (s *fakeScaler) Restore(key types.NamespacedName, snap *scaling.ScalerSnapshot, _ time.Time) bool {
+	if _, ok := s.snaps[key]; !ok {
+		return false
+	}
+	s.snaps[key] = snap
+	return true
+}
+
+func TestCheckpointer(t *testing.T) {
+	const bkt = "bucket"
+	ctx := context.Background()
+	now := time.Now()
+	bs := hash.NewBucketSet(sets.New(bkt))
+	key := types.NamespacedName{Namespace: "ns", Name: "rev"}
+	restored := &Snapshot{
+		Collection: &metrics.CollectionSnapshot{},
+		Scaler:     &scaling.ScalerSnapshot{MaxPanicPods: 3},
+	}
+
+	store := &fakeStore{snaps: map[string]Snapshots{bkt: {key: restored}}}
+	collector := &fakeCollector{snaps: map[types.NamespacedName]*metrics.CollectionSnapshot{}}
+	scaler := &fakeScaler{snaps: map[types.NamespacedName]*scaling.ScalerSnapshot{}}
+	owner := false
+	c := New(TestLogger(t), store, bs, func(string) bool { return owner }, collector, scaler)
+
+	// Nothing happens for buckets not owned.
+	c.tick(ctx, now)
+	if store.loads != 0 {
+		t.Errorf("Loads = %d, want: 0", store.loads)
+	}
+
+	// Acquiring the bucket loads its snapshots, but they can't be restored
+	// yet, so the bucket is not saved either.
+	owner = true
+	store.loadErr = errors.New("transient")
+	c.tick(ctx, now)
+	store.loadErr = nil
+	c.tick(ctx, now)
+	if store.loads != 2 {
+		t.Errorf("Loads = %d, want: 2", store.loads)
+	}
+	if got := store.snaps[bkt][key]; got != restored {
+		t.Errorf("Saved snapshot = %v, want the original one", got)
+	}
+
+	// The collection comes first, then the scaler.
+	collector.snaps[key] = &metrics.CollectionSnapshot{}
+	c.tick(ctx, now)
+	if collector.snaps[key] != restored.Collection {
+		t.Error("Collection was not restored")
+	}
+	if got := store.snaps[bkt][key]; got != restored {
+		t.Errorf("Saved snapshot = %v, want the original one", got)
+	}
+	scaler.snaps[key] = &scaling.ScalerSnapshot{}
+	c.tick(ctx, now)
+	if scaler.snaps[key] != restored.Scaler {
+		t.Error("Scaler was not restored")
+	}
+	if len(c.pending) != 0 {
+		t.Errorf("Pending = %v, want none", c.pending)
+	}
+
+	// Restored, so the bucket is saved from now on.
+	want := Snapshots{key: restored}
+	if got := store.snaps[bkt]; !cmp.Equal(got, want) {
+		t.Error("Saved snapshots mismatch (-want,+got):", cmp.Diff(want, got))
+	}
+
+	// Losing the bucket stops saving it, and acquiring it again reloads it.
+	owner = false
+	store.snaps[bkt] = nil
+	c.tick(ctx, now)
+	if store.snaps[bkt] != nil {
+		t.Errorf("Saved snapshots = %v for a bucket not owned", store.snaps[bkt])
+	}
+	owner = true
+	c.tick(ctx, now)
+	if store.loads != 3 {
+		t.Errorf("Loads = %d, want: 3", store.loads)
+	}
+}
+
+func TestCheckpointerRestoreTimeout(t *testing.T) {
+	const bkt = "bucket"
+	ctx := context.Background()
+	now := time.Now()
+	key := types.NamespacedName{Namespace: "ns", Name: "gone"}
+	store := &fakeStore{snaps: map[string]Snapshots{bkt: {key: {
+		Collection: &metrics.CollectionSnapshot{},
+	}}}}
+	c := New(TestLogger(t), store, hash.NewBucketSet(sets.New(bkt)), func(string) bool { return true },
+		&fakeCollector{}, &fakeScaler{})
+
+	c.tick(ctx, now)
+	if len(c.pending) != 1 {
+		t.Fatalf("Pending = %v, want one", c.pending)
+	}
+	c.tick(ctx, now.Add(restoreTimeout+time.Second))
+	if len(c.pending) != 0 {
+		t.Errorf("Pending = %v, want none", c.pending)
+	}
+	if got := store.snaps[bkt]; len(got) != 0 {
+		t.Errorf("Saved snapshots = %v, want none", got)
+	}
+}
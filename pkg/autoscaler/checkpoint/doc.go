@@ -0,0 +1,21 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package checkpoint periodically saves the metric history and the scaling
+// state of the revisions owned by an Autoscaler pod, so that the next owner of
+// their bucket, e.g. after a restart or a leader change, resumes with warm
+// windows instead of starting over.
+package checkpoint
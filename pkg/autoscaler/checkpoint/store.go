@@ -0,0 +1,231 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkpoint
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"knative.dev/serving/pkg/autoscaler/aggregation"
+	"knative.dev/serving/pkg/autoscaler/metrics"
+	"knative.dev/serving/pkg/autoscaler/scaling"
+)
+
+const (
+	// snapshotsKey is the key of the ConfigMap binary data holding the snapshots.
+	snapshotsKey = "snapshots.json.gz"
+
+	// maxSnapshotsSize is the size the encoded snapshots are trimmed to. The
+	// binary data is base64 encoded in the ConfigMap, which must stay within
+	// 1 MiB along with its metadata.
+	maxSnapshotsSize = 700 * 1024
+)
+
+// Snapshot is the state of a single revision.
+type Snapshot struct {
+	Collection *metrics.CollectionSnapshot `json:"collection,omitempty"`
+	Scaler     *scaling.ScalerSnapshot     `json:"scaler,omitempty"`
+}
+
+// Snapshots holds the state of the revisions in a bucket.
+type Snapshots map[types.NamespacedName]*Snapshot
+
+// Store persists the snapshots of each bucket.
+type Store interface {
+	// Load returns the snapshots last saved for the bucket, if any.
+	Load(ctx context.Context, bucket string) (Snapshots, error)
+	// Save replaces the snapshots of the bucket.
+	Save(ctx context.Context, bucket string, snaps Snapshots) error
+}
+
+// configMapStore is a Store that keeps the snapshots of each bucket in
+// a ConfigMap named after it.
+type configMapStore struct {
+	kc        kubernetes.Interface
+	namespace string
+}
+
+var _ Store = (*configMapStore)(nil)
+
+// NewConfigMapStore returns a Store that keeps the snapshots in ConfigMaps
+// in the given namespace.
+func NewConfigMapStore(kc kubernetes.Interface, namespace string) Store {
+	return &configMapStore{kc: kc, namespace: namespace}
+}
+
+// configMapName returns the name of the ConfigMap of the given bucket.
+func configMapName(bucket string) string {
+	return bucket + "-checkpoint"
+}
+
+// Load implements Store.
+func (s *configMapStore) Load(ctx context.Context, bucket string) (Snapshots, error) {
+	cm, err := s.kc.CoreV1().ConfigMaps(s.namespace).Get(ctx, configMapName(bucket), metav1.GetOptions{})
+	if apierrs.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	data, ok := cm.BinaryData[snapshotsKey]
+	if !ok {
+		return nil, nil
+	}
+	return decode(data)
+}
+
+// Save implements Store.
+func (s *configMapStore) Save(ctx context.Context, bucket string, snaps Snapshots) error {
+	data, err := encodeWithin(snaps, maxSnapshotsSize)
+	if err != nil {
+		return err
+	}
+
+	cms := s.kc.CoreV1().ConfigMaps(s.namespace)
+	cm, err := cms.Get(ctx, configMapName(bucket), metav1.GetOptions{})
+	if apierrs.IsNotFound(err) {
+		_, err = cms.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      configMapName(bucket),
+				Namespace: s.namespace,
+			},
+			BinaryData: map[string][]byte{snapshotsKey: data},
+		}, metav1.CreateOptions{})
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	cm = cm.DeepCopy()
+	cm.BinaryData = map[string][]byte{snapshotsKey: data}
+	_, err = cms.Update(ctx, cm, metav1.UpdateOptions{})
+	return err
+}
+
+// encode serializes the snapshots into gzipped JSON, keyed by the
+// namespace/name of the revisions. The windows are mostly runs of
+// similar numbers, which compress well.
+func encode(snaps Snapshots) ([]byte, error) {
+	byKey := make(map[string]*Snapshot, len(snaps))
+	for key, snap := range snaps {
+		byKey[key.String()] = snap
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(zw).Encode(byKey); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// encodeWithin encodes the snapshots, dropping the oldest half of their
+// windows until they fit within size bytes.
+func encodeWithin(snaps Snapshots, size int) ([]byte, error) {
+	for {
+		data, err := encode(snaps)
+		if err != nil || len(data) <= size {
+			return data, err
+		}
+		var trimmed bool
+		if snaps, trimmed = trimOldest(snaps); !trimmed {
+			return nil, fmt.Errorf("snapshots of %d revisions take %d bytes, more than %d", len(snaps), len(data), size)
+		}
+	}
+}
+
+// trimOldest returns a copy of the snapshots without the oldest half of each
+// of their windows, and whether anything was dropped.
+func trimOldest(snaps Snapshots) (Snapshots, bool) {
+	var trimmed bool
+	buckets := func(s aggregation.BucketsSnapshot) aggregation.BucketsSnapshot {
+		// The buckets are oldest first, and LastWrite is the time of the last.
+		if n := len(s.Buckets); n > 0 {
+			s.Buckets = s.Buckets[n/2+n%2:]
+			trimmed = true
+		}
+		return s
+	}
+	latency := func(s metrics.LatencyWindowSnapshot) metrics.LatencyWindowSnapshot {
+		if n := len(s.Buckets); n > 0 {
+			s.Buckets = s.Buckets[n/2+n%2:]
+			trimmed = true
+		}
+		return s
+	}
+
+	ret := make(Snapshots, len(snaps))
+	for key, snap := range snaps {
+		snap := *snap
+		if snap.Collection != nil {
+			c := *snap.Collection
+			c.Concurrency, c.ConcurrencyPanic = buckets(c.Concurrency), buckets(c.ConcurrencyPanic)
+			c.RPS, c.RPSPanic = buckets(c.RPS), buckets(c.RPSPanic)
+			c.Custom, c.CustomPanic = buckets(c.Custom), buckets(c.CustomPanic)
+			c.QueueDepth, c.QueueDepthPanic = buckets(c.QueueDepth), buckets(c.QueueDepthPanic)
+			c.ServerErrors, c.ServerErrorsPanic = buckets(c.ServerErrors), buckets(c.ServerErrorsPanic)
+			c.Latency, c.LatencyPanic = latency(c.Latency), latency(c.LatencyPanic)
+			snap.Collection = &c
+		}
+		if snap.Scaler != nil && len(snap.Scaler.History) > 0 {
+			s := *snap.Scaler
+			n := len(s.History)
+			s.History = s.History[n/2+n%2:]
+			snap.Scaler = &s
+			trimmed = true
+		}
+		ret[key] = &snap
+	}
+	return ret, trimmed
+}
+
+func decode(data []byte) (Snapshots, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress snapshots: %w", err)
+	}
+	raw, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress snapshots: %w", err)
+	}
+	var byKey map[string]*Snapshot
+	if err := json.Unmarshal(raw, &byKey); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshots: %w", err)
+	}
+
+	ret := make(Snapshots, len(byKey))
+	for k, snap := range byKey {
+		ns, name, ok := strings.Cut(k, "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid snapshot key %q", k)
+		}
+		ret[types.NamespacedName{Namespace: ns, Name: name}] = snap
+	}
+	return ret, nil
+}
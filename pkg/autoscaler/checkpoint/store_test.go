@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checkpoint
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclient "k8s.io/client-go/kubernetes/fake"
+	"knative.dev/serving/pkg/autoscaler/aggregation"
+	"knative.dev/serving/pkg/autoscaler/aggregation/max"
+	"knative.dev/serving/pkg/autoscaler/metrics"
+	"knative.dev/serving/pkg/autoscaler/scaling"
+)
+
+func TestConfigMapStore(t *testing.T) {
+	ctx := context.Background()
+	kc := fakeclient.NewSimpleClientset()
+	store := NewConfigMapStore(kc, "knative-serving")
+
+	got, err := store.Load(ctx, "bucket")
+	if err != nil {
+		t.Fatal("Load() =", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("Load() = %v, want no snapshots", got)
+	}
+
+	now := time.Unix(1000, 0)
+	want := Snapshots{
+		{Namespace: "ns", Name: "rev-1"}: {
+			Collection: &metrics.CollectionSnapshot{
+				Concurrency: aggregation.BucketsSnapshot{
+					LastWrite:   now,
+					Granularity: time.Second,
+					Buckets:     []float64{1, 2, 3},
+				},
+			},
+			Scaler: &scaling.ScalerSnapshot{
+				DelayWindow: []max.Sample{{Time: now, Value: 3}},
+				History:     []scaling.HistorySample{{Time: now, Pods: 5}},
+			},
+		},
+		{Namespace: "ns", Name: "rev-2"}: {
+			Collection: &metrics.CollectionSnapshot{},
+		},
+	}
+	// The first save creates the ConfigMap, the second updates it.
+	for range 2 {
+		if err := store.Save(ctx, "bucket", want); err != nil {
+			t.Fatal("Save() =", err)
+		}
+		got, err := store.Load(ctx, "bucket")
+		if err != nil {
+			t.Fatal("Load() =", err)
+		}
+		if !cmp.Equal(got, want) {
+			t.Error("Load() mismatch (-want,+got):", cmp.Diff(want, got))
+		}
+		delete(want, types.NamespacedName{Namespace: "ns", Name: "rev-2"})
+	}
+
+	if _, err := kc.CoreV1().ConfigMaps("knative-serving").Get(ctx, "bucket-checkpoint", metav1.GetOptions{}); err != nil {
+		t.Error("Failed to get the ConfigMap:", err)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	if _, err := decode([]byte("not gzip")); err == nil {
+		t.Error("decode() = nil, wanted an error for invalid data")
+	}
+	data, err := encode(Snapshots{})
+	if err != nil {
+		t.Fatal("encode() =", err)
+	}
+	if got, err := decode(data); err != nil || len(got) != 0 {
+		t.Errorf("decode() = %v, %v, want no snapshots", got, err)
+	}
+}
+
+func TestEncodeWithin(t *testing.T) {
+	now := time.Unix(1000, 0)
+	// Random values don't compress, so that the size is predictable.
+	r := rand.New(rand.NewSource(1)) //nolint:gosec // Deterministic test data.
+	window := make([]float64, 1000)
+	for i := range window {
+		window[i] = r.Float64()
+	}
+	key := types.NamespacedName{Namespace: "ns", Name: "rev"}
+	snaps := Snapshots{
+		key: {
+			Collection: &metrics.CollectionSnapshot{
+				Concurrency: aggregation.BucketsSnapshot{
+					LastWrite:   now,
+					Granularity: time.Second,
+					Buckets:     window,
+				},
+			},
+		},
+	}
+	full, err := encode(snaps)
+	if err != nil {
+		t.Fatal("encode() =", err)
+	}
+
+	data, err := encodeWithin(snaps, len(full)/3)
+	if err != nil {
+		t.Fatal("encodeWithin() =", err)
+	}
+	if len(data) > len(full)/3 {
+		t.Errorf("Size = %d, want at most %d", len(data), len(full)/3)
+	}
+	got, err := decode(data)
+	if err != nil {
+		t.Fatal("decode() =", err)
+	}
+	// Only the newest buckets are kept, ending at the last write.
+	buckets := got[key].Collection.Concurrency
+	if n := len(buckets.Buckets); n == 0 || n >= len(window) || !cmp.Equal(buckets.Buckets, window[len(window)-n:]) {
+		t.Errorf("Buckets = %v, want the newest of %v", buckets.Buckets, window)
+	}
+	if !buckets.LastWrite.Equal(now) {
+		t.Errorf("LastWrite = %v, want: %v", buckets.LastWrite, now)
+	}
+	// The snapshots saved are left untouched.
+	if got := len(snaps[key].Collection.Concurrency.Buckets); got != len(window) {
+		t.Errorf("Saved buckets = %d, want: %d", got, len(window))
+	}
+
+	if _, err := encodeWithin(snaps, 1); err == nil {
+		t.Error("encodeWithin() = nil, wanted an error for snapshots that can't fit")
+	}
+}
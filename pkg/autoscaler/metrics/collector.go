@@ -59,7 +59,8 @@ type StatMessage struct {
 	Stat Stat
 }
 
-// Collector starts and stops metric collection for a given entity.
+// Collector starts and stops metric collection for a given entity, and
+// summarizes the requests it served.
 type Collector interface {
 	// CreateOrUpdate either creates a collection for the given metric or update it, should
 	// it already exist.
@@ -71,6 +72,10 @@ type Collector interface {
 	// Watch registers a singleton function to call when a specific collector's status changes.
 	// The passed name is the namespace/name of the metric owned by the respective collector.
 	Watch(func(types.NamespacedName))
+	// StableRequests returns the number of requests and of 5xx responses, and
+	// the sketch of the request latencies, over the stable window of the
+	// given entity as of the given time.
+	StableRequests(key types.NamespacedName, now time.Time) (float64, float64, *LatencySketch, error)
 }
 
 // MetricClient surfaces the metrics that can be obtained via the collector.
@@ -82,6 +87,27 @@ type MetricClient interface {
 	// StableAndPanicRPS returns both the stable and the panic RPS
 	// for the given replica as of the given time.
 	StableAndPanicRPS(key types.NamespacedName, now time.Time) (float64, float64, error)
+
+	// StableAndPanicCustom returns both the stable and the panic value of the
+	// application defined custom metric for the given replica as of the given time.
+	StableAndPanicCustom(key types.NamespacedName, now time.Time) (float64, float64, error)
+
+	// StableAndPanicQueueDepth returns both the stable and the panic number of
+	// requests waiting in the queue-proxies of the given replica as of the given time.
+	StableAndPanicQueueDepth(key types.NamespacedName, now time.Time) (float64, float64, error)
+
+	// StableAndPanicServerErrors returns both the stable and the panic rate of
+	// 5xx responses for the given replica as of the given time.
+	StableAndPanicServerErrors(key types.NamespacedName, now time.Time) (float64, float64, error)
+
+	// StableAndPanicLatency returns both the stable and the panic q-quantile of
+	// the request latencies for the given replica as of the given time.
+	StableAndPanicLatency(key types.NamespacedName, now time.Time, q float64) (time.Duration, time.Duration, error)
+
+	// ConcurrencyLimit returns the total number of requests the queue-proxies
+	// of the given replica last reported to let through concurrently, within
+	// the stable window as of the given time.
+	ConcurrencyLimit(key types.NamespacedName, now time.Time) (float64, error)
 }
 
 // MetricCollector manages collection of metrics for many entities.
@@ -225,6 +251,171 @@ func (c *MetricCollector) StableAndPanicRPS(key types.NamespacedName, now time.T
 		nil
 }
 
+// StableAndPanicCustom returns both the stable and the panic custom metric value.
+// It may truncate metric buckets as a side-effect.
+func (c *MetricCollector) StableAndPanicCustom(key types.NamespacedName, now time.Time) (float64, float64, error) {
+	c.collectionsMutex.RLock()
+	defer c.collectionsMutex.RUnlock()
+
+	collection, exists := c.collections[key]
+	if !exists {
+		return 0, 0, ErrNotCollecting
+	}
+
+	if collection.customBuckets.IsEmpty(now) && collection.currentMetric().Spec.ScrapeTarget != "" {
+		return 0, 0, ErrNoData
+	}
+	return collection.customBuckets.WindowAverage(now),
+		collection.customPanicBuckets.WindowAverage(now),
+		nil
+}
+
+// StableAndPanicQueueDepth returns both the stable and the panic queue depth.
+// It may truncate metric buckets as a side-effect.
+func (c *MetricCollector) StableAndPanicQueueDepth(key types.NamespacedName, now time.Time) (float64, float64, error) {
+	c.collectionsMutex.RLock()
+	defer c.collectionsMutex.RUnlock()
+
+	collection, exists := c.collections[key]
+	if !exists {
+		return 0, 0, ErrNotCollecting
+	}
+
+	if collection.queueDepthBuckets.IsEmpty(now) && collection.currentMetric().Spec.ScrapeTarget != "" {
+		return 0, 0, ErrNoData
+	}
+	return collection.queueDepthBuckets.WindowAverage(now),
+		collection.queueDepthPanicBuckets.WindowAverage(now),
+		nil
+}
+
+// StableAndPanicServerErrors returns both the stable and the panic 5xx rate.
+// It may truncate metric buckets as a side-effect.
+func (c *MetricCollector) StableAndPanicServerErrors(key types.NamespacedName, now time.Time) (float64, float64, error) {
+	c.collectionsMutex.RLock()
+	defer c.collectionsMutex.RUnlock()
+
+	collection, exists := c.collections[key]
+	if !exists {
+		return 0, 0, ErrNotCollecting
+	}
+
+	if collection.serverErrorBuckets.IsEmpty(now) && collection.currentMetric().Spec.ScrapeTarget != "" {
+		return 0, 0, ErrNoData
+	}
+	return collection.serverErrorBuckets.WindowAverage(now),
+		collection.serverErrorPanicBuckets.WindowAverage(now),
+		nil
+}
+
+// StableAndPanicLatency returns both the stable and the panic q-quantile of the
+// request latencies. It returns ErrNoData if no request completed over the
+// stable window, and 0 as the panic value if none completed over the panic window.
+func (c *MetricCollector) StableAndPanicLatency(key types.NamespacedName, now time.Time, q float64) (time.Duration, time.Duration, error) {
+	c.collectionsMutex.RLock()
+	defer c.collectionsMutex.RUnlock()
+
+	collection, exists := c.collections[key]
+	if !exists {
+		return 0, 0, ErrNotCollecting
+	}
+
+	stable := collection.latencyWindow.Sketch(now)
+	if stable.Count() == 0 {
+		return 0, 0, ErrNoData
+	}
+	return stable.Quantile(q), collection.latencyPanicWindow.Sketch(now).Quantile(q), nil
+}
+
+// StableRequests returns the requests served over the stable window, as
+// counts rather than rates. It may truncate metric buckets as a side-effect.
+func (c *MetricCollector) StableRequests(key types.NamespacedName, now time.Time) (float64, float64, *LatencySketch, error) {
+	c.collectionsMutex.RLock()
+	defer c.collectionsMutex.RUnlock()
+
+	collection, exists := c.collections[key]
+	if !exists {
+		return 0, 0, nil, ErrNotCollecting
+	}
+
+	if collection.rpsBuckets.IsEmpty(now) {
+		return 0, 0, nil, ErrNoData
+	}
+	window := collection.currentMetric().Spec.StableWindow.Seconds()
+	return collection.rpsBuckets.WindowAverage(now) * window,
+		collection.serverErrorBuckets.WindowAverage(now) * window,
+		collection.latencyWindow.Sketch(now),
+		nil
+}
+
+// ConcurrencyLimit returns the last reported concurrency limit. It returns
+// ErrNoData if none was reported within the stable window.
+func (c *MetricCollector) ConcurrencyLimit(key types.NamespacedName, now time.Time) (float64, error) {
+	c.collectionsMutex.RLock()
+	defer c.collectionsMutex.RUnlock()
+
+	collection, exists := c.collections[key]
+	if !exists {
+		return 0, ErrNotCollecting
+	}
+	return collection.concurrencyLimit(now)
+}
+
+// CollectionSnapshot is a point in time copy of the metric windows of a
+// collection, which allows another collector to resume with warm windows.
+type CollectionSnapshot struct {
+	Concurrency       aggregation.BucketsSnapshot `json:"concurrency"`
+	ConcurrencyPanic  aggregation.BucketsSnapshot `json:"concurrencyPanic"`
+	RPS               aggregation.BucketsSnapshot `json:"rps"`
+	RPSPanic          aggregation.BucketsSnapshot `json:"rpsPanic"`
+	Custom            aggregation.BucketsSnapshot `json:"custom"`
+	CustomPanic       aggregation.BucketsSnapshot `json:"customPanic"`
+	QueueDepth        aggregation.BucketsSnapshot `json:"queueDepth"`
+	QueueDepthPanic   aggregation.BucketsSnapshot `json:"queueDepthPanic"`
+	ServerErrors      aggregation.BucketsSnapshot `json:"serverErrors"`
+	ServerErrorsPanic aggregation.BucketsSnapshot `json:"serverErrorsPanic"`
+	Latency           LatencyWindowSnapshot       `json:"latency"`
+	LatencyPanic      LatencyWindowSnapshot       `json:"latencyPanic"`
+}
+
+// Keys returns the keys of all the metrics being collected.
+func (c *MetricCollector) Keys() []types.NamespacedName {
+	c.collectionsMutex.RLock()
+	defer c.collectionsMutex.RUnlock()
+
+	ret := make([]types.NamespacedName, 0, len(c.collections))
+	for key := range c.collections {
+		ret = append(ret, key)
+	}
+	return ret
+}
+
+// Snapshot returns a copy of the metric windows of the given collection.
+func (c *MetricCollector) Snapshot(key types.NamespacedName) (*CollectionSnapshot, error) {
+	c.collectionsMutex.RLock()
+	defer c.collectionsMutex.RUnlock()
+
+	collection, exists := c.collections[key]
+	if !exists {
+		return nil, ErrNotCollecting
+	}
+	return collection.snapshot(), nil
+}
+
+// Restore records the data of the snapshot into the metric windows of the
+// given collection.
+func (c *MetricCollector) Restore(key types.NamespacedName, snap *CollectionSnapshot) error {
+	c.collectionsMutex.RLock()
+	defer c.collectionsMutex.RUnlock()
+
+	collection, exists := c.collections[key]
+	if !exists {
+		return ErrNotCollecting
+	}
+	collection.restore(snap)
+	return nil
+}
+
 type (
 	// windowAverager is the client side abstraction for various bucket types.
 	windowAverager interface {
@@ -232,6 +423,8 @@ type (
 		ResizeWindow(time.Duration)
 		WindowAverage(time.Time) float64
 		IsEmpty(time.Time) bool
+		Snapshot() aggregation.BucketsSnapshot
+		Restore(aggregation.BucketsSnapshot)
 	}
 
 	// collection represents the collection of metrics for one specific entity.
@@ -246,6 +439,18 @@ type (
 		concurrencyPanicBuckets windowAverager
 		rpsBuckets              windowAverager
 		rpsPanicBuckets         windowAverager
+		customBuckets           windowAverager
+		customPanicBuckets      windowAverager
+		queueDepthBuckets       windowAverager
+		queueDepthPanicBuckets  windowAverager
+		serverErrorBuckets      windowAverager
+		serverErrorPanicBuckets windowAverager
+		latencyWindow           *latencyWindow
+		latencyPanicWindow      *latencyWindow
+
+		// limit is the last reported concurrency limit, at limitTime.
+		limit     float64
+		limitTime time.Time
 
 		// Fields relevant for metric scraping specifically.
 		scraper StatsScraper
@@ -294,6 +499,22 @@ func newCollection(metric *autoscalingv1alpha1.Metric, scraper StatsScraper, clo
 			metric.Spec.StableWindow, config.BucketSize),
 		rpsPanicBuckets: bucketCtor(
 			metric.Spec.PanicWindow, config.BucketSize),
+		customBuckets: bucketCtor(
+			metric.Spec.StableWindow, config.BucketSize),
+		customPanicBuckets: bucketCtor(
+			metric.Spec.PanicWindow, config.BucketSize),
+		queueDepthBuckets: bucketCtor(
+			metric.Spec.StableWindow, config.BucketSize),
+		queueDepthPanicBuckets: bucketCtor(
+			metric.Spec.PanicWindow, config.BucketSize),
+		serverErrorBuckets: bucketCtor(
+			metric.Spec.StableWindow, config.BucketSize),
+		serverErrorPanicBuckets: bucketCtor(
+			metric.Spec.PanicWindow, config.BucketSize),
+		latencyWindow: newLatencyWindow(
+			metric.Spec.StableWindow, config.BucketSize),
+		latencyPanicWindow: newLatencyWindow(
+			metric.Spec.PanicWindow, config.BucketSize),
 		scraper: scraper,
 
 		stopCh: make(chan struct{}),
@@ -322,7 +543,17 @@ func newCollection(metric *autoscalingv1alpha1.Metric, scraper StatsScraper, clo
 					continue
 				}
 
-				stat, err := scraper.Scrape(c.currentMetric().Spec.StableWindow)
+				var (
+					stat   Stat
+					custom float64
+					err    error
+				)
+				window := c.currentMetric().Spec.StableWindow
+				if cs, ok := scraper.(customMetricScraper); ok {
+					stat, custom, err = cs.scrapeWithCustomMetric(window)
+				} else {
+					stat, err = scraper.Scrape(window)
+				}
 				if err != nil {
 					logger.Errorw("Failed to scrape metrics", zap.Error(err))
 				}
@@ -330,7 +561,9 @@ func newCollection(metric *autoscalingv1alpha1.Metric, scraper StatsScraper, clo
 					callback(key)
 				}
 				if stat != emptyStat {
-					c.record(clock.Now(), stat)
+					now := clock.Now()
+					c.record(now, stat)
+					c.recordCustom(now, custom)
 				}
 			}
 		}
@@ -355,6 +588,14 @@ func (c *collection) updateMetric(metric *autoscalingv1alpha1.Metric) {
 	c.concurrencyPanicBuckets.ResizeWindow(metric.Spec.PanicWindow)
 	c.rpsBuckets.ResizeWindow(metric.Spec.StableWindow)
 	c.rpsPanicBuckets.ResizeWindow(metric.Spec.PanicWindow)
+	c.customBuckets.ResizeWindow(metric.Spec.StableWindow)
+	c.customPanicBuckets.ResizeWindow(metric.Spec.PanicWindow)
+	c.queueDepthBuckets.ResizeWindow(metric.Spec.StableWindow)
+	c.queueDepthPanicBuckets.ResizeWindow(metric.Spec.PanicWindow)
+	c.serverErrorBuckets.ResizeWindow(metric.Spec.StableWindow)
+	c.serverErrorPanicBuckets.ResizeWindow(metric.Spec.PanicWindow)
+	c.latencyWindow.ResizeWindow(metric.Spec.StableWindow)
+	c.latencyPanicWindow.ResizeWindow(metric.Spec.PanicWindow)
 }
 
 // currentMetric safely returns the current metric stored in the collection.
@@ -395,6 +636,79 @@ func (c *collection) record(now time.Time, stat Stat) {
 	rps := stat.RequestCount - stat.ProxiedRequestCount
 	c.rpsBuckets.Record(now, rps)
 	c.rpsPanicBuckets.Record(now, rps)
+	c.queueDepthBuckets.Record(now, stat.QueueDepth)
+	c.queueDepthPanicBuckets.Record(now, stat.QueueDepth)
+	c.serverErrorBuckets.Record(now, stat.ServerErrorCount)
+	c.serverErrorPanicBuckets.Record(now, stat.ServerErrorCount)
+	if stat.Latency != nil {
+		c.latencyWindow.Record(now, stat.Latency)
+		c.latencyPanicWindow.Record(now, stat.Latency)
+	}
+	if stat.ConcurrencyLimit > 0 {
+		c.recordLimit(now, stat.ConcurrencyLimit)
+	}
+}
+
+// recordCustom adds a scraped value of the custom metric to the current
+// collection.
+func (c *collection) recordCustom(now time.Time, custom float64) {
+	c.customBuckets.Record(now, custom)
+	c.customPanicBuckets.Record(now, custom)
+}
+
+// recordLimit records the concurrency limit, unless a later one is known.
+func (c *collection) recordLimit(now time.Time, limit float64) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if now.Before(c.limitTime) {
+		return
+	}
+	c.limit, c.limitTime = limit, now
+}
+
+// concurrencyLimit returns the last concurrency limit recorded within the
+// stable window.
+func (c *collection) concurrencyLimit(now time.Time) (float64, error) {
+	c.mux.RLock()
+	defer c.mux.RUnlock()
+
+	if c.limitTime.IsZero() || now.Sub(c.limitTime) > c.metric.Spec.StableWindow {
+		return 0, ErrNoData
+	}
+	return c.limit, nil
+}
+
+func (c *collection) snapshot() *CollectionSnapshot {
+	return &CollectionSnapshot{
+		Concurrency:       c.concurrencyBuckets.Snapshot(),
+		ConcurrencyPanic:  c.concurrencyPanicBuckets.Snapshot(),
+		RPS:               c.rpsBuckets.Snapshot(),
+		RPSPanic:          c.rpsPanicBuckets.Snapshot(),
+		Custom:            c.customBuckets.Snapshot(),
+		CustomPanic:       c.customPanicBuckets.Snapshot(),
+		QueueDepth:        c.queueDepthBuckets.Snapshot(),
+		QueueDepthPanic:   c.queueDepthPanicBuckets.Snapshot(),
+		ServerErrors:      c.serverErrorBuckets.Snapshot(),
+		ServerErrorsPanic: c.serverErrorPanicBuckets.Snapshot(),
+		Latency:           c.latencyWindow.Snapshot(),
+		LatencyPanic:      c.latencyPanicWindow.Snapshot(),
+	}
+}
+
+func (c *collection) restore(snap *CollectionSnapshot) {
+	c.concurrencyBuckets.Restore(snap.Concurrency)
+	c.concurrencyPanicBuckets.Restore(snap.ConcurrencyPanic)
+	c.rpsBuckets.Restore(snap.RPS)
+	c.rpsPanicBuckets.Restore(snap.RPSPanic)
+	c.customBuckets.Restore(snap.Custom)
+	c.customPanicBuckets.Restore(snap.CustomPanic)
+	c.queueDepthBuckets.Restore(snap.QueueDepth)
+	c.queueDepthPanicBuckets.Restore(snap.QueueDepthPanic)
+	c.serverErrorBuckets.Restore(snap.ServerErrors)
+	c.serverErrorPanicBuckets.Restore(snap.ServerErrorsPanic)
+	c.latencyWindow.Restore(snap.Latency)
+	c.latencyPanicWindow.Restore(snap.LatencyPanic)
 }
 
 // add adds the stats from `src` to `dst`.
@@ -403,6 +717,16 @@ func (dst *Stat) add(src Stat) {
 	dst.AverageProxiedConcurrentRequests += src.AverageProxiedConcurrentRequests
 	dst.RequestCount += src.RequestCount
 	dst.ProxiedRequestCount += src.ProxiedRequestCount
+	dst.QueueDepth += src.QueueDepth
+	dst.ServerErrorCount += src.ServerErrorCount
+	dst.ConcurrencyLimit += src.ConcurrencyLimit
+	if src.Latency != nil {
+		// Never share the sketch with src, it's merged into below.
+		if dst.Latency == nil {
+			dst.Latency = &LatencySketch{}
+		}
+		dst.Latency.Merge(src.Latency)
+	}
 }
 
 // average reduces the aggregate stat from `sample` pods to an averaged one over
@@ -421,4 +745,9 @@ func (dst *Stat) average(sample, total float64) {
 	dst.AverageProxiedConcurrentRequests = dst.AverageProxiedConcurrentRequests / sample * total
 	dst.RequestCount = dst.RequestCount / sample * total
 	dst.ProxiedRequestCount = dst.ProxiedRequestCount / sample * total
+	dst.QueueDepth = dst.QueueDepth / sample * total
+	dst.ServerErrorCount = dst.ServerErrorCount / sample * total
+	dst.ConcurrencyLimit = dst.ConcurrencyLimit / sample * total
+	// The latency distribution of the sampled pods stands for the one of all
+	// the pods, so the sketch is left as is.
 }
@@ -493,6 +493,217 @@ func TestMetricCollectorRecord(t *testing.T) {
 	}
 }
 
+func TestMetricCollectorRecordResponseStats(t *testing.T) {
+	logger := TestLogger(t)
+
+	now := time.Now()
+	metricKey := types.NamespacedName{Namespace: defaultNamespace, Name: defaultName}
+	scraper := &testScraper{
+		s: func() (Stat, error) {
+			return emptyStat, nil
+		},
+	}
+	coll := NewMetricCollector(scraperFactory(scraper, nil), logger)
+	coll.CreateOrUpdate(&defaultMetric)
+
+	if _, _, err := coll.StableAndPanicLatency(metricKey, now, 0.5); !errors.Is(err, ErrNoData) {
+		t.Error("StableAndPanicLatency() =", err)
+	}
+
+	fast := &LatencySketch{}
+	for range 10 {
+		fast.Record(time.Millisecond)
+	}
+	coll.Record(metricKey, now, Stat{
+		PodName:          "testPod",
+		QueueDepth:       2,
+		ServerErrorCount: 1,
+		Latency:          fast,
+	})
+
+	if stable, panic, err := coll.StableAndPanicQueueDepth(metricKey, now); err != nil || stable != 2 || panic != 2 {
+		t.Errorf("StableAndPanicQueueDepth() = %v, %v, %v; want 2, 2, nil", stable, panic, err)
+	}
+	if stable, panic, err := coll.StableAndPanicServerErrors(metricKey, now); err != nil || stable != 1 || panic != 1 {
+		t.Errorf("StableAndPanicServerErrors() = %v, %v, %v; want 1, 1, nil", stable, panic, err)
+	}
+
+	// Slow requests that only fall into the stable window.
+	slow := &LatencySketch{}
+	for range 10 {
+		slow.Record(time.Second)
+	}
+	coll.Record(metricKey, now.Add(-30*time.Second), Stat{PodName: "testPod", Latency: slow})
+
+	stable, panic, err := coll.StableAndPanicLatency(metricKey, now, 0.9)
+	if err != nil {
+		t.Fatal("StableAndPanicLatency:", err)
+	}
+	// 1s falls into the bucket bounded by 1.024s.
+	if wantS, wantP := latencySketchBound(40), time.Millisecond; stable != wantS || panic != wantP {
+		t.Errorf("StableAndPanicLatency() = %v, %v; want %v, %v", stable, panic, wantS, wantP)
+	}
+}
+
+func TestMetricCollectorStableRequests(t *testing.T) {
+	logger := TestLogger(t)
+
+	now := time.Now()
+	metricKey := types.NamespacedName{Namespace: defaultNamespace, Name: defaultName}
+	scraper := &testScraper{
+		s: func() (Stat, error) {
+			return emptyStat, nil
+		},
+	}
+	coll := NewMetricCollector(scraperFactory(scraper, nil), logger)
+
+	if _, _, _, err := coll.StableRequests(metricKey, now); !errors.Is(err, ErrNotCollecting) {
+		t.Error("StableRequests() =", err)
+	}
+	coll.CreateOrUpdate(&defaultMetric)
+	if _, _, _, err := coll.StableRequests(metricKey, now); !errors.Is(err, ErrNoData) {
+		t.Error("StableRequests() =", err)
+	}
+
+	latency := &LatencySketch{}
+	for range 10 {
+		latency.Record(time.Millisecond)
+	}
+	coll.Record(metricKey, now, Stat{
+		PodName:          "testPod",
+		RequestCount:     10,
+		ServerErrorCount: 1,
+		Latency:          latency,
+	})
+
+	// The rates are counted over the 60s of the stable window.
+	requests, serverErrors, sketch, err := coll.StableRequests(metricKey, now)
+	if err != nil {
+		t.Fatal("StableRequests:", err)
+	}
+	if requests != 600 || serverErrors != 60 || sketch.Count() != 10 {
+		t.Errorf("StableRequests() = %v, %v, %d latencies; want 600, 60, 10 latencies", requests, serverErrors, sketch.Count())
+	}
+}
+
+func TestMetricCollectorConcurrencyLimit(t *testing.T) {
+	logger := TestLogger(t)
+
+	now := time.Now()
+	metricKey := types.NamespacedName{Namespace: defaultNamespace, Name: defaultName}
+	scraper := &testScraper{
+		s: func() (Stat, error) {
+			return emptyStat, nil
+		},
+	}
+	coll := NewMetricCollector(scraperFactory(scraper, nil), logger)
+
+	if _, err := coll.ConcurrencyLimit(metricKey, now); !errors.Is(err, ErrNotCollecting) {
+		t.Errorf("ConcurrencyLimit() = %v, want: %v", err, ErrNotCollecting)
+	}
+	coll.CreateOrUpdate(&defaultMetric)
+	if _, err := coll.ConcurrencyLimit(metricKey, now); !errors.Is(err, ErrNoData) {
+		t.Errorf("ConcurrencyLimit() = %v, want: %v", err, ErrNoData)
+	}
+
+	coll.Record(metricKey, now, Stat{PodName: "testPod", ConcurrencyLimit: 12})
+	// Stale and unlimited reports don't override the latest limit.
+	coll.Record(metricKey, now.Add(-time.Second), Stat{PodName: "testPod", ConcurrencyLimit: 3})
+	coll.Record(metricKey, now.Add(time.Second), Stat{PodName: "testPod"})
+
+	if got, err := coll.ConcurrencyLimit(metricKey, now.Add(time.Second)); err != nil || got != 12 {
+		t.Errorf("ConcurrencyLimit() = %v, %v; want 12, nil", got, err)
+	}
+	if _, err := coll.ConcurrencyLimit(metricKey, now.Add(defaultMetric.Spec.StableWindow+time.Second)); !errors.Is(err, ErrNoData) {
+		t.Errorf("ConcurrencyLimit() after the stable window = %v, want: %v", err, ErrNoData)
+	}
+}
+
+func TestMetricCollectorSnapshotRestore(t *testing.T) {
+	logger := TestLogger(t)
+
+	now := time.Now()
+	metricKey := types.NamespacedName{Namespace: defaultNamespace, Name: defaultName}
+	factory := scraperFactory(&testScraper{
+		s: func() (Stat, error) {
+			return emptyStat, nil
+		},
+	}, nil)
+	newCollector := func() *MetricCollector {
+		coll := NewMetricCollector(factory, logger)
+		coll.clock = fake.Clock{
+			FakeClock: clocktest.NewFakeClock(now),
+			TP:        &fake.ManualTickProvider{Channel: make(chan time.Time)},
+		}
+		return coll
+	}
+
+	src := newCollector()
+	if _, err := src.Snapshot(metricKey); !errors.Is(err, ErrNotCollecting) {
+		t.Errorf("Snapshot() = %v, want: %v", err, ErrNotCollecting)
+	}
+	src.CreateOrUpdate(&defaultMetric)
+	defer src.Delete(defaultNamespace, defaultName)
+	if got, want := src.Keys(), []types.NamespacedName{metricKey}; !cmp.Equal(got, want) {
+		t.Errorf("Keys() = %v, want: %v", got, want)
+	}
+	for i := range 10 {
+		at := now.Add(time.Duration(i-9) * time.Second)
+		latency := &LatencySketch{}
+		latency.Record(time.Duration(i+1) * 100 * time.Millisecond)
+		src.Record(metricKey, at, Stat{
+			AverageConcurrentRequests: float64(i),
+			RequestCount:              float64(2 * i),
+			Latency:                   latency,
+		})
+		src.collections[metricKey].recordCustom(at, float64(3*i))
+	}
+	snap, err := src.Snapshot(metricKey)
+	if err != nil {
+		t.Fatal("Snapshot() =", err)
+	}
+
+	dst := newCollector()
+	if err := dst.Restore(metricKey, snap); !errors.Is(err, ErrNotCollecting) {
+		t.Errorf("Restore() = %v, want: %v", err, ErrNotCollecting)
+	}
+	dst.CreateOrUpdate(&defaultMetric)
+	defer dst.Delete(defaultNamespace, defaultName)
+	if err := dst.Restore(metricKey, snap); err != nil {
+		t.Fatal("Restore() =", err)
+	}
+
+	for _, get := range []func(*MetricCollector) (float64, float64, error){
+		func(c *MetricCollector) (float64, float64, error) { return c.StableAndPanicConcurrency(metricKey, now) },
+		func(c *MetricCollector) (float64, float64, error) { return c.StableAndPanicRPS(metricKey, now) },
+		func(c *MetricCollector) (float64, float64, error) { return c.StableAndPanicCustom(metricKey, now) },
+	} {
+		wantS, wantP, err := get(src)
+		if err != nil {
+			t.Fatal("Source metrics:", err)
+		}
+		gotS, gotP, err := get(dst)
+		if err != nil {
+			t.Fatal("Restored metrics:", err)
+		}
+		if gotS != wantS || gotP != wantP {
+			t.Errorf("Restored stable, panic = %v, %v, want: %v, %v", gotS, gotP, wantS, wantP)
+		}
+	}
+
+	wantS, wantP, err := src.StableAndPanicLatency(metricKey, now, 0.9)
+	if err != nil {
+		t.Fatal("Source latency:", err)
+	}
+	gotS, gotP, err := dst.StableAndPanicLatency(metricKey, now, 0.9)
+	if err != nil {
+		t.Fatal("Restored latency:", err)
+	}
+	if gotS != wantS || gotP != wantP {
+		t.Errorf("Restored stable, panic latency = %v, %v, want: %v, %v", gotS, gotP, wantS, wantP)
+	}
+}
+
 func TestDoubleWatch(t *testing.T) {
 	defer func() {
 		if x := recover(); x == nil {
@@ -618,6 +829,14 @@ func TestMetricCollectorAggregate(t *testing.T) {
 		concurrencyPanicBuckets: aggregation.NewTimedFloat64Buckets(m.Spec.PanicWindow, config.BucketSize),
 		rpsBuckets:              aggregation.NewTimedFloat64Buckets(m.Spec.StableWindow, config.BucketSize),
 		rpsPanicBuckets:         aggregation.NewTimedFloat64Buckets(m.Spec.PanicWindow, config.BucketSize),
+		customBuckets:           aggregation.NewTimedFloat64Buckets(m.Spec.StableWindow, config.BucketSize),
+		customPanicBuckets:      aggregation.NewTimedFloat64Buckets(m.Spec.PanicWindow, config.BucketSize),
+		queueDepthBuckets:       aggregation.NewTimedFloat64Buckets(m.Spec.StableWindow, config.BucketSize),
+		queueDepthPanicBuckets:  aggregation.NewTimedFloat64Buckets(m.Spec.PanicWindow, config.BucketSize),
+		serverErrorBuckets:      aggregation.NewTimedFloat64Buckets(m.Spec.StableWindow, config.BucketSize),
+		serverErrorPanicBuckets: aggregation.NewTimedFloat64Buckets(m.Spec.PanicWindow, config.BucketSize),
+		latencyWindow:           newLatencyWindow(m.Spec.StableWindow, config.BucketSize),
+		latencyPanicWindow:      newLatencyWindow(m.Spec.PanicWindow, config.BucketSize),
 	}
 	now := time.Now()
 	for i := range 10 {
@@ -627,6 +846,7 @@ func TestMetricCollectorAggregate(t *testing.T) {
 			RequestCount:              float64(i + 5),
 		}
 		c.record(now.Add(time.Duration(i)*time.Second), stat)
+		c.recordCustom(now.Add(time.Duration(i)*time.Second), float64(i+5))
 	}
 
 	now = now.Add(9 * time.Second)
@@ -639,4 +859,40 @@ func TestMetricCollectorAggregate(t *testing.T) {
 	if got, want := c.concurrencyPanicBuckets.WindowAverage(now), 13.5; got != want {
 		t.Errorf("Stable Concurrency = %f, want: %f", got, want)
 	}
+	if got, want := c.customBuckets.WindowAverage(now), 11.5; got != want {
+		t.Errorf("Stable Custom = %f, want: %f", got, want)
+	}
+	if got, want := c.customPanicBuckets.WindowAverage(now), 13.5; got != want {
+		t.Errorf("Panic Custom = %f, want: %f", got, want)
+	}
+}
+
+func TestStatAddAverage(t *testing.T) {
+	src := Stat{
+		RequestCount:     2,
+		QueueDepth:       1,
+		ServerErrorCount: 1,
+		ConcurrencyLimit: 5,
+		Latency:          &LatencySketch{Counts: []uint64{1, 2}},
+	}
+	var sum Stat
+	sum.add(src)
+	sum.add(src)
+	sum.add(Stat{RequestCount: 2})
+	sum.average(3, 6)
+
+	want := Stat{
+		RequestCount:     12,
+		QueueDepth:       4,
+		ServerErrorCount: 4,
+		ConcurrencyLimit: 20,
+		Latency:          &LatencySketch{Counts: []uint64{2, 4}},
+	}
+	if !cmp.Equal(sum, want) {
+		t.Error("Aggregated stat mismatch: diff(-want,+got):", cmp.Diff(want, sum))
+	}
+	// The sketch of the source must not be modified by the aggregation.
+	if got := src.Latency.Counts; got[0] != 1 || got[1] != 2 {
+		t.Errorf("Source sketch = %v, want: [1 2]", got)
+	}
 }
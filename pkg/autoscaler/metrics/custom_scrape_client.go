@@ -0,0 +1,96 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+)
+
+// maxCustomMetricsBodySize bounds how much of an application's metrics page
+// is read when looking for the custom metric.
+const maxCustomMetricsBodySize = 4 << 20
+
+// customMetricsAccept asks for the OpenMetrics text format, falling back to
+// the Prometheus text format.
+const customMetricsAccept = "application/openmetrics-text;version=1.0.0,text/plain;version=0.0.4;q=0.5"
+
+var errCustomMetricNotFound = errors.New("custom metric not found")
+
+// customMetricClient defines the interface for reading an application defined
+// gauge from a Prometheus/OpenMetrics text endpoint. Internal use only.
+type customMetricClient interface {
+	// Gauge executes the given request and returns the value of the named
+	// metric, summed across all of its label sets.
+	Gauge(req *http.Request, name string) (float64, error)
+}
+
+type openMetricsScrapeClient struct {
+	httpClient *http.Client
+}
+
+func newOpenMetricsScrapeClient(httpClient *http.Client) *openMetricsScrapeClient {
+	return &openMetricsScrapeClient{
+		httpClient: httpClient,
+	}
+}
+
+func (c *openMetricsScrapeClient) Gauge(req *http.Request, name string) (float64, error) {
+	req.Header.Add("Accept", customMetricsAccept)
+	resp, err := c.httpClient.Do(req) //nolint:gosec // G704: URL is trusted
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return 0, fmt.Errorf("GET request for URL %q returned HTTP status %v", req.URL.String(), resp.StatusCode)
+	}
+	return gaugeFromText(resp.Body, name)
+}
+
+// gaugeFromText parses a Prometheus or OpenMetrics text exposition and
+// returns the sum of all samples of the named gauge.
+func gaugeFromText(body io.Reader, name string) (float64, error) {
+	parser := expfmt.NewTextParser(model.UTF8Validation)
+	families, err := parser.TextToMetricFamilies(io.LimitReader(body, maxCustomMetricsBodySize))
+	if err != nil {
+		return 0, fmt.Errorf("parsing metrics failed: %w", err)
+	}
+	mf, ok := families[name]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", errCustomMetricNotFound, name)
+	}
+
+	var sum float64
+	for _, m := range mf.GetMetric() {
+		switch mf.GetType() {
+		case dto.MetricType_GAUGE:
+			sum += m.GetGauge().GetValue()
+		case dto.MetricType_UNTYPED:
+			sum += m.GetUntyped().GetValue()
+		default:
+			return 0, fmt.Errorf("%w: %s is a %s", errUnsupportedMetricType, name, mf.GetType())
+		}
+	}
+	return sum, nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testExposition = `# HELP work_queue_length Items waiting to be processed.
+# TYPE work_queue_length gauge
+work_queue_length{queue="a"} 3
+work_queue_length{queue="b"} 4.5
+# TYPE jobs_total counter
+jobs_total 17
+# TYPE batch_backlog untyped
+batch_backlog 2
+# EOF
+`
+
+func TestGaugeFromText(t *testing.T) {
+	tests := []struct {
+		name    string
+		metric  string
+		want    float64
+		wantErr error
+	}{{
+		name:   "gauge summed across label sets",
+		metric: "work_queue_length",
+		want:   7.5,
+	}, {
+		name:   "untyped",
+		metric: "batch_backlog",
+		want:   2,
+	}, {
+		name:    "counter",
+		metric:  "jobs_total",
+		wantErr: errUnsupportedMetricType,
+	}, {
+		name:    "missing",
+		metric:  "nope",
+		wantErr: errCustomMetricNotFound,
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := gaugeFromText(strings.NewReader(testExposition), tc.metric)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("gaugeFromText() error = %v, want %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("gaugeFromText() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestOpenMetricsScrapeClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metrics" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if got := r.Header.Get("Accept"); got != customMetricsAccept {
+			t.Errorf("Accept = %q, want %q", got, customMetricsAccept)
+		}
+		w.Write([]byte(testExposition))
+	}))
+	t.Cleanup(srv.Close)
+
+	c := newOpenMetricsScrapeClient(srv.Client())
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/metrics", nil)
+	if got, err := c.Gauge(req, "work_queue_length"); err != nil || got != 7.5 {
+		t.Errorf("Gauge() = %v, %v; want 7.5, nil", got, err)
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, srv.URL+"/other", nil)
+	if _, err := c.Gauge(req, "work_queue_length"); err == nil {
+		t.Error("Gauge() = nil, wanted an error for a 404")
+	}
+}
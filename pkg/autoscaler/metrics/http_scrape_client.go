@@ -82,8 +82,9 @@ func statFromProto(body io.Reader) (Stat, error) {
 	b := pool.Get().(*bytes.Buffer)
 	b.Reset()
 	defer pool.Put(b)
-	// 6 8-byte fields (+2 bytes marshalling), one hostname, 20 bytes extra space
-	r := io.LimitedReader{R: body, N: 6*10 + 256 + 20}
+	// 9 8-byte fields (+2 bytes marshalling), one hostname, one latency sketch
+	// of up to 10-byte varints (+6 bytes marshalling), 20 bytes extra space
+	r := io.LimitedReader{R: body, N: 9*10 + 256 + MaxLatencySketchBuckets*10 + 6 + 20}
 	_, err := b.ReadFrom(&r)
 	if err != nil {
 		return emptyStat, fmt.Errorf("reading body failed: %w", err)
@@ -92,5 +93,8 @@ func statFromProto(body io.Reader) (Stat, error) {
 	if err != nil {
 		return emptyStat, fmt.Errorf("unmarshalling failed: %w", err)
 	}
+	if !stat.Latency.Valid() {
+		stat.Latency = nil
+	}
 	return stat, nil
 }
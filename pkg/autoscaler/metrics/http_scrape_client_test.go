@@ -68,6 +68,53 @@ func TestHTTPScrapeClientScrapeHappyCaseWithOptionals(t *testing.T) {
 	}
 }
 
+func TestHTTPScrapeClientScrapeResponseStats(t *testing.T) {
+	full := stat
+	full.QueueDepth = 4
+	full.ServerErrorCount = 1.5
+	full.ConcurrencyLimit = 42
+	full.Latency = &LatencySketch{Counts: make([]uint64, MaxLatencySketchBuckets)}
+	for i := range full.Latency.Counts {
+		// Make every bucket take the most space on the wire.
+		full.Latency.Counts[i] = 1<<64 - 1
+	}
+	invalid := full
+	invalid.Latency = &LatencySketch{Counts: make([]uint64, MaxLatencySketchBuckets+1)}
+	dropped := full
+	dropped.Latency = nil
+
+	for _, tc := range []struct {
+		name string
+		stat Stat
+		want Stat
+	}{{
+		name: "full stat",
+		stat: full,
+		want: full,
+	}, {
+		name: "unknown latency buckets",
+		stat: invalid,
+		want: dropped,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			//nolint:bodyclose
+			hClient := newTestHTTPClient(makeProtoResponse(http.StatusOK, tc.stat, netheader.ProtobufMIMEType), nil)
+			sClient := newHTTPScrapeClient(hClient)
+			req, err := http.NewRequest(http.MethodGet, testURL, nil)
+			if err != nil {
+				t.Fatalf("Failed to create a request: %v", err)
+			}
+			got, err := sClient.Do(req)
+			if err != nil {
+				t.Fatalf("Scrape = %v, want no error", err)
+			}
+			if !cmp.Equal(got, tc.want) {
+				t.Errorf("Scraped stat mismatch; diff(-want,+got):\n%s", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
+
 func TestHTTPScrapeClientScrapeProtoErrorCases(t *testing.T) {
 	testCases := []struct {
 		name            string
@@ -101,8 +148,8 @@ func TestHTTPScrapeClientScrapeProtoErrorCases(t *testing.T) {
 		responseCode: http.StatusOK,
 		responseType: "application/protobuf",
 		stat: Stat{
-			// We don't expect PodName to be 600 characters long
-			PodName:                          strings.Repeat("a123456789", 60),
+			// We don't expect PodName to be 1600 characters long
+			PodName:                          strings.Repeat("a123456789", 160),
 			AverageConcurrentRequests:        1.1,
 			AverageProxiedConcurrentRequests: 1.1,
 			RequestCount:                     33.2,
@@ -0,0 +1,234 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// latencySketchBase is the upper bound of the first bucket of a
+	// LatencySketch. Faster requests are all counted in that bucket.
+	latencySketchBase = time.Millisecond
+
+	// latencySketchBucketsPerDoubling is the number of buckets per power of
+	// two, i.e. the bounds of the buckets grow by 2^(1/4), about 19%.
+	latencySketchBucketsPerDoubling = 4
+
+	// MaxLatencySketchBuckets is the number of buckets a LatencySketch can
+	// have. The last bucket, with a bound of about 3.9h, also counts all the
+	// slower requests.
+	MaxLatencySketchBuckets = 96
+)
+
+// Record counts a request that took the given time.
+func (m *LatencySketch) Record(d time.Duration) {
+	i := latencySketchIndex(d)
+	if i >= len(m.Counts) {
+		m.Counts = append(m.Counts, make([]uint64, i+1-len(m.Counts))...)
+	}
+	m.Counts[i]++
+}
+
+// Merge adds the counts of the given sketch to this one.
+func (m *LatencySketch) Merge(o *LatencySketch) {
+	if o == nil {
+		return
+	}
+	if len(o.Counts) > len(m.Counts) {
+		m.Counts = append(m.Counts, make([]uint64, len(o.Counts)-len(m.Counts))...)
+	}
+	for i, c := range o.Counts {
+		m.Counts[i] += c
+	}
+}
+
+// Count returns the number of requests counted in the sketch.
+func (m *LatencySketch) Count() uint64 {
+	var total uint64
+	for _, c := range m.GetCounts() {
+		total += c
+	}
+	return total
+}
+
+// Quantile returns the upper bound of the bucket the q-quantile of the
+// latencies falls into, or 0 if the sketch is empty.
+func (m *LatencySketch) Quantile(q float64) time.Duration {
+	total := m.Count()
+	if total == 0 {
+		return 0
+	}
+	rank := uint64(math.Ceil(q * float64(total)))
+	if rank < 1 {
+		rank = 1
+	}
+	var seen uint64
+	for i, c := range m.Counts {
+		seen += c
+		if seen >= rank {
+			return latencySketchBound(i)
+		}
+	}
+	return latencySketchBound(len(m.Counts) - 1)
+}
+
+// Valid returns whether the sketch has been produced with the bucketing
+// scheme of this package.
+func (m *LatencySketch) Valid() bool {
+	return len(m.GetCounts()) <= MaxLatencySketchBuckets
+}
+
+// latencySketchIndex returns the index of the bucket a latency falls into.
+func latencySketchIndex(d time.Duration) int {
+	if d <= latencySketchBase {
+		return 0
+	}
+	i := int(math.Ceil(latencySketchBucketsPerDoubling * math.Log2(float64(d)/float64(latencySketchBase))))
+	if i >= MaxLatencySketchBuckets {
+		return MaxLatencySketchBuckets - 1
+	}
+	return i
+}
+
+// latencySketchBound returns the upper bound of the bucket with the given index.
+func latencySketchBound(i int) time.Duration {
+	return time.Duration(float64(latencySketchBase) * math.Exp2(float64(i)/latencySketchBucketsPerDoubling))
+}
+
+// latencyWindow keeps the latency sketches recorded over a sliding window,
+// merged per bucket of the given granularity.
+type latencyWindow struct {
+	mux sync.Mutex
+
+	window      time.Duration
+	granularity time.Duration
+	// buckets are ordered by time, oldest first.
+	buckets []latencyBucket
+}
+
+type latencyBucket struct {
+	time   time.Time
+	sketch LatencySketch
+}
+
+func newLatencyWindow(window, granularity time.Duration) *latencyWindow {
+	return &latencyWindow{
+		window:      window,
+		granularity: granularity,
+	}
+}
+
+// Record merges the sketch into the bucket of the given time.
+func (w *latencyWindow) Record(now time.Time, s *LatencySketch) {
+	if s.Count() == 0 {
+		return
+	}
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	t := now.Truncate(w.granularity)
+	// Stats may arrive out of order, look for their bucket from the newest.
+	i := len(w.buckets)
+	for i > 0 && w.buckets[i-1].time.After(t) {
+		i--
+	}
+	if i > 0 && w.buckets[i-1].time.Equal(t) {
+		w.buckets[i-1].sketch.Merge(s)
+		return
+	}
+	b := latencyBucket{time: t}
+	b.sketch.Merge(s)
+	w.buckets = append(w.buckets, latencyBucket{})
+	copy(w.buckets[i+1:], w.buckets[i:])
+	w.buckets[i] = b
+	w.truncate(w.buckets[len(w.buckets)-1].time)
+}
+
+// Sketch returns the merge of all the sketches recorded within the window.
+func (w *latencyWindow) Sketch(now time.Time) *LatencySketch {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	w.truncate(now)
+	ret := &LatencySketch{}
+	for i := range w.buckets {
+		ret.Merge(&w.buckets[i].sketch)
+	}
+	return ret
+}
+
+// ResizeWindow changes the duration of the window.
+func (w *latencyWindow) ResizeWindow(window time.Duration) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	w.window = window
+}
+
+// LatencyWindowSnapshot is a point in time copy of the sketches held by a
+// latency window.
+type LatencyWindowSnapshot struct {
+	// Buckets holds the buckets of the window, oldest first.
+	Buckets []LatencyBucketSnapshot `json:"buckets,omitempty"`
+}
+
+// LatencyBucketSnapshot is the sketch of a single bucket of a latency window.
+type LatencyBucketSnapshot struct {
+	// Time is the start of the bucket.
+	Time time.Time `json:"time"`
+	// Counts are the counts of the sketch of the bucket.
+	Counts []uint64 `json:"counts,omitempty"`
+}
+
+// Snapshot returns a copy of the buckets of the window.
+func (w *latencyWindow) Snapshot() LatencyWindowSnapshot {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	ret := LatencyWindowSnapshot{}
+	for _, b := range w.buckets {
+		ret.Buckets = append(ret.Buckets, LatencyBucketSnapshot{
+			Time:   b.time,
+			Counts: append([]uint64(nil), b.sketch.Counts...),
+		})
+	}
+	return ret
+}
+
+// Restore records the buckets of the snapshot into the window. Sketches
+// produced with another bucketing scheme are ignored.
+func (w *latencyWindow) Restore(s LatencyWindowSnapshot) {
+	for _, b := range s.Buckets {
+		sketch := &LatencySketch{Counts: b.Counts}
+		if !sketch.Valid() {
+			continue
+		}
+		w.Record(b.Time, sketch)
+	}
+}
+
+// truncate drops the buckets that fell out of the window.
+func (w *latencyWindow) truncate(now time.Time) {
+	oldest := now.Truncate(w.granularity).Add(-w.window)
+	i := 0
+	for i < len(w.buckets) && !w.buckets[i].time.After(oldest) {
+		i++
+	}
+	w.buckets = w.buckets[i:]
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLatencySketchIndex(t *testing.T) {
+	tests := []struct {
+		latency time.Duration
+		want    int
+	}{
+		{0, 0},
+		{time.Millisecond, 0},
+		{time.Millisecond + 1, 1},
+		{2 * time.Millisecond, 4},
+		{time.Second, 40},
+		{1001 * time.Millisecond, 40},
+		{1200 * time.Millisecond, 41},
+		{24 * time.Hour, MaxLatencySketchBuckets - 1},
+	}
+	for _, tc := range tests {
+		if got := latencySketchIndex(tc.latency); got != tc.want {
+			t.Errorf("latencySketchIndex(%v) = %d, want: %d", tc.latency, got, tc.want)
+		}
+		if got := latencySketchBound(latencySketchIndex(tc.latency)); tc.latency < 3*time.Hour && (got < tc.latency || float64(got) > 1.2*float64(tc.latency)+float64(time.Millisecond)) {
+			t.Errorf("latencySketchBound(latencySketchIndex(%v)) = %v, want within 20%%", tc.latency, got)
+		}
+	}
+}
+
+func TestLatencySketchQuantile(t *testing.T) {
+	s := &LatencySketch{}
+	if got := s.Quantile(0.5); got != 0 {
+		t.Errorf("Quantile(0.5) of empty sketch = %v, want: 0", got)
+	}
+
+	for range 90 {
+		s.Record(time.Millisecond)
+	}
+	for range 10 {
+		s.Record(2 * time.Millisecond)
+	}
+	if got, want := s.Count(), uint64(100); got != want {
+		t.Errorf("Count() = %d, want: %d", got, want)
+	}
+	if got, want := len(s.Counts), 5; got != want {
+		t.Errorf("len(Counts) = %d, want: %d", got, want)
+	}
+	for _, tc := range []struct {
+		q    float64
+		want time.Duration
+	}{
+		{0, time.Millisecond},
+		{0.5, time.Millisecond},
+		{0.9, time.Millisecond},
+		{0.91, 2 * time.Millisecond},
+		{1, 2 * time.Millisecond},
+	} {
+		if got := s.Quantile(tc.q); got != tc.want {
+			t.Errorf("Quantile(%v) = %v, want: %v", tc.q, got, tc.want)
+		}
+	}
+}
+
+func TestLatencySketchMerge(t *testing.T) {
+	a := &LatencySketch{Counts: []uint64{1, 2}}
+	a.Merge(&LatencySketch{Counts: []uint64{3, 0, 5}})
+	a.Merge(nil)
+	if got, want := a.Counts, []uint64{4, 2, 5}; len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Errorf("Counts = %v, want: %v", got, want)
+	}
+}
+
+func TestLatencySketchValid(t *testing.T) {
+	var s *LatencySketch
+	if !s.Valid() {
+		t.Error("nil sketch is not valid")
+	}
+	s = &LatencySketch{Counts: make([]uint64, MaxLatencySketchBuckets)}
+	if !s.Valid() {
+		t.Error("sketch with all the buckets is not valid")
+	}
+	s.Counts = append(s.Counts, 1)
+	if s.Valid() {
+		t.Error("sketch with too many buckets is valid")
+	}
+}
+
+func TestLatencyWindow(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	w := newLatencyWindow(5*time.Second, time.Second)
+
+	record := func(at time.Time, d time.Duration) {
+		s := &LatencySketch{}
+		s.Record(d)
+		w.Record(at, s)
+	}
+	record(now, time.Millisecond)
+	record(now.Add(2*time.Second), time.Second)
+	// Out of order.
+	record(now.Add(time.Second), time.Second)
+	// Too old.
+	record(now.Add(-10*time.Second), time.Hour)
+
+	if got, want := w.Sketch(now.Add(2*time.Second)).Count(), uint64(3); got != want {
+		t.Errorf("Count() = %d, want: %d", got, want)
+	}
+	if got, want := w.Sketch(now.Add(2*time.Second)).Quantile(0.3), time.Millisecond; got != want {
+		t.Errorf("Quantile(0.3) = %v, want: %v", got, want)
+	}
+
+	// The first record falls out of the window.
+	if got, want := w.Sketch(now.Add(5*time.Second)).Quantile(0.3), latencySketchBound(40); got != want {
+		t.Errorf("Quantile(0.3) = %v, want: %v", got, want)
+	}
+
+	w.ResizeWindow(time.Second)
+	if got, want := w.Sketch(now.Add(5*time.Second)).Count(), uint64(0); got != want {
+		t.Errorf("Count() after resize = %d, want: %d", got, want)
+	}
+}
+
+func TestLatencyWindowSnapshotRestore(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	src := newLatencyWindow(5*time.Second, time.Second)
+	for i := range 3 {
+		s := &LatencySketch{}
+		s.Record(time.Duration(i+1) * time.Second)
+		src.Record(now.Add(time.Duration(i)*time.Second), s)
+	}
+
+	snap := src.Snapshot()
+	// Sketches of another bucketing scheme are dropped.
+	snap.Buckets = append(snap.Buckets, LatencyBucketSnapshot{
+		Time:   now.Add(3 * time.Second),
+		Counts: make([]uint64, MaxLatencySketchBuckets+1),
+	})
+	snap.Buckets[len(snap.Buckets)-1].Counts[0] = 1
+
+	dst := newLatencyWindow(5*time.Second, time.Second)
+	dst.Restore(snap)
+	at := now.Add(3 * time.Second)
+	if got, want := dst.Sketch(at).Counts, src.Sketch(at).Counts; !cmp.Equal(got, want) {
+		t.Errorf("Restored counts = %v, want: %v", got, want)
+	}
+}
@@ -57,6 +57,17 @@ type Stat struct {
 	// Time/date that the stat was generated in seconds since
 	// 1970-01-01 00:00:00.000 UTC.
 	Timestamp int64 `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Latencies of the requests completed since last Stat.
+	Latency *LatencySketch `protobuf:"bytes,8,opt,name=latency,proto3" json:"latency,omitempty"`
+	// Number of requests waiting for capacity in the queue-proxy at the time
+	// the stat was generated.
+	QueueDepth float64 `protobuf:"fixed64,9,opt,name=queue_depth,json=queueDepth,proto3" json:"queue_depth,omitempty"`
+	// Number of responses with a 5xx status since last Stat (approximately
+	// responses per second).
+	ServerErrorCount float64 `protobuf:"fixed64,10,opt,name=server_error_count,json=serverErrorCount,proto3" json:"server_error_count,omitempty"`
+	// Number of requests the queue-proxy lets through to the user container
+	// concurrently at the time the stat was generated.
+	ConcurrencyLimit float64 `protobuf:"fixed64,11,opt,name=concurrency_limit,json=concurrencyLimit,proto3" json:"concurrency_limit,omitempty"`
 }
 
 func (m *Stat) Reset()         { *m = Stat{} }
@@ -141,6 +152,34 @@ func (m *Stat) GetTimestamp() int64 {
 	return 0
 }
 
+func (m *Stat) GetLatency() *LatencySketch {
+	if m != nil {
+		return m.Latency
+	}
+	return nil
+}
+
+func (m *Stat) GetQueueDepth() float64 {
+	if m != nil {
+		return m.QueueDepth
+	}
+	return 0
+}
+
+func (m *Stat) GetServerErrorCount() float64 {
+	if m != nil {
+		return m.ServerErrorCount
+	}
+	return 0
+}
+
+func (m *Stat) GetConcurrencyLimit() float64 {
+	if m != nil {
+		return m.ConcurrencyLimit
+	}
+	return 0
+}
+
 // WireStatMessage is a copy of the StatMessage Golang type, exploding the fields of
 // `types.NamespacedName` to make it compatible with protobufs.
 type WireStatMessage struct {
@@ -252,39 +291,97 @@ func (m *WireStatMessages) GetMessages() []*WireStatMessage {
 	return nil
 }
 
+// LatencySketch is a compact histogram of request latencies. Its buckets grow
+// exponentially, so that any quantile is known within a fixed relative error
+// regardless of the magnitude of the latencies. Sketches are merged by adding
+// up their counts bucket by bucket.
+type LatencySketch struct {
+	// Number of requests whose latency fell into each bucket, starting with the
+	// first one. Trailing empty buckets are omitted.
+	Counts []uint64 `protobuf:"varint,1,rep,packed,name=counts,proto3" json:"counts,omitempty"`
+}
+
+func (m *LatencySketch) Reset()         { *m = LatencySketch{} }
+func (m *LatencySketch) String() string { return proto.CompactTextString(m) }
+func (*LatencySketch) ProtoMessage()    {}
+func (*LatencySketch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cf216df9f6fff44c, []int{3}
+}
+func (m *LatencySketch) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LatencySketch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LatencySketch.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LatencySketch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LatencySketch.Merge(m, src)
+}
+func (m *LatencySketch) XXX_Size() int {
+	return m.Size()
+}
+func (m *LatencySketch) XXX_DiscardUnknown() {
+	xxx_messageInfo_LatencySketch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LatencySketch proto.InternalMessageInfo
+
+func (m *LatencySketch) GetCounts() []uint64 {
+	if m != nil {
+		return m.Counts
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Stat)(nil), "metrics.Stat")
 	proto.RegisterType((*WireStatMessage)(nil), "metrics.WireStatMessage")
 	proto.RegisterType((*WireStatMessages)(nil), "metrics.WireStatMessages")
+	proto.RegisterType((*LatencySketch)(nil), "metrics.LatencySketch")
 }
 
 func init() { proto.RegisterFile("pkg/autoscaler/metrics/stat.proto", fileDescriptor_cf216df9f6fff44c) }
 
 var fileDescriptor_cf216df9f6fff44c = []byte{
-	// 359 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0x4f, 0x4b, 0xc3, 0x30,
-	0x18, 0xc6, 0x97, 0xad, 0xee, 0x4f, 0xe6, 0x54, 0x22, 0x42, 0x86, 0x52, 0xba, 0x0d, 0xa1, 0xa7,
-	0x0d, 0xa6, 0x67, 0x0f, 0xee, 0xe2, 0x65, 0x22, 0x15, 0xf1, 0x58, 0x62, 0xf6, 0x3a, 0x8a, 0xb6,
-	0x89, 0x49, 0x2a, 0x7e, 0x0c, 0x3f, 0x96, 0x27, 0xd9, 0xd1, 0xa3, 0x6c, 0x5f, 0x44, 0x1a, 0xb3,
-	0x4d, 0xc7, 0x4e, 0x0d, 0xcf, 0xfb, 0x7b, 0x9e, 0x17, 0x9e, 0xb7, 0xb8, 0x23, 0x9f, 0xa6, 0x03,
-	0x96, 0x1b, 0xa1, 0x39, 0x7b, 0x06, 0x35, 0x48, 0xc1, 0xa8, 0x84, 0xeb, 0x81, 0x36, 0xcc, 0xf4,
-	0xa5, 0x12, 0x46, 0x90, 0x9a, 0xd3, 0xba, 0x9f, 0x65, 0xec, 0xdd, 0x1a, 0x66, 0x48, 0x1b, 0xd7,
-	0xa5, 0x98, 0xc4, 0x19, 0x4b, 0x81, 0xa2, 0x00, 0x85, 0x8d, 0xa8, 0x26, 0xc5, 0xe4, 0x9a, 0xa5,
-	0x40, 0x2e, 0xf0, 0x31, 0x7b, 0x05, 0xc5, 0xa6, 0x10, 0x73, 0x91, 0xf1, 0x5c, 0x29, 0xc8, 0x4c,
-	0xac, 0xe0, 0x25, 0x07, 0x6d, 0x34, 0x2d, 0x07, 0x28, 0x44, 0x51, 0xdb, 0x21, 0xa3, 0x15, 0x11,
-	0x39, 0x80, 0x8c, 0x71, 0x6f, 0xe9, 0x97, 0x4a, 0xbc, 0x25, 0x30, 0xd9, 0x9a, 0x53, 0xb1, 0x39,
-	0x81, 0x43, 0x6f, 0x7e, 0xc9, 0x2d, 0x71, 0x3d, 0xdc, 0x72, 0x9e, 0x98, 0x8b, 0x3c, 0x33, 0xd4,
-	0xb3, 0xc6, 0x5d, 0x27, 0x8e, 0x0a, 0x8d, 0x0c, 0xf1, 0xd1, 0x72, 0xd7, 0x7f, 0x78, 0xc7, 0xc2,
-	0x87, 0x6e, 0x18, 0xfd, 0xf5, 0x9c, 0xe2, 0x3d, 0xa9, 0x04, 0x07, 0xad, 0xe3, 0x5c, 0x9a, 0x24,
-	0x05, 0x5a, 0xb5, 0x70, 0xcb, 0xa9, 0x77, 0x56, 0x24, 0x27, 0xb8, 0x51, 0x7c, 0xb5, 0x61, 0xa9,
-	0xa4, 0xb5, 0x00, 0x85, 0x95, 0x68, 0x2d, 0x74, 0x1f, 0xf1, 0xfe, 0x7d, 0xa2, 0xa0, 0xe8, 0x74,
-	0x0c, 0x5a, 0xb3, 0xa9, 0x35, 0x14, 0xb5, 0x6a, 0xc9, 0xf8, 0xb2, 0xdb, 0xb5, 0x40, 0x08, 0xf6,
-	0x6c, 0xe9, 0x65, 0x3b, 0xb0, 0x6f, 0xd2, 0xc1, 0x5e, 0x71, 0x2c, 0x5b, 0x49, 0x73, 0xd8, 0xea,
-	0xbb, 0x6b, 0xf5, 0x8b, 0xd4, 0xc8, 0x8e, 0xba, 0x57, 0xf8, 0x60, 0x63, 0x8f, 0x26, 0xe7, 0xb8,
-	0x9e, 0xba, 0x37, 0x45, 0x41, 0x25, 0x6c, 0x0e, 0xe9, 0xca, 0xba, 0x01, 0x47, 0x2b, 0xf2, 0x92,
-	0x7e, 0xcc, 0x7d, 0x34, 0x9b, 0xfb, 0xe8, 0x7b, 0xee, 0xa3, 0xf7, 0x85, 0x5f, 0x9a, 0x2d, 0xfc,
-	0xd2, 0xd7, 0xc2, 0x2f, 0x3d, 0x54, 0xed, 0xcf, 0x72, 0xf6, 0x13, 0x00, 0x00, 0xff, 0xff, 0x6d,
-	0xc3, 0xa5, 0x6a, 0x51, 0x02, 0x00, 0x00,
+	// 469 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x93, 0xcd, 0x6e, 0x13, 0x31,
+	0x10, 0xc7, 0x63, 0x12, 0xf2, 0x31, 0x21, 0x50, 0x8c, 0xa8, 0x5c, 0x81, 0x96, 0x6d, 0x2a, 0x44,
+	0x24, 0x50, 0x82, 0x02, 0x67, 0x0e, 0x14, 0x24, 0x0e, 0x2d, 0x42, 0xae, 0x10, 0xc7, 0x95, 0x71,
+	0x86, 0x74, 0xd5, 0x6c, 0xec, 0xda, 0xde, 0x8a, 0xbe, 0x05, 0x8f, 0xc5, 0xb1, 0x47, 0x8e, 0x28,
+	0x79, 0x0d, 0x0e, 0xc8, 0x5e, 0x67, 0xfb, 0xa1, 0x9e, 0xd6, 0xfe, 0xcf, 0x6f, 0xfe, 0xa3, 0x19,
+	0xcf, 0xc2, 0xae, 0x3e, 0x99, 0x4f, 0x44, 0xe9, 0x94, 0x95, 0x62, 0x81, 0x66, 0x52, 0xa0, 0x33,
+	0xb9, 0xb4, 0x13, 0xeb, 0x84, 0x1b, 0x6b, 0xa3, 0x9c, 0xa2, 0x9d, 0xa8, 0x0d, 0xff, 0x35, 0xa1,
+	0x75, 0xe4, 0x84, 0xa3, 0x3b, 0xd0, 0xd5, 0x6a, 0x96, 0x2d, 0x45, 0x81, 0x8c, 0xa4, 0x64, 0xd4,
+	0xe3, 0x1d, 0xad, 0x66, 0x9f, 0x45, 0x81, 0xf4, 0x1d, 0x3c, 0x11, 0x67, 0x68, 0xc4, 0x1c, 0x33,
+	0xa9, 0x96, 0xb2, 0x34, 0x06, 0x97, 0x2e, 0x33, 0x78, 0x5a, 0xa2, 0x75, 0x96, 0xdd, 0x49, 0xc9,
+	0x88, 0xf0, 0x9d, 0x88, 0xec, 0xd7, 0x04, 0x8f, 0x00, 0x3d, 0x84, 0xbd, 0x4d, 0xbe, 0x36, 0xea,
+	0x67, 0x8e, 0xb3, 0x5b, 0x7d, 0x9a, 0xc1, 0x27, 0x8d, 0xe8, 0x97, 0x8a, 0xbc, 0xc5, 0x6e, 0x0f,
+	0x06, 0x31, 0x27, 0x93, 0xaa, 0x5c, 0x3a, 0xd6, 0x0a, 0x89, 0xf7, 0xa2, 0xb8, 0xef, 0x35, 0x3a,
+	0x85, 0xc7, 0x9b, 0x5a, 0xd7, 0xe1, 0xbb, 0x01, 0x7e, 0x14, 0x83, 0xfc, 0x6a, 0xce, 0x73, 0xb8,
+	0xaf, 0x8d, 0x92, 0x68, 0x6d, 0x56, 0x6a, 0x97, 0x17, 0xc8, 0xda, 0x01, 0x1e, 0x44, 0xf5, 0x6b,
+	0x10, 0xe9, 0x53, 0xe8, 0xf9, 0xaf, 0x75, 0xa2, 0xd0, 0xac, 0x93, 0x92, 0x51, 0x93, 0x5f, 0x0a,
+	0xf4, 0x35, 0x74, 0x16, 0xc2, 0xe1, 0x52, 0x9e, 0xb3, 0x6e, 0x4a, 0x46, 0xfd, 0xe9, 0xf6, 0x38,
+	0xce, 0x7a, 0x7c, 0x50, 0xe9, 0x47, 0x27, 0xe8, 0xe4, 0x31, 0xdf, 0x60, 0xf4, 0x19, 0xf4, 0x4f,
+	0x4b, 0x2c, 0x31, 0x9b, 0xa1, 0x76, 0xc7, 0xac, 0x17, 0x6a, 0x42, 0x90, 0x3e, 0x78, 0x85, 0xbe,
+	0x02, 0x6a, 0xd1, 0x9c, 0xa1, 0xc9, 0xd0, 0x18, 0x65, 0x62, 0x23, 0x10, 0xb8, 0xad, 0x2a, 0xf2,
+	0xd1, 0x07, 0xaa, 0x2e, 0x5e, 0xc2, 0xc3, 0x7a, 0xba, 0xf2, 0x3c, 0x5b, 0xe4, 0x45, 0xee, 0x58,
+	0xbf, 0x82, 0xaf, 0x04, 0x0e, 0xbc, 0x3e, 0xfc, 0x01, 0x0f, 0xbe, 0xe5, 0x06, 0xfd, 0x06, 0x1c,
+	0xa2, 0xb5, 0x62, 0x1e, 0xda, 0xf3, 0x4b, 0x60, 0xb5, 0x90, 0x9b, 0x4d, 0xb8, 0x14, 0x28, 0x85,
+	0x96, 0xbf, 0x84, 0x47, 0xef, 0xf1, 0x70, 0xa6, 0xbb, 0xd0, 0xf2, 0xab, 0x15, 0x1e, 0xb0, 0x3f,
+	0x1d, 0xd4, 0xfd, 0x7a, 0x57, 0x1e, 0x42, 0xc3, 0x4f, 0xb0, 0x75, 0xa3, 0x8e, 0xa5, 0x6f, 0xa1,
+	0x5b, 0xc4, 0x33, 0x23, 0x69, 0x73, 0xd4, 0x9f, 0xb2, 0x3a, 0xf5, 0x06, 0xcc, 0x6b, 0x72, 0xf8,
+	0x02, 0x06, 0xd7, 0xe6, 0x48, 0xb7, 0xa1, 0x1d, 0x06, 0x52, 0x99, 0xb4, 0x78, 0xbc, 0xbd, 0x67,
+	0xbf, 0x57, 0x09, 0xb9, 0x58, 0x25, 0xe4, 0xef, 0x2a, 0x21, 0xbf, 0xd6, 0x49, 0xe3, 0x62, 0x9d,
+	0x34, 0xfe, 0xac, 0x93, 0xc6, 0xf7, 0x76, 0xf8, 0x07, 0xde, 0xfc, 0x1f, 0x00, 0xe6, 0x61, 0x42,
+	0xae, 0x28, 0x03, 0x00, 0x00,
 }
 
 func (m *Stat) Marshal() (dAtA []byte, err error) {
@@ -307,6 +404,36 @@ func (m *Stat) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.ConcurrencyLimit != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.ConcurrencyLimit))))
+		i--
+		dAtA[i] = 0x59
+	}
+	if m.ServerErrorCount != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.ServerErrorCount))))
+		i--
+		dAtA[i] = 0x51
+	}
+	if m.QueueDepth != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.QueueDepth))))
+		i--
+		dAtA[i] = 0x49
+	}
+	if m.Latency != nil {
+		{
+			size, err := m.Latency.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintStat(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
+	}
 	if m.Timestamp != 0 {
 		i = encodeVarintStat(dAtA, i, uint64(m.Timestamp))
 		i--
@@ -438,6 +565,47 @@ func (m *WireStatMessages) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *LatencySketch) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LatencySketch) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LatencySketch) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Counts) > 0 {
+		dAtA2 := make([]byte, len(m.Counts)*10)
+		var j1 int
+		for _, num := range m.Counts {
+			for num >= 1<<7 {
+				dAtA2[j1] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j1++
+			}
+			dAtA2[j1] = uint8(num)
+			j1++
+		}
+		i -= j1
+		copy(dAtA[i:], dAtA2[:j1])
+		i = encodeVarintStat(dAtA, i, uint64(j1))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintStat(dAtA []byte, offset int, v uint64) int {
 	offset -= sovStat(v)
 	base := offset
@@ -477,6 +645,19 @@ func (m *Stat) Size() (n int) {
 	if m.Timestamp != 0 {
 		n += 1 + sovStat(uint64(m.Timestamp))
 	}
+	if m.Latency != nil {
+		l = m.Latency.Size()
+		n += 1 + l + sovStat(uint64(l))
+	}
+	if m.QueueDepth != 0 {
+		n += 9
+	}
+	if m.ServerErrorCount != 0 {
+		n += 9
+	}
+	if m.ConcurrencyLimit != 0 {
+		n += 9
+	}
 	return n
 }
 
@@ -516,6 +697,22 @@ func (m *WireStatMessages) Size() (n int) {
 	return n
 }
 
+func (m *LatencySketch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Counts) > 0 {
+		l = 0
+		for _, e := range m.Counts {
+			l += sovStat(uint64(e))
+		}
+		n += 1 + sovStat(uint64(l)) + l
+	}
+	return n
+}
+
 func sovStat(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -657,6 +854,75 @@ func (m *Stat) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Latency", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthStat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthStat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Latency == nil {
+				m.Latency = &LatencySketch{}
+			}
+			if err := m.Latency.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QueueDepth", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.QueueDepth = float64(math.Float64frombits(v))
+		case 10:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ServerErrorCount", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.ServerErrorCount = float64(math.Float64frombits(v))
+		case 11:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConcurrencyLimit", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.ConcurrencyLimit = float64(math.Float64frombits(v))
 		default:
 			iNdEx = preIndex
 			skippy, err := skipStat(dAtA[iNdEx:])
@@ -912,6 +1178,132 @@ func (m *WireStatMessages) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *LatencySketch) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowStat
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LatencySketch: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LatencySketch: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowStat
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Counts = append(m.Counts, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowStat
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthStat
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthStat
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Counts) == 0 {
+					m.Counts = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowStat
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Counts = append(m.Counts, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Counts", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipStat(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthStat
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipStat(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -68,6 +68,11 @@ var (
 	// stat from an unscraped pod
 	ErrDidNotReceiveStat = errors.New("did not receive stat from an unscraped pod")
 
+	// ErrCustomMetricNeedsPodScraping is returned when the revision scales on a
+	// custom metric, but pods cannot be scraped directly, e.g. in mesh mode. The
+	// user container's metrics endpoint is not reachable via the metrics service.
+	ErrCustomMetricNeedsPodScraping = errors.New("custom metric requires direct pod scraping")
+
 	// Sentinel error to return from pod scraping routine, when all pods fail
 	// with a 503 error code, indicating (most likely), that mesh is enabled.
 	errDirectScrapingNotAvailable = errors.New("all pod scrapes returned 503 error")
@@ -83,6 +88,16 @@ type StatsScraper interface {
 	Scrape(time.Duration) (Stat, error)
 }
 
+// customMetricScraper is implemented by the StatsScraper of a revision that
+// may scale on a custom metric. The application defined gauge is read from the
+// user container rather than reported by the queue-proxy, so it isn't part of
+// the Stat and is returned alongside it instead.
+type customMetricScraper interface {
+	// scrapeWithCustomMetric is Scrape, but also returns the custom metric
+	// averaged over all the pods the same way as the Stat.
+	scrapeWithCustomMetric(time.Duration) (Stat, float64, error)
+}
+
 // scrapeClient defines the interface for collecting Revision metrics for a given
 // URL. Internal used only.
 type scrapeClient interface {
@@ -145,10 +160,19 @@ type serviceScraper struct {
 
 	duration metric.Float64Histogram
 	clock    clock.Clock
+
+	// customClient, customMetric and customPortAndPath are set when the
+	// revision scales on an application defined gauge, which is scraped
+	// from the user container alongside the queue-proxy stats.
+	customClient      customMetricClient
+	customMetric      string
+	customPortAndPath string
 }
 
 // NewStatsScraper creates a new StatsScraper for the Revision which
-// the given Metric is responsible for.
+// the given Metric is responsible for. Custom metrics are read from the pods
+// directly, so they are rejected when the mesh compatibility mode forces
+// scraping via the service.
 func NewStatsScraper(
 	metric *autoscalingv1alpha1.Metric,
 	revisionName string,
@@ -157,10 +181,15 @@ func NewStatsScraper(
 	meshMode netcfg.MeshCompatibilityMode,
 	logger *zap.SugaredLogger,
 	mp metric.MeterProvider,
-) StatsScraper {
+) (StatsScraper, error) {
+	if _, _, _, ok := metric.CustomMetric(); ok && meshMode == netcfg.MeshCompatibilityModeEnabled {
+		return nil, ErrCustomMetricNeedsPodScraping
+	}
 	directClient := newHTTPScrapeClient(client)
 	meshClient := newHTTPScrapeClient(noKeepaliveClient)
-	return newServiceScraperWithClient(metric, revisionName, podAccessor, usePassthroughLb, meshMode, directClient, meshClient, logger, mp)
+	s := newServiceScraperWithClient(metric, revisionName, podAccessor, usePassthroughLb, meshMode, directClient, meshClient, logger, mp)
+	s.customClient = newOpenMetricsScrapeClient(client)
+	return s, nil
 }
 
 // We aren't using revisionName because OTel metrics can't because removed
@@ -190,7 +219,7 @@ func newServiceScraperWithClient(
 		panic(err)
 	}
 
-	return &serviceScraper{
+	s := &serviceScraper{
 		meshMode:         meshMode,
 		directClient:     directClient,
 		meshClient:       meshClient,
@@ -210,6 +239,11 @@ func newServiceScraperWithClient(
 			// metrics.RevisionNameKey.With(revisionName),
 		),
 	}
+	if name, port, path, ok := m.CustomMetric(); ok {
+		s.customMetric = name
+		s.customPortAndPath = strconv.Itoa(port) + path
+	}
+	return s
 }
 
 var portAndPath = strconv.Itoa(networking.AutoscalingQueueMetricsPort) + "/metrics"
@@ -220,7 +254,13 @@ func urlFromTarget(t, ns string) string {
 
 // Scrape calls the destination service then sends it
 // to the given stats channel.
-func (s *serviceScraper) Scrape(window time.Duration) (stat Stat, err error) {
+func (s *serviceScraper) Scrape(window time.Duration) (Stat, error) {
+	stat, _, err := s.scrapeWithCustomMetric(window)
+	return stat, err
+}
+
+// scrapeWithCustomMetric implements customMetricScraper.
+func (s *serviceScraper) scrapeWithCustomMetric(window time.Duration) (stat Stat, custom float64, err error) {
 	startTime := s.clock.Now()
 	defer func() {
 		// No errors and an empty stat? We didn't scrape at all because
@@ -236,17 +276,18 @@ func (s *serviceScraper) Scrape(window time.Duration) (stat Stat, err error) {
 	switch s.meshMode {
 	case netcfg.MeshCompatibilityModeEnabled:
 		s.logger.Debug("Scraping via service due to meshMode setting")
-		return s.scrapeService(window)
+		stat, err = s.scrapeService(window)
+		return stat, 0, err
 	case netcfg.MeshCompatibilityModeDisabled:
 		s.logger.Debug("Scraping pods directly due to meshMode setting")
 		return s.scrapePods(window)
 	default:
 		if s.podsAddressable || s.usePassthroughLb {
-			stat, err := s.scrapePods(window)
+			stat, custom, err := s.scrapePods(window)
 			// Return here if some pods were scraped, but not enough or if we're using a
 			// passthrough loadbalancer and want no fallback to service-scrape logic.
 			if !errors.Is(err, errDirectScrapingNotAvailable) || s.usePassthroughLb {
-				return stat, err
+				return stat, custom, err
 			}
 			// Else fall back to service scrape.
 		}
@@ -257,28 +298,29 @@ func (s *serviceScraper) Scrape(window time.Duration) (stat Stat, err error) {
 			// thus it is probably a mesh case.
 			s.podsAddressable = false
 		}
-		return stat, err
+		return stat, 0, err
 	}
 }
 
-func (s *serviceScraper) scrapePods(window time.Duration) (Stat, error) {
+func (s *serviceScraper) scrapePods(window time.Duration) (Stat, float64, error) {
 	pods, youngPods, err := s.podAccessor.PodIPsSplitByAge(window, time.Now())
 	if err != nil {
 		s.logger.Infow("Error querying pods by age", zap.Error(err))
-		return emptyStat, err
+		return emptyStat, 0, err
 	}
 	lp := len(pods)
 	lyp := len(youngPods)
 	s.logger.Debugf("|OldPods| = %d, |YoungPods| = %d", lp, lyp)
 	total := lp + lyp
 	if total == 0 {
-		return emptyStat, nil
+		return emptyStat, 0, nil
 	}
 
 	frpc := float64(total)
 	sampleSizeF := populationMeanSampleSize(frpc)
 	sampleSize := int(sampleSizeF)
 	results := make(chan Stat, sampleSize)
+	customs := make(chan float64, sampleSize)
 
 	// 1. If not enough: shuffle young pods and expect to use N-lp of those
 	//		no need to shuffle old pods, since all of them are expected to be used.
@@ -325,8 +367,13 @@ func (s *serviceScraper) scrapePods(window time.Duration) (Stat, error) {
 				}
 
 				stat, err := s.directClient.Do(req)
+				var custom float64
+				if err == nil && s.customMetric != "" {
+					custom, err = s.scrapeCustomMetric(egCtx, pods[myIdx])
+				}
 				if err == nil {
 					results <- stat
+					customs <- custom
 					return nil
 				}
 
@@ -341,6 +388,7 @@ func (s *serviceScraper) scrapePods(window time.Duration) (Stat, error) {
 
 	err = grp.Wait()
 	close(results)
+	close(customs)
 
 	// We only get here if one of the scrapers failed to scrape
 	// at least one pod.
@@ -348,21 +396,35 @@ func (s *serviceScraper) scrapePods(window time.Duration) (Stat, error) {
 		// Got some (but not enough) successful pods.
 		if len(results) > 0 {
 			s.logger.Warn("Too many pods failed scraping for meaningful interpolation")
-			return emptyStat, errPodsExhausted
+			return emptyStat, 0, errPodsExhausted
 		}
 		// We didn't get any pods, but we don't want to fall back to service
 		// scraping because we saw an error which was not mesh-related.
 		if sawNonMeshError.Load() {
 			s.logger.Warn("0 pods scraped, but did not see a mesh-related error")
-			return emptyStat, errPodsExhausted
+			return emptyStat, 0, errPodsExhausted
 		}
 		// No pods, and we only saw mesh-related errors, so infer that mesh must be
 		// enabled and fall back to service scraping.
 		s.logger.Warn("0 pods were successfully scraped out of ", strconv.Itoa(len(pods)))
-		return emptyStat, errDirectScrapingNotAvailable
+		return emptyStat, 0, errDirectScrapingNotAvailable
 	}
 
-	return computeAverages(results, sampleSizeF, frpc), nil
+	var custom float64
+	for c := range customs {
+		custom += c
+	}
+	return computeAverages(results, sampleSizeF, frpc), custom / sampleSizeF * frpc, nil
+}
+
+// scrapeCustomMetric reads the custom metric from the user container of the
+// pod with the given IP.
+func (s *serviceScraper) scrapeCustomMetric(ctx context.Context, ip string) (float64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+ip+":"+s.customPortAndPath, nil)
+	if err != nil {
+		return 0, err
+	}
+	return s.customClient.Gauge(req, s.customMetric)
 }
 
 func computeAverages(results <-chan Stat, sample, total float64) Stat {
@@ -382,6 +444,9 @@ func computeAverages(results <-chan Stat, sample, total float64) Stat {
 // scrapeService scrapes the metrics using service endpoint
 // as its target, rather than individual pods.
 func (s *serviceScraper) scrapeService(window time.Duration) (Stat, error) {
+	if s.customMetric != "" {
+		return emptyStat, ErrCustomMetricNeedsPodScraping
+	}
 	readyPods, err := s.podAccessor.ReadyCount()
 	if err != nil {
 		return emptyStat, ErrFailedGetEndpoints
@@ -36,6 +36,7 @@ import (
 	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
 	fakepodsinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/pod/fake"
 	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/serving/pkg/apis/autoscaling"
 	autoscalingv1alpha1 "knative.dev/serving/pkg/apis/autoscaling/v1alpha1"
 	"knative.dev/serving/pkg/apis/serving"
 	"knative.dev/serving/pkg/resources"
@@ -99,7 +100,10 @@ func TestNewServiceScraperWithClientHappyCase(t *testing.T) {
 	accessor := resources.NewPodAccessor(
 		fakepodsinformer.Get(ctx).Lister(),
 		testNamespace, testRevision)
-	sc := NewStatsScraper(metric, testRevision, accessor, false, netcfg.MeshCompatibilityModeAuto, logtesting.TestLogger(t), mp)
+	sc, err := NewStatsScraper(metric, testRevision, accessor, false, netcfg.MeshCompatibilityModeAuto, logtesting.TestLogger(t), mp)
+	if err != nil {
+		t.Fatal("NewStatsScraper() =", err)
+	}
 	if svcS, want := sc.(*serviceScraper), urlFromTarget(testRevision+"-zhudex", testNamespace); svcS.url != want {
 		t.Errorf("scraper.url = %s, want: %s", svcS.url, want)
 	}
@@ -161,6 +165,67 @@ func TestPodDirectScrapeSuccess(t *testing.T) {
 	}
 }
 
+func TestPodDirectScrapeCustomMetric(t *testing.T) {
+	ctx, cancel, informers := SetupFakeContextWithCancel(t)
+	wf, err := RunAndSyncInformers(ctx, informers...)
+	if err != nil {
+		cancel()
+		t.Fatal("Failed to start informers:", err)
+	}
+	t.Cleanup(func() {
+		cancel()
+		wf()
+	})
+
+	client := newTestScrapeClient(testStats, []error{nil})
+	scraper := serviceScraperForTest(ctx, t, netcfg.MeshCompatibilityModeAuto, client, nil /* mesh not used */, true /*podsAddressable*/, false /*passthroughLb*/)
+	customClient := &fakeCustomMetricClient{value: 4, urls: sets.New[string]()}
+	scraper.customClient = customClient
+	scraper.customMetric = "queue_length"
+	scraper.customPortAndPath = "8080/stats"
+
+	makePods(ctx, "pods-", 3, metav1.Now())
+	stat, custom, err := scraper.scrapeWithCustomMetric(defaultMetric.Spec.StableWindow)
+	if err != nil {
+		t.Fatal("Unexpected error from scraper.scrapeWithCustomMetric():", err)
+	}
+	checkBaseStat(t, stat)
+	// (4 + 4 + 4) / 3.0 * 3 = 12
+	if got, want := custom, 12.; got != want {
+		t.Errorf("custom metric = %v, want %v", got, want)
+	}
+	if got, want := customClient.urls, sets.New("http://pods-1.2.3.4:8080/stats", "http://pods-1.2.3.5:8080/stats", "http://pods-1.2.3.6:8080/stats"); !got.Equal(want) {
+		t.Errorf("Scraped custom metric URLs = %v, want %v", sets.List(got), sets.List(want))
+	}
+
+	// The user container is not reachable through the metrics service.
+	scraper.meshMode = netcfg.MeshCompatibilityModeEnabled
+	if _, err := scraper.Scrape(defaultMetric.Spec.StableWindow); !errors.Is(err, ErrCustomMetricNeedsPodScraping) {
+		t.Errorf("Scrape() = %v, want %v", err, ErrCustomMetricNeedsPodScraping)
+	}
+}
+
+func TestNewStatsScraperCustomMetricMeshMode(t *testing.T) {
+	m := testMetric()
+	m.Annotations = map[string]string{
+		autoscaling.MetricAnnotationKey:           autoscaling.Custom,
+		autoscaling.CustomMetricNameAnnotationKey: "queue_length",
+	}
+	ctx, cancel, _ := SetupFakeContextWithCancel(t)
+	t.Cleanup(cancel)
+	accessor := resources.NewPodAccessor(
+		fakepodsinformer.Get(ctx).Lister(),
+		testNamespace, testRevision)
+	mp := metric.NewMeterProvider()
+
+	if _, err := NewStatsScraper(m, testRevision, accessor, false, netcfg.MeshCompatibilityModeEnabled, logtesting.TestLogger(t), mp); !errors.Is(err, ErrCustomMetricNeedsPodScraping) {
+		t.Errorf("NewStatsScraper() = %v, want %v", err, ErrCustomMetricNeedsPodScraping)
+	}
+	if _, err := NewStatsScraper(m, testRevision, accessor, false, netcfg.MeshCompatibilityModeAuto, logtesting.TestLogger(t), mp); err != nil {
+		t.Error("NewStatsScraper() =", err)
+	}
+}
+
 func TestPodDirectScrapeSomeFailButSuccess(t *testing.T) {
 	// For 5 pods, we need 4 successes.
 	ctx, cancel, informers := SetupFakeContextWithCancel(t)
@@ -850,6 +915,19 @@ func (c *fakeScrapeClient) Do(req *http.Request) (Stat, error) {
 	return ans, err
 }
 
+type fakeCustomMetricClient struct {
+	value float64
+	urls  sets.Set[string]
+	mutex sync.Mutex
+}
+
+func (c *fakeCustomMetricClient) Gauge(req *http.Request, _ string) (float64, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.urls.Insert(req.URL.String())
+	return c.value, nil
+}
+
 func TestURLFromTarget(t *testing.T) {
 	if got, want := "http://dance.now:9090/metrics", urlFromTarget("dance", "now"); got != want {
 		t.Errorf("urlFromTarget = %s, want: %s, diff: %s", got, want, cmp.Diff(got, want))
@@ -48,6 +48,10 @@ type autoscaler struct {
 	metricClient am.MetricClient
 	podCounter   podCounter
 
+	// stateMux guards the state below, up to the deciderSpec, which is
+	// otherwise only accessed from Scale.
+	stateMux sync.Mutex
+
 	// State in panic mode.
 	panicTime    time.Time
 	maxPanicPods int32
@@ -61,6 +65,14 @@ type autoscaler struct {
 	deciderSpec *DeciderSpec
 
 	metrics *scalingMetrics
+
+	// history remembers the desired scale for predictive pre-scaling.
+	// It is only accessed from Scale.
+	history *seasonalHistory
+	// schedule is the parsed form of scheduleSrc, the min-scale schedule
+	// of the current spec. Both are only accessed from Scale.
+	schedule    autoscaling.MinScaleSchedule
+	scheduleSrc string
 }
 
 // New creates a new instance of default autoscaler implementation.
@@ -141,12 +153,60 @@ func (a *autoscaler) OnDelete() {
 	a.metrics.OnDelete()
 }
 
+// Snapshot returns a copy of the state the autoscaler has built up over time.
+func (a *autoscaler) Snapshot(now time.Time) *ScalerSnapshot {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+
+	ret := &ScalerSnapshot{
+		Time:         now,
+		PanicTime:    a.panicTime,
+		MaxPanicPods: a.maxPanicPods,
+	}
+	if a.delayWindow != nil {
+		ret.DelayWindow = a.delayWindow.Samples()
+	}
+	if a.history != nil {
+		ret.History = a.history.samples()
+	}
+	return ret
+}
+
+// Restore resumes from the state in the snapshot. Since the snapshot comes
+// along with the metric history, this also ends the panic mode the
+// autoscaler starts in for the lack of history, unless it was panicking
+// when the snapshot was taken, or the snapshot is older than the stable
+// window, so that little of the history is left in the window.
+func (a *autoscaler) Restore(snap *ScalerSnapshot, now time.Time) {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+
+	spec := a.currentSpec()
+	if now.Sub(snap.Time) < spec.StableWindow {
+		a.panicTime = snap.PanicTime
+		a.maxPanicPods = snap.MaxPanicPods
+		a.metrics.SetPanic(!a.panicTime.IsZero())
+	}
+	if a.delayWindow != nil {
+		for _, s := range snap.DelayWindow {
+			a.delayWindow.Record(s.Time, s.Value)
+		}
+	}
+	if season := spec.PredictiveSeason; season > 0 && len(snap.History) > 0 {
+		if a.history == nil || a.history.season != season {
+			a.history = newSeasonalHistory(season)
+		}
+		a.history.restore(snap.History)
+	}
+}
+
 // Scale calculates the desired scale based on current statistics given the current time.
 // desiredPodCount is the calculated pod count the autoscaler would like to set.
 // validScale signifies whether the desiredPodCount should be applied or not.
-// Scale is not thread safe in regards to panic state, but it's thread safe in
-// regards to acquiring the decider spec.
 func (a *autoscaler) Scale(logger *zap.SugaredLogger, now time.Time) ScaleResult {
+	a.stateMux.Lock()
+	defer a.stateMux.Unlock()
+
 	desugared := logger.Desugar()
 	debugEnabled := desugared.Core().Enabled(zapcore.DebugLevel)
 
@@ -163,22 +223,46 @@ func (a *autoscaler) Scale(logger *zap.SugaredLogger, now time.Time) ScaleResult
 	metricKey := types.NamespacedName{Namespace: a.namespace, Name: a.revision}
 
 	metricName := spec.ScalingMetric
+	targetValue, totalValue := spec.TargetValue, spec.TotalValue
 	var observedStableValue, observedPanicValue float64
 	switch spec.ScalingMetric {
 	case autoscaling.RPS:
 		observedStableValue, observedPanicValue, err = a.metricClient.StableAndPanicRPS(metricKey, now)
+	case autoscaling.Custom:
+		observedStableValue, observedPanicValue, err = a.observedCustom(metricKey, now, spec, originalReadyPodsCount)
+	case autoscaling.Latency:
+		observedStableValue, observedPanicValue, err = a.observedLatency(metricKey, now, spec)
 	default:
 		metricName = autoscaling.Concurrency // concurrency is used by default
 		observedStableValue, observedPanicValue, err = a.metricClient.StableAndPanicConcurrency(metricKey, now)
+		if err == nil && originalReadyPodsCount > 0 {
+			targetValue, totalValue = a.limitedTargets(logger, metricKey, now, spec, originalReadyPodsCount)
+		}
 	}
 
+	floor := a.prescaleFloor(logger, spec, now)
+
 	if err != nil {
 		if errors.Is(err, am.ErrNoData) {
 			logger.Debug("No data to scale on yet")
 		} else {
 			logger.Errorw("Failed to obtain metrics", zap.Error(err))
 		}
-		return invalidSR
+		if floor == 0 {
+			return invalidSR
+		}
+		// Without data there is no telling the current demand, but the
+		// revision is still pre-scaled ahead of the expected one.
+		logger.Debugf("Pre-scaling to %d pods without data", floor)
+		ebc := int32(-1)
+		if spec.TargetBurstCapacity == 0 {
+			ebc = 0
+		}
+		return ScaleResult{
+			DesiredPodCount:     floor,
+			ExcessBurstCapacity: ebc,
+			ScaleValid:          true,
+		}
 	}
 
 	// Make sure we don't get stuck with the same number of pods, if the scale up rate
@@ -193,13 +277,24 @@ func (a *autoscaler) Scale(logger *zap.SugaredLogger, now time.Time) ScaleResult
 		maxScaleDown = math.Floor(readyPodsCount / spec.MaxScaleDownRate)
 	}
 
-	dspc := math.Ceil(observedStableValue / spec.TargetValue)
-	dppc := math.Ceil(observedPanicValue / spec.TargetValue)
+	var dspc, dppc float64
+	if spec.ScalingMetric == autoscaling.Latency {
+		dspc = latencyPodCount(readyPodsCount, observedStableValue, spec.TargetValue)
+		dppc = latencyPodCount(readyPodsCount, observedPanicValue, spec.TargetValue)
+	} else {
+		dspc = math.Ceil(observedStableValue / targetValue)
+		dppc = math.Ceil(observedPanicValue / targetValue)
+	}
+	if a.history != nil {
+		// Remember the demand, rather than the eventual decision, so that
+		// neither panicking nor pre-scaling feed back into the forecast.
+		a.history.record(now, int32(dspc))
+	}
 	if debugEnabled {
 		desugared.Debug(
 			fmt.Sprintf("For metric %s observed values: stable = %0.3f; panic = %0.3f; target = %0.3f "+
 				"Desired StablePodCount = %0.0f, PanicPodCount = %0.0f, ReadyEndpointCount = %d, MaxScaleUp = %0.0f, MaxScaleDown = %0.0f",
-				metricName, observedStableValue, observedPanicValue, spec.TargetValue,
+				metricName, observedStableValue, observedPanicValue, targetValue,
 				dspc, dppc, originalReadyPodsCount, maxScaleUp, maxScaleDown))
 	}
 
@@ -275,6 +370,11 @@ func (a *autoscaler) Scale(logger *zap.SugaredLogger, now time.Time) ScaleResult
 		}
 	}
 
+	if desiredPodCount < floor {
+		logger.Debugf("Pre-scaling from %d to %d pods", desiredPodCount, floor)
+		desiredPodCount = floor
+	}
+
 	// Compute excess burst capacity
 	//
 	// the excess burst capacity is based on panic value, since we don't want to
@@ -282,18 +382,21 @@ func (a *autoscaler) Scale(logger *zap.SugaredLogger, now time.Time) ScaleResult
 	// Negative EBC means that the deployment does not have enough capacity to serve
 	// the desired burst off hand.
 	// EBC = TotCapacity - Cur#ReqInFlight - TargetBurstCapacity
+	// Latency does not tell the capacity of the pods, so the activator is
+	// kept in the request path unless burst capacity is disabled altogether.
 	excessBCF := -1.
 	switch {
 	case spec.TargetBurstCapacity == 0:
 		excessBCF = 0
+	case spec.ScalingMetric == autoscaling.Latency:
 	case spec.TargetBurstCapacity > 0:
-		totCap := float64(originalReadyPodsCount) * spec.TotalValue
+		totCap := float64(originalReadyPodsCount) * totalValue
 		excessBCF = math.Floor(totCap - spec.TargetBurstCapacity - observedPanicValue)
 	}
 
 	if debugEnabled {
 		desugared.Debug(fmt.Sprintf("PodCount=%d Total1PodCapacity=%0.3f ObsStableValue=%0.3f ObsPanicValue=%0.3f TargetBC=%0.3f ExcessBC=%0.3f",
-			originalReadyPodsCount, spec.TotalValue, observedStableValue,
+			originalReadyPodsCount, totalValue, observedStableValue,
 			observedPanicValue, spec.TargetBurstCapacity, excessBCF))
 	}
 
@@ -302,7 +405,7 @@ func (a *autoscaler) Scale(logger *zap.SugaredLogger, now time.Time) ScaleResult
 		int64(desiredPodCount),
 		observedStableValue,
 		observedPanicValue,
-		spec.TargetValue,
+		targetValue,
 	)
 
 	return ScaleResult{
@@ -312,6 +415,130 @@ func (a *autoscaler) Scale(logger *zap.SugaredLogger, now time.Time) ScaleResult
 	}
 }
 
+// limitedTargets returns the per pod target and total values, lowered to the
+// concurrency limit the queue-proxies currently enforce, if any. The limit is
+// adapted to what the pods are observed to sustain, so it may be well below
+// the configured container concurrency.
+func (a *autoscaler) limitedTargets(logger *zap.SugaredLogger, key types.NamespacedName, now time.Time,
+	spec *DeciderSpec, readyPods int,
+) (float64, float64) {
+	limit, err := a.metricClient.ConcurrencyLimit(key, now)
+	if err != nil {
+		if !errors.Is(err, am.ErrNoData) {
+			logger.Errorw("Failed to obtain the concurrency limit", zap.Error(err))
+		}
+		return spec.TargetValue, spec.TotalValue
+	}
+	perPod := limit / float64(readyPods)
+	if perPod >= spec.TotalValue {
+		return spec.TargetValue, spec.TotalValue
+	}
+	// Keep the configured utilization of the lowered limit.
+	return spec.TargetValue * perPod / spec.TotalValue, perPod
+}
+
+// latencyHeadroom is the fraction of the latency target under which the
+// latency metric lets the revision scale down. Between it and the target the
+// current scale is kept, so as not to flap around the target.
+const latencyHeadroom = 0.7
+
+// observedLatency returns the stable and the panic percentile of the request
+// latencies in milliseconds, as configured in the spec.
+func (a *autoscaler) observedLatency(key types.NamespacedName, now time.Time, spec *DeciderSpec) (float64, float64, error) {
+	// Requests that are still in flight have no latency yet, so whether
+	// there's traffic at all is told by concurrency.
+	stableConcurrency, panicConcurrency, err := a.metricClient.StableAndPanicConcurrency(key, now)
+	if err != nil {
+		return 0, 0, err
+	}
+	stableLatency, panicLatency, err := a.metricClient.StableAndPanicLatency(key, now, spec.LatencyPercentile)
+	if errors.Is(err, am.ErrNoData) {
+		if stableConcurrency == 0 && panicConcurrency == 0 {
+			// No traffic: nothing to serve, let the revision scale down.
+			return 0, 0, nil
+		}
+		// Requests are waiting or in flight, but none completed so far.
+		// There's no telling their latency, so keep the current scale,
+		// which may be activating from zero.
+		return spec.TargetValue, spec.TargetValue, nil
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	return float64(stableLatency) / float64(time.Millisecond), float64(panicLatency) / float64(time.Millisecond), nil
+}
+
+func (a *autoscaler) observedCustom(key types.NamespacedName, now time.Time, spec *DeciderSpec, readyPodsCount int) (float64, float64, error) {
+	stableValue, panicValue, err := a.metricClient.StableAndPanicCustom(key, now)
+	if !errors.Is(err, am.ErrNoData) || readyPodsCount > 0 {
+		return stableValue, panicValue, err
+	}
+	// Without pods there is no endpoint to scrape the metric from, so whether
+	// there's traffic is told by the concurrency the activator reports.
+	stableConcurrency, panicConcurrency, err := a.metricClient.StableAndPanicConcurrency(key, now)
+	if err != nil {
+		return 0, 0, err
+	}
+	if stableConcurrency == 0 && panicConcurrency == 0 {
+		return 0, 0, nil
+	}
+	// Requests are waiting for the revision to activate: ask for a single
+	// pod, until it reports the metric.
+	return spec.TargetValue, spec.TargetValue, nil
+}
+
+// latencyPodCount returns the number of pods needed to bring the observed
+// latency to the target. Unlike the other metrics latency does not add up
+// across pods, so the current number of pods is scaled in proportion to how
+// far the latency is off the target. When there is headroom the revision
+// scales down just enough to use it, and keeps its scale within.
+func latencyPodCount(readyPodsCount, observed, target float64) float64 {
+	switch {
+	case observed > target:
+		return math.Ceil(readyPodsCount * observed / target)
+	case observed < target*latencyHeadroom:
+		return math.Ceil(readyPodsCount * observed / (target * latencyHeadroom))
+	default:
+		return readyPodsCount
+	}
+}
+
+// prescaleFloor returns the scale the revision is kept at ahead of demand,
+// which is the larger of the forecast and the scheduled min-scale, or zero
+// when the revision is not reachable.
+func (a *autoscaler) prescaleFloor(logger *zap.SugaredLogger, spec *DeciderSpec, now time.Time) int32 {
+	if spec.PredictiveSeason <= 0 {
+		a.history = nil
+	} else if a.history == nil || a.history.season != spec.PredictiveSeason {
+		a.history = newSeasonalHistory(spec.PredictiveSeason)
+	}
+	if spec.MinScaleSchedule != a.scheduleSrc {
+		a.scheduleSrc = spec.MinScaleSchedule
+		a.schedule = nil
+		if spec.MinScaleSchedule != "" {
+			schedule, err := autoscaling.ParseMinScaleSchedule(spec.MinScaleSchedule)
+			if err != nil {
+				logger.Errorw("Ignoring invalid min-scale schedule", zap.Error(err))
+			}
+			a.schedule = schedule
+		}
+	}
+
+	var forecast int32
+	if a.history != nil {
+		forecast = a.history.forecast(now)
+	}
+	a.metrics.SetForecast(int64(forecast))
+	if !spec.Reachable {
+		// Like min-scale, pre-scaling only applies to reachable revisions.
+		return 0
+	}
+	if scheduled := a.schedule.MinScale(now); scheduled > forecast {
+		return scheduled
+	}
+	return forecast
+}
+
 func (a *autoscaler) currentSpec() *DeciderSpec {
 	a.specMux.RLock()
 	defer a.specMux.RUnlock()
@@ -31,6 +31,7 @@ import (
 
 	logtesting "knative.dev/pkg/logging/testing"
 	"knative.dev/pkg/observability/metrics/metricstest"
+	"knative.dev/serving/pkg/autoscaler/aggregation/max"
 	"knative.dev/serving/pkg/autoscaler/metrics"
 	"knative.dev/serving/pkg/resources"
 )
@@ -119,6 +120,61 @@ func TestAutoscalerScaleDownDelay(t *testing.T) {
 	})
 }
 
+func TestAutoscalerSnapshotRestore(t *testing.T) {
+	attrs := attribute.NewSet(attribute.String("foo", "bar"))
+	metrics := &metricClient{}
+	spec := &DeciderSpec{
+		TargetValue:      10,
+		MaxScaleDownRate: 10,
+		MaxScaleUpRate:   10,
+		PanicThreshold:   100,
+		StableWindow:     stableWindow,
+		ScaleDownDelay:   5 * time.Minute,
+		Reachable:        true,
+	}
+	now := time.Time{}
+
+	src := New(attrs, nil, testNamespace, testRevision, metrics, &fakePodCounter{}, spec).(*autoscaler)
+	metrics.SetStableAndPanicConcurrency(40, 40)
+	expectScale(t, src, now.Add(2*time.Second), ScaleResult{
+		ScaleValid:      true,
+		DesiredPodCount: 4,
+	})
+	snap := src.Snapshot(now.Add(2 * time.Second))
+	if got, want := snap.DelayWindow, []max.Sample{{Time: now.Add(2 * time.Second), Value: 4}}; !cmp.Equal(got, want) {
+		t.Error("DelayWindow mismatch (-want,+got):", cmp.Diff(want, got))
+	}
+
+	// The new autoscaler starts in panic mode for the lack of history.
+	dst := New(attrs, nil, testNamespace, testRevision, metrics, &fakePodCounter{readyCount: 4}, spec).(*autoscaler)
+	if dst.panicTime.IsZero() {
+		t.Fatal("Autoscaler did not start in panic mode")
+	}
+	dst.Restore(snap, now.Add(3*time.Second))
+	if !dst.panicTime.IsZero() {
+		t.Error("Autoscaler is still in panic mode after restoring")
+	}
+
+	// Little of the history of a snapshot older than the stable window is
+	// left, so the autoscaler keeps panicking.
+	stale := New(attrs, nil, testNamespace, testRevision, metrics, &fakePodCounter{readyCount: 4}, spec).(*autoscaler)
+	stale.Restore(snap, now.Add(2*time.Second+stableWindow))
+	if stale.panicTime.IsZero() {
+		t.Error("Autoscaler left panic mode after restoring a stale snapshot")
+	}
+
+	// The scale-down delay carries on where the original autoscaler left off.
+	metrics.SetStableAndPanicConcurrency(0, 0)
+	expectScale(t, dst, now.Add(5*time.Minute), ScaleResult{
+		ScaleValid:      true,
+		DesiredPodCount: 4,
+	})
+	expectScale(t, dst, now.Add(5*time.Minute+2*time.Second), ScaleResult{
+		ScaleValid:      true,
+		DesiredPodCount: 0,
+	})
+}
+
 func TestAutoscalerScaleDownDelayNotReachable(t *testing.T) {
 	reader := metric.NewManualReader()
 	mp := metric.NewMeterProvider(metric.WithReader(reader))
@@ -230,6 +286,7 @@ func TestAutoscalerStartMetrics(t *testing.T) {
 		t, reader,
 		metricstest.MetricsEqual(scopeName,
 			panicMetric(true),
+			forecastMetric(0),
 			metricdata.Metrics{
 				Name:        "kn.revision.pods.desired",
 				Description: "Number of pods the autoscaler wants to allocate",
@@ -332,6 +389,7 @@ func TestAutoscalerMetricsWithRPS(t *testing.T) {
 		metricstest.MetricsEqual(
 			scopeName,
 			panicMetric(true),
+			forecastMetric(0),
 
 			metricdata.Metrics{
 				Name:        "kn.revision.pods.desired",
@@ -405,6 +463,23 @@ func TestAutoscalerStableModeIncreaseWithConcurrencyDefault(t *testing.T) {
 	expectScale(t, a, time.Now(), ScaleResult{10, expectedEBC(10, 101, 10, 1), true})
 }
 
+func TestAutoscalerConcurrencyLimit(t *testing.T) {
+	metrics := &metricClient{StableConcurrency: 9, PanicConcurrency: 2, Limit: 4}
+	a, pc, _ := newTestAutoscaler(10, 5, metrics)
+	// The pod can take 4 requests rather than 13.3, hence it's targeted at 3.
+	expectScale(t, a, time.Now(), ScaleResult{3, -3, true})
+
+	// The limit is split among the ready pods, 20 each is above the configured 13.3.
+	pc.readyCount = 2
+	metrics.Limit = 40
+	expectScale(t, a, time.Now(), ScaleResult{1, expectedEBC(10, 5, 2, 2), true})
+
+	// Without a reported limit the configured target applies.
+	metrics.Limit = 0
+	metrics.StableConcurrency = 30
+	expectScale(t, a, time.Now(), ScaleResult{3, expectedEBC(10, 5, 2, 2), true})
+}
+
 func TestAutoscalerStableModeIncreaseWithRPS(t *testing.T) {
 	metrics := &metricClient{StableRPS: 50.0, PanicRPS: 50}
 	a, _, _ := newTestAutoscalerWithScalingMetric(10, 101, metrics, "rps", false /*startInPanic*/)
@@ -415,6 +490,127 @@ func TestAutoscalerStableModeIncreaseWithRPS(t *testing.T) {
 	expectScale(t, a, time.Now(), ScaleResult{10, expectedEBC(10, 101, 99, 1), true})
 }
 
+func TestAutoscalerStableModeIncreaseWithCustom(t *testing.T) {
+	metrics := &metricClient{StableCustom: 50.0, PanicCustom: 50, StableConcurrency: 1000}
+	a, _, _ := newTestAutoscalerWithScalingMetric(10, 101, metrics, "custom", false /*startInPanic*/)
+	expectScale(t, a, time.Now(), ScaleResult{5, expectedEBC(10, 101, 50, 1), true})
+
+	metrics.StableCustom = 100
+	metrics.PanicCustom = 99
+	expectScale(t, a, time.Now(), ScaleResult{10, expectedEBC(10, 101, 99, 1), true})
+}
+
+func TestAutoscalerCustomActivation(t *testing.T) {
+	tests := []struct {
+		name       string
+		metrics    *metricClient
+		readyCount int
+		want       ScaleResult
+	}{{
+		name: "no pods, activator load",
+		metrics: &metricClient{
+			StableConcurrency: 3,
+			PanicConcurrency:  5,
+			CustomErr:         metrics.ErrNoData,
+		},
+		want: ScaleResult{1, expectedEBC(10, 101, 10, 0), true},
+	}, {
+		name: "no pods, no load",
+		metrics: &metricClient{
+			CustomErr: metrics.ErrNoData,
+		},
+		want: ScaleResult{0, expectedEBC(10, 101, 0, 0), true},
+	}, {
+		name: "no pods, no activator data",
+		metrics: &metricClient{
+			CustomErr: metrics.ErrNoData,
+			ErrF: func(types.NamespacedName, time.Time) error {
+				return metrics.ErrNoData
+			},
+		},
+		want: invalidSR,
+	}, {
+		name: "no data from ready pods",
+		metrics: &metricClient{
+			StableConcurrency: 3,
+			PanicConcurrency:  5,
+			CustomErr:         metrics.ErrNoData,
+		},
+		readyCount: 1,
+		want:       invalidSR,
+	}, {
+		name: "custom data without pods",
+		metrics: &metricClient{
+			StableCustom:      20,
+			PanicCustom:       20,
+			StableConcurrency: 3,
+			PanicConcurrency:  5,
+		},
+		want: ScaleResult{2, expectedEBC(10, 101, 20, 0), true},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a, pc, _ := newTestAutoscalerWithScalingMetric(10, 101, test.metrics, "custom", false /*startInPanic*/)
+			pc.readyCount = test.readyCount
+			expectScale(t, a, time.Now(), test.want)
+		})
+	}
+}
+
+func TestAutoscalerLatency(t *testing.T) {
+	mc := &metricClient{
+		StableConcurrency: 5,
+		PanicConcurrency:  5,
+		StableLatency:     400 * time.Millisecond,
+		PanicLatency:      300 * time.Millisecond,
+	}
+	a, pc, _ := newTestAutoscalerWithScalingMetric(200, 101, mc, "latency", false /*startInPanic*/)
+	pc.readyCount = 4
+
+	// p95 is twice the target: double the pods. The capacity of the pods
+	// is unknown, so the activator stays in the path.
+	expectScale(t, a, time.Now(), ScaleResult{8, -1, true})
+
+	// Within the headroom: keep the pods.
+	mc.StableLatency = 180 * time.Millisecond
+	expectScale(t, a, time.Now(), ScaleResult{4, -1, true})
+
+	// Below the headroom: scale down to use it.
+	mc.StableLatency = 70 * time.Millisecond
+	expectScale(t, a, time.Now(), ScaleResult{2, -1, true})
+
+	// Requests in flight but none completed: keep the pods.
+	mc.LatencyErr = metrics.ErrNoData
+	expectScale(t, a, time.Now(), ScaleResult{4, -1, true})
+
+	// No traffic at all.
+	mc.StableConcurrency, mc.PanicConcurrency = 0, 0
+	expectScale(t, a, time.Now(), ScaleResult{0, -1, true})
+}
+
+func TestAutoscalerLatencyPanic(t *testing.T) {
+	mc := &metricClient{
+		StableConcurrency: 5,
+		PanicConcurrency:  5,
+		StableLatency:     200 * time.Millisecond,
+		PanicLatency:      900 * time.Millisecond,
+	}
+	a, pc, _ := newTestAutoscalerWithScalingMetric(200, 0, mc, "latency", false /*startInPanic*/)
+	pc.readyCount = 4
+
+	// The panic window is over twice the target.
+	now := time.Now()
+	expectScale(t, a, now, ScaleResult{18, 0, true})
+	if a.panicTime != now {
+		t.Errorf("PanicTime = %v, want: %v", a.panicTime, now)
+	}
+
+	// No scaling down while panicking.
+	mc.PanicLatency = 200 * time.Millisecond
+	expectScale(t, a, now.Add(time.Second), ScaleResult{18, 0, true})
+}
+
 func TestAutoscalerUnpanicAfterSlowIncrease(t *testing.T) {
 	// Do initial jump from 10 to 25 pods.
 	metrics := &metricClient{StableConcurrency: 11, PanicConcurrency: 25}
@@ -503,6 +699,68 @@ func TestAutoscalerActivationScale(t *testing.T) {
 	expectScale(t, a, time.Now(), ScaleResult{2, expectedEBC(10, 75, 0, 1), true})
 }
 
+func TestAutoscalerMinScaleSchedule(t *testing.T) {
+	mc := &metricClient{StableConcurrency: 0, PanicConcurrency: 0}
+	a := newTestAutoscalerNoPC(10, 75, mc)
+	a.deciderSpec.MinScaleSchedule = "0 8 * * * 1h 3"
+
+	morning := time.Date(2026, time.October, 16, 8, 30, 0, 0, time.UTC)
+	expectScale(t, a, morning, ScaleResult{3, expectedEBC(10, 75, 0, 1), true})
+
+	// Scheduled pre-scaling does not need data to go on.
+	mc.ErrF = func(types.NamespacedName, time.Time) error {
+		return metrics.ErrNoData
+	}
+	expectScale(t, a, morning, ScaleResult{3, -1, true})
+
+	// Nor are unreachable revisions pre-scaled, with or without data.
+	a.deciderSpec.Reachable = false
+	expectScale(t, a, morning, invalidSR)
+	mc.ErrF = nil
+	expectScale(t, a, morning, ScaleResult{0, expectedEBC(10, 75, 0, 1), true})
+	a.deciderSpec.Reachable = true
+
+	expectScale(t, a, morning.Add(time.Hour), ScaleResult{0, expectedEBC(10, 75, 0, 1), true})
+}
+
+func TestAutoscalerPredictiveScaling(t *testing.T) {
+	metrics := &metricClient{StableConcurrency: 50, PanicConcurrency: 50}
+	a := newTestAutoscalerNoPC(10, 75, metrics)
+	a.deciderSpec.PredictiveSeason = 24 * time.Hour
+
+	yesterday := time.Date(2026, time.October, 15, 10, 0, 0, 0, time.UTC)
+	expectScale(t, a, yesterday, ScaleResult{5, expectedEBC(10, 75, 50, 1), true})
+
+	metrics.SetStableAndPanicConcurrency(0, 0)
+	today := yesterday.Add(24 * time.Hour)
+
+	// The history carries over to the autoscaler restored from a snapshot.
+	snap := a.Snapshot(today)
+	if got, want := snap.History, []HistorySample{{Time: yesterday, Pods: 5}}; !cmp.Equal(got, want) {
+		t.Error("History mismatch (-want,+got):", cmp.Diff(want, got))
+	}
+	b := newTestAutoscalerNoPC(10, 75, metrics)
+	b.deciderSpec.PredictiveSeason = 24 * time.Hour
+	b.Restore(snap, today)
+	if got, want := b.Snapshot(today).History, snap.History; !cmp.Equal(got, want) {
+		t.Error("History mismatch (-want,+got):", cmp.Diff(want, got))
+	}
+	expectScale(t, b, today.Add(-10*time.Minute), ScaleResult{5, expectedEBC(10, 75, 0, 1), true})
+
+	expectScale(t, a, today.Add(-20*time.Minute), ScaleResult{0, expectedEBC(10, 75, 0, 1), true})
+	// The forecast covers the two slots after the current one.
+	expectScale(t, a, today.Add(-10*time.Minute), ScaleResult{5, expectedEBC(10, 75, 0, 1), true})
+	expectScale(t, a, today.Add(4*time.Minute), ScaleResult{5, expectedEBC(10, 75, 0, 1), true})
+	expectScale(t, a, today.Add(5*time.Minute), ScaleResult{0, expectedEBC(10, 75, 0, 1), true})
+
+	// Turning the forecast off forgets the history.
+	a.deciderSpec.PredictiveSeason = 0
+	expectScale(t, a, today, ScaleResult{0, expectedEBC(10, 75, 0, 1), true})
+	if a.history != nil {
+		t.Error("History was not dropped with the predictive scaling turned off")
+	}
+}
+
 // QPS is increasing exponentially. Each scaling event bring concurrency
 // back to the target level (1.0) but then traffic continues to increase.
 // At 1296 QPS traffic stabilizes.
@@ -885,6 +1143,17 @@ type metricClient struct {
 	PanicConcurrency  float64
 	StableRPS         float64
 	PanicRPS          float64
+	StableCustom      float64
+	PanicCustom       float64
+	CustomErr         error
+	StableQueueDepth  float64
+	PanicQueueDepth   float64
+	StableErrors      float64
+	PanicErrors       float64
+	StableLatency     time.Duration
+	PanicLatency      time.Duration
+	LatencyErr        error
+	Limit             float64
 	ErrF              func(key types.NamespacedName, now time.Time) error
 }
 
@@ -913,6 +1182,55 @@ func (mc *metricClient) StableAndPanicRPS(key types.NamespacedName, now time.Tim
 	return mc.StableRPS, mc.PanicRPS, err
 }
 
+// StableAndPanicCustom returns stable/panic custom metric values stored in the
+// object and the result of Errf or CustomErr as the error.
+func (mc *metricClient) StableAndPanicCustom(key types.NamespacedName, now time.Time) (float64, float64, error) {
+	err := mc.CustomErr
+	if mc.ErrF != nil {
+		err = mc.ErrF(key, now)
+	}
+	return mc.StableCustom, mc.PanicCustom, err
+}
+
+// StableAndPanicQueueDepth returns stable/panic queue depth stored in the
+// object and the result of Errf as the error.
+func (mc *metricClient) StableAndPanicQueueDepth(key types.NamespacedName, now time.Time) (float64, float64, error) {
+	var err error
+	if mc.ErrF != nil {
+		err = mc.ErrF(key, now)
+	}
+	return mc.StableQueueDepth, mc.PanicQueueDepth, err
+}
+
+// StableAndPanicServerErrors returns stable/panic 5xx rates stored in the
+// object and the result of Errf as the error.
+func (mc *metricClient) StableAndPanicServerErrors(key types.NamespacedName, now time.Time) (float64, float64, error) {
+	var err error
+	if mc.ErrF != nil {
+		err = mc.ErrF(key, now)
+	}
+	return mc.StableErrors, mc.PanicErrors, err
+}
+
+// StableAndPanicLatency returns stable/panic latencies stored in the object,
+// regardless of the quantile, and the result of Errf or LatencyErr as the error.
+func (mc *metricClient) StableAndPanicLatency(key types.NamespacedName, now time.Time, _ float64) (time.Duration, time.Duration, error) {
+	err := mc.LatencyErr
+	if mc.ErrF != nil {
+		err = mc.ErrF(key, now)
+	}
+	return mc.StableLatency, mc.PanicLatency, err
+}
+
+// ConcurrencyLimit returns the limit stored in the object, or ErrNoData
+// if there is none.
+func (mc *metricClient) ConcurrencyLimit(types.NamespacedName, time.Time) (float64, error) {
+	if mc.Limit == 0 {
+		return 0, metrics.ErrNoData
+	}
+	return mc.Limit, nil
+}
+
 func BenchmarkAutoscaler(b *testing.B) {
 	metrics := &metricClient{StableConcurrency: 50.0, PanicConcurrency: 10}
 	a := newTestAutoscalerNoPC(10, 101, metrics)
@@ -940,9 +1258,24 @@ func panicMetric(panic bool) metricdata.Metrics {
 	}
 }
 
+func forecastMetric(pods int64) metricdata.Metrics {
+	return metricdata.Metrics{
+		Name:        "kn.revision.pods.forecast",
+		Description: "Number of pods the autoscaler forecasts the revision to need ahead of demand",
+		Unit:        "{item}",
+		Data: metricdata.Gauge[int64]{
+			DataPoints: []metricdata.DataPoint[int64]{{
+				Value:      pods,
+				Attributes: attribute.NewSet(attribute.String("foo", "bar")),
+			}},
+		},
+	}
+}
+
 func concurrencyDataPoints(attrs attribute.Set, isPanic bool, pods int64, ebc, stable, panic, target float64) []metricdata.Metrics {
 	return []metricdata.Metrics{
 		panicMetric(isPanic),
+		forecastMetric(0),
 		{
 			Name:        "kn.revision.pods.desired",
 			Description: "Number of pods the autoscaler wants to allocate",
@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	// forecastSlot is the granularity of the desired scale history.
+	forecastSlot = 5 * time.Minute
+	// forecastSlotsAhead is how many slots past the current one the forecast
+	// covers, so that pods are ready by the time the demand arrives.
+	forecastSlotsAhead = 2
+)
+
+// seasonalHistory is a seasonal-naive forecaster of the desired scale.
+// It remembers the largest desired scale in each slot over one season and
+// forecasts the coming slots to need what the same slots needed a season ago.
+type seasonalHistory struct {
+	season time.Duration
+	// slots is a ring of one season worth of slots, plus one, so that
+	// recording the current slot does not evict the slot a season ago,
+	// which the forecast for the current slot is based on.
+	slots []historySlot
+}
+
+type historySlot struct {
+	// index is the absolute number of the slot since the epoch; it tells
+	// current entries apart from ones left over from earlier seasons.
+	index int64
+	max   int32
+}
+
+func newSeasonalHistory(season time.Duration) *seasonalHistory {
+	return &seasonalHistory{
+		season: season,
+		slots:  make([]historySlot, season/forecastSlot+1),
+	}
+}
+
+func slotIndex(t time.Time) int64 {
+	return t.UnixNano() / int64(forecastSlot)
+}
+
+func (h *seasonalHistory) slot(idx int64) *historySlot {
+	return &h.slots[idx%int64(len(h.slots))]
+}
+
+// record remembers that pods were desired at now.
+func (h *seasonalHistory) record(now time.Time, pods int32) {
+	idx := slotIndex(now)
+	if s := h.slot(idx); s.index == idx {
+		s.max = max(s.max, pods)
+	} else {
+		*s = historySlot{index: idx, max: pods}
+	}
+}
+
+// forecast returns the largest desired scale recorded one season before the
+// current slot and the forecastSlotsAhead slots following it.
+func (h *seasonalHistory) forecast(now time.Time) int32 {
+	seasonSlots := int64(h.season / forecastSlot)
+	idx := slotIndex(now) - seasonSlots
+	var ret int32
+	for i := idx; i <= idx+forecastSlotsAhead; i++ {
+		if s := h.slot(i); s.index == i {
+			ret = max(ret, s.max)
+		}
+	}
+	return ret
+}
+
+// samples returns the recorded slots of the history, oldest first.
+func (h *seasonalHistory) samples() []HistorySample {
+	ret := make([]HistorySample, 0, len(h.slots))
+	for _, s := range h.slots {
+		if s.max > 0 {
+			ret = append(ret, HistorySample{
+				Time: time.Unix(0, s.index*int64(forecastSlot)).UTC(),
+				Pods: s.max,
+			})
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Time.Before(ret[j].Time)
+	})
+	return ret
+}
+
+// restore records the samples into the history.
+func (h *seasonalHistory) restore(samples []HistorySample) {
+	for _, s := range samples {
+		h.record(s.Time, s.Pods)
+	}
+}
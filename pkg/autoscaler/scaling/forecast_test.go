@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaling
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSeasonalHistory(t *testing.T) {
+	h := newSeasonalHistory(time.Hour)
+	start := time.Date(2026, time.October, 16, 9, 0, 0, 0, time.UTC)
+
+	if got := h.forecast(start); got != 0 {
+		t.Errorf("forecast without history = %d, want: 0", got)
+	}
+
+	h.record(start, 3)
+	h.record(start.Add(time.Minute), 7)
+	h.record(start.Add(2*time.Minute), 2)
+	h.record(start.Add(20*time.Minute), 4)
+
+	for _, tc := range []struct {
+		name string
+		at   time.Duration
+		want int32
+	}{{
+		name: "two slots before",
+		at:   time.Hour - 2*forecastSlot,
+		want: 7,
+	}, {
+		name: "three slots before",
+		at:   time.Hour - 3*forecastSlot,
+		want: 0,
+	}, {
+		name: "same slot",
+		at:   time.Hour + forecastSlot - time.Second,
+		want: 7,
+	}, {
+		name: "slot after",
+		at:   time.Hour + 2*forecastSlot,
+		want: 4,
+	}, {
+		name: "later slot",
+		at:   time.Hour + 20*time.Minute,
+		want: 4,
+	}, {
+		name: "two seasons on",
+		at:   2 * time.Hour,
+		want: 0,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := h.forecast(start.Add(tc.at)); got != tc.want {
+				t.Errorf("forecast = %d, want: %d", got, tc.want)
+			}
+		})
+	}
+
+	// Recording the current slot keeps the one a season ago around.
+	h.record(start.Add(time.Hour), 1)
+	if got, want := h.forecast(start.Add(time.Hour)), int32(7); got != want {
+		t.Errorf("forecast = %d, want: %d", got, want)
+	}
+	// But a season later it replaces it.
+	if got, want := h.forecast(start.Add(2*time.Hour)), int32(1); got != want {
+		t.Errorf("forecast = %d, want: %d", got, want)
+	}
+}
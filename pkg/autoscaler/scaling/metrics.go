@@ -34,6 +34,7 @@ type scalingMetrics struct {
 	desiredPods         metric.Int64ObservableGauge
 	excessBurstCapacity metric.Float64ObservableGauge
 	panicMode           metric.Int64ObservableGauge
+	forecastPods        metric.Int64ObservableGauge
 
 	panicMetric  metric.Float64ObservableGauge
 	stableMetric metric.Float64ObservableGauge
@@ -46,6 +47,7 @@ type scalingMetrics struct {
 	desiredPodsValue         int64
 	excessBurstCapacityValue float64
 	panicModeValue           int64
+	forecastPodsValue        int64
 }
 
 func (m *scalingMetrics) OnDelete() {
@@ -62,6 +64,7 @@ func (m *scalingMetrics) callback(ctx context.Context, o metric.Observer) error
 	o.ObserveInt64(m.desiredPods, m.desiredPodsValue, opt)
 	o.ObserveFloat64(m.excessBurstCapacity, m.excessBurstCapacityValue, opt)
 	o.ObserveInt64(m.panicMode, m.panicModeValue, opt)
+	o.ObserveInt64(m.forecastPods, m.forecastPodsValue, opt)
 
 	o.ObserveFloat64(m.panicMetric, m.panicValue, opt)
 	o.ObserveFloat64(m.stableMetric, m.stableValue, opt)
@@ -99,6 +102,12 @@ func newMetrics(mp metric.MeterProvider, scalingMetric string, attrs attribute.S
 		metric.WithDescription("If greater than 0 the autoscaler is in panic mode"),
 	))
 
+	m.forecastPods = must(meter.Int64ObservableGauge(
+		"kn.revision.pods.forecast",
+		metric.WithDescription("Number of pods the autoscaler forecasts the revision to need ahead of demand"),
+		metric.WithUnit("{item}"),
+	))
+
 	switch scalingMetric {
 	case autoscaling.RPS:
 		m.stableMetric = must(meter.Float64ObservableGauge(
@@ -116,6 +125,35 @@ func newMetrics(mp metric.MeterProvider, scalingMetric string, attrs attribute.S
 			metric.WithDescription("The desired concurrent requests for each pod"),
 			metric.WithUnit("{request}/s"),
 		))
+	case autoscaling.Custom:
+		m.stableMetric = must(meter.Float64ObservableGauge(
+			"kn.revision.custom.stable",
+			metric.WithDescription("Sum of the custom metric over the pods of the revision, averaged over the stable window"),
+		))
+		m.panicMetric = must(meter.Float64ObservableGauge(
+			"kn.revision.custom.panic",
+			metric.WithDescription("Sum of the custom metric over the pods of the revision, averaged over the panic window"),
+		))
+		m.targetMetric = must(meter.Float64ObservableGauge(
+			"kn.revision.custom.target",
+			metric.WithDescription("The desired custom metric value for each pod"),
+		))
+	case autoscaling.Latency:
+		m.stableMetric = must(meter.Float64ObservableGauge(
+			"kn.revision.latency.stable",
+			metric.WithDescription("Percentile of the request latencies over the stable window"),
+			metric.WithUnit("ms"),
+		))
+		m.panicMetric = must(meter.Float64ObservableGauge(
+			"kn.revision.latency.panic",
+			metric.WithDescription("Percentile of the request latencies over the panic window"),
+			metric.WithUnit("ms"),
+		))
+		m.targetMetric = must(meter.Float64ObservableGauge(
+			"kn.revision.latency.target",
+			metric.WithDescription("The desired percentile of the request latencies"),
+			metric.WithUnit("ms"),
+		))
 	default:
 		m.stableMetric = must(meter.Float64ObservableGauge(
 			"kn.revision.concurrency.stable",
@@ -138,6 +176,7 @@ func newMetrics(mp metric.MeterProvider, scalingMetric string, attrs attribute.S
 		m.desiredPods,
 		m.excessBurstCapacity,
 		m.panicMode,
+		m.forecastPods,
 		m.stableMetric,
 		m.panicMetric,
 		m.targetMetric,
@@ -159,6 +198,14 @@ func (m *scalingMetrics) SetPanic(panic bool) {
 	m.panicModeValue = val
 }
 
+func (m *scalingMetrics) SetForecast(pods int64) {
+	if m == nil {
+		return
+	}
+
+	m.forecastPodsValue = pods
+}
+
 func (m *scalingMetrics) Record(
 	excessBurstCapacity float64,
 	desiredPods int64,
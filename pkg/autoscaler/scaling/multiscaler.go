@@ -28,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"knative.dev/pkg/logging/logkey"
 	autoscalingv1alpha1 "knative.dev/serving/pkg/apis/autoscaling/v1alpha1"
+	"knative.dev/serving/pkg/autoscaler/aggregation/max"
 	"knative.dev/serving/pkg/autoscaler/metrics"
 )
 
@@ -82,6 +83,15 @@ type DeciderSpec struct {
 	// min-scale value while also preserving the ability to scale to zero.
 	// ActivationScale must be >= 2.
 	ActivationScale int32
+	// PredictiveSeason is the season over which the desired scale is remembered
+	// and forecast. Zero disables predictive pre-scaling.
+	PredictiveSeason time.Duration
+	// MinScaleSchedule is the unparsed min-scale schedule of the revision, if any.
+	MinScaleSchedule string
+	// LatencyPercentile is the percentile of the request latencies, as a
+	// fraction, that is kept under TargetValue milliseconds when the scaling
+	// metric is latency.
+	LatencyPercentile float64
 }
 
 // DeciderStatus is the current scale recommendation.
@@ -126,6 +136,36 @@ type UniScaler interface {
 	OnDelete()
 }
 
+// ScalerSnapshot is a point in time copy of the state a UniScaler has built
+// up over time, which allows another one to resume from it.
+type ScalerSnapshot struct {
+	// Time is when the snapshot was taken.
+	Time time.Time `json:"time,omitempty"`
+	// PanicTime is when the panic mode started, zero if not panicking.
+	PanicTime time.Time `json:"panicTime,omitempty"`
+	// MaxPanicPods is the largest scale recommended during the panic mode.
+	MaxPanicPods int32 `json:"maxPanicPods,omitempty"`
+	// DelayWindow holds the samples of the scale-down delay window.
+	DelayWindow []max.Sample `json:"delayWindow,omitempty"`
+	// History holds the desired scale recorded for the predictive
+	// pre-scaling, oldest first.
+	History []HistorySample `json:"history,omitempty"`
+}
+
+// HistorySample is the largest desired scale recorded in a slot of the
+// predictive pre-scaling history, which starts at Time.
+type HistorySample struct {
+	Time time.Time `json:"time"`
+	Pods int32     `json:"pods"`
+}
+
+// snapshotter is implemented by the UniScalers that support resuming from
+// a ScalerSnapshot.
+type snapshotter interface {
+	Snapshot(now time.Time) *ScalerSnapshot
+	Restore(snap *ScalerSnapshot, now time.Time)
+}
+
 // UniScalerFactory creates a UniScaler for a given PA using the given dynamic configuration.
 type UniScalerFactory func(*Decider) (UniScaler, error)
 
@@ -266,6 +306,33 @@ func (m *MultiScaler) Delete(_ context.Context, namespace, name string) {
 	}
 }
 
+// Snapshot returns a copy of the state of the given Decider's scaler, or
+// nil if there is no such Decider or its scaler does not support snapshots.
+func (m *MultiScaler) Snapshot(key types.NamespacedName, now time.Time) *ScalerSnapshot {
+	m.scalersMutex.RLock()
+	defer m.scalersMutex.RUnlock()
+	if scaler, exists := m.scalers[key]; exists {
+		if s, ok := scaler.scaler.(snapshotter); ok {
+			return s.Snapshot(now)
+		}
+	}
+	return nil
+}
+
+// Restore resumes the given Decider's scaler from the snapshot. It returns
+// false if there is no such Decider or its scaler does not support snapshots.
+func (m *MultiScaler) Restore(key types.NamespacedName, snap *ScalerSnapshot, now time.Time) bool {
+	m.scalersMutex.RLock()
+	defer m.scalersMutex.RUnlock()
+	if scaler, exists := m.scalers[key]; exists {
+		if s, ok := scaler.scaler.(snapshotter); ok {
+			s.Restore(snap, now)
+			return true
+		}
+	}
+	return false
+}
+
 // Watch registers a singleton function to call when DeciderStatus is updated.
 func (m *MultiScaler) Watch(fn func(types.NamespacedName)) {
 	m.watcherMutex.Lock()
@@ -365,6 +365,34 @@ func TestMultiScalerUpdate(t *testing.T) {
 	ms.Delete(ctx, decider.Namespace, decider.Name)
 }
 
+func TestMultiScalerSnapshotRestore(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ms, _ := createMultiScaler(ctx, TestLogger(t))
+
+	decider := newDecider()
+	key := types.NamespacedName{Namespace: decider.Namespace, Name: decider.Name}
+	snap := &ScalerSnapshot{MaxPanicPods: 42}
+
+	if got := ms.Snapshot(key, time.Time{}); got != nil {
+		t.Errorf("Snapshot() = %v, want: nil", got)
+	}
+	if ms.Restore(key, snap, time.Time{}) {
+		t.Error("Restore() = true for a missing decider")
+	}
+
+	if _, err := ms.Create(ctx, decider); err != nil {
+		t.Fatal("Create() =", err)
+	}
+	defer ms.Delete(ctx, decider.Namespace, decider.Name)
+	if !ms.Restore(key, snap, time.Time{}) {
+		t.Error("Restore() = false")
+	}
+	if got := ms.Snapshot(key, time.Time{}); got != snap {
+		t.Errorf("Snapshot() = %v, want: %v", got, snap)
+	}
+}
+
 func createMultiScaler(ctx context.Context, l *zap.SugaredLogger) (*MultiScaler, *fakeUniScaler) {
 	uniscaler := &fakeUniScaler{}
 	ms := NewMultiScaler(ctx.Done(), uniscaler.fakeUniScalerFactory, l)
@@ -377,6 +405,7 @@ type fakeUniScaler struct {
 	surplus    int32
 	scaled     bool
 	scaleCount int
+	snapshot   *ScalerSnapshot
 }
 
 func (u *fakeUniScaler) fakeUniScalerFactory(*Decider) (UniScaler, error) {
@@ -402,6 +431,18 @@ func (u *fakeUniScaler) setScaleResult(replicas, surplus int32, scaled bool) {
 func (u *fakeUniScaler) Update(*DeciderSpec) {}
 func (u *fakeUniScaler) OnDelete()           {}
 
+func (u *fakeUniScaler) Snapshot(time.Time) *ScalerSnapshot {
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
+	return u.snapshot
+}
+
+func (u *fakeUniScaler) Restore(snap *ScalerSnapshot, _ time.Time) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+	u.snapshot = snap
+}
+
 func newDecider() *Decider {
 	return &Decider{
 		ObjectMeta: metav1.ObjectMeta{
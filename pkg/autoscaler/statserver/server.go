@@ -189,6 +189,11 @@ func (s *Server) Handler(w http.ResponseWriter, r *http.Request) {
 					// To allow for future protobuf schema changes.
 					continue
 				}
+				if !wsm.Stat.Latency.Valid() {
+					// Drop latencies bucketed with a scheme we don't know,
+					// but keep the rest of the stat.
+					wsm.Stat.Latency = nil
+				}
 
 				sm := wsm.ToStatMessage()
 				s.logger.Debugf("Received stat message: %+v", sm)
@@ -108,6 +108,43 @@ func TestStatsReceived(t *testing.T) {
 	closeSink(t, statSink)
 }
 
+func TestStatsReceivedWithLatencies(t *testing.T) {
+	statsCh := make(chan metrics.StatMessage)
+	server := newTestServer(statsCh)
+
+	defer server.Shutdown(0)
+	go server.listenAndServe()
+
+	statSink := dialOK(t, server.listenAddr())
+
+	rich := metrics.StatMessage{
+		Key: msg1.Key,
+		Stat: metrics.Stat{
+			PodName:          "pod1",
+			RequestCount:     10,
+			QueueDepth:       3,
+			ServerErrorCount: 1,
+			Latency:          &metrics.LatencySketch{Counts: []uint64{1, 0, 4}},
+		},
+	}
+	assertReceivedProto(t, []metrics.StatMessage{rich}, statSink, statsCh)
+
+	// A sketch with more buckets than we know of is dropped, the rest of the
+	// stat is kept.
+	invalid := rich
+	invalid.Stat.Latency = &metrics.LatencySketch{Counts: make([]uint64, metrics.MaxLatencySketchBuckets+1)}
+	if err := sendProto(statSink, []metrics.StatMessage{invalid}); err != nil {
+		t.Fatal("Expected send to succeed, got:", err)
+	}
+	want := rich
+	want.Stat.Latency = nil
+	if got := <-statsCh; !cmp.Equal(got, want) {
+		t.Error("StatMessage mismatch: diff (-got, +want)", cmp.Diff(got, want))
+	}
+
+	closeSink(t, statSink)
+}
+
 func TestServerShutdown(t *testing.T) {
 	statsCh := make(chan metrics.StatMessage)
 	server := newTestServer(statsCh)
@@ -17,6 +17,8 @@ limitations under the License.
 package deployment
 
 import (
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"strings"
@@ -60,6 +62,27 @@ const (
 	// (e.g. ko.local) where tags should not be resolved to digests.
 	registriesSkippingTagResolvingKey = "registries-skipping-tag-resolving"
 
+	// allowedImageDigestsKey is the config map key for the set of digests the
+	// images of the revisions may be resolved to.
+	allowedImageDigestsKey = "allowed-image-digests"
+
+	// imageSignatureKeysSecretKey is the config map key for the name of the
+	// secret holding the public keys the images of the revisions must be
+	// signed with.
+	imageSignatureKeysSecretKey = "image-signature-keys-secret"
+
+	// imageSignaturePublicKeysKey is the config map key for the PEM encoded
+	// public keys the images of the revisions may be signed with.
+	imageSignaturePublicKeysKey = "image-signature-public-keys"
+
+	// imageSignatureNamespacesKey is the config map key for the namespaces
+	// where the signatures of the images are verified.
+	imageSignatureNamespacesKey = "image-signature-namespaces"
+
+	// digestReResolutionIntervalKey is the config map key for the interval at
+	// which the tags of the revisions are resolved again to detect drift.
+	digestReResolutionIntervalKey = "digest-re-resolution-interval"
+
 	// queueSidecar resource request keys.
 	queueSidecarCPURequestKey              = "queue-sidecar-cpu-request"
 	queueSidecarMemoryRequestKey           = "queue-sidecar-memory-request"
@@ -197,6 +220,11 @@ func NewConfigFromMap(configMap map[string]string) (*Config, error) {
 		cm.AsDuration(ProgressDeadlineKey, &nc.ProgressDeadline),
 		cm.AsDuration(digestResolutionTimeoutKey, &nc.DigestResolutionTimeout),
 		cm.AsStringSet(registriesSkippingTagResolvingKey, &nc.RegistriesSkippingTagResolving),
+		cm.AsStringSet(allowedImageDigestsKey, &nc.AllowedImageDigests),
+		cm.AsString(imageSignatureKeysSecretKey, &nc.ImageSignatureKeysSecret),
+		cm.AsString(imageSignaturePublicKeysKey, &nc.ImageSignaturePublicKeys),
+		cm.AsStringSet(imageSignatureNamespacesKey, &nc.ImageSignatureNamespaces),
+		cm.AsDuration(digestReResolutionIntervalKey, &nc.DigestReResolutionInterval),
 
 		cm.AsQuantity(queueSidecarCPURequestKey, &nc.QueueSidecarCPURequest),
 		cm.AsQuantity(queueSidecarMemoryRequestKey, &nc.QueueSidecarMemoryRequest),
@@ -236,6 +264,18 @@ func NewConfigFromMap(configMap map[string]string) (*Config, error) {
 		return nil, fmt.Errorf("digest-resolution-timeout cannot be a non-positive duration, was %v", nc.DigestResolutionTimeout)
 	}
 
+	// Tolerate empty lists.
+	nc.AllowedImageDigests.Delete("")
+	nc.ImageSignatureNamespaces.Delete("")
+
+	if err := validateImageSignatureConfig(nc); err != nil {
+		return nil, err
+	}
+
+	if nc.DigestReResolutionInterval < 0 {
+		return nil, fmt.Errorf("%s cannot be a negative duration, was %v", digestReResolutionIntervalKey, nc.DigestReResolutionInterval)
+	}
+
 	if affinity, ok := configMap[defaultAffinityTypeKey]; ok {
 		switch opt := AffinityType(affinity); opt {
 		case None, PreferSpreadRevisionOverNodes:
@@ -288,6 +328,29 @@ type Config struct {
 	// DigestResolutionTimeout is the maximum time allowed for image digest resolution.
 	DigestResolutionTimeout time.Duration
 
+	// AllowedImageDigests is the set of digests the images may be resolved to,
+	// e.g. "sha256:...", or any digest if empty.
+	AllowedImageDigests sets.Set[string]
+
+	// ImageSignatureKeysSecret is the name of the secret in the system namespace
+	// holding the PEM encoded public keys, one of which must have signed the
+	// resolved digests, or empty to not verify the signatures.
+	ImageSignatureKeysSecret string
+
+	// ImageSignaturePublicKeys holds PEM encoded public keys, one of which may
+	// have signed the resolved digests, on top of the ones of
+	// ImageSignatureKeysSecret.
+	ImageSignaturePublicKeys string
+
+	// ImageSignatureNamespaces is the set of namespaces where the signatures
+	// are verified, or all of them if empty.
+	ImageSignatureNamespaces sets.Set[string]
+
+	// DigestReResolutionInterval is the interval at which the tags of the images
+	// of the routed revisions are resolved again to detect a drift from the
+	// resolved digests, or zero to resolve them only once.
+	DigestReResolutionInterval time.Duration
+
 	// ProgressDeadline is the time in seconds we wait for the deployment to
 	// be ready before considering it failed.
 	ProgressDeadline time.Duration
@@ -341,3 +404,26 @@ type Config struct {
 	// PodIsAlwaysSchedulable specifies whether pods are considered to be always schedulable
 	PodIsAlwaysSchedulable bool
 }
+
+// VerifiesImageSignatures returns whether the signatures of the images of the
+// revisions in the namespace are verified.
+func (d Config) VerifiesImageSignatures(namespace string) bool {
+	if d.ImageSignatureKeysSecret == "" && d.ImageSignaturePublicKeys == "" {
+		return false
+	}
+	return len(d.ImageSignatureNamespaces) == 0 || d.ImageSignatureNamespaces.Has(namespace)
+}
+
+// validateImageSignatureConfig validates the public keys the signatures of
+// the images are verified with.
+func validateImageSignatureConfig(c *Config) error {
+	for rest := []byte(c.ImageSignaturePublicKeys); ; {
+		var block *pem.Block
+		if block, rest = pem.Decode(rest); block == nil {
+			return nil
+		}
+		if _, err := x509.ParsePKIXPublicKey(block.Bytes); err != nil {
+			return fmt.Errorf("%s holds an invalid public key: %w", imageSignaturePublicKeysKey, err)
+		}
+	}
+}
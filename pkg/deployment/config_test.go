@@ -40,6 +40,11 @@ import (
 
 const defaultSidecarImage = "defaultImage"
 
+const testSignaturePublicKey = `-----BEGIN PUBLIC KEY-----
+MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEfAuTAICVxrKzE+O4fBd/YusG1gaL
++2gvBkGGYSiXQWEtWSOU/021AtKJwxuqbNPZyjmVR9tGIkXWBH6UxcI6/g==
+-----END PUBLIC KEY-----`
+
 func TestMatchingExceptions(t *testing.T) {
 	cfg := defaultConfig()
 
@@ -494,6 +499,58 @@ kata:
 			queueSidecarTLSCipherSuitesKey:     "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
 			queueSidecarTLSCurvePreferencesKey: "X25519,CurveP256",
 		},
+	}, {
+		name: "controller configuration with image policy",
+		wantConfig: &Config{
+			RegistriesSkippingTagResolving: sets.New("kind.local", "ko.local", "dev.local"),
+			DigestResolutionTimeout:        digestResolutionTimeoutDefault,
+			AllowedImageDigests:            sets.New("sha256:1a2b", "sha256:3c4d"),
+			ImageSignatureKeysSecret:       "cosign-keys",
+			DigestReResolutionInterval:     time.Hour,
+			QueueSidecarImage:              defaultSidecarImage,
+			QueueSidecarCPURequest:         &QueueSidecarCPURequestDefault,
+			QueueSidecarTokenAudiences:     sets.New(""),
+			ProgressDeadline:               ProgressDeadlineDefault,
+			DefaultAffinityType:            defaultAffinityTypeValue,
+		},
+		data: map[string]string{
+			QueueSidecarImageKey:          defaultSidecarImage,
+			allowedImageDigestsKey:        "sha256:1a2b, sha256:3c4d,",
+			imageSignatureKeysSecretKey:   "cosign-keys",
+			digestReResolutionIntervalKey: "1h",
+		},
+	}, {
+		name: "controller configuration with image signature verification",
+		wantConfig: &Config{
+			RegistriesSkippingTagResolving: sets.New("kind.local", "ko.local", "dev.local"),
+			DigestResolutionTimeout:        digestResolutionTimeoutDefault,
+			ImageSignaturePublicKeys:       testSignaturePublicKey,
+			ImageSignatureNamespaces:       sets.New("prod", "staging"),
+			QueueSidecarImage:              defaultSidecarImage,
+			QueueSidecarCPURequest:         &QueueSidecarCPURequestDefault,
+			QueueSidecarTokenAudiences:     sets.New(""),
+			ProgressDeadline:               ProgressDeadlineDefault,
+			DefaultAffinityType:            defaultAffinityTypeValue,
+		},
+		data: map[string]string{
+			QueueSidecarImageKey:        defaultSidecarImage,
+			imageSignaturePublicKeysKey: testSignaturePublicKey,
+			imageSignatureNamespacesKey: "prod,staging",
+		},
+	}, {
+		name:    "controller configuration with invalid image signature public keys",
+		wantErr: true,
+		data: map[string]string{
+			QueueSidecarImageKey:        defaultSidecarImage,
+			imageSignaturePublicKeysKey: "-----BEGIN PUBLIC KEY-----\nZm9v\n-----END PUBLIC KEY-----\n",
+		},
+	}, {
+		name:    "controller configuration with negative re-resolution interval",
+		wantErr: true,
+		data: map[string]string{
+			QueueSidecarImageKey:          defaultSidecarImage,
+			digestReResolutionIntervalKey: "-1h",
+		},
 	}}
 
 	for _, tt := range configTests {
@@ -646,3 +703,42 @@ func TestPodRuntimeClassName(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifiesImageSignatures(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       Config
+		namespace string
+		want      bool
+	}{{
+		name:      "not configured",
+		namespace: "foo",
+	}, {
+		name:      "keys secret",
+		cfg:       Config{ImageSignatureKeysSecret: "keys"},
+		namespace: "foo",
+		want:      true,
+	}, {
+		name:      "public keys",
+		cfg:       Config{ImageSignaturePublicKeys: testSignaturePublicKey},
+		namespace: "foo",
+		want:      true,
+	}, {
+		name:      "enforced namespace",
+		cfg:       Config{ImageSignaturePublicKeys: testSignaturePublicKey, ImageSignatureNamespaces: sets.New("foo")},
+		namespace: "foo",
+		want:      true,
+	}, {
+		name:      "other namespace",
+		cfg:       Config{ImageSignaturePublicKeys: testSignaturePublicKey, ImageSignatureNamespaces: sets.New("foo")},
+		namespace: "bar",
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cfg.VerifiesImageSignatures(tt.namespace); got != tt.want {
+				t.Errorf("VerifiesImageSignatures(%q) = %v, want %v", tt.namespace, got, tt.want)
+			}
+		})
+	}
+}
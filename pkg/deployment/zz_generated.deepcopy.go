@@ -35,6 +35,20 @@ func (in *Config) DeepCopyInto(out *Config) {
 			(*out)[key] = val
 		}
 	}
+	if in.AllowedImageDigests != nil {
+		in, out := &in.AllowedImageDigests, &out.AllowedImageDigests
+		*out = make(sets.Set[string], len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ImageSignatureNamespaces != nil {
+		in, out := &in.ImageSignatureNamespaces, &out.ImageSignatureNamespaces
+		*out = make(sets.Set[string], len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.QueueSidecarCPURequest != nil {
 		in, out := &in.QueueSidecarCPURequest, &out.QueueSidecarCPURequest
 		x := (*in).DeepCopy()
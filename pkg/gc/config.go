@@ -27,6 +27,8 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	cm "knative.dev/pkg/configmap"
+	"knative.dev/serving/pkg/apis/serving"
+	"sigs.k8s.io/yaml"
 )
 
 const (
@@ -56,6 +58,38 @@ type Config struct {
 	// regardless of creation or staleness time-bounds.
 	// Set Disabled (-1) to disable/ignore max.
 	MaxNonActiveRevisions int64
+	// DryRun reports the revisions which would be collected, instead of
+	// deleting them.
+	DryRun bool
+	// Archive snapshots the revisions into an archive before deleting them,
+	// from which they can be restored.
+	Archive bool
+	// Maximum number of archived revisions to keep per Configuration, the
+	// oldest archives past it are deleted.
+	// Set Disabled (-1) to disable/ignore max.
+	MaxArchivedRevisions int64
+	// RetentionRules retain the non-active revisions matching their label
+	// selector, regardless of the settings above.
+	RetentionRules []RetentionRule
+}
+
+// RetentionRule retains the non-active revisions with the given labels.
+type RetentionRule struct {
+	// Selector holds the labels of the revisions the rule retains.
+	Selector map[string]string
+	// RetainSinceCreateTime retains the selected revisions created within
+	// this duration. Zero when unset.
+	RetainSinceCreateTime time.Duration
+	// KeepLast retains this number of the most recently active selected
+	// revisions. Zero when unset.
+	KeepLast int64
+}
+
+// retentionRule is the serialized form of a RetentionRule.
+type retentionRule struct {
+	Selector              map[string]string `json:"selector"`
+	RetainSinceCreateTime string            `json:"retain-since-create-time,omitempty"`
+	KeepLast              int64             `json:"keep-last,omitempty"`
 }
 
 func defaultConfig() *Config {
@@ -64,6 +98,7 @@ func defaultConfig() *Config {
 		RetainSinceLastActiveTime: 15 * time.Hour,
 		MinNonActiveRevisions:     20,
 		MaxNonActiveRevisions:     1000,
+		MaxArchivedRevisions:      20,
 	}
 }
 
@@ -72,12 +107,16 @@ func NewConfigFromConfigMapFunc(ctx context.Context) func(configMap *corev1.Conf
 	return func(configMap *corev1.ConfigMap) (*Config, error) {
 		c := defaultConfig()
 
-		var retainCreate, retainActive, max string
+		var retainCreate, retainActive, max, maxArchived, rules string
 		if err := cm.Parse(configMap.Data,
 			cm.AsString("retain-since-create-time", &retainCreate),
 			cm.AsString("retain-since-last-active-time", &retainActive),
 			cm.AsInt64("min-non-active-revisions", &c.MinNonActiveRevisions),
 			cm.AsString("max-non-active-revisions", &max),
+			cm.AsBool("dry-run", &c.DryRun),
+			cm.AsBool("archive", &c.Archive),
+			cm.AsString("max-archived-revisions", &maxArchived),
+			cm.AsString("retention-rules", &rules),
 		); err != nil {
 			return nil, fmt.Errorf("failed to parse data: %w", err)
 		}
@@ -92,16 +131,98 @@ func NewConfigFromConfigMapFunc(ctx context.Context) func(configMap *corev1.Conf
 		if err := parseDisabledOrInt64(max, &c.MaxNonActiveRevisions); err != nil {
 			return nil, fmt.Errorf("failed to parse max-non-active-revisions: %w", err)
 		}
-		if c.MinNonActiveRevisions < 0 {
-			return nil, fmt.Errorf("min-non-active-revisions must be non-negative, was: %d", c.MinNonActiveRevisions)
+		if err := parseDisabledOrInt64(maxArchived, &c.MaxArchivedRevisions); err != nil {
+			return nil, fmt.Errorf("failed to parse max-archived-revisions: %w", err)
+		}
+		if err := parseRetentionRules(rules, &c.RetentionRules); err != nil {
+			return nil, fmt.Errorf("failed to parse retention-rules: %w", err)
 		}
-		if c.MaxNonActiveRevisions >= 0 && c.MinNonActiveRevisions > c.MaxNonActiveRevisions {
-			return nil, fmt.Errorf("min-non-active-revisions(%d) must be <= max-non-active-revisions(%d)", c.MinNonActiveRevisions, c.MaxNonActiveRevisions)
+		if err := c.validate(); err != nil {
+			return nil, err
 		}
 		return c, nil
 	}
 }
 
+func (c *Config) validate() error {
+	if c.MinNonActiveRevisions < 0 {
+		return fmt.Errorf("min-non-active-revisions must be non-negative, was: %d", c.MinNonActiveRevisions)
+	}
+	if c.MaxNonActiveRevisions >= 0 && c.MinNonActiveRevisions > c.MaxNonActiveRevisions {
+		return fmt.Errorf("min-non-active-revisions(%d) must be <= max-non-active-revisions(%d)", c.MinNonActiveRevisions, c.MaxNonActiveRevisions)
+	}
+	return nil
+}
+
+// WithOverrides returns a copy of the config with the settings overridden
+// by the garbage collection annotations of a Configuration.
+func (c *Config) WithOverrides(annotations map[string]string) (*Config, error) {
+	ret := c.DeepCopy()
+	if _, v, ok := serving.GCRetainSinceCreateTimeAnnotation.Get(annotations); ok {
+		if err := parseDisabledOrDuration(v, &ret.RetainSinceCreateTime); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", serving.GCRetainSinceCreateTimeKey, err)
+		}
+	}
+	if _, v, ok := serving.GCRetainSinceLastActiveTimeAnnotation.Get(annotations); ok {
+		if err := parseDisabledOrDuration(v, &ret.RetainSinceLastActiveTime); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", serving.GCRetainSinceLastActiveTimeKey, err)
+		}
+	}
+	if _, v, ok := serving.GCMinNonActiveRevisionsAnnotation.Get(annotations); ok {
+		if err := parseDisabledOrInt64(v, &ret.MinNonActiveRevisions); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", serving.GCMinNonActiveRevisionsKey, err)
+		}
+	}
+	if _, v, ok := serving.GCMaxNonActiveRevisionsAnnotation.Get(annotations); ok {
+		if err := parseDisabledOrInt64(v, &ret.MaxNonActiveRevisions); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", serving.GCMaxNonActiveRevisionsKey, err)
+		}
+	}
+	if _, v, ok := serving.GCDryRunAnnotation.Get(annotations); ok {
+		ret.DryRun = strings.EqualFold(v, "true")
+	}
+	if err := ret.validate(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func parseRetentionRules(val string, toSet *[]RetentionRule) error {
+	if val == "" {
+		return nil
+	}
+	var rules []retentionRule
+	if err := yaml.UnmarshalStrict([]byte(val), &rules); err != nil {
+		return err
+	}
+	ret := make([]RetentionRule, 0, len(rules))
+	for i, r := range rules {
+		if len(r.Selector) == 0 {
+			return fmt.Errorf("rule %d: selector must not be empty", i)
+		}
+		rule := RetentionRule{
+			Selector: r.Selector,
+			KeepLast: r.KeepLast,
+		}
+		if r.RetainSinceCreateTime != "" {
+			d, err := time.ParseDuration(r.RetainSinceCreateTime)
+			if err != nil {
+				return fmt.Errorf("rule %d: failed to parse retain-since-create-time: %w", i, err)
+			}
+			rule.RetainSinceCreateTime = d
+		}
+		if rule.RetainSinceCreateTime < 0 || rule.KeepLast < 0 {
+			return fmt.Errorf("rule %d: retain-since-create-time and keep-last must be non-negative", i)
+		}
+		if rule.RetainSinceCreateTime == 0 && rule.KeepLast == 0 {
+			return fmt.Errorf("rule %d: one of retain-since-create-time or keep-last is required", i)
+		}
+		ret = append(ret, rule)
+	}
+	*toSet = ret
+	return nil
+}
+
 func parseDisabledOrInt64(val string, toSet *int64) error {
 	switch {
 	case val == "":
@@ -27,6 +27,7 @@ import (
 
 	. "knative.dev/pkg/configmap/testing"
 	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/serving/pkg/apis/serving"
 )
 
 func TestOurConfig(t *testing.T) {
@@ -53,6 +54,7 @@ func TestOurConfig(t *testing.T) {
 			RetainSinceLastActiveTime: 16 * time.Hour,
 			MinNonActiveRevisions:     5,
 			MaxNonActiveRevisions:     500,
+			MaxArchivedRevisions:      20,
 		},
 		data: map[string]string{
 			"retain-since-create-time":      "17h",
@@ -123,6 +125,104 @@ func TestOurConfig(t *testing.T) {
 		data: map[string]string{
 			"retain-since-last-active-time": disabled,
 		},
+	}, {
+		name: "dry-run",
+		want: func() *Config {
+			d := defaultConfig()
+			d.DryRun = true
+			return d
+		}(),
+		data: map[string]string{
+			"dry-run": "true",
+		},
+	}, {
+		name: "archive",
+		want: func() *Config {
+			d := defaultConfig()
+			d.Archive = true
+			return d
+		}(),
+		data: map[string]string{
+			"archive": "true",
+		},
+	}, {
+		name: "max archived revisions",
+		want: func() *Config {
+			d := defaultConfig()
+			d.MaxArchivedRevisions = 5
+			return d
+		}(),
+		data: map[string]string{
+			"max-archived-revisions": "5",
+		},
+	}, {
+		name: "max archived revisions disabled",
+		want: func() *Config {
+			d := defaultConfig()
+			d.MaxArchivedRevisions = Disabled
+			return d
+		}(),
+		data: map[string]string{
+			"max-archived-revisions": disabled,
+		},
+	}, {
+		name: "invalid negative max archived revisions",
+		fail: true,
+		data: map[string]string{
+			"max-archived-revisions": "-1",
+		},
+	}, {
+		name: "retention rules",
+		want: func() *Config {
+			d := defaultConfig()
+			d.RetentionRules = []RetentionRule{{
+				Selector:              map[string]string{"release": "true"},
+				RetainSinceCreateTime: 2160 * time.Hour,
+			}, {
+				Selector: map[string]string{"channel": "nightly"},
+				KeepLast: 3,
+			}}
+			return d
+		}(),
+		data: map[string]string{
+			"retention-rules": `
+- selector:
+    release: "true"
+  retain-since-create-time: 2160h
+- selector:
+    channel: nightly
+  keep-last: 3`,
+		},
+	}, {
+		name: "retention rule without selector",
+		fail: true,
+		data: map[string]string{
+			"retention-rules": "- keep-last: 3",
+		},
+	}, {
+		name: "retention rule without retention",
+		fail: true,
+		data: map[string]string{
+			"retention-rules": "- selector: {release: \"true\"}",
+		},
+	}, {
+		name: "retention rule with unparsable duration",
+		fail: true,
+		data: map[string]string{
+			"retention-rules": "- {selector: {release: \"true\"}, retain-since-create-time: forever}",
+		},
+	}, {
+		name: "retention rule with negative keep-last",
+		fail: true,
+		data: map[string]string{
+			"retention-rules": "- {selector: {release: \"true\"}, keep-last: -1}",
+		},
+	}, {
+		name: "retention rule with unknown field",
+		fail: true,
+		data: map[string]string{
+			"retention-rules": "- {selector: {release: \"true\"}, keep-first: 1}",
+		},
 	}, {
 		name: "max-non-active unparsable",
 		fail: true,
@@ -153,3 +253,58 @@ func TestOurConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestWithOverrides(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		annos map[string]string
+		want  *Config
+		fail  bool
+	}{{
+		name: "no overrides",
+		want: defaultConfig(),
+	}, {
+		name: "all overridden",
+		annos: map[string]string{
+			serving.GCRetainSinceCreateTimeKey:     "disabled",
+			serving.GCRetainSinceLastActiveTimeKey: "1h",
+			serving.GCMinNonActiveRevisionsKey:     "2",
+			serving.GCMaxNonActiveRevisionsKey:     "10",
+			serving.GCDryRunKey:                    "true",
+		},
+		want: &Config{
+			RetainSinceCreateTime:     time.Duration(Disabled),
+			RetainSinceLastActiveTime: time.Hour,
+			MinNonActiveRevisions:     2,
+			MaxNonActiveRevisions:     10,
+			DryRun:                    true,
+			MaxArchivedRevisions:      20,
+		},
+	}, {
+		name: "max below the default min",
+		annos: map[string]string{
+			serving.GCMaxNonActiveRevisionsKey: "10",
+		},
+		fail: true,
+	}, {
+		name: "unparsable",
+		annos: map[string]string{
+			serving.GCRetainSinceCreateTimeKey: "two days",
+		},
+		fail: true,
+	}} {
+		t.Run(tt.name, func(t *testing.T) {
+			c := defaultConfig()
+			got, err := c.WithOverrides(tt.annos)
+			if tt.fail != (err != nil) {
+				t.Fatal("Unexpected error value:", err)
+			}
+			if !cmp.Equal(tt.want, got) {
+				t.Error("GC config (-want, +got):", cmp.Diff(tt.want, got))
+			}
+			if !cmp.Equal(c, defaultConfig()) {
+				t.Error("WithOverrides modified the config:", cmp.Diff(defaultConfig(), c))
+			}
+		})
+	}
+}
@@ -24,6 +24,13 @@ package gc
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Config) DeepCopyInto(out *Config) {
 	*out = *in
+	if in.RetentionRules != nil {
+		in, out := &in.RetentionRules, &out.RetentionRules
+		*out = make([]RetentionRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -36,3 +43,49 @@ func (in *Config) DeepCopy() *Config {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetentionRule) DeepCopyInto(out *RetentionRule) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetentionRule.
+func (in *RetentionRule) DeepCopy() *RetentionRule {
+	if in == nil {
+		return nil
+	}
+	out := new(RetentionRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *retentionRule) DeepCopyInto(out *retentionRule) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new retentionRule.
+func (in *retentionRule) DeepCopy() *retentionRule {
+	if in == nil {
+		return nil
+	}
+	out := new(retentionRule)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	netheader "knative.dev/networking/pkg/http/header"
+	"knative.dev/serving/pkg/apis/serving"
+	pkghttp "knative.dev/serving/pkg/http"
+)
+
+const (
+	// aimdBackoffRatio is the factor the AIMD limit is multiplied by when a
+	// request fails with an overload status.
+	aimdBackoffRatio = 0.9
+
+	// gradientLongWindow and gradientShortWindow are the number of samples
+	// the long-term and the short-term latencies are averaged over.
+	gradientLongWindow  = 600
+	gradientShortWindow = 10
+	// gradientTolerance is how much the short-term latency may exceed the
+	// long-term one before the limit is decreased.
+	gradientTolerance = 1.5
+	// gradientSmoothing is the weight of a new limit against the current one.
+	gradientSmoothing = 0.2
+
+	// shedRetryAfter is the value of the Retry-After header of shed requests.
+	shedRetryAfter = 1 * time.Second
+)
+
+// limitSample is a request that went through the breaker.
+type limitSample struct {
+	// rtt is the time the user container took to respond.
+	rtt time.Duration
+	// inFlight is the number of requests in the user container when the
+	// request completed.
+	inFlight int
+	// dropped is whether the user container failed the request for being
+	// overloaded.
+	dropped bool
+}
+
+// limitAlgorithm computes the concurrency limit.
+type limitAlgorithm interface {
+	// update returns the new limit given the current one and a sample.
+	update(limit float64, s limitSample) float64
+}
+
+// aimdLimit increases the limit by one for every request that succeeds while
+// the limit is used, and backs off when a request fails with an overload status.
+type aimdLimit struct{}
+
+func (aimdLimit) update(limit float64, s limitSample) float64 {
+	if s.dropped {
+		return limit * aimdBackoffRatio
+	}
+	if float64(s.inFlight)*2 >= limit {
+		return limit + 1
+	}
+	return limit
+}
+
+// gradientLimit moves the limit along the ratio of the long-term to the
+// short-term latency, which drops as requests start to contend for
+// resources in the user container. A margin of the square root of the limit
+// is kept for requests to queue up, which lets the limit grow while the
+// latency holds.
+type gradientLimit struct {
+	longRTT  ewma
+	shortRTT ewma
+}
+
+func (g *gradientLimit) update(limit float64, s limitSample) float64 {
+	short := g.shortRTT.add(float64(s.rtt), gradientShortWindow)
+	long := g.longRTT.add(float64(s.rtt), gradientLongWindow)
+	// Let the long-term latency catch up quickly when the latency drops
+	// for good, e.g. after a slow start.
+	if long/short > 2 {
+		g.longRTT.value *= 0.95
+	}
+	// Don't grow the limit while it isn't used.
+	if float64(s.inFlight) < limit/2 {
+		return limit
+	}
+
+	gradient := math.Max(0.5, math.Min(1, gradientTolerance*long/short))
+	newLimit := limit*gradient + math.Sqrt(limit)
+	return limit*(1-gradientSmoothing) + newLimit*gradientSmoothing
+}
+
+// ewma is an exponentially weighted moving average.
+type ewma struct {
+	value float64
+	count int
+}
+
+// add adds v to an average over about the given number of samples and
+// returns the new average.
+func (e *ewma) add(v float64, window int) float64 {
+	e.count++
+	// Average the first samples evenly, so that the average doesn't start
+	// skewed towards zero.
+	n := min(e.count, window)
+	e.value += (v - e.value) / float64(n)
+	return e.value
+}
+
+// AdaptiveLimiter tunes the capacity of a breaker from the latency and the
+// status of the requests it lets through, between 1 and the given maximum.
+type AdaptiveLimiter struct {
+	breaker  *Breaker
+	maxLimit float64
+
+	mux       sync.Mutex
+	algorithm limitAlgorithm
+	limit     float64
+}
+
+// NewAdaptiveLimiter creates an AdaptiveLimiter for the breaker using the
+// given algorithm, one of the serving.AdaptiveConcurrency* values. The limit
+// starts at maxLimit, which should be the capacity of the breaker.
+func NewAdaptiveLimiter(algorithm string, breaker *Breaker, maxLimit int) (*AdaptiveLimiter, error) {
+	l := &AdaptiveLimiter{
+		breaker:  breaker,
+		maxLimit: float64(maxLimit),
+		limit:    float64(maxLimit),
+	}
+	switch algorithm {
+	case serving.AdaptiveConcurrencyGradient:
+		l.algorithm = &gradientLimit{}
+	case serving.AdaptiveConcurrencyAIMD:
+		l.algorithm = aimdLimit{}
+	default:
+		return nil, fmt.Errorf("unknown adaptive concurrency algorithm %q", algorithm)
+	}
+	return l, nil
+}
+
+// Limit returns the current concurrency limit.
+func (l *AdaptiveLimiter) Limit() int {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	return int(l.limit)
+}
+
+// record updates the limit with a completed request.
+func (l *AdaptiveLimiter) record(s limitSample) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	prev := int(l.limit)
+	l.limit = math.Max(1, math.Min(l.maxLimit, l.algorithm.update(l.limit, s)))
+	if cur := int(l.limit); cur != prev {
+		l.breaker.UpdateConcurrency(cur)
+	}
+}
+
+// ShedHandler responds right away with a 503 and a Retry-After header to the
+// requests which would have to wait behind as many requests as the limit,
+// rather than letting them wait for a user container that is overloaded.
+func (l *AdaptiveLimiter) ShedHandler(next http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !netheader.IsProbe(r) && l.breaker.Waiting() >= l.Limit() {
+			w.Header().Set("Retry-After", strconv.Itoa(int(shedRetryAfter.Seconds())))
+			http.Error(w, "concurrency limit exceeded", http.StatusServiceUnavailable)
+			return
+		}
+		next.ServeHTTP(w, r)
+	}
+}
+
+// SampleHandler feeds the latency and the status of the requests to the
+// limiter. It must be wrapped by the breaker, so that the time requests
+// wait for capacity isn't accounted as latency of the user container.
+func (l *AdaptiveLimiter) SampleHandler(next http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if netheader.IsProbe(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		rr := pkghttp.NewResponseRecorder(w, http.StatusOK)
+		start := time.Now()
+		next.ServeHTTP(rr, r)
+		l.record(limitSample{
+			rtt:      time.Since(start),
+			inFlight: l.breaker.InFlight() - l.breaker.Waiting(),
+			dropped:  isOverloadStatus(rr.ResponseCode),
+		})
+	}
+}
+
+// isOverloadStatus returns whether the status tells that the user container
+// is overloaded.
+func isOverloadStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
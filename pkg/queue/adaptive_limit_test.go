@@ -0,0 +1,162 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	netheader "knative.dev/networking/pkg/http/header"
+	"knative.dev/serving/pkg/apis/serving"
+)
+
+func TestAIMDLimit(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		sample limitSample
+		want   float64
+	}{{
+		name:   "limit used",
+		sample: limitSample{rtt: time.Second, inFlight: 5},
+		want:   11,
+	}, {
+		name:   "limit unused",
+		sample: limitSample{rtt: time.Second, inFlight: 4},
+		want:   10,
+	}, {
+		name:   "dropped",
+		sample: limitSample{rtt: time.Second, inFlight: 10, dropped: true},
+		want:   9,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := (aimdLimit{}).update(10, tc.sample); got != tc.want {
+				t.Errorf("update() = %v, want: %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGradientLimit(t *testing.T) {
+	g := &gradientLimit{}
+	limit := 100.
+	for range 100 {
+		limit = g.update(limit, limitSample{rtt: 10 * time.Millisecond, inFlight: int(limit)})
+	}
+	if limit <= 100 {
+		t.Errorf("Limit with steady latency = %v, want it to grow above 100", limit)
+	}
+
+	grown := limit
+	for range 20 {
+		limit = g.update(limit, limitSample{rtt: 100 * time.Millisecond, inFlight: int(limit)})
+	}
+	if limit >= grown {
+		t.Errorf("Limit with rising latency = %v, want it to drop below %v", limit, grown)
+	}
+
+	if got := g.update(limit, limitSample{rtt: 10 * time.Millisecond, inFlight: 1}); got != limit {
+		t.Errorf("Limit while unused = %v, want: %v", got, limit)
+	}
+}
+
+func TestNewAdaptiveLimiterUnknownAlgorithm(t *testing.T) {
+	b := NewBreaker(BreakerParams{QueueDepth: 1, MaxConcurrency: 1, InitialCapacity: 1})
+	if _, err := NewAdaptiveLimiter("vegas", b, 1); err == nil {
+		t.Error("NewAdaptiveLimiter() = nil, want an error")
+	}
+}
+
+func TestAdaptiveLimiterSampleHandler(t *testing.T) {
+	b := NewBreaker(BreakerParams{QueueDepth: 10, MaxConcurrency: 10, InitialCapacity: 10})
+	l, err := NewAdaptiveLimiter(serving.AdaptiveConcurrencyAIMD, b, 10)
+	if err != nil {
+		t.Fatal("NewAdaptiveLimiter() =", err)
+	}
+
+	status := http.StatusServiceUnavailable
+	h := l.SampleHandler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+	}))
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	if got, want := l.Limit(), 9; got != want {
+		t.Errorf("Limit() = %d, want: %d", got, want)
+	}
+	if got, want := b.Capacity(), 9; got != want {
+		t.Errorf("Capacity() = %d, want: %d", got, want)
+	}
+
+	// Probes don't tell anything about the user container's load.
+	probe := httptest.NewRequest(http.MethodGet, "/", nil)
+	probe.Header.Set(netheader.ProbeKey, "activator")
+	h.ServeHTTP(httptest.NewRecorder(), probe)
+	if got, want := l.Limit(), 9; got != want {
+		t.Errorf("Limit() after probe = %d, want: %d", got, want)
+	}
+}
+
+func TestAdaptiveLimiterShedHandler(t *testing.T) {
+	b := NewBreaker(BreakerParams{QueueDepth: 10, MaxConcurrency: 1, InitialCapacity: 1})
+	l, err := NewAdaptiveLimiter(serving.AdaptiveConcurrencyGradient, b, 1)
+	if err != nil {
+		t.Fatal("NewAdaptiveLimiter() =", err)
+	}
+	h := l.ShedHandler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	resp := httptest.NewRecorder()
+	h.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/", nil))
+	if resp.Code != http.StatusOK {
+		t.Errorf("Status without waiting requests = %d, want: %d", resp.Code, http.StatusOK)
+	}
+
+	// Occupy the breaker and have a request wait for it.
+	release := make(chan struct{})
+	done := make(chan struct{})
+	for range 2 {
+		go func() {
+			b.Maybe(context.Background(), func() { <-release })
+			done <- struct{}{}
+		}()
+	}
+	waitForWaiting(t, b, 1)
+
+	resp = httptest.NewRecorder()
+	h.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/", nil))
+	if resp.Code != http.StatusServiceUnavailable {
+		t.Errorf("Status over the limit = %d, want: %d", resp.Code, http.StatusServiceUnavailable)
+	}
+	if got, want := resp.Header().Get("Retry-After"), "1"; got != want {
+		t.Errorf("Retry-After = %q, want: %q", got, want)
+	}
+
+	probe := httptest.NewRequest(http.MethodGet, "/", nil)
+	probe.Header.Set(netheader.ProbeKey, "queue")
+	resp = httptest.NewRecorder()
+	h.ServeHTTP(resp, probe)
+	if resp.Code != http.StatusOK {
+		t.Errorf("Probe status over the limit = %d, want: %d", resp.Code, http.StatusOK)
+	}
+
+	close(release)
+	<-done
+	<-done
+}
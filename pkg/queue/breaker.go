@@ -36,6 +36,10 @@ type BreakerParams struct {
 	QueueDepth      int
 	MaxConcurrency  int
 	InitialCapacity int
+	// Priorities, if set, configures the queueing of each priority level,
+	// highest priority first. The priority of a request is taken from its
+	// context, see WithPriority.
+	Priorities []PriorityParams
 }
 
 // Breaker is a component that enforces a concurrency limit on the
@@ -44,8 +48,11 @@ type BreakerParams struct {
 // beyond the limit of the queue are failed immediately.
 type Breaker struct {
 	inFlight   atomic.Int64
+	waiting    atomic.Int64
 	totalSlots int64
 	sem        *semaphore
+	// psem replaces sem if the breaker has priority levels.
+	psem *prioritySemaphore
 
 	// release is the callback function returned to callers by Reserve to
 	// allow the reservation made by Reserve to be released.
@@ -67,12 +74,17 @@ func NewBreaker(params BreakerParams) *Breaker {
 
 	b := &Breaker{
 		totalSlots: int64(params.QueueDepth + params.MaxConcurrency),
-		sem:        newSemaphore(params.MaxConcurrency, params.InitialCapacity),
+	}
+
+	if len(params.Priorities) > 0 {
+		b.psem = newPrioritySemaphore(params.InitialCapacity, params.Priorities)
+	} else {
+		b.sem = newSemaphore(params.MaxConcurrency, params.InitialCapacity)
 	}
 
 	// Allocating the closure returned by Reserve here avoids an allocation in Reserve.
 	b.release = func() {
-		b.sem.release()
+		b.releaseActive()
 		b.releasePending()
 	}
 
@@ -119,7 +131,7 @@ func (b *Breaker) Reserve(ctx context.Context) (func(), bool) {
 		return nil, false
 	}
 
-	if !b.sem.tryAcquire() {
+	if !b.tryAcquire() {
 		b.releasePending()
 		return nil, false
 	}
@@ -139,14 +151,17 @@ func (b *Breaker) Maybe(ctx context.Context, thunk func()) error {
 	defer b.releasePending()
 
 	// Wait for capacity in the active queue.
-	if err := b.sem.acquire(ctx); err != nil {
+	b.waiting.Add(1)
+	err := b.acquire(ctx)
+	b.waiting.Add(-1)
+	if err != nil {
 		return err
 	}
 	// Defer releasing capacity in the active.
 	// It's safe to ignore the error returned by release since we
 	// make sure the semaphore is only manipulated here and acquire
 	// + release calls are equally paired.
-	defer b.sem.release()
+	defer b.releaseActive()
 
 	// Do the thing.
 	thunk()
@@ -159,16 +174,55 @@ func (b *Breaker) InFlight() int {
 	return int(b.inFlight.Load())
 }
 
+// Waiting returns the number of requests currently waiting for capacity in
+// this breaker.
+func (b *Breaker) Waiting() int {
+	return int(b.waiting.Load())
+}
+
 // UpdateConcurrency updates the maximum number of in-flight requests.
 func (b *Breaker) UpdateConcurrency(size int) {
+	if b.psem != nil {
+		b.psem.updateCapacity(size)
+		return
+	}
 	b.sem.updateCapacity(size)
 }
 
 // Capacity returns the number of allowed in-flight requests on this breaker.
 func (b *Breaker) Capacity() int {
+	if b.psem != nil {
+		return b.psem.Capacity()
+	}
 	return b.sem.Capacity()
 }
 
+// acquire waits for capacity in the active semaphore.
+// With priority levels, the request waits in the queue of its priority.
+func (b *Breaker) acquire(ctx context.Context) error {
+	if b.psem != nil {
+		return b.psem.acquire(ctx, priorityFrom(ctx))
+	}
+	return b.sem.acquire(ctx)
+}
+
+// releaseActive releases capacity in the active semaphore.
+func (b *Breaker) releaseActive() {
+	if b.psem != nil {
+		b.psem.release()
+		return
+	}
+	b.sem.release()
+}
+
+// tryAcquire acquires capacity from the active semaphore without waiting.
+func (b *Breaker) tryAcquire() bool {
+	if b.psem != nil {
+		return b.psem.tryAcquire()
+	}
+	return b.sem.tryAcquire()
+}
+
 // newSemaphore creates a semaphore with the desired initial capacity.
 func newSemaphore(maxCapacity, initialCapacity int) *semaphore {
 	queue := make(chan struct{}, maxCapacity)
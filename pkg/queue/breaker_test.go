@@ -169,6 +169,41 @@ func TestBreakerNoOverload(t *testing.T) {
 	reqs.processSuccessfully(t)
 }
 
+func TestBreakerPriority(t *testing.T) {
+	b := NewBreaker(BreakerParams{
+		QueueDepth:      10,
+		MaxConcurrency:  1,
+		InitialCapacity: 1,
+		Priorities:      []PriorityParams{{QueueDepth: 5}, {QueueDepth: 5}},
+	})
+
+	release, ok := b.Reserve(context.Background())
+	if !ok {
+		t.Fatal("Reserve() = false, want true")
+	}
+
+	order := make(chan int, 2)
+	for _, level := range []int{1, 0} {
+		go b.Maybe(WithPriority(context.Background(), level), func() {
+			order <- level
+		})
+		// Wait for the request to be queued, so the order is deterministic.
+		for queued := false; !queued; {
+			b.psem.mu.Lock()
+			queued = len(b.psem.queues[level]) == 1
+			b.psem.mu.Unlock()
+		}
+	}
+
+	release()
+	if got, want := <-order, 0; got != want {
+		t.Errorf("First level = %d, want: %d", got, want)
+	}
+	if got, want := <-order, 1; got != want {
+		t.Errorf("Second level = %d, want: %d", got, want)
+	}
+}
+
 func TestBreakerCancel(t *testing.T) {
 	params := BreakerParams{QueueDepth: 1, MaxConcurrency: 1, InitialCapacity: 0}
 	b := NewBreaker(params)
@@ -222,6 +257,35 @@ func TestBreakerUpdateConcurrency(t *testing.T) {
 	}
 }
 
+func TestBreakerWaiting(t *testing.T) {
+	params := BreakerParams{QueueDepth: 2, MaxConcurrency: 1, InitialCapacity: 0}
+	b := NewBreaker(params)
+	reqs := newRequestor(b)
+
+	reqs.request()
+	reqs.request()
+	waitForWaiting(t, b, 2)
+
+	// One request gets capacity, the other keeps waiting.
+	b.UpdateConcurrency(1)
+	waitForWaiting(t, b, 1)
+
+	reqs.processSuccessfully(t)
+	reqs.processSuccessfully(t)
+	if got := b.Waiting(); got != 0 {
+		t.Errorf("Waiting() = %d, want: 0", got)
+	}
+}
+
+func waitForWaiting(t *testing.T, b *Breaker, want int) {
+	t.Helper()
+	for start := time.Now(); b.Waiting() != want; time.Sleep(time.Millisecond) {
+		if time.Since(start) > semAcquireTimeout {
+			t.Fatalf("Waiting() = %d, want: %d", b.Waiting(), want)
+		}
+	}
+}
+
 // Test empty semaphore, token cannot be acquired
 func TestSemaphoreAcquireHasNoCapacity(t *testing.T) {
 	gotChan := make(chan struct{}, 1)
@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificate
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const scopeName = "knative.dev/serving/pkg/queue/certificate"
+
+type watcherMetrics struct {
+	rotations    metric.Int64Counter
+	registration metric.Registration
+}
+
+func newWatcherMetrics(mp metric.MeterProvider, notAfter func() time.Time) *watcherMetrics {
+	p := mp
+	if p == nil {
+		p = otel.GetMeterProvider()
+	}
+
+	meter := p.Meter(scopeName)
+
+	m := &watcherMetrics{
+		rotations: must(meter.Int64Counter(
+			"kn.queueproxy.certificate.rotations",
+			metric.WithDescription("Number of times the serving certificate was reloaded after a change on disk"),
+			metric.WithUnit("{rotation}"),
+		)),
+	}
+	expiry := must(meter.Float64ObservableGauge(
+		"kn.queueproxy.certificate.expiry",
+		metric.WithDescription("Time remaining until the serving certificate expires"),
+		metric.WithUnit("s"),
+	))
+	m.registration = must(meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		o.ObserveFloat64(expiry, time.Until(notAfter()).Seconds())
+		return nil
+	}, expiry))
+	return m
+}
+
+func (m *watcherMetrics) recordRotation() {
+	if m == nil {
+		return
+	}
+	m.rotations.Add(context.Background(), 1)
+}
+
+func (m *watcherMetrics) unregister() {
+	if m == nil {
+		return
+	}
+	m.registration.Unregister()
+}
+
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
@@ -17,14 +17,19 @@ limitations under the License.
 package certificate
 
 import (
+	"context"
 	"crypto/sha256"
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"net"
+	"net/http"
 	"os"
 	"path"
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 )
 
@@ -33,6 +38,9 @@ const (
 )
 
 // CertWatcher watches certificate and key files and reloads them if they change on disk.
+//
+// The connections established with a previous certificate are drained
+// gracefully, see DrainHandler.
 type CertWatcher struct {
 	certPath     string
 	certChecksum [sha256.Size]byte
@@ -40,17 +48,26 @@ type CertWatcher struct {
 	keyChecksum  [sha256.Size]byte
 
 	certificate *tls.Certificate
-
-	logger *zap.SugaredLogger
-	ticker *time.Ticker
-	stop   chan struct{}
-	mux    sync.RWMutex
+	notAfter    time.Time
+	// generation is incremented every time the certificate is reloaded.
+	generation uint64
+	// conns maps the connections to the generation of the certificate
+	// they were established with.
+	conns sync.Map
+
+	metrics *watcherMetrics
+	logger  *zap.SugaredLogger
+	ticker  *time.Ticker
+	stop    chan struct{}
+	mux     sync.RWMutex
 }
 
+type connKey struct{}
+
 // NewCertWatcher creates a CertWatcher and watches
 // the certificate and key files. It reloads the contents on file change.
 // Make sure to stop the CertWatcher using Stop() upon destroy.
-func NewCertWatcher(certPath, keyPath string, reloadInterval time.Duration, logger *zap.SugaredLogger) (*CertWatcher, error) {
+func NewCertWatcher(certPath, keyPath string, reloadInterval time.Duration, logger *zap.SugaredLogger, mp metric.MeterProvider) (*CertWatcher, error) {
 	cw := &CertWatcher{
 		certPath: certPath,
 		keyPath:  keyPath,
@@ -70,6 +87,7 @@ func NewCertWatcher(certPath, keyPath string, reloadInterval time.Duration, logg
 	if err := cw.loadCert(); err != nil {
 		return nil, err
 	}
+	cw.metrics = newWatcherMetrics(mp, cw.NotAfter)
 
 	go cw.watch()
 
@@ -81,15 +99,81 @@ func (cw *CertWatcher) Stop() {
 	cw.logger.Info("Stopping file watcher")
 	close(cw.stop)
 	cw.ticker.Stop()
+	cw.metrics.unregister()
 }
 
 // GetCertificate returns the server certificate for a client-hello request.
-func (cw *CertWatcher) GetCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+func (cw *CertWatcher) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
 	cw.mux.RLock()
 	defer cw.mux.RUnlock()
+	if hello != nil && hello.Conn != nil {
+		cw.conns.Store(hello.Conn, cw.generation)
+	}
 	return cw.certificate, nil
 }
 
+// NotAfter returns the expiry time of the current certificate.
+func (cw *CertWatcher) NotAfter() time.Time {
+	cw.mux.RLock()
+	defer cw.mux.RUnlock()
+	return cw.notAfter
+}
+
+// ConnContext is to be used as the http.Server's ConnContext, it records
+// the connection in the context for DrainHandler.
+func (cw *CertWatcher) ConnContext(ctx context.Context, c net.Conn) context.Context {
+	c = netConn(c)
+	cw.mux.RLock()
+	// Resumed TLS sessions don't ask for the certificate, attribute them
+	// to the current generation.
+	cw.conns.LoadOrStore(c, cw.generation)
+	cw.mux.RUnlock()
+	return context.WithValue(ctx, connKey{}, c)
+}
+
+// ConnState is to be used as the http.Server's ConnState, it forgets the
+// connections once they are closed.
+func (cw *CertWatcher) ConnState(c net.Conn, state http.ConnState) {
+	if state == http.StateClosed || state == http.StateHijacked {
+		cw.conns.Delete(netConn(c))
+	}
+}
+
+// DrainHandler closes the connections established with a previous
+// certificate after their in-flight requests: HTTP/1 connections are closed
+// after the response, and HTTP/2 connections are sent a GOAWAY and closed
+// once their streams are done, so that the clients reconnect with the new
+// certificate instead of breaking.
+func (cw *CertWatcher) DrainHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if cw.isStale(r.Context()) {
+			w.Header().Set("Connection", "close")
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (cw *CertWatcher) isStale(ctx context.Context) bool {
+	c, ok := ctx.Value(connKey{}).(net.Conn)
+	if !ok {
+		return false
+	}
+	generation, ok := cw.conns.Load(c)
+	if !ok {
+		return false
+	}
+	cw.mux.RLock()
+	defer cw.mux.RUnlock()
+	return generation.(uint64) < cw.generation
+}
+
+func netConn(c net.Conn) net.Conn {
+	if tc, ok := c.(*tls.Conn); ok {
+		return tc.NetConn()
+	}
+	return c
+}
+
 func (cw *CertWatcher) watch() {
 	for {
 		select {
@@ -120,7 +204,11 @@ func (cw *CertWatcher) loadCert() error {
 	keyChecksum := sha256.Sum256(keyFile)
 
 	if certChecksum != cw.certChecksum || keyChecksum != cw.keyChecksum {
-		keyPair, err := tls.LoadX509KeyPair(cw.certPath, cw.keyPath)
+		keyPair, err := tls.X509KeyPair(certFile, keyFile)
+		if err != nil {
+			return fmt.Errorf("failed to parse certificate: %w", err)
+		}
+		leaf, err := x509.ParseCertificate(keyPair.Certificate[0])
 		if err != nil {
 			return fmt.Errorf("failed to parse certificate: %w", err)
 		}
@@ -128,11 +216,17 @@ func (cw *CertWatcher) loadCert() error {
 		cw.mux.Lock()
 		defer cw.mux.Unlock()
 
+		rotated := cw.certificate != nil
 		cw.certificate = &keyPair
+		cw.notAfter = leaf.NotAfter
 		cw.certChecksum = certChecksum
 		cw.keyChecksum = keyChecksum
 
-		cw.logger.Info(CertReloadMessage)
+		if rotated {
+			cw.generation++
+			cw.metrics.recordRotation()
+		}
+		cw.logger.Infow(CertReloadMessage, zap.Time("notAfter", leaf.NotAfter))
 	}
 
 	return nil
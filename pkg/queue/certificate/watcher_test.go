@@ -26,13 +26,20 @@ import (
 	"crypto/x509/pkix"
 	"encoding/pem"
 	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"knative.dev/networking/pkg/certificates"
 	ktesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/observability/metrics/metricstest"
 )
 
 const (
@@ -50,7 +57,9 @@ func TestCertificateRotation(t *testing.T) {
 	}
 
 	// Watch the certificate files
-	cw, err := NewCertWatcher(dir+"/"+certificates.CertName, dir+"/"+certificates.PrivateKeyName, 1*time.Second, ktesting.TestLogger(t))
+	reader := metric.NewManualReader()
+	mp := metric.NewMeterProvider(metric.WithReader(reader))
+	cw, err := NewCertWatcher(dir+"/"+certificates.CertName, dir+"/"+certificates.PrivateKeyName, 1*time.Second, ktesting.TestLogger(t), mp)
 	if err != nil {
 		t.Fatal("failed to create CertWatcher", err)
 	}
@@ -97,6 +106,97 @@ func TestCertificateRotation(t *testing.T) {
 	}); err != nil {
 		t.Fatal(err)
 	}
+
+	metricstest.AssertMetrics(t, reader,
+		metricstest.MetricsPresent(scopeName, "kn.queueproxy.certificate.rotations", "kn.queueproxy.certificate.expiry"))
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal("failed to collect metrics", err)
+	}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		switch data := m.Data.(type) {
+		case metricdata.Sum[int64]:
+			if got := data.DataPoints[0].Value; got != 1 {
+				t.Errorf("%s = %d, want: 1", m.Name, got)
+			}
+		case metricdata.Gauge[float64]:
+			if got := data.DataPoints[0].Value; got < (9 * 365 * 24 * time.Hour).Seconds() {
+				t.Errorf("%s = %v, want: about 10 years", m.Name, got)
+			}
+		}
+	}
+}
+
+func TestDrainHandler(t *testing.T) {
+	dir := t.TempDir()
+	if err := createAndSaveCertificate(initialSAN, dir); err != nil {
+		t.Fatal("failed to create and save initial certificate", err)
+	}
+	cw, err := NewCertWatcher(dir+"/"+certificates.CertName, dir+"/"+certificates.PrivateKeyName, time.Hour, ktesting.TestLogger(t), nil)
+	if err != nil {
+		t.Fatal("failed to create CertWatcher", err)
+	}
+	defer cw.Stop()
+
+	var conns atomic.Int32
+	srv := httptest.NewUnstartedServer(cw.DrainHandler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})))
+	srv.EnableHTTP2 = true
+	srv.TLS = &tls.Config{GetCertificate: cw.GetCertificate}
+	srv.Config.ConnContext = cw.ConnContext
+	srv.Config.ConnState = func(c net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+		cw.ConnState(c, state)
+	}
+	srv.StartTLS()
+	defer srv.Close()
+
+	client := srv.Client()
+	transport := client.Transport.(*http.Transport)
+	// Send the SNI so that the certificate of the CertWatcher is served.
+	transport.TLSClientConfig.ServerName = initialSAN
+	transport.TLSClientConfig.InsecureSkipVerify = true
+
+	get := func() {
+		t.Helper()
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			t.Fatal("Get() =", err)
+		}
+		resp.Body.Close()
+		if resp.ProtoMajor != 2 {
+			t.Fatalf("Proto = %s, want: HTTP/2", resp.Proto)
+		}
+	}
+
+	get()
+	get()
+	if got := conns.Load(); got != 1 {
+		t.Fatalf("#connections = %d, want: 1", got)
+	}
+
+	if err := createAndSaveCertificate(updatedSAN, dir); err != nil {
+		t.Fatal("failed to update and save certificate", err)
+	}
+	if err := cw.loadCert(); err != nil {
+		t.Fatal("failed to reload certificate", err)
+	}
+
+	// The request on the connection established with the old certificate
+	// succeeds, and the connection is drained.
+	get()
+	get()
+	if got := conns.Load(); got != 2 {
+		t.Fatalf("#connections = %d, want: 2", got)
+	}
+	get()
+	if got := conns.Load(); got != 2 {
+		t.Fatalf("#connections = %d, want: 2", got)
+	}
 }
 
 func createAndSaveCertificate(san, dir string) error {
@@ -0,0 +1,262 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"sync"
+
+	netheader "knative.dev/networking/pkg/http/header"
+	"knative.dev/serving/pkg/apis/serving"
+)
+
+// PriorityParams defines the queueing parameters of a single priority level
+// of the breaker.
+type PriorityParams struct {
+	// QueueDepth is the number of requests of this level that can wait
+	// for capacity.
+	QueueDepth int
+	// Weight is the relative share of dequeues this level gets while the
+	// breaker is contended. If all the weights are zero, higher levels are
+	// always dequeued first.
+	Weight int
+}
+
+// NewPriorityParams splits the queueDepth across the given priority classes
+// according to their queue shares.
+func NewPriorityParams(classes []serving.PriorityClass, queueDepth int) []PriorityParams {
+	if len(classes) == 0 {
+		return nil
+	}
+	ret := make([]PriorityParams, len(classes))
+	for i, c := range classes {
+		ret[i] = PriorityParams{
+			QueueDepth: max(1, queueDepth*c.QueueShare/100),
+			Weight:     c.Weight,
+		}
+	}
+	return ret
+}
+
+type priorityCtxKey struct{}
+
+// WithPriority attaches the priority level of the request to the context.
+// Level 0 is the highest priority.
+func WithPriority(ctx context.Context, level int) context.Context {
+	return context.WithValue(ctx, priorityCtxKey{}, level)
+}
+
+// priorityFrom returns the priority level of the request or -1 if unset.
+func priorityFrom(ctx context.Context) int {
+	if level, ok := ctx.Value(priorityCtxKey{}).(int); ok {
+		return level
+	}
+	return -1
+}
+
+// PriorityLevel returns the priority level of the request with the given
+// headers given the classes. The class is picked by the priority header,
+// falling back to the route tag. Unclassified requests get the lowest priority.
+func PriorityLevel(classes []serving.PriorityClass, h http.Header) int {
+	for _, name := range []string{h.Get(serving.PriorityHeaderName), h.Get(netheader.RouteTagKey)} {
+		if name == "" {
+			continue
+		}
+		if i := slices.IndexFunc(classes, func(c serving.PriorityClass) bool { return c.Name == name }); i >= 0 {
+			return i
+		}
+	}
+	return len(classes) - 1
+}
+
+type priorityHandler struct {
+	classes []serving.PriorityClass
+	next    http.Handler
+}
+
+// NewPriorityHandler attaches the priority level of the request, according to
+// the given classes, to the request context.
+func NewPriorityHandler(classes []serving.PriorityClass, next http.Handler) http.Handler {
+	if len(classes) == 0 {
+		return next
+	}
+	return &priorityHandler{classes: classes, next: next}
+}
+
+func (h *priorityHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.next.ServeHTTP(w, r.WithContext(WithPriority(r.Context(), PriorityLevel(h.classes, r.Header))))
+}
+
+// prioritySemaphore is a semaphore with a separate FIFO queue per priority level.
+// Unlike semaphore it hands the released capacity directly to the next waiter,
+// which is picked either strictly by priority, or by smooth weighted round robin
+// across the levels with waiters, if the levels have weights.
+type prioritySemaphore struct {
+	mu       sync.Mutex
+	capacity int
+	inFlight int
+
+	levels []PriorityParams
+	queues [][]*priorityWaiter
+	// credits are the current weights of the smooth weighted round robin.
+	credits  []int
+	weighted bool
+}
+
+type priorityWaiter struct {
+	ready   chan struct{}
+	granted bool
+}
+
+func newPrioritySemaphore(initialCapacity int, levels []PriorityParams) *prioritySemaphore {
+	s := &prioritySemaphore{
+		capacity: initialCapacity,
+		levels:   levels,
+		queues:   make([][]*priorityWaiter, len(levels)),
+		credits:  make([]int, len(levels)),
+	}
+	for _, l := range levels {
+		s.weighted = s.weighted || l.Weight > 0
+	}
+	return s
+}
+
+// tryAcquire acquires capacity from the semaphore if there is any, without waiting.
+func (s *prioritySemaphore) tryAcquire() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.inFlight < s.capacity {
+		s.inFlight++
+		return true
+	}
+	return false
+}
+
+// acquire acquires capacity from the semaphore, waiting in the queue of the
+// given level if there is none. Out of range levels are treated as the lowest.
+func (s *prioritySemaphore) acquire(ctx context.Context, level int) error {
+	if level < 0 || level >= len(s.levels) {
+		level = len(s.levels) - 1
+	}
+
+	s.mu.Lock()
+	// Waiters only exist while the semaphore is at capacity, so if there is
+	// capacity we are not overtaking anyone.
+	if s.inFlight < s.capacity {
+		s.inFlight++
+		s.mu.Unlock()
+		return nil
+	}
+	if len(s.queues[level]) >= s.levels[level].QueueDepth {
+		s.mu.Unlock()
+		return ErrRequestQueueFull
+	}
+	w := &priorityWaiter{ready: make(chan struct{})}
+	s.queues[level] = append(s.queues[level], w)
+	s.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		if w.granted {
+			// We raced the grant, give the capacity back.
+			s.mu.Unlock()
+			s.release()
+			return ctx.Err()
+		}
+		s.queues[level] = slices.DeleteFunc(s.queues[level], func(o *priorityWaiter) bool { return o == w })
+		s.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// release releases capacity in the semaphore and hands it to the next waiter.
+func (s *prioritySemaphore) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.inFlight == 0 {
+		panic("release and acquire are not paired")
+	}
+	s.inFlight--
+	s.dispatchLocked()
+}
+
+// updateCapacity updates the capacity of the semaphore to the desired size.
+func (s *prioritySemaphore) updateCapacity(size int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.capacity = size
+	s.dispatchLocked()
+}
+
+// Capacity is the capacity of the semaphore.
+func (s *prioritySemaphore) Capacity() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.capacity
+}
+
+// dispatchLocked grants the free capacity to the waiters.
+// Must be called with mu held.
+func (s *prioritySemaphore) dispatchLocked() {
+	for s.inFlight < s.capacity {
+		level := s.nextLevelLocked()
+		if level < 0 {
+			return
+		}
+		w := s.queues[level][0]
+		s.queues[level][0] = nil
+		s.queues[level] = s.queues[level][1:]
+		w.granted = true
+		s.inFlight++
+		close(w.ready)
+	}
+}
+
+// nextLevelLocked returns the level to dequeue from next, or -1 if there are no waiters.
+// Must be called with mu held.
+func (s *prioritySemaphore) nextLevelLocked() int {
+	if !s.weighted {
+		for i, q := range s.queues {
+			if len(q) > 0 {
+				return i
+			}
+		}
+		return -1
+	}
+
+	// Smooth weighted round robin, as in nginx, across the levels with waiters.
+	pick, total := -1, 0
+	for i, q := range s.queues {
+		if len(q) == 0 {
+			continue
+		}
+		s.credits[i] += s.levels[i].Weight
+		total += s.levels[i].Weight
+		if pick < 0 || s.credits[i] > s.credits[pick] {
+			pick = i
+		}
+	}
+	if pick >= 0 {
+		s.credits[pick] -= total
+	}
+	return pick
+}
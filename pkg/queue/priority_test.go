@@ -0,0 +1,206 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	netheader "knative.dev/networking/pkg/http/header"
+	"knative.dev/serving/pkg/apis/serving"
+)
+
+var testPriorityClasses = []serving.PriorityClass{
+	{Name: "premium", QueueShare: 20},
+	{Name: "default", QueueShare: 30},
+	{Name: "bulk", QueueShare: 50},
+}
+
+func TestNewPriorityParams(t *testing.T) {
+	got := NewPriorityParams(testPriorityClasses, 10)
+	want := []PriorityParams{{QueueDepth: 2}, {QueueDepth: 3}, {QueueDepth: 5}}
+	if !cmp.Equal(got, want) {
+		t.Error("NewPriorityParams (-want, +got):", cmp.Diff(want, got))
+	}
+	if got := NewPriorityParams(nil, 10); got != nil {
+		t.Errorf("NewPriorityParams(nil) = %v, want nil", got)
+	}
+}
+
+func TestPriorityLevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    int
+	}{{
+		name: "unclassified",
+		want: 2,
+	}, {
+		name:    "priority header",
+		headers: map[string]string{serving.PriorityHeaderName: "premium"},
+		want:    0,
+	}, {
+		name:    "route tag",
+		headers: map[string]string{netheader.RouteTagKey: "default"},
+		want:    1,
+	}, {
+		name: "priority header wins over route tag",
+		headers: map[string]string{
+			serving.PriorityHeaderName: "premium",
+			netheader.RouteTagKey:      "default",
+		},
+		want: 0,
+	}, {
+		name:    "unknown class",
+		headers: map[string]string{serving.PriorityHeaderName: "gold"},
+		want:    2,
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			h := http.Header{}
+			for k, v := range tc.headers {
+				h.Set(k, v)
+			}
+			if got := PriorityLevel(testPriorityClasses, h); got != tc.want {
+				t.Errorf("PriorityLevel() = %d, want: %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPriorityHandler(t *testing.T) {
+	got := -1
+	h := NewPriorityHandler(testPriorityClasses, http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		got = priorityFrom(r.Context())
+	}))
+	r := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+	r.Header.Set(serving.PriorityHeaderName, "default")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+	if want := 1; got != want {
+		t.Errorf("Priority = %d, want: %d", got, want)
+	}
+}
+
+// queueWaiter enqueues a waiter on the given level and returns a channel
+// which receives the level once the waiter acquires the semaphore.
+func queueWaiter(t *testing.T, s *prioritySemaphore, level int, order chan<- int) {
+	t.Helper()
+	s.mu.Lock()
+	before := len(s.queues[level])
+	s.mu.Unlock()
+	go func() {
+		if err := s.acquire(context.Background(), level); err != nil {
+			t.Errorf("acquire() = %v", err)
+			return
+		}
+		order <- level
+	}()
+	// Wait for the waiter to be queued, so the order is deterministic.
+	for {
+		s.mu.Lock()
+		n := len(s.queues[level])
+		s.mu.Unlock()
+		if n > before {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestPrioritySemaphoreStrict(t *testing.T) {
+	s := newPrioritySemaphore(1, NewPriorityParams(testPriorityClasses, 10))
+	if !s.tryAcquire() {
+		t.Fatal("tryAcquire() = false, want true")
+	}
+	if s.tryAcquire() {
+		t.Fatal("tryAcquire() = true, want false")
+	}
+
+	order := make(chan int, 3)
+	queueWaiter(t, s, 2, order)
+	queueWaiter(t, s, 1, order)
+	queueWaiter(t, s, 0, order)
+
+	for _, want := range []int{0, 1, 2} {
+		s.release()
+		if got := <-order; got != want {
+			t.Errorf("Dequeued level = %d, want: %d", got, want)
+		}
+	}
+	s.release()
+}
+
+func TestPrioritySemaphoreWeighted(t *testing.T) {
+	s := newPrioritySemaphore(1, []PriorityParams{
+		{QueueDepth: 10, Weight: 2},
+		{QueueDepth: 10, Weight: 1},
+	})
+	s.tryAcquire()
+
+	order := make(chan int, 6)
+	for range 3 {
+		queueWaiter(t, s, 0, order)
+		queueWaiter(t, s, 1, order)
+	}
+
+	got := make([]int, 0, 6)
+	for range 6 {
+		s.release()
+		got = append(got, <-order)
+	}
+	s.release()
+	// The low priority level is not starved.
+	if want := []int{0, 1, 0, 0, 1, 1}; !cmp.Equal(got, want) {
+		t.Error("Dequeue order (-want, +got):", cmp.Diff(want, got))
+	}
+}
+
+func TestPrioritySemaphoreQueueFull(t *testing.T) {
+	s := newPrioritySemaphore(0, []PriorityParams{{QueueDepth: 1}, {QueueDepth: 1}})
+	order := make(chan int, 2)
+	queueWaiter(t, s, 1, order)
+	if err := s.acquire(context.Background(), 1); !errors.Is(err, ErrRequestQueueFull) {
+		t.Fatalf("acquire() = %v, want: %v", err, ErrRequestQueueFull)
+	}
+	// Other levels have their own queue.
+	queueWaiter(t, s, 0, order)
+
+	s.updateCapacity(2)
+	<-order
+	<-order
+}
+
+func TestPrioritySemaphoreCancel(t *testing.T) {
+	s := newPrioritySemaphore(0, []PriorityParams{{QueueDepth: 1}})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := s.acquire(ctx, 0); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("acquire() = %v, want: %v", err, context.DeadlineExceeded)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if got := len(s.queues[0]); got != 0 {
+		t.Errorf("len(queue) = %d, want: 0", got)
+	}
+}
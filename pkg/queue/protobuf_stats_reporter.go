@@ -42,7 +42,7 @@ type ProtobufStatsReporter struct {
 	// podName is the immutable identity of this pod, set at construction time.
 	podName string
 
-	// RequestCount and ProxiedRequestCount need to be divided by the reporting period
+	// RequestCount, ProxiedRequestCount and ServerErrorCount need to be divided by the reporting period
 	// they were collected over to get a "per-second" value.
 	reportingPeriodSeconds float64
 }
@@ -65,16 +65,22 @@ func NewProtobufStatsReporter(pod string, reportingPeriod time.Duration) *Protob
 }
 
 // Report captures request metrics.
-func (r *ProtobufStatsReporter) Report(stats netstats.RequestStatsReport) {
+func (r *ProtobufStatsReporter) Report(stats netstats.RequestStatsReport, resp ResponseStatsReport) {
 	r.stat.Store(metrics.Stat{
 		PodName:       r.podName,
 		ProcessUptime: time.Since(r.startTime).Seconds(),
 
-		// RequestCount and ProxiedRequestCount are a rate over time while concurrency is not.
+		// RequestCount, ProxiedRequestCount and ServerErrorCount are a rate over time
+		// while concurrency and queue depth are not.
 		RequestCount:                     stats.RequestCount / r.reportingPeriodSeconds,
 		ProxiedRequestCount:              stats.ProxiedRequestCount / r.reportingPeriodSeconds,
 		AverageConcurrentRequests:        stats.AverageConcurrency,
 		AverageProxiedConcurrentRequests: stats.AverageProxiedConcurrency,
+
+		Latency:          resp.Latency,
+		QueueDepth:       resp.QueueDepth,
+		ServerErrorCount: resp.ServerErrorCount / r.reportingPeriodSeconds,
+		ConcurrencyLimit: resp.ConcurrencyLimit,
 	})
 }
 
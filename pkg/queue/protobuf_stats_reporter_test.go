@@ -40,6 +40,7 @@ var testCases = []struct {
 	name            string
 	reportingPeriod time.Duration
 	report          netstats.RequestStatsReport
+	resp            ResponseStatsReport
 	want            metrics.Stat
 }{{
 	name:            "no proxy requests",
@@ -99,6 +100,28 @@ var testCases = []struct {
 		ProxiedRequestCount:              15,
 		RequestCount:                     39,
 	},
+}, {
+	name:            "latencies, errors and queue depth",
+	reportingPeriod: 2 * time.Second,
+
+	report: netstats.RequestStatsReport{
+		AverageConcurrency: 3,
+		RequestCount:       39,
+	},
+	resp: ResponseStatsReport{
+		Latency:          &metrics.LatencySketch{Counts: []uint64{30, 0, 9}},
+		ServerErrorCount: 4,
+		QueueDepth:       2,
+		ConcurrencyLimit: 10,
+	},
+	want: metrics.Stat{
+		AverageConcurrentRequests: 3,
+		RequestCount:              19.5,
+		Latency:                   &metrics.LatencySketch{Counts: []uint64{30, 0, 9}},
+		ServerErrorCount:          2,
+		QueueDepth:                2,
+		ConcurrencyLimit:          10,
+	},
 }}
 
 func TestProtobufStatsReporterReport(t *testing.T) {
@@ -107,7 +130,7 @@ func TestProtobufStatsReporterReport(t *testing.T) {
 			reporter := NewProtobufStatsReporter(pod, test.reportingPeriod)
 			// Make the value slightly more interesting, rather than microseconds.
 			reporter.startTime = reporter.startTime.Add(-5 * time.Second)
-			reporter.Report(test.report)
+			reporter.Report(test.report, test.resp)
 			got := scrapeProtobufStat(t, reporter)
 			test.want.PodName = pod
 			if !cmp.Equal(test.want, got, ignoreStatFields) {
@@ -179,7 +202,7 @@ func TestProtobufStatsReporterPodNameImmutable(t *testing.T) {
 	}
 
 	for i, report := range reports {
-		reporter.Report(report)
+		reporter.Report(report, ResponseStatsReport{})
 		stat := scrapeProtobufStat(t, reporter)
 
 		// Verify pod name never changes regardless of what stats are reported
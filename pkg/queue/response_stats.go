@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	netheader "knative.dev/networking/pkg/http/header"
+	"knative.dev/serving/pkg/autoscaler/metrics"
+	pkghttp "knative.dev/serving/pkg/http"
+)
+
+// ResponseStatsReport is the latency, error and queueing data collected over
+// a reporting period.
+type ResponseStatsReport struct {
+	// Latency is the sketch of the latencies of the requests completed
+	// during the period, nil if none completed.
+	Latency *metrics.LatencySketch
+	// ServerErrorCount is the number of 5xx responses during the period.
+	ServerErrorCount float64
+	// QueueDepth is the number of requests waiting in the breaker at the
+	// time of the report.
+	QueueDepth float64
+	// ConcurrencyLimit is the capacity of the breaker at the time of the
+	// report, which an AdaptiveLimiter may have tuned.
+	ConcurrencyLimit float64
+}
+
+// ResponseStats collects the latencies and the status codes of the requests
+// handled by the queue-proxy, for the autoscaler.
+type ResponseStats struct {
+	breaker *Breaker
+
+	mux          sync.Mutex
+	latency      metrics.LatencySketch
+	serverErrors float64
+}
+
+// NewResponseStats creates a ResponseStats, which reports the queue depth and
+// the capacity of the given breaker. The breaker may be nil.
+func NewResponseStats(breaker *Breaker) *ResponseStats {
+	return &ResponseStats{breaker: breaker}
+}
+
+// Record records a completed request.
+func (s *ResponseStats) Record(latency time.Duration, status int) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.latency.Record(latency)
+	if status >= http.StatusInternalServerError {
+		s.serverErrors++
+	}
+}
+
+// Report returns the data collected since the last report and resets it.
+func (s *ResponseStats) Report() ResponseStatsReport {
+	var report ResponseStatsReport
+	if s.breaker != nil {
+		report.QueueDepth = float64(s.breaker.Waiting())
+		report.ConcurrencyLimit = float64(s.breaker.Capacity())
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if len(s.latency.Counts) > 0 {
+		report.Latency = &metrics.LatencySketch{Counts: s.latency.Counts}
+		s.latency.Counts = nil
+	}
+	report.ServerErrorCount = s.serverErrors
+	s.serverErrors = 0
+	return report
+}
+
+// ResponseStatsHandler records the latency and the status of the requests
+// to `stats`. Probes are not recorded.
+func ResponseStatsHandler(stats *ResponseStats, next http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if netheader.IsProbe(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		rr := pkghttp.NewResponseRecorder(w, http.StatusOK)
+		start := time.Now()
+		defer func() {
+			// If ServeHTTP panics, recover, record the failure and panic again.
+			err := recover()
+			status := rr.ResponseCode
+			if err != nil {
+				status = http.StatusInternalServerError
+			}
+			stats.Record(time.Since(start), status)
+			if err != nil {
+				panic(err)
+			}
+		}()
+		next.ServeHTTP(rr, r)
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	netheader "knative.dev/networking/pkg/http/header"
+)
+
+func TestResponseStatsHandler(t *testing.T) {
+	stats := NewResponseStats(nil)
+	status := http.StatusOK
+	h := ResponseStatsHandler(stats, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+
+	serve := func(probe bool) {
+		req := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+		if probe {
+			req.Header.Set(netheader.ProbeKey, "queue")
+		}
+		h.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	serve(false)
+	status = http.StatusBadGateway
+	serve(false)
+	serve(true)
+	status = http.StatusNotFound
+	serve(false)
+
+	report := stats.Report()
+	if got, want := report.Latency.Count(), uint64(3); got != want {
+		t.Errorf("Latency.Count() = %d, want: %d", got, want)
+	}
+	if got, want := report.ServerErrorCount, 1.; got != want {
+		t.Errorf("ServerErrorCount = %v, want: %v", got, want)
+	}
+	if got, want := report.QueueDepth, 0.; got != want {
+		t.Errorf("QueueDepth = %v, want: %v", got, want)
+	}
+
+	// The data is reset by reporting.
+	report = stats.Report()
+	if report.Latency != nil || report.ServerErrorCount != 0 {
+		t.Errorf("Report() = %#v, want an empty report", report)
+	}
+}
+
+func TestResponseStatsQueueDepth(t *testing.T) {
+	b := NewBreaker(BreakerParams{QueueDepth: 2, MaxConcurrency: 1, InitialCapacity: 0})
+	stats := NewResponseStats(b)
+	reqs := newRequestor(b)
+
+	reqs.request()
+	waitForWaiting(t, b, 1)
+	report := stats.Report()
+	if got, want := report.QueueDepth, 1.; got != want {
+		t.Errorf("QueueDepth = %v, want: %v", got, want)
+	}
+	if got, want := report.ConcurrencyLimit, 0.; got != want {
+		t.Errorf("ConcurrencyLimit = %v, want: %v", got, want)
+	}
+
+	b.UpdateConcurrency(1)
+	reqs.processSuccessfully(t)
+	if got, want := stats.Report().QueueDepth, 0.; got != want {
+		t.Errorf("QueueDepth = %v, want: %v", got, want)
+	}
+}
+
+func TestResponseStatsRecord(t *testing.T) {
+	stats := NewResponseStats(nil)
+	stats.Record(time.Millisecond, http.StatusInternalServerError)
+	stats.Record(time.Second, http.StatusServiceUnavailable)
+	stats.Record(time.Second, http.StatusTooManyRequests)
+
+	report := stats.Report()
+	if got, want := report.ServerErrorCount, 2.; got != want {
+		t.Errorf("ServerErrorCount = %v, want: %v", got, want)
+	}
+	if got, want := report.Latency.Quantile(0.3), time.Millisecond; got != want {
+		t.Errorf("Latency.Quantile(0.3) = %v, want: %v", got, want)
+	}
+}
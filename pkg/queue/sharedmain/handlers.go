@@ -29,6 +29,7 @@ import (
 	netheader "knative.dev/networking/pkg/http/header"
 	netstats "knative.dev/networking/pkg/http/stats"
 	pkghandler "knative.dev/pkg/network/handlers"
+	"knative.dev/serving/pkg/apis/serving"
 	"knative.dev/serving/pkg/http/handler"
 	"knative.dev/serving/pkg/queue"
 	"knative.dev/serving/pkg/queue/health"
@@ -43,14 +44,16 @@ func mainHandler(
 	env config,
 	d Defaults,
 	prober func() bool,
+	breaker *queue.Breaker,
+	classes []serving.PriorityClass,
 	stats *netstats.RequestStats,
+	respStats *queue.ResponseStats,
 	logger *zap.SugaredLogger,
 	mp metric.MeterProvider,
 	tp trace.TracerProvider,
 ) (http.Handler, drainers) {
 	var drainers drainers
 	tracer := tp.Tracer("knative.dev/serving/pkg/queue")
-	breaker := buildBreaker(logger, env)
 
 	timeout := time.Duration(env.RevisionTimeoutSeconds) * time.Second
 	responseStartTimeout := 0 * time.Second
@@ -65,14 +68,25 @@ func mainHandler(
 	// Note: innermost handlers are specified first, ie. the last handler in the chain will be executed first.
 	composedHandler := d.ProxyHandler
 
+	limiter := adaptiveLimiter(logger, env, breaker)
+
 	composedHandler = requestAppMetricsHandler(logger, composedHandler, breaker, mp)
+	if limiter != nil {
+		composedHandler = limiter.SampleHandler(composedHandler)
+	}
 	composedHandler = queue.ProxyHandler(tracer, breaker, stats, composedHandler)
+	// The requests shed by the queue-proxy are not errors of the user container.
+	composedHandler = queue.ResponseStatsHandler(respStats, composedHandler)
+	if limiter != nil {
+		composedHandler = limiter.ShedHandler(composedHandler)
+	}
 	composedHandler = queue.ForwardedShimHandler(composedHandler)
 	composedHandler = handler.NewTimeoutHandler(composedHandler, "request timeout",
 		func(r *http.Request) (time.Duration, time.Duration, time.Duration) {
 			return timeout, responseStartTimeout, idleTimeout
 		}, logger)
 
+	composedHandler = queue.NewPriorityHandler(classes, composedHandler)
 	composedHandler = queue.NewRouteTagHandler(composedHandler)
 	composedHandler = withFullDuplex(composedHandler, env.EnableHTTPFullDuplex, logger)
 
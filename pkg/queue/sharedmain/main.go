@@ -48,6 +48,7 @@ import (
 	pkgnet "knative.dev/pkg/network"
 	"knative.dev/pkg/observability/runtime"
 	"knative.dev/pkg/signals"
+	"knative.dev/serving/pkg/apis/serving"
 	pkghttp "knative.dev/serving/pkg/http"
 	"knative.dev/serving/pkg/logging"
 	"knative.dev/serving/pkg/networking"
@@ -94,6 +95,8 @@ type config struct {
 	RevisionResponseStartTimeoutSeconds int    `split_words:"true"` // optional
 	RevisionIdleTimeoutSeconds          int    `split_words:"true"` // optional
 	ServingReadinessProbe               string `split_words:"true"` // optional
+	PriorityClasses                     string `split_words:"true"` // optional
+	AdaptiveConcurrency                 string `split_words:"true"` // optional
 
 	// See https://github.com/knative/serving/issues/12387
 	EnableHTTPFullDuplex       bool `split_words:"true"`                      // optional
@@ -229,11 +232,15 @@ func Main(opts ...Option) error {
 	reportTicker := time.NewTicker(reportingPeriod)
 	defer reportTicker.Stop()
 
+	classes := priorityClasses(logger, env)
+	breaker := buildBreaker(logger, env, classes)
+
 	stats := netstats.NewRequestStats(time.Now())
+	respStats := queue.NewResponseStats(breaker)
 	go func() {
 		for now := range reportTicker.C {
 			stat := stats.Report(now)
-			protoStatReporter.Report(stat)
+			protoStatReporter.Report(stat, respStats.Report())
 		}
 	}()
 
@@ -246,7 +253,17 @@ func Main(opts ...Option) error {
 	// Enable TLS when certificate is mounted.
 	tlsEnabled := exists(logger, certPath) && exists(logger, keyPath)
 
-	mainHandler, drainers := mainHandler(env, d, probe, stats, logger, mp, tp)
+	var certWatcher *certificate.CertWatcher
+	if tlsEnabled {
+		var err error
+		certWatcher, err = certificate.NewCertWatcher(certPath, keyPath, 1*time.Minute, logger, mp)
+		if err != nil {
+			logger.Fatal("failed to create certWatcher", zap.Error(err))
+		}
+		defer certWatcher.Stop()
+	}
+
+	mainHandler, drainers := mainHandler(env, d, probe, breaker, classes, stats, respStats, logger, mp, tp)
 	adminHandler := adminHandler(d.Ctx, logger, drainers.StandardDrainer)
 
 	// Enable TLS server when activator server certs are mounted.
@@ -266,8 +283,6 @@ func Main(opts ...Option) error {
 	}
 
 	var tlsServer *http.Server
-	var certWatcher *certificate.CertWatcher
-	var err error
 
 	errCh := make(chan error)
 	for name, server := range httpServers {
@@ -281,15 +296,13 @@ func Main(opts ...Option) error {
 	}
 
 	if tlsEnabled {
-		tlsServer = mainServer(":"+env.QueueServingTLSPort, mainHandler)
+		// Connections established with a previous certificate are drained
+		// once it is rotated.
+		tlsServer = mainServer(":"+env.QueueServingTLSPort, certWatcher.DrainHandler(mainHandler))
+		tlsServer.ConnContext = certWatcher.ConnContext
+		tlsServer.ConnState = certWatcher.ConnState
 		// Keep admin server on HTTP even with TLS enabled since it's only accessed locally by kubelet
 
-		certWatcher, err = certificate.NewCertWatcher(certPath, keyPath, 1*time.Minute, logger)
-		if err != nil {
-			logger.Fatal("failed to create certWatcher", zap.Error(err))
-		}
-		defer certWatcher.Stop()
-
 		tlsCfg, err := knativetls.DefaultConfigFromEnv("QUEUE_PROXY_")
 		if err != nil {
 			logger.Fatalw("Failed to read TLS configuration from environment", zap.Error(err))
@@ -403,7 +416,7 @@ func buildProxyHandler(logger *zap.SugaredLogger, env config, transport http.Rou
 	return httpProxy
 }
 
-func buildBreaker(logger *zap.SugaredLogger, env config) *queue.Breaker {
+func buildBreaker(logger *zap.SugaredLogger, env config, classes []serving.PriorityClass) *queue.Breaker {
 	if env.ContainerConcurrency < 1 {
 		return nil
 	}
@@ -415,11 +428,45 @@ func buildBreaker(logger *zap.SugaredLogger, env config) *queue.Breaker {
 		QueueDepth:      queueDepth,
 		MaxConcurrency:  env.ContainerConcurrency,
 		InitialCapacity: env.ContainerConcurrency,
+		Priorities:      queue.NewPriorityParams(classes, queueDepth),
 	}
 	logger.Infof("Queue container is starting with BreakerParams = %#v", params)
 	return queue.NewBreaker(params)
 }
 
+// adaptiveLimiter returns the limiter tuning the capacity of the breaker, if
+// the revision asks for adaptive concurrency.
+func adaptiveLimiter(logger *zap.SugaredLogger, env config, breaker *queue.Breaker) *queue.AdaptiveLimiter {
+	if env.AdaptiveConcurrency == "" {
+		return nil
+	}
+	if breaker == nil {
+		logger.Warn("Ignoring adaptive concurrency, which requires a container concurrency")
+		return nil
+	}
+	limiter, err := queue.NewAdaptiveLimiter(env.AdaptiveConcurrency, breaker, env.ContainerConcurrency)
+	if err != nil {
+		// The value is validated by the webhook, so this should never happen.
+		logger.Errorw("Ignoring invalid adaptive concurrency", zap.Error(err))
+		return nil
+	}
+	return limiter
+}
+
+// priorityClasses returns the request priority classes of the revision, if any.
+func priorityClasses(logger *zap.SugaredLogger, env config) []serving.PriorityClass {
+	if env.PriorityClasses == "" {
+		return nil
+	}
+	classes, err := serving.ParsePriorityClasses(env.PriorityClasses)
+	if err != nil {
+		// The value is validated by the webhook, so this should never happen.
+		logger.Errorw("Ignoring invalid priority classes", zap.Error(err))
+		return nil
+	}
+	return classes
+}
+
 func requestLogHandler(logger *zap.SugaredLogger, currentHandler http.Handler, env config) http.Handler {
 	revInfo := &pkghttp.RequestLogRevision{
 		Name:          env.ServingRevision,
@@ -171,6 +171,34 @@ func TestMakeDecider(t *testing.T) {
 				d.Spec.ActivationScale = 3
 				d.Annotations[autoscaling.ActivationScaleKey] = "3"
 			}),
+	}, {
+		name: "with predictive scaling and min-scale schedule",
+		pa: pa(func(pa *autoscalingv1alpha1.PodAutoscaler) {
+			pa.Annotations[autoscaling.PredictiveScalingAnnotationKey] = autoscaling.PredictiveScalingDaily
+			pa.Annotations[autoscaling.MinScaleScheduleAnnotationKey] = "0 8 * * * 1h 3"
+		}),
+		want: decider(withTarget(100.0), withPanicThreshold(2.0), withTotal(100),
+			func(d *scaling.Decider) {
+				d.Spec.PredictiveSeason = 24 * time.Hour
+				d.Spec.MinScaleSchedule = "0 8 * * * 1h 3"
+				d.Annotations[autoscaling.PredictiveScalingAnnotationKey] = autoscaling.PredictiveScalingDaily
+				d.Annotations[autoscaling.MinScaleScheduleAnnotationKey] = "0 8 * * * 1h 3"
+			}),
+	}, {
+		name: "with latency metric",
+		pa: pa(func(pa *autoscalingv1alpha1.PodAutoscaler) {
+			pa.Annotations[autoscaling.MetricAnnotationKey] = autoscaling.Latency
+			pa.Annotations[autoscaling.TargetAnnotationKey] = "200"
+			pa.Annotations[autoscaling.LatencyPercentileAnnotationKey] = "99"
+		}),
+		want: decider(withTarget(200.0), withPanicThreshold(2.0), withTotal(200),
+			func(d *scaling.Decider) {
+				d.Spec.ScalingMetric = autoscaling.Latency
+				d.Spec.LatencyPercentile = 0.99
+				d.Annotations[autoscaling.MetricAnnotationKey] = autoscaling.Latency
+				d.Annotations[autoscaling.TargetAnnotationKey] = "200"
+				d.Annotations[autoscaling.LatencyPercentileAnnotationKey] = "99"
+			}),
 	}}
 
 	for _, tc := range cases {
@@ -34,6 +34,11 @@ func ResolveMetricTarget(pa *autoscalingv1alpha1.PodAutoscaler, config *autoscal
 	case autoscaling.RPS:
 		total = config.RPSTargetDefault
 		tu = config.TargetUtilization
+	case autoscaling.Custom, autoscaling.Latency:
+		// Custom and latency metrics have no system default, the target
+		// annotation is required. It's only scaled by an explicit target
+		// utilization annotation, never by the configured default.
+		tu = 1
 	default:
 		// Concurrency is used by default
 		total = float64(pa.Spec.ContainerConcurrency)
@@ -170,6 +170,21 @@ func TestResolveMetricTarget(t *testing.T) {
 		pa:         pa(WithMetricAnnotation(autoscaling.RPS), WithTargetAnnotation("300")),
 		wantTarget: 210,
 		wantTotal:  300,
+	}, {
+		name:       "Custom: with target annotation 10",
+		pa:         pa(WithMetricAnnotation(autoscaling.Custom), WithTargetAnnotation("10"), WithPAContainerConcurrency(1)),
+		wantTarget: 10,
+		wantTotal:  10,
+	}, {
+		name:       "Custom: with target annotation 10 and TU annotation 50%",
+		pa:         pa(WithMetricAnnotation(autoscaling.Custom), WithTargetAnnotation("10"), WithTUAnnotation("50")),
+		wantTarget: 5,
+		wantTotal:  10,
+	}, {
+		name:       "Latency: with target annotation 200",
+		pa:         pa(WithMetricAnnotation(autoscaling.Latency), WithTargetAnnotation("200"), WithPAContainerConcurrency(1)),
+		wantTarget: 200,
+		wantTotal:  200,
 	}}
 
 	for _, tc := range cases {
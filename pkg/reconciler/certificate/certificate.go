@@ -21,9 +21,12 @@ import (
 	"errors"
 	"fmt"
 	"hash/adler32"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
+	acmev1 "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 
@@ -58,6 +61,10 @@ const (
 	httpDomainLabel      = "acme.cert-manager.io/http-domain"
 	httpChallengePath    = "/.well-known/acme-challenge"
 	renewingEvent        = "Renewing"
+
+	dns01ChallengePendingReason = "DNS01ChallengePending"
+	dns01ChallengeFailedReason  = "DNS01ChallengeFailed"
+	wildcardNotSupportedReason  = "WildcardRequiresDNS01"
 )
 
 // It comes from cert-manager status:
@@ -109,6 +116,10 @@ func (c *Reconciler) reconcile(ctx context.Context, knCert *v1alpha1.Certificate
 		return errors.New(errCondition.Message)
 	}
 
+	if err := c.checkWildcardSolvers(knCert, cmCert); err != nil {
+		return err
+	}
+
 	cmCert, err := c.reconcileCMCertificate(ctx, knCert, cmCert)
 	if err != nil {
 		return err
@@ -123,10 +134,10 @@ func (c *Reconciler) reconcile(ctx context.Context, knCert *v1alpha1.Certificate
 	switch {
 	case cmCertReadyCondition == nil:
 		knCert.Status.MarkNotReady(noCMConditionReason, noCMConditionMessage)
-		return c.setHTTP01Challenges(ctx, knCert, cmCert)
+		return c.setChallenges(ctx, knCert, cmCert)
 	case cmCertReadyCondition.Status == cmmeta.ConditionUnknown:
 		knCert.Status.MarkNotReady(cmCertReadyCondition.Reason, cmCertReadyCondition.Message)
-		return c.setHTTP01Challenges(ctx, knCert, cmCert)
+		return c.setChallenges(ctx, knCert, cmCert)
 	case cmCertReadyCondition.Status == cmmeta.ConditionTrue:
 		if cmCert.Status.RenewalTime != nil && time.Now().After(cmCert.Status.RenewalTime.Time) {
 			// add a temporary renewing state when cm certificate is being renewed
@@ -139,7 +150,7 @@ func (c *Reconciler) reconcile(ctx context.Context, knCert *v1alpha1.Certificate
 				Status: corev1.ConditionTrue,
 			}
 			certificateCondSet.Manage(&knCert.Status).SetCondition(renewCondition)
-			return c.setHTTP01Challenges(ctx, knCert, cmCert)
+			return c.setChallenges(ctx, knCert, cmCert)
 		}
 		// remove renew condition if exists
 		certificateCondSet.Manage(&knCert.Status).ClearCondition(renewingEvent)
@@ -151,7 +162,7 @@ func (c *Reconciler) reconcile(ctx context.Context, knCert *v1alpha1.Certificate
 		} else {
 			knCert.Status.MarkFailed(cmCertReadyCondition.Reason, cmCertReadyCondition.Message)
 		}
-		return c.setHTTP01Challenges(ctx, knCert, cmCert)
+		return c.setChallenges(ctx, knCert, cmCert)
 	}
 	return nil
 }
@@ -190,13 +201,107 @@ func (c *Reconciler) reconcileCMCertificate(ctx context.Context, knCert *v1alpha
 	return cmCert, nil
 }
 
-func (c *Reconciler) setHTTP01Challenges(ctx context.Context, knCert *v1alpha1.Certificate, cmCert *cmv1.Certificate) error {
-	logger := logging.FromContext(ctx)
-	if isHTTP, err := c.isHTTPChallenge(cmCert); err != nil {
+// checkWildcardSolvers fails the Knative Certificate when it has wildcard DNS
+// names, which its ACME issuer can't solve without a DNS-01 solver.
+func (c *Reconciler) checkWildcardSolvers(knCert *v1alpha1.Certificate, cmCert *cmv1.Certificate) error {
+	wildcard := false
+	for _, dnsName := range cmCert.Spec.DNSNames {
+		if resources.IsWildcard(dnsName) {
+			wildcard = true
+			break
+		}
+	}
+	if !wildcard {
+		return nil
+	}
+	issuer, err := c.cmIssuerLister.Get(cmCert.Spec.IssuerRef.Name)
+	if err != nil {
+		return err
+	}
+	if issuer.Spec.ACME == nil || hasDNS01Solver(issuer.Spec.ACME.Solvers) {
+		return nil
+	}
+	msg := fmt.Sprintf("Wildcard certificates can only be issued through DNS-01 challenges, but the issuer %s has no DNS-01 solver", issuer.Name)
+	knCert.Status.MarkFailed(wildcardNotSupportedReason, msg)
+	return errors.New(msg)
+}
+
+// setChallenges propagates the ACME challenges of the cert-manager
+// Certificate to the Knative Certificate, depending on the solvers of its
+// issuer.
+func (c *Reconciler) setChallenges(ctx context.Context, knCert *v1alpha1.Certificate, cmCert *cmv1.Certificate) error {
+	issuer, err := c.cmIssuerLister.Get(cmCert.Spec.IssuerRef.Name)
+	if err != nil {
 		return err
-	} else if !isHTTP {
+	}
+	if issuer.Spec.ACME == nil || len(issuer.Spec.ACME.Solvers) == 0 {
+		return nil
+	}
+	if hasDNS01Solver(issuer.Spec.ACME.Solvers) {
+		if err := c.setDNS01Challenges(knCert, cmCert); err != nil {
+			return err
+		}
+	}
+	if issuer.Spec.ACME.Solvers[0].HTTP01 != nil {
+		return c.setHTTP01Challenges(ctx, knCert, cmCert)
+	}
+	return nil
+}
+
+// setDNS01Challenges reflects the state of the DNS-01 challenges of the
+// cert-manager Certificate in the Ready condition of the Knative Certificate,
+// until it is ready. There is nothing to route for these challenges, they
+// are solved by the issuer through the DNS provider.
+func (c *Reconciler) setDNS01Challenges(knCert *v1alpha1.Certificate, cmCert *cmv1.Certificate) error {
+	if knCert.Status.GetCondition(v1alpha1.CertificateConditionReady).IsTrue() {
 		return nil
 	}
+	cmChallenges, err := c.cmChallengeLister.Challenges(cmCert.Namespace).List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list challenges: %w", err)
+	}
+	dnsNames := sets.NewString(cmCert.Spec.DNSNames...)
+	pending := make([]string, 0, len(cmCert.Spec.DNSNames))
+	sort.Slice(cmChallenges, func(i, j int) bool {
+		return cmChallenges[i].Name < cmChallenges[j].Name
+	})
+	for _, cmChallenge := range cmChallenges {
+		dnsName := cmChallenge.Spec.DNSName
+		if cmChallenge.Spec.Wildcard {
+			dnsName = "*." + dnsName
+		}
+		if cmChallenge.Spec.Type != acmev1.ACMEChallengeTypeDNS01 ||
+			cmChallenge.Spec.IssuerRef.Name != cmCert.Spec.IssuerRef.Name ||
+			!dnsNames.Has(dnsName) {
+			continue
+		}
+		if err := c.tracker.TrackReference(challengeRef(cmChallenge.Namespace, cmChallenge.Name), knCert); err != nil {
+			return err
+		}
+
+		switch cmChallenge.Status.State {
+		case acmev1.Valid:
+		case acmev1.Invalid, acmev1.Errored, acmev1.Expired:
+			knCert.Status.MarkFailed(dns01ChallengeFailedReason,
+				fmt.Sprintf("The DNS-01 challenge for %s failed: %s", dnsName, cmChallenge.Status.Reason))
+			return nil
+		default:
+			if cmChallenge.Status.Reason != "" {
+				pending = append(pending, fmt.Sprintf("%s (%s)", dnsName, cmChallenge.Status.Reason))
+			} else {
+				pending = append(pending, dnsName)
+			}
+		}
+	}
+	if len(pending) > 0 {
+		knCert.Status.MarkNotReady(dns01ChallengePendingReason,
+			"Waiting for the DNS-01 challenges for "+strings.Join(pending, ", "))
+	}
+	return nil
+}
+
+func (c *Reconciler) setHTTP01Challenges(ctx context.Context, knCert *v1alpha1.Certificate, cmCert *cmv1.Certificate) error {
+	logger := logging.FromContext(ctx)
 	challenges := make([]v1alpha1.HTTP01Challenge, 0, len(cmCert.Spec.DNSNames))
 	for _, dnsName := range cmCert.Spec.DNSNames {
 		// This selector comes from:
@@ -249,15 +354,13 @@ func (c *Reconciler) setHTTP01Challenges(ctx context.Context, knCert *v1alpha1.C
 	return nil
 }
 
-func (c *Reconciler) isHTTPChallenge(cmCert *cmv1.Certificate) (bool, error) {
-	var issuer *cmv1.ClusterIssuer
-	var err error
-	if issuer, err = c.cmIssuerLister.Get(cmCert.Spec.IssuerRef.Name); err != nil {
-		return false, err
+func hasDNS01Solver(solvers []acmev1.ACMEChallengeSolver) bool {
+	for _, solver := range solvers {
+		if solver.DNS01 != nil {
+			return true
+		}
 	}
-	return issuer.Spec.ACME != nil &&
-		len(issuer.Spec.ACME.Solvers) > 0 &&
-		issuer.Spec.ACME.Solvers[0].HTTP01 != nil, nil
+	return false
 }
 
 func svcRef(namespace, name string) tracker.Reference {
@@ -270,3 +373,13 @@ func svcRef(namespace, name string) tracker.Reference {
 		Name:       name,
 	}
 }
+
+func challengeRef(namespace, name string) tracker.Reference {
+	apiVersion, kind := acmev1.SchemeGroupVersion.WithKind("Challenge").ToAPIVersionAndKind()
+	return tracker.Reference{
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Namespace:  namespace,
+		Name:       name,
+	}
+}
@@ -63,6 +63,7 @@ var (
 	correctDNSNames   = []string{"correct-dns1.example.com", "correct-dns2.example.com"}
 	shortenedDNSNames = []string{"k.example.com", "reallyreallyreallyreallyreallyreallylongname.namespace.example.com"}
 	incorrectDNSNames = []string{"incorrect-dns.example.com"}
+	wildcardDNSNames  = []string{"*.example.com"}
 	exampleDomain     = "example.com"
 	notAfter          = &metav1.Time{
 		Time: time.Unix(123, 456),
@@ -100,10 +101,26 @@ var (
 		},
 	}
 
+	dns01Issuer = &cmv1.ClusterIssuer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "Letsencrypt-issuer",
+		},
+		Spec: cmv1.IssuerSpec{
+			IssuerConfig: cmv1.IssuerConfig{
+				ACME: &acmev1.ACMEIssuer{
+					Solvers: []acmev1.ACMEChallengeSolver{{
+						DNS01: &acmev1.ACMEChallengeSolverDNS01{},
+					}},
+				},
+			},
+		},
+	}
+
 	externalCert, _                  = resources.MakeCertManagerCertificate(certmanagerConfig(), knCert("knCert", "foo"))
 	localCert, _                     = resources.MakeCertManagerCertificate(certmanagerConfig(), withCertType(knCert("knCert", "foo"), netcfg.CertificateClusterLocalDomain))
 	systemInternalCert, _            = resources.MakeCertManagerCertificate(certmanagerConfig(), withCertType(knCert("knCert", "foo"), netcfg.CertificateSystemInternal))
 	externalCertShortenedDNSNames, _ = resources.MakeCertManagerCertificate(certmanagerConfig(), knCertShortenedDNSNames("knCert", "foo"))
+	wildcardCert, _                  = resources.MakeCertManagerCertificate(certmanagerConfig(), knCertWildcard("knCert", "foo"))
 )
 
 func TestNewController(t *testing.T) {
@@ -772,6 +789,145 @@ func TestReconcile_HTTP01Challenges(t *testing.T) {
 	}))
 }
 
+func TestReconcile_DNS01Challenges(t *testing.T) {
+	table := pkgreconcilertesting.TableTest{{
+		Name: "set pending DNS-01 challenges on Knative certificate",
+		Key:  "foo/knCert",
+		Objects: []runtime.Object{
+			cmDNS01Challenge(correctDNSNames[0], "foo", acmev1.Pending, "Waiting for DNS-01 challenge propagation"),
+			cmDNS01Challenge(correctDNSNames[1], "foo", acmev1.Valid, ""),
+			cmChallenge("other.example.com", "foo"),
+			cmCertWithStatus("knCert", "foo", correctDNSNames, []cmv1.CertificateCondition{{
+				Type:   cmv1.CertificateConditionReady,
+				Status: cmmeta.ConditionFalse,
+				Reason: "InProgress",
+			}}, nil),
+			knCert("knCert", "foo"),
+			dns01Issuer,
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: knCertWithStatus("knCert", "foo",
+				&v1alpha1.CertificateStatus{
+					NotAfter: notAfter,
+					Status: duckv1.Status{
+						ObservedGeneration: generation,
+						Conditions: duckv1.Conditions{{
+							Type:     v1alpha1.CertificateConditionReady,
+							Status:   corev1.ConditionUnknown,
+							Severity: apis.ConditionSeverityError,
+							Reason:   dns01ChallengePendingReason,
+							Message:  "Waiting for the DNS-01 challenges for correct-dns1.example.com (Waiting for DNS-01 challenge propagation)",
+						}},
+					},
+				}),
+		}},
+	}, {
+		Name: "set failed DNS-01 challenge on Knative certificate",
+		Key:  "foo/knCert",
+		Objects: []runtime.Object{
+			cmDNS01Challenge(correctDNSNames[0], "foo", acmev1.Invalid, "NXDOMAIN looking up TXT records"),
+			cmDNS01Challenge(correctDNSNames[1], "foo", acmev1.Pending, ""),
+			cmCertWithStatus("knCert", "foo", correctDNSNames, []cmv1.CertificateCondition{{
+				Type:   cmv1.CertificateConditionReady,
+				Status: cmmeta.ConditionFalse,
+				Reason: "InProgress",
+			}}, nil),
+			knCert("knCert", "foo"),
+			dns01Issuer,
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: knCertWithStatus("knCert", "foo",
+				&v1alpha1.CertificateStatus{
+					NotAfter: notAfter,
+					Status: duckv1.Status{
+						ObservedGeneration: generation,
+						Conditions: duckv1.Conditions{{
+							Type:     v1alpha1.CertificateConditionReady,
+							Status:   corev1.ConditionFalse,
+							Severity: apis.ConditionSeverityError,
+							Reason:   dns01ChallengeFailedReason,
+							Message:  "The DNS-01 challenge for correct-dns1.example.com failed: NXDOMAIN looking up TXT records",
+						}},
+					},
+				}),
+		}},
+	}, {
+		Name: "create wildcard CM certificate with DNS-01 issuer",
+		Key:  "foo/knCert",
+		Objects: []runtime.Object{
+			knCertWildcard("knCert", "foo"),
+			dns01Issuer,
+		},
+		WantCreates: []runtime.Object{
+			wildcardCert,
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: withDNSNames(knCertWithStatus("knCert", "foo",
+				&v1alpha1.CertificateStatus{
+					Status: duckv1.Status{
+						ObservedGeneration: generation,
+						Conditions: duckv1.Conditions{{
+							Type:     v1alpha1.CertificateConditionReady,
+							Status:   corev1.ConditionUnknown,
+							Severity: apis.ConditionSeverityError,
+							Reason:   noCMConditionReason,
+							Message:  noCMConditionMessage,
+						}},
+					},
+				}), wildcardDNSNames),
+		}},
+		WantEvents: []string{
+			pkgreconcilertesting.Eventf(corev1.EventTypeNormal, "Created", "Created Cert-Manager Certificate %s/%s", "foo", "knCert"),
+		},
+	}, {
+		Name:    "wildcard certificate requires DNS-01 issuer",
+		Key:     "foo/knCert",
+		WantErr: true,
+		Objects: []runtime.Object{
+			knCertWildcard("knCert", "foo"),
+			http01Issuer,
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: withDNSNames(knCertWithStatus("knCert", "foo",
+				&v1alpha1.CertificateStatus{
+					Status: duckv1.Status{
+						ObservedGeneration: generation,
+						Conditions: duckv1.Conditions{{
+							Type:     v1alpha1.CertificateConditionReady,
+							Status:   corev1.ConditionFalse,
+							Severity: apis.ConditionSeverityError,
+							Reason:   wildcardNotSupportedReason,
+							Message:  "Wildcard certificates can only be issued through DNS-01 challenges, but the issuer Letsencrypt-issuer has no DNS-01 solver",
+						}},
+					},
+				}), wildcardDNSNames),
+		}},
+		WantEvents: []string{
+			pkgreconcilertesting.Eventf(corev1.EventTypeWarning, "InternalError", "Wildcard certificates can only be issued through DNS-01 challenges, but the issuer Letsencrypt-issuer has no DNS-01 solver"),
+		},
+	}}
+
+	table.Test(t, certmanagertesting.MakeFactory(func(ctx context.Context, listers *certmanagertesting.Listers, cmw configmap.Watcher) controller.Reconciler {
+		r := &Reconciler{
+			cmCertificateLister: listers.GetCMCertificateLister(),
+			cmChallengeLister:   listers.GetCMChallengeLister(),
+			cmIssuerLister:      listers.GetCMClusterIssuerLister(),
+			svcLister:           listers.GetK8sServiceLister(),
+			certManagerClient:   fakecertmanagerclient.Get(ctx),
+			tracker:             &pkgreconcilertesting.NullTracker{},
+		}
+		return certreconciler.NewReconciler(ctx, logging.FromContext(ctx), networkingclient.Get(ctx),
+			listers.GetCertificateLister(), controller.GetEventRecorder(ctx), r,
+			netcfg.CertManagerCertificateClassName, controller.Options{
+				ConfigStore: &testConfigStore{
+					config: &config.Config{
+						CertManager: certmanagerConfig(),
+					},
+				},
+			})
+	}))
+}
+
 type testConfigStore struct {
 	config *config.Config
 }
@@ -809,6 +965,15 @@ func knCertShortenedDNSNames(name, namespace string) *v1alpha1.Certificate {
 	return cert
 }
 
+func knCertWildcard(name, namespace string) *v1alpha1.Certificate {
+	return withDNSNames(knCert(name, namespace), wildcardDNSNames)
+}
+
+func withDNSNames(certificate *v1alpha1.Certificate, dnsNames []string) *v1alpha1.Certificate {
+	certificate.Spec.DNSNames = dnsNames
+	return certificate
+}
+
 func knCertDomainTooLong(name, namespace string, status *v1alpha1.CertificateStatus, gen int) *v1alpha1.Certificate {
 	return &v1alpha1.Certificate{
 		ObjectMeta: metav1.ObjectMeta{
@@ -893,6 +1058,28 @@ func cmChallenge(hostname, namespace string) *acmev1.Challenge {
 	}
 }
 
+func cmDNS01Challenge(hostname, namespace string, state acmev1.State, reason string) *acmev1.Challenge {
+	return &acmev1.Challenge{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "challenge-" + hostname,
+			Namespace: namespace,
+		},
+		Spec: acmev1.ChallengeSpec{
+			Type:    acmev1.ACMEChallengeTypeDNS01,
+			DNSName: hostname,
+			Token:   "cm-challenge-token",
+			IssuerRef: cmmeta.ObjectReference{
+				Kind: "ClusterIssuer",
+				Name: "Letsencrypt-issuer",
+			},
+		},
+		Status: acmev1.ChallengeStatus{
+			State:  state,
+			Reason: reason,
+		},
+	}
+}
+
 func cmSolverService(hostname, namespace string) *corev1.Service {
 	return &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
@@ -17,8 +17,13 @@ limitations under the License.
 package config
 
 import (
+	"errors"
+	"fmt"
+
 	cmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/yaml"
 )
 
@@ -26,6 +31,7 @@ const (
 	issuerRefKey             = "issuerRef"
 	clusterLocalIssuerRefKey = "clusterLocalIssuerRef"
 	systemInternalIssuerRef  = "systemInternalIssuerRef"
+	domainIssuerRefsKey      = "domainIssuerRefs"
 
 	// CertManagerConfigName is the name of the configmap containing all
 	// configuration related to Cert-Manager.
@@ -44,6 +50,31 @@ type CertManagerConfig struct {
 	IssuerRef               *cmeta.ObjectReference
 	ClusterLocalIssuerRef   *cmeta.ObjectReference
 	SystemInternalIssuerRef *cmeta.ObjectReference
+
+	// DomainIssuerRefs override IssuerRef for the external-domain
+	// certificates they select, e.g. to issue them through DNS-01
+	// challenges.
+	DomainIssuerRefs []DomainIssuerRef
+}
+
+// DomainIssuerRef selects the issuer of external-domain certificates by
+// their domains or their labels.
+type DomainIssuerRef struct {
+	// Name identifies the entry in the certificate-issuer annotation, or
+	// label, of a certificate.
+	Name string `json:"name"`
+
+	// Domains select the certificates whose names are all within one of
+	// these domains, or their subdomains.
+	// +optional
+	Domains []string `json:"domains,omitempty"`
+
+	// Selector selects the certificates matching these labels.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// IssuerRef is the issuer of the selected certificates.
+	IssuerRef *cmeta.ObjectReference `json:"issuerRef"`
 }
 
 // NewCertManagerConfigFromConfigMap creates an CertManagerConfig from the supplied ConfigMap
@@ -73,5 +104,35 @@ func NewCertManagerConfigFromConfigMap(configMap *corev1.ConfigMap) (*CertManage
 		}
 	}
 
+	if v, ok := configMap.Data[domainIssuerRefsKey]; ok {
+		if err := yaml.Unmarshal([]byte(v), &config.DomainIssuerRefs); err != nil {
+			return nil, err
+		}
+		if err := validateDomainIssuerRefs(config.DomainIssuerRefs); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", domainIssuerRefsKey, err)
+		}
+	}
+
 	return config, nil
 }
+
+func validateDomainIssuerRefs(refs []DomainIssuerRef) error {
+	names := sets.New[string]()
+	for _, ref := range refs {
+		switch {
+		case ref.Name == "":
+			return errors.New("name is required")
+		case names.Has(ref.Name):
+			return fmt.Errorf("name %q is duplicated", ref.Name)
+		case ref.IssuerRef == nil || ref.IssuerRef.Name == "":
+			return fmt.Errorf("issuerRef of %q is required", ref.Name)
+		}
+		names.Insert(ref.Name)
+		if ref.Selector != nil {
+			if _, err := metav1.LabelSelectorAsSelector(ref.Selector); err != nil {
+				return fmt.Errorf("selector of %q: %w", ref.Name, err)
+			}
+		}
+	}
+	return nil
+}
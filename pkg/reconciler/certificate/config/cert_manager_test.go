@@ -165,3 +165,97 @@ func TestIssuerRef(t *testing.T) {
 		})
 	}
 }
+
+func TestDomainIssuerRefs(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []DomainIssuerRef
+		wantErr bool
+	}{{
+		name: "valid",
+		data: `
+- name: private
+  domains:
+  - internal.example.com
+  issuerRef:
+    kind: ClusterIssuer
+    name: dns01-issuer
+- name: namespaces
+  selector:
+    matchLabels:
+      networking.knative.dev/wildcardDomain: example.com
+  issuerRef:
+    kind: Issuer
+    name: wildcard-issuer`,
+		want: []DomainIssuerRef{{
+			Name:    "private",
+			Domains: []string{"internal.example.com"},
+			IssuerRef: &cmmeta.ObjectReference{
+				Kind: "ClusterIssuer",
+				Name: "dns01-issuer",
+			},
+		}, {
+			Name: "namespaces",
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"networking.knative.dev/wildcardDomain": "example.com"},
+			},
+			IssuerRef: &cmmeta.ObjectReference{
+				Kind: "Issuer",
+				Name: "wildcard-issuer",
+			},
+		}},
+	}, {
+		name:    "invalid format",
+		data:    "wrong format",
+		wantErr: true,
+	}, {
+		name:    "missing name",
+		data:    "- issuerRef: {kind: ClusterIssuer, name: dns01-issuer}",
+		wantErr: true,
+	}, {
+		name: "duplicated name",
+		data: `
+- name: private
+  issuerRef: {kind: ClusterIssuer, name: dns01-issuer}
+- name: private
+  issuerRef: {kind: ClusterIssuer, name: other-issuer}`,
+		wantErr: true,
+	}, {
+		name:    "missing issuerRef",
+		data:    "- name: private",
+		wantErr: true,
+	}, {
+		name: "invalid selector",
+		data: `
+- name: private
+  selector:
+    matchExpressions:
+    - {key: foo, operator: Bogus}
+  issuerRef: {kind: ClusterIssuer, name: dns01-issuer}`,
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewCertManagerConfigFromConfigMap(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: system.Namespace(),
+					Name:      CertManagerConfigName,
+				},
+				Data: map[string]string{
+					domainIssuerRefsKey: tt.data,
+				},
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewCertManagerConfigFromConfigMap() error = %v, WantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !cmp.Equal(got.DomainIssuerRefs, tt.want) {
+				t.Error("DomainIssuerRefs (-want, +got):", cmp.Diff(tt.want, got.DomainIssuerRefs))
+			}
+		})
+	}
+}
@@ -23,6 +23,7 @@ package config
 
 import (
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -43,6 +44,13 @@ func (in *CertManagerConfig) DeepCopyInto(out *CertManagerConfig) {
 		*out = new(v1.ObjectReference)
 		**out = **in
 	}
+	if in.DomainIssuerRefs != nil {
+		in, out := &in.DomainIssuerRefs, &out.DomainIssuerRefs
+		*out = make([]DomainIssuerRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -55,3 +63,34 @@ func (in *CertManagerConfig) DeepCopy() *CertManagerConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DomainIssuerRef) DeepCopyInto(out *DomainIssuerRef) {
+	*out = *in
+	if in.Domains != nil {
+		in, out := &in.Domains, &out.Domains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IssuerRef != nil {
+		in, out := &in.IssuerRef, &out.IssuerRef
+		*out = new(v1.ObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DomainIssuerRef.
+func (in *DomainIssuerRef) DeepCopy() *DomainIssuerRef {
+	if in == nil {
+		return nil
+	}
+	out := new(DomainIssuerRef)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -19,6 +19,7 @@ package certificate
 import (
 	"context"
 
+	acmev1 "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/tools/cache"
@@ -115,5 +116,12 @@ func NewController(
 		),
 	))
 
+	cmChallengeInformer.Informer().AddEventHandler(controller.HandleAll(
+		controller.EnsureTypeMeta(
+			c.tracker.OnChanged,
+			acmev1.SchemeGroupVersion.WithKind("Challenge"),
+		),
+	))
+
 	return impl
 }
@@ -18,17 +18,20 @@ package resources
 
 import (
 	"fmt"
+	"strings"
 
 	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"knative.dev/networking/pkg/apis/networking"
 	"knative.dev/networking/pkg/apis/networking/v1alpha1"
 	netapi "knative.dev/networking/pkg/config"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/kmeta"
+	"knative.dev/serving/pkg/apis/serving"
 	"knative.dev/serving/pkg/reconciler/certificate/config"
 )
 
@@ -142,15 +145,11 @@ func MakeCertManagerCertificate(cmConfig *config.CertManagerConfig, knCert *v1al
 		issuerRef = *cmConfig.SystemInternalIssuerRef
 
 	case netapi.CertificateExternalDomain:
-		if cmConfig.IssuerRef == nil {
-			return nil, &apis.Condition{
-				Type:    IssuerNotSetCondition,
-				Status:  corev1.ConditionFalse,
-				Reason:  "issuerRef not set",
-				Message: "error creating cert-manager certificate: issuerRef was not set in config-certmanager",
-			}
+		ref, errCondition := externalDomainIssuerRef(cmConfig, knCert, dnsNames)
+		if errCondition != nil {
+			return nil, errCondition
 		}
-		issuerRef = *cmConfig.IssuerRef
+		issuerRef = *ref
 
 	default:
 		return nil, &apis.Condition{
@@ -184,6 +183,76 @@ func MakeCertManagerCertificate(cmConfig *config.CertManagerConfig, knCert *v1al
 	return cert, nil
 }
 
+// externalDomainIssuerRef selects the issuer of an external-domain certificate
+// among the domain issuers of the config, in favor of the entry named by its
+// certificate-issuer label or annotation, and defaults to the issuerRef.
+func externalDomainIssuerRef(cmConfig *config.CertManagerConfig, knCert *v1alpha1.Certificate, dnsNames []string) (*cmeta.ObjectReference, *apis.Condition) {
+	name, ok := knCert.Labels[serving.CertificateIssuerKey]
+	if !ok {
+		name, ok = knCert.Annotations[serving.CertificateIssuerKey]
+	}
+	for _, ref := range cmConfig.DomainIssuerRefs {
+		if ok {
+			if ref.Name == name {
+				return ref.IssuerRef, nil
+			}
+			continue
+		}
+		if ref.Selector != nil {
+			// The selectors are validated while loading the config.
+			if selector, err := metav1.LabelSelectorAsSelector(ref.Selector); err == nil && selector.Matches(labels.Set(knCert.Labels)) {
+				return ref.IssuerRef, nil
+			}
+		}
+		if len(ref.Domains) > 0 && coversAll(ref.Domains, dnsNames) {
+			return ref.IssuerRef, nil
+		}
+	}
+	if ok {
+		return nil, &apis.Condition{
+			Type:    IssuerNotSetCondition,
+			Status:  corev1.ConditionFalse,
+			Reason:  "certificate issuer not found",
+			Message: fmt.Sprintf("error creating cert-manager certificate: certificate issuer %q was not found in domainIssuerRefs of config-certmanager", name),
+		}
+	}
+	if cmConfig.IssuerRef == nil {
+		return nil, &apis.Condition{
+			Type:    IssuerNotSetCondition,
+			Status:  corev1.ConditionFalse,
+			Reason:  "issuerRef not set",
+			Message: "error creating cert-manager certificate: issuerRef was not set in config-certmanager",
+		}
+	}
+	return cmConfig.IssuerRef, nil
+}
+
+// coversAll returns whether each of the DNS names is one of the domains or
+// one of their subdomains.
+func coversAll(domains, dnsNames []string) bool {
+	if len(dnsNames) == 0 {
+		return false
+	}
+	for _, dnsName := range dnsNames {
+		covered := false
+		for _, domain := range domains {
+			if dnsName == domain || strings.HasSuffix(dnsName, "."+domain) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+// IsWildcard returns whether the DNS name is a wildcard, e.g. "*.example.com".
+func IsWildcard(dnsName string) bool {
+	return strings.HasPrefix(dnsName, "*.")
+}
+
 // GetReadyCondition gets the ready condition of a Cert-Manager `Certificate`.
 func GetReadyCondition(cmCert *cmv1.Certificate) *cmv1.CertificateCondition {
 	for _, cond := range cmCert.Status.Conditions {
@@ -410,6 +410,92 @@ func TestMakeCertManagerCertificateSystemInternalIssuerNotSet(t *testing.T) {
 	}
 }
 
+func TestMakeCertManagerCertificateDomainIssuerRefs(t *testing.T) {
+	dns01Issuer := &cmmeta.ObjectReference{Kind: "ClusterIssuer", Name: "dns01-issuer"}
+	routeIssuer := &cmmeta.ObjectReference{Kind: "Issuer", Name: "route-issuer"}
+	domainConfig := cmConfig.DeepCopy()
+	domainConfig.DomainIssuerRefs = []config.DomainIssuerRef{{
+		Name:      "route",
+		Selector:  &metav1.LabelSelector{MatchLabels: map[string]string{servingRouteLabelKey: "other-route"}},
+		IssuerRef: routeIssuer,
+	}, {
+		Name:      "private",
+		Domains:   []string{"internal.example.com", "example.org"},
+		IssuerRef: dns01Issuer,
+	}}
+
+	tests := []struct {
+		name     string
+		dnsNames []string
+		labels   map[string]string
+		annos    map[string]string
+		want     *cmmeta.ObjectReference
+		wantErr  string
+	}{{
+		name:     "default issuer",
+		dnsNames: []string{"host1.example.com"},
+		want:     cmConfig.IssuerRef,
+	}, {
+		name:     "domain",
+		dnsNames: []string{"host1.internal.example.com", "example.org", "*.example.org"},
+		want:     dns01Issuer,
+	}, {
+		name:     "domain does not cover all the names",
+		dnsNames: []string{"host1.internal.example.com", "host1.example.com"},
+		want:     cmConfig.IssuerRef,
+	}, {
+		name:     "suffix is not a subdomain",
+		dnsNames: []string{"notexample.org"},
+		want:     cmConfig.IssuerRef,
+	}, {
+		name:     "selector",
+		dnsNames: []string{"host1.internal.example.com"},
+		labels:   map[string]string{servingRouteLabelKey: "other-route"},
+		want:     routeIssuer,
+	}, {
+		name:     "annotation",
+		dnsNames: []string{"host1.example.com"},
+		annos:    map[string]string{"serving.knative.dev/certificate-issuer": "private"},
+		want:     dns01Issuer,
+	}, {
+		name:     "label overrides the domain",
+		dnsNames: []string{"host1.internal.example.com"},
+		labels:   map[string]string{"serving.knative.dev/certificate-issuer": "route"},
+		want:     routeIssuer,
+	}, {
+		name:     "issuer not found",
+		dnsNames: []string{"host1.internal.example.com"},
+		annos:    map[string]string{"serving.knative.dev/certificate-issuer": "missing"},
+		wantErr:  `error creating cert-manager certificate: certificate issuer "missing" was not found in domainIssuerRefs of config-certmanager`,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			knCert := cert.DeepCopy()
+			knCert.Spec.DNSNames = test.dnsNames
+			knCert.Labels = kmeta.UnionMaps(knCert.Labels, test.labels)
+			knCert.Annotations = kmeta.UnionMaps(knCert.Annotations, test.annos)
+
+			got, gotErr := MakeCertManagerCertificate(domainConfig, knCert)
+			if test.wantErr != "" {
+				if gotErr == nil {
+					t.Fatal("MakeCertManagerCertificate succeeded, want error:", test.wantErr)
+				}
+				if gotErr.Message != test.wantErr {
+					t.Errorf("MakeCertManagerCertificate error = %q, want: %q", gotErr.Message, test.wantErr)
+				}
+				return
+			}
+			if gotErr != nil {
+				t.Fatal("MakeCertManagerCertificate error:", gotErr.Message)
+			}
+			if diff := cmp.Diff(*test.want, got.Spec.IssuerRef); diff != "" {
+				t.Errorf("IssuerRef (-want, +got) = %s", diff)
+			}
+		})
+	}
+}
+
 func TestGetReadyCondition(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -0,0 +1,209 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package domainmapping
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/tracker"
+	"knative.dev/serving/pkg/apis/serving"
+	"knative.dev/serving/pkg/apis/serving/v1beta1"
+)
+
+const (
+	// defaultCertificateExpiryWarning is how long before the expiry of an
+	// externally provided certificate to warn about it, unless overridden by
+	// the certificate-expiry-warning annotation of the DomainMapping.
+	defaultCertificateExpiryWarning = 30 * 24 * time.Hour
+)
+
+// certificateError is a problem with an externally provided certificate,
+// surfaced with its reason in the CertificateProvisioned condition.
+type certificateError struct {
+	reason  string
+	message string
+}
+
+func (e *certificateError) Error() string {
+	return e.message
+}
+
+func newCertificateError(reason, format string, args ...interface{}) error {
+	return &certificateError{reason: reason, message: fmt.Sprintf(format, args...)}
+}
+
+// reconcileExternalCertificate checks the certificate provided externally
+// through spec.tls, and surfaces its problems and its expiry in the status
+// of the DomainMapping. The Ingress keeps using the certificate regardless,
+// so only the errors getting the Secret are returned.
+func (r *Reconciler) reconcileExternalCertificate(ctx context.Context, dm *v1beta1.DomainMapping) error {
+	name := dm.Spec.TLS.SecretName
+	if err := r.tracker.TrackReference(tracker.Reference{
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Namespace:  dm.Namespace,
+		Name:       name,
+	}, dm); err != nil {
+		return fmt.Errorf("failed to track Secret %q: %w", name, err)
+	}
+
+	secret, err := r.secrets.Get(ctx, types.NamespacedName{Namespace: dm.Namespace, Name: dm.Name},
+		types.NamespacedName{Namespace: dm.Namespace, Name: name})
+	if apierrs.IsNotFound(err) {
+		err = newCertificateError("SecretNotFound", "Secret %q does not exist", name)
+	} else if err != nil {
+		return fmt.Errorf("failed to get Secret %q: %w", name, err)
+	}
+
+	recorder := controller.GetEventRecorder(ctx)
+	previous := dm.Status.GetCondition(v1beta1.DomainMappingConditionCertificateProvisioned)
+	now := r.clock.Now()
+	var cert *x509.Certificate
+	if err == nil {
+		cert, err = checkExternalCertificate(secret, dm.Host(), now)
+	}
+	if cert != nil {
+		dm.Status.CertificateNotAfter = &metav1.Time{Time: cert.NotAfter}
+	} else {
+		dm.Status.CertificateNotAfter = nil
+	}
+
+	var certErr *certificateError
+	if errors.As(err, &certErr) {
+		if previous == nil || previous.Reason != certErr.reason {
+			recorder.Event(dm, corev1.EventTypeWarning, certErr.reason, certErr.message)
+		}
+		dm.Status.MarkCertificateInvalid(certErr.reason, certErr.message)
+		return nil
+	}
+
+	warning := certificateExpiryWarning(ctx, dm)
+	if cert.NotAfter.Sub(now) <= warning {
+		// Check the certificate again once expired.
+		r.enqueueAfter(dm, cert.NotAfter.Sub(now))
+		if previous == nil || previous.Reason != v1beta1.CertificateExpiringSoonReason {
+			recorder.Eventf(dm, corev1.EventTypeWarning, v1beta1.CertificateExpiringSoonReason,
+				"The TLS certificate of Secret %q expires at %s", name, cert.NotAfter.UTC().Format(time.RFC3339))
+		}
+		dm.Status.MarkCertificateExpiringSoon(cert.NotAfter)
+		return nil
+	}
+	// Warn about the expiry in time.
+	r.enqueueAfter(dm, cert.NotAfter.Add(-warning).Sub(now))
+	dm.Status.MarkCertificateNotRequired(v1beta1.TLSCertificateProvidedExternally)
+	return nil
+}
+
+// certificateExpiryWarning returns how long before the expiry of the
+// externally provided certificate of the DomainMapping to warn about it.
+func certificateExpiryWarning(ctx context.Context, dm *v1beta1.DomainMapping) time.Duration {
+	if k, v, ok := serving.CertificateExpiryWarningAnnotation.Get(dm.Annotations); ok {
+		d, err := time.ParseDuration(v)
+		if err == nil && d > 0 {
+			return d
+		}
+		// Validation should've caught an invalid value here.
+		logging.FromContext(ctx).Warnf("DM.Annotations[%s] = %q is invalid", k, v)
+	}
+	return defaultCertificateExpiryWarning
+}
+
+// checkExternalCertificate checks that the certificate of the TLS Secret is
+// paired with its private key, covers the host, and is valid at now. The
+// certificate is returned whenever it could be parsed.
+func checkExternalCertificate(secret *corev1.Secret, host string, now time.Time) (*x509.Certificate, error) {
+	certPEM, keyPEM := secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey]
+	if len(certPEM) == 0 || len(keyPEM) == 0 {
+		return nil, newCertificateError("InvalidCertificate", "Secret %q must hold the %q and %q keys",
+			secret.Name, corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+	}
+
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, newCertificateError("InvalidCertificate", "Secret %q does not hold a PEM encoded certificate", secret.Name)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, newCertificateError("InvalidCertificate", "failed to parse the certificate of Secret %q: %v", secret.Name, err)
+	}
+
+	key, err := parsePrivateKey(keyPEM)
+	if err != nil {
+		return cert, newCertificateError("InvalidCertificate", "failed to parse the private key of Secret %q: %v", secret.Name, err)
+	}
+	if pub, ok := key.Public().(interface{ Equal(crypto.PublicKey) bool }); !ok || !pub.Equal(cert.PublicKey) {
+		return cert, newCertificateError("CertificateKeyMismatch",
+			"The private key of Secret %q does not match its certificate", secret.Name)
+	}
+
+	if err := cert.VerifyHostname(host); err != nil {
+		return cert, newCertificateError("CertificateHostMismatch",
+			"The certificate of Secret %q is not valid for %q, only for %s", secret.Name, host, strings.Join(cert.DNSNames, ", "))
+	}
+
+	switch {
+	case now.Before(cert.NotBefore):
+		return cert, newCertificateError("CertificateNotYetValid",
+			"The certificate of Secret %q is not valid before %s", secret.Name, cert.NotBefore.UTC().Format(time.RFC3339))
+	case now.After(cert.NotAfter):
+		return cert, newCertificateError("CertificateExpired",
+			"The certificate of Secret %q expired at %s", secret.Name, cert.NotAfter.UTC().Format(time.RFC3339))
+	}
+	return cert, nil
+}
+
+// parsePrivateKey parses the first PEM encoded private key, in the PKCS #1,
+// PKCS #8 or SEC 1 forms, like crypto/tls.
+func parsePrivateKey(keyPEM []byte) (crypto.Signer, error) {
+	for {
+		var block *pem.Block
+		block, keyPEM = pem.Decode(keyPEM)
+		if block == nil {
+			return nil, errors.New("no PEM encoded private key")
+		}
+		if block.Type != "PRIVATE KEY" && !strings.HasSuffix(block.Type, " PRIVATE KEY") {
+			continue
+		}
+
+		if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+			return key, nil
+		}
+		if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+			if signer, ok := key.(crypto.Signer); ok {
+				return signer, nil
+			}
+			return nil, errors.New("unsupported private key type")
+		}
+		if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+			return key, nil
+		}
+		return nil, errors.New("unsupported private key form")
+	}
+}
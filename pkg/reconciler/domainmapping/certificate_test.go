@@ -0,0 +1,162 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package domainmapping
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// tlsSecret returns a TLS Secret holding a self-signed certificate for the
+// hosts, valid between notBefore and notAfter.
+func tlsSecret(t testing.TB, ns, name string, hosts []string, notBefore, notAfter time.Time) *corev1.Secret {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal("GenerateKey() =", err)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: hosts[0]},
+		DNSNames:     hosts,
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}, &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: hosts[0]},
+	}, key.Public(), key)
+	if err != nil {
+		t.Fatal("CreateCertificate() =", err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal("MarshalPKCS8PrivateKey() =", err)
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ns,
+			Name:      name,
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			corev1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}),
+		},
+	}
+}
+
+func TestCheckExternalCertificate(t *testing.T) {
+	now := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	notBefore, notAfter := now.Add(-24*time.Hour), now.Add(90*24*time.Hour)
+	valid := tlsSecret(t, "ns", "valid", []string{"example.com"}, notBefore, notAfter)
+	other := tlsSecret(t, "ns", "other", []string{"example.com"}, notBefore, notAfter)
+
+	tests := []struct {
+		name       string
+		secret     *corev1.Secret
+		host       string
+		wantReason string
+	}{{
+		name:   "valid",
+		secret: valid,
+		host:   "example.com",
+	}, {
+		name:   "wildcard certificate",
+		secret: tlsSecret(t, "ns", "wildcard", []string{"*.example.com"}, notBefore, notAfter),
+		host:   "a.example.com",
+	}, {
+		name:   "wildcard host",
+		secret: tlsSecret(t, "ns", "wildcard", []string{"*.example.com"}, notBefore, notAfter),
+		host:   "*.example.com",
+	}, {
+		name:       "wildcard host without wildcard certificate",
+		secret:     tlsSecret(t, "ns", "exact", []string{"a.example.com"}, notBefore, notAfter),
+		host:       "*.example.com",
+		wantReason: "CertificateHostMismatch",
+	}, {
+		name:       "host not covered",
+		secret:     valid,
+		host:       "example.org",
+		wantReason: "CertificateHostMismatch",
+	}, {
+		name: "missing key",
+		secret: &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "missing"},
+			Data:       map[string][]byte{corev1.TLSCertKey: valid.Data[corev1.TLSCertKey]},
+		},
+		host:       "example.com",
+		wantReason: "InvalidCertificate",
+	}, {
+		name: "not a certificate",
+		secret: &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "garbage"},
+			Data: map[string][]byte{
+				corev1.TLSCertKey:       []byte("garbage"),
+				corev1.TLSPrivateKeyKey: valid.Data[corev1.TLSPrivateKeyKey],
+			},
+		},
+		host:       "example.com",
+		wantReason: "InvalidCertificate",
+	}, {
+		name: "mismatched key",
+		secret: &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "mismatched"},
+			Data: map[string][]byte{
+				corev1.TLSCertKey:       valid.Data[corev1.TLSCertKey],
+				corev1.TLSPrivateKeyKey: other.Data[corev1.TLSPrivateKeyKey],
+			},
+		},
+		host:       "example.com",
+		wantReason: "CertificateKeyMismatch",
+	}, {
+		name:       "not yet valid",
+		secret:     tlsSecret(t, "ns", "future", []string{"example.com"}, now.Add(time.Hour), notAfter),
+		host:       "example.com",
+		wantReason: "CertificateNotYetValid",
+	}, {
+		name:       "expired",
+		secret:     tlsSecret(t, "ns", "expired", []string{"example.com"}, notBefore, now.Add(-time.Hour)),
+		host:       "example.com",
+		wantReason: "CertificateExpired",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := checkExternalCertificate(test.secret, test.host, now)
+			var certErr *certificateError
+			switch {
+			case test.wantReason == "" && err != nil:
+				t.Error("checkExternalCertificate() =", err)
+			case test.wantReason != "" && !errors.As(err, &certErr):
+				t.Errorf("checkExternalCertificate() = %v, want reason %s", err, test.wantReason)
+			case test.wantReason != "" && certErr.reason != test.wantReason:
+				t.Errorf("reason = %s, want %s (%v)", certErr.reason, test.wantReason, err)
+			}
+		})
+	}
+}
@@ -19,12 +19,15 @@ package domainmapping
 import (
 	"context"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/clock"
 	netclient "knative.dev/networking/pkg/client/injection/client"
 	certificateinformer "knative.dev/networking/pkg/client/injection/informers/networking/v1alpha1/certificate"
 	domainclaiminformer "knative.dev/networking/pkg/client/injection/informers/networking/v1alpha1/clusterdomainclaim"
 	ingressinformer "knative.dev/networking/pkg/client/injection/informers/networking/v1alpha1/ingress"
 	netcfg "knative.dev/networking/pkg/config"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
@@ -48,6 +51,7 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		ingressLister:     ingressInformer.Lister(),
 		domainClaimLister: domainClaimInformer.Lister(),
 		netclient:         netclient.Get(ctx),
+		clock:             clock.RealClock{},
 	}
 
 	impl := kindreconciler.NewImpl(ctx, r, func(impl *controller.Impl) controller.Options {
@@ -71,7 +75,14 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	certificateInformer.Informer().AddEventHandler(handleControllerOf)
 	ingressInformer.Informer().AddEventHandler(handleControllerOf)
 
+	// Check the externally provided certificates again when their Secret
+	// changes. Only the Secrets referenced by the DomainMappings are watched.
+	r.secrets = newSecretWatcher(ctx, kubeclient.Get(ctx), controller.HandleAll(
+		controller.EnsureTypeMeta(impl.Tracker.OnChanged, corev1.SchemeGroupVersion.WithKind("Secret"))))
+
 	r.resolver = resolver.NewURIResolverFromTracker(ctx, impl.Tracker)
+	r.tracker = impl.Tracker
+	r.enqueueAfter = impl.EnqueueAfter
 
 	return impl
 }
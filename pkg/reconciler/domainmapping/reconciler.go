@@ -23,6 +23,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	kaccessor "knative.dev/serving/pkg/reconciler/accessor"
 	networkaccessor "knative.dev/serving/pkg/reconciler/accessor/networking"
@@ -31,6 +32,9 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
 
 	netapi "knative.dev/networking/pkg/apis/networking"
 	netv1alpha1 "knative.dev/networking/pkg/apis/networking/v1alpha1"
@@ -44,6 +48,7 @@ import (
 	"knative.dev/pkg/network"
 	"knative.dev/pkg/reconciler"
 	"knative.dev/pkg/resolver"
+	"knative.dev/pkg/tracker"
 	v1 "knative.dev/serving/pkg/apis/serving/v1"
 	"knative.dev/serving/pkg/apis/serving/v1beta1"
 	domainmappingreconciler "knative.dev/serving/pkg/client/injection/reconciler/serving/v1beta1/domainmapping"
@@ -60,6 +65,10 @@ type Reconciler struct {
 	domainClaimLister networkinglisters.ClusterDomainClaimLister
 	netclient         netclientset.Interface
 	resolver          *resolver.URIResolver
+	secrets           secretGetter
+	tracker           tracker.Interface
+	clock             clock.PassiveClock
+	enqueueAfter      func(interface{}, time.Duration)
 }
 
 // Check that our Reconciler implements Interface
@@ -94,8 +103,9 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, dm *v1beta1.DomainMappin
 		dm.Status.MarkIngressNotConfigured()
 	}
 
-	// Mapped URL is the metadata.name of the DomainMapping.
-	url := &apis.URL{Scheme: config.FromContext(ctx).Network.DefaultExternalScheme, Host: dm.Name}
+	// Mapped URL is the metadata.name of the DomainMapping, or its subdomains
+	// for a wildcard DomainMapping.
+	url := &apis.URL{Scheme: config.FromContext(ctx).Network.DefaultExternalScheme, Host: dm.Host()}
 	dm.Status.URL = url
 	dm.Status.Address = &duckv1.Addressable{URL: url}
 
@@ -116,8 +126,9 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, dm *v1beta1.DomainMappin
 		return err
 	}
 
-	// Resolve the spec.Ref to a URI following the Addressable contract.
-	targetHost, targetBackendSvc, err := r.resolveRef(ctx, dm)
+	// Resolve the spec.Ref, and the refs of the paths, to URIs following the
+	// Addressable contract.
+	backends, err := r.resolveBackends(ctx, dm)
 	if err != nil {
 		return err
 	}
@@ -129,8 +140,10 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, dm *v1beta1.DomainMappin
 	}
 
 	// Reconcile the Ingress resource corresponding to the requested Mapping.
-	logger.Debugf("Mapping %s to ref %s/%s (host: %q, svc: %q)", url, dm.Spec.Ref.Namespace, dm.Spec.Ref.Name, targetHost, targetBackendSvc)
-	desired := resources.MakeIngress(dm, targetBackendSvc, targetHost, ingressClass, httpOption, tls, acmeChallenges...)
+	for _, backend := range backends {
+		logger.Debugf("Mapping %s%s to host: %q, svc: %q", url, backend.Path, backend.Host, backend.ServiceName)
+	}
+	desired := resources.MakeIngress(dm, backends, ingressClass, httpOption, tls, acmeChallenges...)
 	ingress, err := r.reconcileIngress(ctx, dm, desired)
 	if err != nil {
 		return err
@@ -148,12 +161,14 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, dm *v1beta1.DomainMappin
 
 // FinalizeKind cleans up the ClusterDomainClaim created by the DomainMapping.
 func (r *Reconciler) FinalizeKind(ctx context.Context, dm *v1beta1.DomainMapping) reconciler.Event {
+	r.secrets.Forget(types.NamespacedName{Namespace: dm.Namespace, Name: dm.Name})
+
 	if !config.FromContext(ctx).Network.AutocreateClusterDomainClaims {
 		// If we're not responsible for creating domain claims, we're not responsible for cleaning them up.
 		return nil
 	}
 
-	dc, err := r.domainClaimLister.Get(dm.Name)
+	dc, err := r.domainClaimLister.Get(resources.DomainClaimName(dm))
 	if err != nil {
 		if apierrs.IsNotFound(err) {
 			// Nothing to do since the domain was never claimed.
@@ -169,7 +184,7 @@ func (r *Reconciler) FinalizeKind(ctx context.Context, dm *v1beta1.DomainMapping
 		return nil
 	}
 
-	return r.netclient.NetworkingV1alpha1().ClusterDomainClaims().Delete(ctx, dm.Name, metav1.DeleteOptions{})
+	return r.netclient.NetworkingV1alpha1().ClusterDomainClaims().Delete(ctx, dc.Name, metav1.DeleteOptions{})
 }
 
 func externalDomainTLSEnabled(ctx context.Context, dm *v1beta1.DomainMapping) bool {
@@ -195,14 +210,18 @@ func certClass(ctx context.Context) string {
 
 func (r *Reconciler) tls(ctx context.Context, dm *v1beta1.DomainMapping) ([]netv1alpha1.IngressTLS, []netv1alpha1.HTTP01Challenge, error) {
 	if dm.Spec.TLS != nil {
-		dm.Status.MarkCertificateNotRequired(v1beta1.TLSCertificateProvidedExternally)
+		if err := r.reconcileExternalCertificate(ctx, dm); err != nil {
+			return nil, nil, err
+		}
 		dm.Status.URL.Scheme = "https"
 		return []netv1alpha1.IngressTLS{{
-			Hosts:           []string{dm.Name},
+			Hosts:           []string{dm.Host()},
 			SecretName:      dm.Spec.TLS.SecretName,
 			SecretNamespace: dm.Namespace,
 		}}, nil, nil
 	}
+	// Stop watching the Secret the DomainMapping may have referenced.
+	r.secrets.Forget(types.NamespacedName{Namespace: dm.Namespace, Name: dm.Name})
 
 	if !externalDomainTLSEnabled(ctx, dm) {
 		dm.Status.MarkTLSNotEnabled(v1.ExternalDomainTLSNotEnabledMessage)
@@ -221,7 +240,7 @@ func (r *Reconciler) tls(ctx context.Context, dm *v1beta1.DomainMapping) ([]netv
 	}
 
 	for _, dnsName := range desiredCert.Spec.DNSNames {
-		if dnsName == dm.Name {
+		if dnsName == dm.Host() {
 			dm.Status.URL.Scheme = "https"
 			break
 		}
@@ -278,8 +297,40 @@ func (r *Reconciler) reconcileIngress(ctx context.Context, dm *v1beta1.DomainMap
 	return ingress, err
 }
 
-func (r *Reconciler) resolveRef(ctx context.Context, dm *v1beta1.DomainMapping) (host, backendSvc string, err error) {
-	resolved, err := r.resolver.URIFromKReference(ctx, &dm.Spec.Ref, dm)
+// resolveBackends resolves the ref of the DomainMapping, and the refs of its
+// paths, to the backends of the Ingress.
+func (r *Reconciler) resolveBackends(ctx context.Context, dm *v1beta1.DomainMapping) ([]resources.Backend, error) {
+	backends := make([]resources.Backend, 0, 1+len(dm.Spec.Paths))
+	host, backendSvc, err := r.resolveRef(ctx, dm, &dm.Spec.Ref)
+	if err != nil {
+		return nil, err
+	}
+	backends = append(backends, resources.Backend{
+		ServiceName: backendSvc,
+		Host:        host,
+		Headers:     dm.Spec.Headers,
+	})
+
+	for i := range dm.Spec.Paths {
+		path := &dm.Spec.Paths[i]
+		host, backendSvc, err := r.resolveRef(ctx, dm, &path.Ref)
+		if err != nil {
+			return nil, fmt.Errorf("path %q: %w", path.Path, err)
+		}
+		backends = append(backends, resources.Backend{
+			Path:        path.Path,
+			ServiceName: backendSvc,
+			Host:        host,
+			Headers:     path.Headers,
+		})
+	}
+
+	dm.Status.MarkReferenceResolved()
+	return backends, nil
+}
+
+func (r *Reconciler) resolveRef(ctx context.Context, dm *v1beta1.DomainMapping, ref *duckv1.KReference) (host, backendSvc string, err error) {
+	resolved, err := r.resolver.URIFromKReference(ctx, ref, dm)
 	if err != nil {
 		dm.Status.MarkReferenceNotResolved(err.Error())
 		return "", "", fmt.Errorf("resolving reference: %w", err)
@@ -313,37 +364,93 @@ func (r *Reconciler) resolveRef(ctx context.Context, dm *v1beta1.DomainMapping)
 		return "", "", fmt.Errorf("resolved URI %q must be in same namespace as DomainMapping", resolved)
 	}
 
-	dm.Status.MarkReferenceResolved()
 	return resolved.Host, parts[0], nil
 }
 
 func (r *Reconciler) reconcileDomainClaim(ctx context.Context, dm *v1beta1.DomainMapping) error {
-	dc, err := r.domainClaimLister.Get(dm.Name)
+	dc, err := r.domainClaimLister.Get(resources.DomainClaimName(dm))
 	if err != nil && !apierrs.IsNotFound(err) {
 		return fmt.Errorf("failed to get ClusterDomainClaim: %w", err)
 	} else if apierrs.IsNotFound(err) {
-		if err := r.createDomainClaim(ctx, dm); err != nil {
+		claims, err := r.domainClaimLister.List(labels.Everything())
+		if err != nil {
+			return fmt.Errorf("failed to list ClusterDomainClaims: %w", err)
+		}
+		if err := checkDomainClaimOverlaps(dm, nil, claims); err != nil {
+			return err
+		}
+		dc, err := r.createDomainClaim(ctx, dm)
+		if err != nil {
+			return err
+		}
+		// The informer may not know yet of the overlapping claims created
+		// concurrently by other namespaces, so check the claims of the API
+		// server again now that the claim is owned, before the Ingress is created.
+		list, err := r.netclient.NetworkingV1alpha1().ClusterDomainClaims().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to list ClusterDomainClaims: %w", err)
+		}
+		claims = make([]*netv1alpha1.ClusterDomainClaim, 0, len(list.Items))
+		for i := range list.Items {
+			claims = append(claims, &list.Items[i])
+		}
+		if err := checkDomainClaimOverlaps(dm, dc, claims); err != nil {
 			return err
 		}
 	} else if dm.Namespace != dc.Spec.Namespace {
 		dm.Status.MarkDomainClaimNotOwned()
-		return fmt.Errorf("namespace %q does not own ClusterDomainClaim for %q", dm.Namespace, dm.Name)
+		return fmt.Errorf("namespace %q does not own ClusterDomainClaim for %q", dm.Namespace, dm.Host())
+	} else {
+		claims, err := r.domainClaimLister.List(labels.Everything())
+		if err != nil {
+			return fmt.Errorf("failed to list ClusterDomainClaims: %w", err)
+		}
+		if err := checkDomainClaimOverlaps(dm, dc, claims); err != nil {
+			return err
+		}
 	}
 
 	dm.Status.MarkDomainClaimed()
 	return nil
 }
 
-func (r *Reconciler) createDomainClaim(ctx context.Context, dm *v1beta1.DomainMapping) error {
+// checkDomainClaimOverlaps returns an error if the host of the DomainMapping
+// overlaps with a host claimed by another namespace, e.g. `a.example.com` and
+// `*.example.com`. When the DomainMapping already owns a claim, only the claims
+// created before it are considered, so that the first claim wins.
+func checkDomainClaimOverlaps(dm *v1beta1.DomainMapping, own *netv1alpha1.ClusterDomainClaim, claims []*netv1alpha1.ClusterDomainClaim) error {
+	for _, dc := range claims {
+		if dc.Spec.Namespace == dm.Namespace || (own != nil && !claimedBefore(dc, own)) {
+			continue
+		}
+		if host := resources.ClaimedHost(dc); resources.HostsOverlap(dm.Host(), host) {
+			dm.Status.MarkDomainClaimOverlaps(host)
+			return fmt.Errorf("domain %q overlaps with %q claimed by namespace %q", dm.Host(), host, dc.Spec.Namespace)
+		}
+	}
+	return nil
+}
+
+// claimedBefore returns whether the claim a was created before the claim b.
+// The creation timestamps only have a precision of a second, so the claims
+// created at the same second are ordered by name, for a single claim to win.
+func claimedBefore(a, b *netv1alpha1.ClusterDomainClaim) bool {
+	if a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.Name < b.Name
+	}
+	return a.CreationTimestamp.Before(&b.CreationTimestamp)
+}
+
+func (r *Reconciler) createDomainClaim(ctx context.Context, dm *v1beta1.DomainMapping) (*netv1alpha1.ClusterDomainClaim, error) {
 	if !config.FromContext(ctx).Network.AutocreateClusterDomainClaims {
 		dm.Status.MarkDomainClaimNotOwned()
-		return fmt.Errorf("no ClusterDomainClaim found for domain %q (and autocreate-cluster-domain-claims property is not true)", dm.Name)
+		return nil, fmt.Errorf("no ClusterDomainClaim found for domain %q (and autocreate-cluster-domain-claims property is not true)", dm.Host())
 	}
 
-	_, err := r.netclient.NetworkingV1alpha1().ClusterDomainClaims().Create(ctx, resources.MakeDomainClaim(dm), metav1.CreateOptions{})
+	dc, err := r.netclient.NetworkingV1alpha1().ClusterDomainClaims().Create(ctx, resources.MakeDomainClaim(dm), metav1.CreateOptions{})
 	if err != nil {
-		return fmt.Errorf("failed to create ClusterDomainClaim: %w", err)
+		return nil, fmt.Errorf("failed to create ClusterDomainClaim: %w", err)
 	}
 
-	return nil
+	return dc, nil
 }
@@ -32,5 +32,5 @@ import (
 func MakeCertificate(dm *v1beta1.DomainMapping, certClass string) *networkingv1alpha1.Certificate {
 	certName := kmeta.ChildName(dm.GetName(), "")
 	return routeresources.MakeCertificate(
-		dm, serving.DomainMappingUIDLabelKey, dm.Name, certName, certClass, dm.Name)
+		dm, serving.DomainMappingUIDLabelKey, dm.Host(), certName, certClass, dm.Name)
 }
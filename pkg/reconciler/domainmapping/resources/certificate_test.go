@@ -109,6 +109,39 @@ func TestMakeCertificate(t *testing.T) {
 				SecretName: "mapping.com",
 			},
 		},
+	}, {
+		name: "wildcard",
+		dm: v1beta1.DomainMapping{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mapping.com",
+				Namespace: "the-namespace",
+			},
+			Spec: v1beta1.DomainMappingSpec{
+				Ref: duckv1.KReference{
+					Namespace: "the-namespace",
+					Name:      "the-name",
+				},
+				Wildcard: true,
+			},
+		},
+		want: networkingv1alpha1.Certificate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "mapping.com",
+				Namespace:   "the-namespace",
+				Annotations: map[string]string{networking.CertificateClassAnnotationKey: certClass},
+				Labels: map[string]string{
+					serving.DomainMappingUIDLabelKey:   "mapping.com",
+					networking.CertificateTypeLabelKey: string(config.CertificateExternalDomain),
+				},
+			},
+			Spec: networkingv1alpha1.CertificateSpec{
+				Domain: "mapping.com",
+				DNSNames: []string{
+					"*.mapping.com",
+				},
+				SecretName: "mapping.com",
+			},
+		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.want.OwnerReferences = []metav1.OwnerReference{*kmeta.NewControllerRef(&tc.dm)}
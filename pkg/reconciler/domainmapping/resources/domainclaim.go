@@ -17,17 +17,74 @@ limitations under the License.
 package resources
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	netv1alpha1 "knative.dev/networking/pkg/apis/networking/v1alpha1"
+	"knative.dev/serving/pkg/apis/serving"
 	"knative.dev/serving/pkg/apis/serving/v1beta1"
 )
 
+// wildcardClaimPrefix prefixes the names of the ClusterDomainClaims of the
+// wildcard DomainMappings. Since the names can't hold a "*", they are made of a
+// hash of the domain in a single label, which the name of a DomainMapping, a
+// fully qualified domain name, can't be.
+const wildcardClaimPrefix = "wildcard-"
+
+// DomainClaimName returns the name of the ClusterDomainClaim of the given
+// DomainMapping: its name, or `wildcard-{hash of the name}` for a wildcard
+// DomainMapping.
+func DomainClaimName(dm *v1beta1.DomainMapping) string {
+	if dm.Spec.Wildcard {
+		return wildcardClaimName(dm.Name)
+	}
+	return dm.Name
+}
+
+func wildcardClaimName(domain string) string {
+	sum := sha256.Sum256([]byte(domain))
+	return wildcardClaimPrefix + hex.EncodeToString(sum[:16])
+}
+
+// ClaimedHost returns the host claimed by the ClusterDomainClaim: the wildcard
+// host `*.{domain}` for the claim of a wildcard DomainMapping, annotated with
+// its domain, or its name otherwise.
+func ClaimedHost(dc *netv1alpha1.ClusterDomainClaim) string {
+	if domain, ok := dc.Annotations[serving.WildcardDomainAnnotationKey]; ok && dc.Name == wildcardClaimName(domain) {
+		return "*." + domain
+	}
+	return dc.Name
+}
+
+// HostsOverlap returns whether the hosts, either of which may be a wildcard
+// host matching the subdomains at any depth, match a common host.
+func HostsOverlap(a, b string) bool {
+	aDomain, aWildcard := strings.CutPrefix(a, "*")
+	bDomain, bWildcard := strings.CutPrefix(b, "*")
+	switch {
+	case aWildcard && bWildcard:
+		return strings.HasSuffix(aDomain, bDomain) || strings.HasSuffix(bDomain, aDomain)
+	case aWildcard:
+		return strings.HasSuffix(b, aDomain)
+	case bWildcard:
+		return strings.HasSuffix(a, bDomain)
+	}
+	return a == b
+}
+
 // MakeDomainClaim creates a ClusterDomainClaim named after the given DomainMapping
 // and giving ownership of the domain name to the DomainMapping's namespace.
 func MakeDomainClaim(dm *v1beta1.DomainMapping) *netv1alpha1.ClusterDomainClaim {
+	var annotations map[string]string
+	if dm.Spec.Wildcard {
+		annotations = map[string]string{serving.WildcardDomainAnnotationKey: dm.Name}
+	}
 	return &netv1alpha1.ClusterDomainClaim{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: dm.Name,
+			Name:        DomainClaimName(dm),
+			Annotations: annotations,
 		},
 		Spec: netv1alpha1.ClusterDomainClaimSpec{
 			Namespace: dm.Namespace,
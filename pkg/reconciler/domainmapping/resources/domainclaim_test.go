@@ -22,6 +22,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	netv1alpha1 "knative.dev/networking/pkg/apis/networking/v1alpha1"
+	"knative.dev/serving/pkg/apis/serving"
 	"knative.dev/serving/pkg/apis/serving/v1beta1"
 )
 
@@ -46,3 +47,91 @@ func TestMakeDomainClaim(t *testing.T) {
 		t.Errorf("Unexpected DomainClaim (-want, +got):\n%s", cmp.Diff(want, got))
 	}
 }
+
+// wildcardHash is the hash of "tenant.mapping.com" in the name of its wildcard
+// claim.
+const wildcardHash = "d000d2952fae1df9be1aedc99e8b1c2d"
+
+func TestMakeWildcardDomainClaim(t *testing.T) {
+	dm := &v1beta1.DomainMapping{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tenant.mapping.com",
+			Namespace: "the-namespace",
+		},
+		Spec: v1beta1.DomainMappingSpec{Wildcard: true},
+	}
+	got := MakeDomainClaim(dm)
+
+	want := &netv1alpha1.ClusterDomainClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "wildcard-" + wildcardHash,
+			Annotations: map[string]string{serving.WildcardDomainAnnotationKey: "tenant.mapping.com"},
+		},
+		Spec: netv1alpha1.ClusterDomainClaimSpec{
+			Namespace: "the-namespace",
+		},
+	}
+
+	if !cmp.Equal(want, got) {
+		t.Errorf("Unexpected DomainClaim (-want, +got):\n%s", cmp.Diff(want, got))
+	}
+	if got, want := ClaimedHost(got), dm.Host(); got != want {
+		t.Errorf("ClaimedHost() = %q, want: %q", got, want)
+	}
+}
+
+func TestClaimedHost(t *testing.T) {
+	claim := func(name string, annotations map[string]string) *netv1alpha1.ClusterDomainClaim {
+		return &netv1alpha1.ClusterDomainClaim{ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: annotations}}
+	}
+	for _, tc := range []struct {
+		name  string
+		claim *netv1alpha1.ClusterDomainClaim
+		want  string
+	}{{
+		name:  "domain",
+		claim: claim("tenant.mapping.com", nil),
+		want:  "tenant.mapping.com",
+	}, {
+		name:  "domain looking like the former wildcard claims",
+		claim: claim("wildcard.tenant.mapping.com", nil),
+		want:  "wildcard.tenant.mapping.com",
+	}, {
+		name:  "wildcard",
+		claim: claim("wildcard-"+wildcardHash, map[string]string{serving.WildcardDomainAnnotationKey: "tenant.mapping.com"}),
+		want:  "*.tenant.mapping.com",
+	}, {
+		name:  "annotation not matching the name",
+		claim: claim("tenant.mapping.com", map[string]string{serving.WildcardDomainAnnotationKey: "mapping.com"}),
+		want:  "tenant.mapping.com",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ClaimedHost(tc.claim); got != tc.want {
+				t.Errorf("ClaimedHost() = %q, want: %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestHostsOverlap(t *testing.T) {
+	for _, tc := range []struct {
+		a, b string
+		want bool
+	}{
+		{a: "mapping.com", b: "mapping.com", want: true},
+		{a: "mapping.com", b: "other.com"},
+		{a: "*.mapping.com", b: "a.mapping.com", want: true},
+		{a: "*.mapping.com", b: "a.b.mapping.com", want: true},
+		{a: "*.mapping.com", b: "mapping.com"},
+		{a: "*.mapping.com", b: "amapping.com"},
+		{a: "a.mapping.com", b: "*.mapping.com", want: true},
+		{a: "*.mapping.com", b: "*.mapping.com", want: true},
+		{a: "*.a.mapping.com", b: "*.mapping.com", want: true},
+		{a: "*.mapping.com", b: "*.a.mapping.com", want: true},
+		{a: "*.amapping.com", b: "*.mapping.com"},
+	} {
+		if got := HostsOverlap(tc.a, tc.b); got != tc.want {
+			t.Errorf("HostsOverlap(%q, %q) = %v, want: %v", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package resources
 
 import (
+	"slices"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
@@ -29,37 +31,78 @@ import (
 	routeresources "knative.dev/serving/pkg/reconciler/route/resources"
 )
 
+// Backend is a Service receiving the requests of a DomainMapping.
+type Backend struct {
+	// Path is the path prefix of the requests the Service receives, or empty
+	// for the requests to any other path.
+	Path string
+
+	// ServiceName is the name of the Service, in the namespace of the
+	// DomainMapping.
+	ServiceName string
+
+	// Host is the host the requests are rewritten to.
+	Host string
+
+	// Headers manipulates the headers of the requests. Only setting request
+	// headers is supported by the KIngress.
+	Headers *servingv1beta1.DomainMappingHeaders
+}
+
 // MakeIngress creates an Ingress object for a DomainMapping.  The Ingress is
 // always created in the same namespace as the DomainMapping, and the ingress
-// backend is always in the same namespace also (as this is required by
-// KIngress).  The created ingress will contain a RewriteHost rule to cause the
-// given hostName to be used as the host.
-func MakeIngress(dm *servingv1beta1.DomainMapping, backendServiceName, hostName, ingressClass string, httpOption netv1alpha1.HTTPOption, tls []netv1alpha1.IngressTLS, acmeChallenges ...netv1alpha1.HTTP01Challenge) *netv1alpha1.Ingress {
+// backends are always in the same namespace also (as this is required by
+// KIngress).  The created ingress will contain a RewriteHost rule per backend
+// to cause the host of the backend to be used as the host. The requests are
+// routed to the backend with the longest matching path prefix, and the request
+// headers set by the backend are appended to them.
+func MakeIngress(dm *servingv1beta1.DomainMapping, backends []Backend, ingressClass string, httpOption netv1alpha1.HTTPOption, tls []netv1alpha1.IngressTLS, acmeChallenges ...netv1alpha1.HTTP01Challenge) *netv1alpha1.Ingress {
+	// The paths are matched in order, so the longest prefixes go first.
+	backends = slices.Clone(backends)
+	slices.SortStableFunc(backends, func(a, b Backend) int {
+		return len(b.Path) - len(a.Path)
+	})
+
+	var originalHost map[string]string
+	if !dm.Spec.Wildcard {
+		// The original host of a wildcard mapping is only known per request.
+		originalHost = map[string]string{
+			netheader.OriginalHostKey: dm.Name,
+		}
+	}
+	paths := make([]netv1alpha1.HTTPIngressPath, 0, len(backends))
+	for _, backend := range backends {
+		appendHeaders := originalHost
+		if h := backend.Headers; h != nil && h.Request != nil && len(h.Request.Set) > 0 {
+			appendHeaders = kmeta.UnionMaps(h.Request.Set, originalHost)
+		}
+		paths = append(paths, netv1alpha1.HTTPIngressPath{
+			Path:        backend.Path,
+			RewriteHost: backend.Host,
+			Splits: []netv1alpha1.IngressBackendSplit{{
+				Percent:       100,
+				AppendHeaders: appendHeaders,
+				IngressBackend: netv1alpha1.IngressBackend{
+					ServiceNamespace: dm.Namespace,
+					ServiceName:      backend.ServiceName,
+					ServicePort:      intstr.FromInt(80),
+				},
+			}},
+		})
+	}
+
 	// Traffic rule
 	rules := []netv1alpha1.IngressRule{{
-		Hosts:      []string{dm.Name},
+		Hosts:      []string{dm.Host()},
 		Visibility: netv1alpha1.IngressVisibilityExternalIP,
 		HTTP: &netv1alpha1.HTTPIngressRuleValue{
-			Paths: []netv1alpha1.HTTPIngressPath{{
-				RewriteHost: hostName,
-				Splits: []netv1alpha1.IngressBackendSplit{{
-					Percent: 100,
-					AppendHeaders: map[string]string{
-						netheader.OriginalHostKey: dm.Name,
-					},
-					IngressBackend: netv1alpha1.IngressBackend{
-						ServiceNamespace: dm.Namespace,
-						ServiceName:      backendServiceName,
-						ServicePort:      intstr.FromInt(80),
-					},
-				}},
-			}},
+			Paths: paths,
 		},
 	}}
 
 	// Handle ACME challenges
 	for _, challenge := range acmeChallenges {
-		if challenge.URL.Host == dm.Name {
+		if challenge.URL.Host == dm.Host() {
 			// Merge ACME challenge into the main rule (matching host)
 			acmePath := routeresources.MakeACMEIngressPath(challenge)
 			// Prepend ACME path before traffic path
@@ -39,6 +39,7 @@ func TestMakeIngress(t *testing.T) {
 		name           string
 		dm             v1beta1.DomainMapping
 		want           netv1alpha1.Ingress
+		backends       []Backend
 		tls            []netv1alpha1.IngressTLS
 		acmeChallenges []netv1alpha1.HTTP01Challenge
 	}{{
@@ -298,6 +299,213 @@ func TestMakeIngress(t *testing.T) {
 				}},
 			},
 		},
+	}, {
+		name: "paths",
+		dm: v1beta1.DomainMapping{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mapping.com",
+				Namespace: "the-namespace",
+				UID:       types.UID("the-uid"),
+			},
+			Spec: v1beta1.DomainMappingSpec{
+				Ref: duckv1.KReference{
+					Namespace: "the-namespace",
+					Name:      "the-name",
+				},
+			},
+		},
+		backends: []Backend{
+			{ServiceName: "the-target-svc", Host: "the-rewrite-host"},
+			{Path: "/api", ServiceName: "the-api-svc", Host: "the-api-host"},
+			{Path: "/api/v2", ServiceName: "the-api-v2-svc", Host: "the-api-v2-host"},
+		},
+		want: netv1alpha1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mapping.com",
+				Namespace: "the-namespace",
+				Annotations: map[string]string{
+					netapi.IngressClassAnnotationKey: "the-ingress-class",
+				},
+			},
+			Spec: netv1alpha1.IngressSpec{
+				HTTPOption: netv1alpha1.HTTPOptionEnabled,
+				Rules: []netv1alpha1.IngressRule{{
+					Hosts:      []string{"mapping.com"},
+					Visibility: netv1alpha1.IngressVisibilityExternalIP,
+					HTTP: &netv1alpha1.HTTPIngressRuleValue{
+						// The longest prefixes come first.
+						Paths: []netv1alpha1.HTTPIngressPath{{
+							Path:        "/api/v2",
+							RewriteHost: "the-api-v2-host",
+							Splits: []netv1alpha1.IngressBackendSplit{{
+								Percent: 100,
+								AppendHeaders: map[string]string{
+									netheader.OriginalHostKey: "mapping.com",
+								},
+								IngressBackend: netv1alpha1.IngressBackend{
+									ServiceName:      "the-api-v2-svc",
+									ServiceNamespace: "the-namespace",
+									ServicePort:      intstr.FromInt(80),
+								},
+							}},
+						}, {
+							Path:        "/api",
+							RewriteHost: "the-api-host",
+							Splits: []netv1alpha1.IngressBackendSplit{{
+								Percent: 100,
+								AppendHeaders: map[string]string{
+									netheader.OriginalHostKey: "mapping.com",
+								},
+								IngressBackend: netv1alpha1.IngressBackend{
+									ServiceName:      "the-api-svc",
+									ServiceNamespace: "the-namespace",
+									ServicePort:      intstr.FromInt(80),
+								},
+							}},
+						}, {
+							RewriteHost: "the-rewrite-host",
+							Splits: []netv1alpha1.IngressBackendSplit{{
+								Percent: 100,
+								AppendHeaders: map[string]string{
+									netheader.OriginalHostKey: "mapping.com",
+								},
+								IngressBackend: netv1alpha1.IngressBackend{
+									ServiceName:      "the-target-svc",
+									ServiceNamespace: "the-namespace",
+									ServicePort:      intstr.FromInt(80),
+								},
+							}},
+						}},
+					},
+				}},
+			},
+		},
+	}, {
+		name: "wildcard",
+		dm: v1beta1.DomainMapping{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mapping.com",
+				Namespace: "the-namespace",
+				UID:       types.UID("the-uid"),
+			},
+			Spec: v1beta1.DomainMappingSpec{
+				Ref: duckv1.KReference{
+					Namespace: "the-namespace",
+					Name:      "the-name",
+				},
+				Wildcard: true,
+			},
+		},
+		want: netv1alpha1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mapping.com",
+				Namespace: "the-namespace",
+				Annotations: map[string]string{
+					netapi.IngressClassAnnotationKey: "the-ingress-class",
+				},
+			},
+			Spec: netv1alpha1.IngressSpec{
+				HTTPOption: netv1alpha1.HTTPOptionEnabled,
+				Rules: []netv1alpha1.IngressRule{{
+					Hosts:      []string{"*.mapping.com"},
+					Visibility: netv1alpha1.IngressVisibilityExternalIP,
+					HTTP: &netv1alpha1.HTTPIngressRuleValue{
+						Paths: []netv1alpha1.HTTPIngressPath{{
+							RewriteHost: "the-rewrite-host",
+							Splits: []netv1alpha1.IngressBackendSplit{{
+								Percent: 100,
+								IngressBackend: netv1alpha1.IngressBackend{
+									ServiceName:      "the-target-svc",
+									ServiceNamespace: "the-namespace",
+									ServicePort:      intstr.FromInt(80),
+								},
+							}},
+						}},
+					},
+				}},
+			},
+		},
+	}, {
+		name: "request headers",
+		dm: v1beta1.DomainMapping{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mapping.com",
+				Namespace: "the-namespace",
+				UID:       types.UID("the-uid"),
+			},
+			Spec: v1beta1.DomainMappingSpec{
+				Ref: duckv1.KReference{
+					Namespace: "the-namespace",
+					Name:      "the-name",
+				},
+			},
+		},
+		backends: []Backend{{
+			ServiceName: "the-target-svc",
+			Host:        "the-rewrite-host",
+			Headers: &v1beta1.DomainMappingHeaders{
+				Request: &v1beta1.HeaderOperations{
+					Set: map[string]string{"X-Tenant": "web"},
+				},
+			},
+		}, {
+			Path:        "/api",
+			ServiceName: "the-api-svc",
+			Host:        "the-api-host",
+			Headers: &v1beta1.DomainMappingHeaders{
+				Request: &v1beta1.HeaderOperations{
+					Set: map[string]string{"X-Forwarded-Prefix": "/api"},
+				},
+			},
+		}},
+		want: netv1alpha1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mapping.com",
+				Namespace: "the-namespace",
+				Annotations: map[string]string{
+					netapi.IngressClassAnnotationKey: "the-ingress-class",
+				},
+			},
+			Spec: netv1alpha1.IngressSpec{
+				HTTPOption: netv1alpha1.HTTPOptionEnabled,
+				Rules: []netv1alpha1.IngressRule{{
+					Hosts:      []string{"mapping.com"},
+					Visibility: netv1alpha1.IngressVisibilityExternalIP,
+					HTTP: &netv1alpha1.HTTPIngressRuleValue{
+						Paths: []netv1alpha1.HTTPIngressPath{{
+							Path:        "/api",
+							RewriteHost: "the-api-host",
+							Splits: []netv1alpha1.IngressBackendSplit{{
+								Percent: 100,
+								AppendHeaders: map[string]string{
+									"X-Forwarded-Prefix":      "/api",
+									netheader.OriginalHostKey: "mapping.com",
+								},
+								IngressBackend: netv1alpha1.IngressBackend{
+									ServiceName:      "the-api-svc",
+									ServiceNamespace: "the-namespace",
+									ServicePort:      intstr.FromInt(80),
+								},
+							}},
+						}, {
+							RewriteHost: "the-rewrite-host",
+							Splits: []netv1alpha1.IngressBackendSplit{{
+								Percent: 100,
+								AppendHeaders: map[string]string{
+									"X-Tenant":                "web",
+									netheader.OriginalHostKey: "mapping.com",
+								},
+								IngressBackend: netv1alpha1.IngressBackend{
+									ServiceName:      "the-target-svc",
+									ServiceNamespace: "the-namespace",
+									ServicePort:      intstr.FromInt(80),
+								},
+							}},
+						}},
+					},
+				}},
+			},
+		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.want.Labels = kmeta.UnionMaps(tc.dm.Labels, map[string]string{
@@ -305,8 +513,11 @@ func TestMakeIngress(t *testing.T) {
 				serving.DomainMappingNamespaceLabelKey: "the-namespace",
 			})
 			tc.want.OwnerReferences = []metav1.OwnerReference{*kmeta.NewControllerRef(&tc.dm)}
-			got := *MakeIngress(&tc.dm,
-				"the-target-svc", "the-rewrite-host", "the-ingress-class",
+			backends := tc.backends
+			if backends == nil {
+				backends = []Backend{{ServiceName: "the-target-svc", Host: "the-rewrite-host"}}
+			}
+			got := *MakeIngress(&tc.dm, backends, "the-ingress-class",
 				netv1alpha1.HTTPOptionEnabled,
 				tc.tls, tc.acmeChallenges...)
 			if diff := cmp.Diff(tc.want, got); diff != "" {
@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package domainmapping
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	coreinformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/controller"
+)
+
+// secretGetter gets the Secrets referenced by the DomainMappings.
+type secretGetter interface {
+	// Get returns the Secret referenced by the owner, watching it from now
+	// on, in place of the one the owner referenced before.
+	Get(ctx context.Context, owner, secret types.NamespacedName) (*corev1.Secret, error)
+	// Forget stops watching the Secret referenced by the owner, unless
+	// other owners reference it too.
+	Forget(owner types.NamespacedName)
+}
+
+// secretWatcher watches each of the Secrets referenced by the DomainMappings
+// through an informer selecting it by name, so that neither the Secrets of
+// the cluster nor those of the namespaces are cached.
+type secretWatcher struct {
+	// ctx bounds the lifetime of the informers.
+	ctx     context.Context
+	client  kubernetes.Interface
+	handler cache.ResourceEventHandler
+
+	mu sync.Mutex
+	// watches holds the informer of each watched Secret.
+	watches map[types.NamespacedName]*secretWatch
+	// owners maps the DomainMappings to the Secret they reference.
+	owners map[types.NamespacedName]types.NamespacedName
+}
+
+// secretWatch is the informer of a single Secret.
+type secretWatch struct {
+	informer cache.SharedIndexInformer
+	cancel   context.CancelFunc
+	owners   int
+}
+
+var _ secretGetter = (*secretWatcher)(nil)
+
+func newSecretWatcher(ctx context.Context, client kubernetes.Interface, handler cache.ResourceEventHandler) *secretWatcher {
+	return &secretWatcher{
+		ctx:     ctx,
+		client:  client,
+		handler: handler,
+		watches: make(map[types.NamespacedName]*secretWatch),
+		owners:  make(map[types.NamespacedName]types.NamespacedName),
+	}
+}
+
+// Get implements secretGetter.
+func (w *secretWatcher) Get(ctx context.Context, owner, secret types.NamespacedName) (*corev1.Secret, error) {
+	informer := w.watch(owner, secret)
+	if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+		return nil, fmt.Errorf("failed to sync the informer of Secret %q", secret)
+	}
+	obj, exists, err := informer.GetStore().GetByKey(secret.String())
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, apierrs.NewNotFound(corev1.Resource("secrets"), secret.Name)
+	}
+	return obj.(*corev1.Secret), nil
+}
+
+// watch returns the informer of the Secret referenced by the owner,
+// starting it if needed.
+func (w *secretWatcher) watch(owner, secret types.NamespacedName) cache.SharedIndexInformer {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if prev, ok := w.owners[owner]; ok && prev != secret {
+		w.release(prev)
+	}
+	sw, ok := w.watches[secret]
+	if !ok {
+		informer := coreinformers.NewFilteredSecretInformer(w.client, secret.Namespace,
+			controller.GetResyncPeriod(w.ctx), cache.Indexers{},
+			func(opts *metav1.ListOptions) {
+				opts.FieldSelector = fields.OneTermEqualSelector("metadata.name", secret.Name).String()
+			})
+		informer.AddEventHandler(w.handler)
+		ctx, cancel := context.WithCancel(w.ctx)
+		go informer.Run(ctx.Done())
+		sw = &secretWatch{informer: informer, cancel: cancel}
+		w.watches[secret] = sw
+	}
+	if w.owners[owner] != secret {
+		w.owners[owner] = secret
+		sw.owners++
+	}
+	return sw.informer
+}
+
+// Forget implements secretGetter.
+func (w *secretWatcher) Forget(owner types.NamespacedName) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if secret, ok := w.owners[owner]; ok {
+		delete(w.owners, owner)
+		w.release(secret)
+	}
+}
+
+// release drops a reference to the Secret, stopping its informer once
+// unreferenced. It must be called with mu held.
+func (w *secretWatcher) release(secret types.NamespacedName) {
+	sw, ok := w.watches[secret]
+	if !ok {
+		return
+	}
+	if sw.owners--; sw.owners <= 0 {
+		sw.cancel()
+		delete(w.watches, secret)
+	}
+}
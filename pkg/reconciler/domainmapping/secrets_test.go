@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package domainmapping
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestSecretWatcher(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "tls"},
+	})
+	changed := make(chan string, 10)
+	w := newSecretWatcher(ctx, client, cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(_, obj interface{}) {
+			changed <- obj.(*corev1.Secret).Name
+		},
+	})
+
+	dm := types.NamespacedName{Namespace: "ns", Name: "example.com"}
+	secret := types.NamespacedName{Namespace: "ns", Name: "tls"}
+	got, err := w.Get(ctx, dm, secret)
+	if err != nil {
+		t.Fatal("Get() =", err)
+	}
+	if got.Name != "tls" {
+		t.Errorf("Get() = %s, want: tls", got.Name)
+	}
+
+	// Another DomainMapping referencing the same Secret shares its watch.
+	other := types.NamespacedName{Namespace: "ns", Name: "other.example.com"}
+	if _, err := w.Get(ctx, other, secret); err != nil {
+		t.Fatal("Get() =", err)
+	}
+	if got := len(w.watches); got != 1 {
+		t.Errorf("Watches = %d, want: 1", got)
+	}
+
+	// Changes to the Secret are handled.
+	updated := got.DeepCopy()
+	updated.Data = map[string][]byte{"tls.crt": []byte("cert")}
+	if _, err := client.CoreV1().Secrets("ns").Update(ctx, updated, metav1.UpdateOptions{}); err != nil {
+		t.Fatal("Update() =", err)
+	}
+	select {
+	case name := <-changed:
+		if name != "tls" {
+			t.Errorf("Changed Secret = %s, want: tls", name)
+		}
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatal("Timed out waiting for the change of the Secret")
+	}
+
+	// Referencing a missing Secret drops the reference to the previous one.
+	if _, err := w.Get(ctx, dm, types.NamespacedName{Namespace: "ns", Name: "missing"}); !apierrs.IsNotFound(err) {
+		t.Errorf("Get() = %v, want NotFound", err)
+	}
+	if got := w.watches[secret].owners; got != 1 {
+		t.Errorf("Owners of the Secret = %d, want: 1", got)
+	}
+
+	// The watches stop once unreferenced.
+	w.Forget(dm)
+	w.Forget(other)
+	if got := len(w.watches); got != 0 {
+		t.Errorf("Watches = %d, want: 0", got)
+	}
+}
@@ -20,13 +20,16 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	clientgotesting "k8s.io/client-go/testing"
+	clocktest "k8s.io/utils/clock/testing"
 
 	netapi "knative.dev/networking/pkg/apis/networking"
 	netv1alpha1 "knative.dev/networking/pkg/apis/networking/v1alpha1"
@@ -96,7 +99,7 @@ func TestReconcile(t *testing.T) {
 		SkipNamespaceValidation: true, // allow creation of ClusterDomainClaim.
 		WantCreates: []runtime.Object{
 			resources.MakeDomainClaim(domainMapping("default", "first-reconcile.com", withRef("default", "target"))),
-			resources.MakeIngress(domainMapping("default", "first-reconcile.com", withRef("default", "target")), "the-target-svc", "the-target-svc.default.svc.cluster.local", "the-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
+			resources.MakeIngress(domainMapping("default", "first-reconcile.com", withRef("default", "target")), []resources.Backend{{ServiceName: "the-target-svc", Host: "the-target-svc.default.svc.cluster.local"}}, "the-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
 		},
 		WantPatches: []clientgotesting.PatchActionImpl{
 			patchAddFinalizerAction("default", "first-reconcile.com"),
@@ -196,7 +199,7 @@ func TestReconcile(t *testing.T) {
 			resources.MakeDomainClaim(domainMapping("default", "first-reconcile.com", withRef("default", "target", withAPIVersionKind("v1", "Service")))),
 			resources.MakeIngress(
 				domainMapping("default", "first-reconcile.com", withRef("default", "target", withAPIVersionKind("v1", "Service"))),
-				"target", "target.default.svc.cluster.local", "the-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
+				[]resources.Backend{{ServiceName: "target", Host: "target.default.svc.cluster.local"}}, "the-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
 		},
 		WantPatches: []clientgotesting.PatchActionImpl{
 			patchAddFinalizerAction("default", "first-reconcile.com"),
@@ -345,7 +348,7 @@ func TestReconcile(t *testing.T) {
 			),
 		}},
 		WantCreates: []runtime.Object{
-			resources.MakeIngress(domainMapping("default", "first-reconcile.com", withRef("default", "target")), "the-target-svc", "the-target-svc.default.svc.cluster.local", "the-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
+			resources.MakeIngress(domainMapping("default", "first-reconcile.com", withRef("default", "target")), []resources.Backend{{ServiceName: "the-target-svc", Host: "the-target-svc.default.svc.cluster.local"}}, "the-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
 		},
 		WantPatches: []clientgotesting.PatchActionImpl{
 			patchAddFinalizerAction("default", "first-reconcile.com"),
@@ -412,6 +415,220 @@ func TestReconcile(t *testing.T) {
 			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", "first-reconcile.com"),
 			Eventf(corev1.EventTypeWarning, "InternalError", `namespace "default" does not own ClusterDomainClaim for "first-reconcile.com"`),
 		},
+	}, {
+		Name: "first reconcile, domain overlaps a wildcard claimed by another namespace",
+		Key:  "default/a.first-reconcile.com",
+		Objects: []runtime.Object{
+			domainMapping("default", "a.first-reconcile.com", withRef("default", "target")),
+			resources.MakeDomainClaim(
+				domainMapping("other-namespace", "first-reconcile.com", withRef("other-namespace", "target"),
+					withWildcard,
+				),
+			),
+		},
+		WantErr: true,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: domainMapping("default", "a.first-reconcile.com",
+				withRef("default", "target"),
+				withURL("http", "a.first-reconcile.com"),
+				withAddress("http", "a.first-reconcile.com"),
+				withInitDomainMappingConditions,
+				withDomainClaimOverlaps("*.first-reconcile.com"),
+			),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchAddFinalizerAction("default", "a.first-reconcile.com"),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", "a.first-reconcile.com"),
+			Eventf(corev1.EventTypeWarning, "InternalError", `domain "a.first-reconcile.com" overlaps with "*.first-reconcile.com" claimed by namespace "other-namespace"`),
+		},
+	}, {
+		Name: "first reconcile, wildcard",
+		Key:  "default/first-reconcile.com",
+		Objects: []runtime.Object{
+			ksvc("default", "target", "the-target-svc.default.svc.cluster.local", ""),
+			domainMapping("default", "first-reconcile.com", withRef("default", "target"), withWildcard),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: domainMapping("default", "first-reconcile.com",
+				withRef("default", "target"),
+				withWildcard,
+				withURL("http", "*.first-reconcile.com"),
+				withAddress("http", "*.first-reconcile.com"),
+				withInitDomainMappingConditions,
+				withTLSNotEnabled,
+				withDomainClaimed,
+				withIngressNotConfigured,
+				withReferenceResolved,
+			),
+		}},
+		SkipNamespaceValidation: true, // allow creation of ClusterDomainClaim.
+		WantCreates: []runtime.Object{
+			resources.MakeDomainClaim(domainMapping("default", "first-reconcile.com", withRef("default", "target"), withWildcard)),
+			resources.MakeIngress(domainMapping("default", "first-reconcile.com", withRef("default", "target"), withWildcard), []resources.Backend{{ServiceName: "the-target-svc", Host: "the-target-svc.default.svc.cluster.local"}}, "the-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchAddFinalizerAction("default", "first-reconcile.com"),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", "first-reconcile.com"),
+			Eventf(corev1.EventTypeNormal, "Created", "Created Ingress %q", "first-reconcile.com"),
+		},
+	}, {
+		Name: "first reconcile, wildcard overlaps a domain claimed concurrently by another namespace",
+		Key:  "default/first-reconcile.com",
+		Objects: []runtime.Object{
+			ksvc("default", "target", "the-target-svc.default.svc.cluster.local", ""),
+			domainMapping("default", "first-reconcile.com", withRef("default", "target"), withWildcard),
+		},
+		WithReactors: []clientgotesting.ReactionFunc{
+			// The claim created at the same second is not in the informer yet.
+			func(action clientgotesting.Action) (bool, runtime.Object, error) {
+				if !action.Matches("list", "clusterdomainclaims") {
+					return false, nil, nil
+				}
+				return true, &netv1alpha1.ClusterDomainClaimList{
+					Items: []netv1alpha1.ClusterDomainClaim{
+						*resources.MakeDomainClaim(domainMapping("other-namespace", "a.first-reconcile.com", withRef("other-namespace", "target"))),
+					},
+				}, nil
+			},
+		},
+		WantErr: true,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: domainMapping("default", "first-reconcile.com",
+				withRef("default", "target"),
+				withWildcard,
+				withURL("http", "*.first-reconcile.com"),
+				withAddress("http", "*.first-reconcile.com"),
+				withInitDomainMappingConditions,
+				withDomainClaimOverlaps("a.first-reconcile.com"),
+			),
+		}},
+		SkipNamespaceValidation: true, // allow creation of ClusterDomainClaim.
+		WantCreates: []runtime.Object{
+			resources.MakeDomainClaim(domainMapping("default", "first-reconcile.com", withRef("default", "target"), withWildcard)),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchAddFinalizerAction("default", "first-reconcile.com"),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", "first-reconcile.com"),
+			Eventf(corev1.EventTypeWarning, "InternalError", `domain "*.first-reconcile.com" overlaps with "a.first-reconcile.com" claimed by namespace "other-namespace"`),
+		},
+	}, {
+		Name: "first reconcile, paths",
+		Key:  "default/first-reconcile.com",
+		Objects: []runtime.Object{
+			ksvc("default", "target", "the-target-svc.default.svc.cluster.local", ""),
+			ksvc("default", "api", "the-api-svc.default.svc.cluster.local", ""),
+			domainMapping("default", "first-reconcile.com", withRef("default", "target"), withPath("/api", "default", "api")),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: domainMapping("default", "first-reconcile.com",
+				withRef("default", "target"),
+				withPath("/api", "default", "api"),
+				withURL("http", "first-reconcile.com"),
+				withAddress("http", "first-reconcile.com"),
+				withInitDomainMappingConditions,
+				withTLSNotEnabled,
+				withDomainClaimed,
+				withIngressNotConfigured,
+				withReferenceResolved,
+			),
+		}},
+		SkipNamespaceValidation: true, // allow creation of ClusterDomainClaim.
+		WantCreates: []runtime.Object{
+			resources.MakeDomainClaim(domainMapping("default", "first-reconcile.com", withRef("default", "target"))),
+			resources.MakeIngress(domainMapping("default", "first-reconcile.com", withRef("default", "target"), withPath("/api", "default", "api")), []resources.Backend{
+				{ServiceName: "the-target-svc", Host: "the-target-svc.default.svc.cluster.local"},
+				{Path: "/api", ServiceName: "the-api-svc", Host: "the-api-svc.default.svc.cluster.local"},
+			}, "the-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchAddFinalizerAction("default", "first-reconcile.com"),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", "first-reconcile.com"),
+			Eventf(corev1.EventTypeNormal, "Created", "Created Ingress %q", "first-reconcile.com"),
+		},
+	}, {
+		Name: "first reconcile, request headers",
+		Key:  "default/first-reconcile.com",
+		Objects: []runtime.Object{
+			ksvc("default", "target", "the-target-svc.default.svc.cluster.local", ""),
+			ksvc("default", "api", "the-api-svc.default.svc.cluster.local", ""),
+			domainMapping("default", "first-reconcile.com", withRef("default", "target"), withPath("/api", "default", "api"),
+				withRequestHeader("X-Tenant", "web")),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: domainMapping("default", "first-reconcile.com",
+				withRef("default", "target"),
+				withPath("/api", "default", "api"),
+				withRequestHeader("X-Tenant", "web"),
+				withURL("http", "first-reconcile.com"),
+				withAddress("http", "first-reconcile.com"),
+				withInitDomainMappingConditions,
+				withTLSNotEnabled,
+				withDomainClaimed,
+				withIngressNotConfigured,
+				withReferenceResolved,
+			),
+		}},
+		SkipNamespaceValidation: true, // allow creation of ClusterDomainClaim.
+		WantCreates: []runtime.Object{
+			resources.MakeDomainClaim(domainMapping("default", "first-reconcile.com", withRef("default", "target"))),
+			resources.MakeIngress(domainMapping("default", "first-reconcile.com", withRef("default", "target")), []resources.Backend{{
+				ServiceName: "the-target-svc",
+				Host:        "the-target-svc.default.svc.cluster.local",
+				Headers: &v1beta1.DomainMappingHeaders{
+					Request: &v1beta1.HeaderOperations{Set: map[string]string{"X-Tenant": "web"}},
+				},
+			}, {
+				Path:        "/api",
+				ServiceName: "the-api-svc",
+				Host:        "the-api-svc.default.svc.cluster.local",
+			}}, "the-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchAddFinalizerAction("default", "first-reconcile.com"),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", "first-reconcile.com"),
+			Eventf(corev1.EventTypeNormal, "Created", "Created Ingress %q", "first-reconcile.com"),
+		},
+	}, {
+		Name: "first reconcile, path ref does not exist",
+		Key:  "default/first-reconcile.com",
+		Objects: []runtime.Object{
+			ksvc("default", "target", "the-target-svc.default.svc.cluster.local", ""),
+			domainMapping("default", "first-reconcile.com", withRef("default", "target"), withPath("/api", "default", "api")),
+		},
+		WantErr: true,
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: domainMapping("default", "first-reconcile.com",
+				withRef("default", "target"),
+				withPath("/api", "default", "api"),
+				withURL("http", "first-reconcile.com"),
+				withAddress("http", "first-reconcile.com"),
+				withInitDomainMappingConditions,
+				withTLSNotEnabled,
+				withDomainClaimed,
+				withReferenceNotResolved(`failed to get object default/api: services.serving.knative.dev "api" not found`),
+			),
+		}},
+		SkipNamespaceValidation: true, // allow creation of ClusterDomainClaim.
+		WantCreates: []runtime.Object{
+			resources.MakeDomainClaim(domainMapping("default", "first-reconcile.com", withRef("default", "target"))),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchAddFinalizerAction("default", "first-reconcile.com"),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", "first-reconcile.com"),
+			Eventf(corev1.EventTypeWarning, "InternalError", `path "/api": resolving reference: failed to get object default/api: services.serving.knative.dev "api" not found`),
+		},
 	}, {
 		Name: "reconcile with ingressClass annotation",
 		Key:  "default/ingressclass.first-reconcile.com",
@@ -442,7 +659,7 @@ func TestReconcile(t *testing.T) {
 		WantCreates: []runtime.Object{
 			resources.MakeDomainClaim(domainMapping("default", "ingressclass.first-reconcile.com", withRef("default", "target"))),
 			resources.MakeIngress(domainMapping("default", "ingressclass.first-reconcile.com", withRef("default", "target")),
-				"the-target-svc", "the-target-svc.default.svc.cluster.local", "overridden-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
+				[]resources.Backend{{ServiceName: "the-target-svc", Host: "the-target-svc.default.svc.cluster.local"}}, "overridden-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
 		},
 		WantPatches: []clientgotesting.PatchActionImpl{
 			patchAddFinalizerAction("default", "ingressclass.first-reconcile.com"),
@@ -481,7 +698,7 @@ func TestReconcile(t *testing.T) {
 		WantCreates: []runtime.Object{
 			resources.MakeDomainClaim(domainMapping("default", "ingressclass.first-reconcile.com", withRef("default", "target"))),
 			resources.MakeIngress(domainMapping("default", "ingressclass.first-reconcile.com", withRef("default", "target"), withLabels(map[string]string{netapi.IngressLabelKey: "new-label"})),
-				"the-target-svc", "the-target-svc.default.svc.cluster.local", "the-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
+				[]resources.Backend{{ServiceName: "the-target-svc", Host: "the-target-svc.default.svc.cluster.local"}}, "the-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
 		},
 		WantPatches: []clientgotesting.PatchActionImpl{
 			patchAddFinalizerAction("default", "ingressclass.first-reconcile.com"),
@@ -496,7 +713,7 @@ func TestReconcile(t *testing.T) {
 		Objects: []runtime.Object{
 			ksvc("default", "changed", "changed.default.svc.cluster.local", ""),
 			domainMapping("default", "ingress-exists.org", withRef("default", "changed")),
-			resources.MakeIngress(domainMapping("default", "ingress-exists.org", withRef("default", "changed")), "previous", "previous.default.svc.cluster.local", "the-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
+			resources.MakeIngress(domainMapping("default", "ingress-exists.org", withRef("default", "changed")), []resources.Backend{{ServiceName: "previous", Host: "previous.default.svc.cluster.local"}}, "the-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
 			resources.MakeDomainClaim(domainMapping("default", "ingress-exists.org", withRef("default", "changed"))),
 		},
 		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
@@ -750,6 +967,7 @@ func TestReconcile(t *testing.T) {
 			netclient:         networkingclient.Get(ctx),
 			resolver:          resolver.NewURIResolverFromTracker(ctx, tracker.New(func(types.NamespacedName) {}, 0)),
 			domainClaimLister: listers.GetDomainClaimLister(),
+			secrets:           listerSecrets{listers.GetSecretLister()},
 		}
 
 		cfg := &config.Config{
@@ -823,7 +1041,7 @@ func TestReconcileAutocreateClaimsDisabled(t *testing.T) {
 		}},
 		SkipNamespaceValidation: true, // allow creation of ClusterDomainClaim.
 		WantCreates: []runtime.Object{
-			resources.MakeIngress(domainMapping("default", "first-reconcile.com", withRef("default", "target")), "the-target-svc", "the-target-svc.default.svc.cluster.local", "the-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
+			resources.MakeIngress(domainMapping("default", "first-reconcile.com", withRef("default", "target")), []resources.Backend{{ServiceName: "the-target-svc", Host: "the-target-svc.default.svc.cluster.local"}}, "the-ingress-class", netv1alpha1.HTTPOptionEnabled, nil /* tls */),
 		},
 		WantPatches: []clientgotesting.PatchActionImpl{
 			patchAddFinalizerAction("default", "first-reconcile.com"),
@@ -883,6 +1101,7 @@ func TestReconcileAutocreateClaimsDisabled(t *testing.T) {
 			netclient:         networkingclient.Get(ctx),
 			resolver:          resolver.NewURIResolverFromTracker(ctx, tracker.New(func(types.NamespacedName) {}, 0)),
 			domainClaimLister: listers.GetDomainClaimLister(),
+			secrets:           listerSecrets{listers.GetSecretLister()},
 		}
 
 		return domainmappingreconciler.NewReconciler(ctx, logging.FromContext(ctx),
@@ -902,6 +1121,9 @@ func TestReconcileAutocreateClaimsDisabled(t *testing.T) {
 }
 
 func TestReconcileTLSEnabled(t *testing.T) {
+	certNow := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	certNotAfter := certNow.Add(90 * 24 * time.Hour)
+
 	table := TableTest{{
 		Name: "first reconcile",
 		Key:  "default/first.reconcile.io",
@@ -1202,6 +1424,7 @@ func TestReconcileTLSEnabled(t *testing.T) {
 				withURL("https", "certificateless.com"),
 				withAddress("https", "certificateless.com"),
 			),
+			tlsSecret(t, "default", "tls-secret", []string{"certificateless.com"}, certNow.Add(-time.Hour), certNotAfter),
 			resources.MakeDomainClaim(domainMapping("default", "certificateless.com", withRef("default", "ready"))),
 		},
 		WantCreates: []runtime.Object{
@@ -1223,6 +1446,7 @@ func TestReconcileTLSEnabled(t *testing.T) {
 				withCertificateReady,
 				withDomainClaimed,
 				withReferenceResolved,
+				withCertificateNotAfter(certNotAfter),
 				withCertificateNotRequired,
 				withIngressNotConfigured,
 			),
@@ -1234,6 +1458,136 @@ func TestReconcileTLSEnabled(t *testing.T) {
 			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", "certificateless.com"),
 			Eventf(corev1.EventTypeNormal, "Created", "Created Ingress %q", "certificateless.com"),
 		},
+	}, {
+		Name: "TLS secret not found",
+		Key:  "default/certificateless.com",
+		Objects: []runtime.Object{
+			ksvc("default", "ready", "ready.default.svc.cluster.local", ""),
+			domainMapping("default", "certificateless.com",
+				withTLSSecret("missing-secret"),
+				withRef("default", "ready"),
+			),
+			resources.MakeDomainClaim(domainMapping("default", "certificateless.com", withRef("default", "ready"))),
+		},
+		WantCreates: []runtime.Object{
+			ingress(domainMapping("default", "certificateless.com", withRef("default", "ready")), "the-ingress-class",
+				withIngressHTTPOption(netv1alpha1.HTTPOptionRedirected),
+				withIngressTLS(netv1alpha1.IngressTLS{
+					Hosts:           []string{"certificateless.com"},
+					SecretName:      "missing-secret",
+					SecretNamespace: "default",
+				})),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: domainMapping("default", "certificateless.com",
+				withRef("default", "ready"),
+				withURL("https", "certificateless.com"),
+				withAddress("https", "certificateless.com"),
+				withTLSSecret("missing-secret"),
+				withInitDomainMappingConditions,
+				withDomainClaimed,
+				withReferenceResolved,
+				withCertificateInvalid("SecretNotFound", `Secret "missing-secret" does not exist`),
+				withIngressNotConfigured,
+			),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchAddFinalizerAction("default", "certificateless.com"),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", "certificateless.com"),
+			Eventf(corev1.EventTypeWarning, "SecretNotFound", `Secret "missing-secret" does not exist`),
+			Eventf(corev1.EventTypeNormal, "Created", "Created Ingress %q", "certificateless.com"),
+		},
+	}, {
+		Name: "TLS secret for another host",
+		Key:  "default/certificateless.com",
+		Objects: []runtime.Object{
+			ksvc("default", "ready", "ready.default.svc.cluster.local", ""),
+			tlsSecret(t, "default", "tls-secret", []string{"example.com"}, certNow.Add(-time.Hour), certNotAfter),
+			domainMapping("default", "certificateless.com",
+				withTLSSecret("tls-secret"),
+				withRef("default", "ready"),
+			),
+			resources.MakeDomainClaim(domainMapping("default", "certificateless.com", withRef("default", "ready"))),
+		},
+		WantCreates: []runtime.Object{
+			ingress(domainMapping("default", "certificateless.com", withRef("default", "ready")), "the-ingress-class",
+				withIngressHTTPOption(netv1alpha1.HTTPOptionRedirected),
+				withIngressTLS(netv1alpha1.IngressTLS{
+					Hosts:           []string{"certificateless.com"},
+					SecretName:      "tls-secret",
+					SecretNamespace: "default",
+				})),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: domainMapping("default", "certificateless.com",
+				withRef("default", "ready"),
+				withURL("https", "certificateless.com"),
+				withAddress("https", "certificateless.com"),
+				withTLSSecret("tls-secret"),
+				withInitDomainMappingConditions,
+				withDomainClaimed,
+				withReferenceResolved,
+				withCertificateNotAfter(certNotAfter),
+				withCertificateInvalid("CertificateHostMismatch", `The certificate of Secret "tls-secret" is not valid for "certificateless.com", only for example.com`),
+				withIngressNotConfigured,
+			),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchAddFinalizerAction("default", "certificateless.com"),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", "certificateless.com"),
+			Eventf(corev1.EventTypeWarning, "CertificateHostMismatch", `The certificate of Secret "tls-secret" is not valid for "certificateless.com", only for example.com`),
+			Eventf(corev1.EventTypeNormal, "Created", "Created Ingress %q", "certificateless.com"),
+		},
+	}, {
+		Name: "TLS secret expiring soon",
+		Key:  "default/certificateless.com",
+		Objects: []runtime.Object{
+			ksvc("default", "ready", "ready.default.svc.cluster.local", ""),
+			tlsSecret(t, "default", "tls-secret", []string{"certificateless.com"}, certNow.Add(-time.Hour), certNotAfter),
+			domainMapping("default", "certificateless.com",
+				withTLSSecret("tls-secret"),
+				withRef("default", "ready"),
+				withAnnotations(map[string]string{serving.CertificateExpiryWarningKey: "2400h"}),
+			),
+			resources.MakeDomainClaim(domainMapping("default", "certificateless.com", withRef("default", "ready"))),
+		},
+		WantCreates: []runtime.Object{
+			ingress(domainMapping("default", "certificateless.com", withRef("default", "ready"),
+				withAnnotations(map[string]string{serving.CertificateExpiryWarningKey: "2400h"})), "the-ingress-class",
+				withIngressHTTPOption(netv1alpha1.HTTPOptionRedirected),
+				withIngressTLS(netv1alpha1.IngressTLS{
+					Hosts:           []string{"certificateless.com"},
+					SecretName:      "tls-secret",
+					SecretNamespace: "default",
+				})),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: domainMapping("default", "certificateless.com",
+				withRef("default", "ready"),
+				withAnnotations(map[string]string{serving.CertificateExpiryWarningKey: "2400h"}),
+				withURL("https", "certificateless.com"),
+				withAddress("https", "certificateless.com"),
+				withTLSSecret("tls-secret"),
+				withInitDomainMappingConditions,
+				withDomainClaimed,
+				withReferenceResolved,
+				withCertificateNotAfter(certNotAfter),
+				withCertificateExpiringSoon(certNotAfter),
+				withIngressNotConfigured,
+			),
+		}},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchAddFinalizerAction("default", "certificateless.com"),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", "Updated %q finalizers", "certificateless.com"),
+			Eventf(corev1.EventTypeWarning, "CertificateExpiringSoon", `The TLS certificate of Secret "tls-secret" expires at 2026-05-30T00:00:00Z`),
+			Eventf(corev1.EventTypeNormal, "Created", "Created Ingress %q", "certificateless.com"),
+		},
 	}}
 
 	table.Test(t, MakeFactory(func(ctx context.Context, listers *Listers, cmw configmap.Watcher) controller.Reconciler {
@@ -1244,6 +1598,10 @@ func TestReconcileTLSEnabled(t *testing.T) {
 			domainClaimLister: listers.GetDomainClaimLister(),
 			netclient:         networkingclient.Get(ctx),
 			resolver:          resolver.NewURIResolverFromTracker(ctx, tracker.New(func(types.NamespacedName) {}, 0)),
+			secrets:           listerSecrets{listers.GetSecretLister()},
+			tracker:           &NullTracker{},
+			clock:             clocktest.NewFakePassiveClock(certNow),
+			enqueueAfter:      func(interface{}, time.Duration) {},
 		}
 
 		return domainmappingreconciler.NewReconciler(ctx, logging.FromContext(ctx),
@@ -1311,6 +1669,7 @@ func TestReconcileTLSEnabledButDowngraded(t *testing.T) {
 			ingressLister:     listers.GetIngressLister(),
 			netclient:         networkingclient.Get(ctx),
 			resolver:          resolver.NewURIResolverFromTracker(ctx, tracker.New(func(types.NamespacedName) {}, 0)),
+			secrets:           listerSecrets{listers.GetSecretLister()},
 		}
 
 		return domainmappingreconciler.NewReconciler(ctx, logging.FromContext(ctx),
@@ -1378,6 +1737,32 @@ func withRef(namespace, name string, opt ...refOption) domainMappingOption {
 	}
 }
 
+func withPath(path, namespace, name string) domainMappingOption {
+	return func(dm *v1beta1.DomainMapping) {
+		dm.Spec.Paths = append(dm.Spec.Paths, v1beta1.DomainMappingPath{
+			Path: path,
+			Ref: duckv1.KReference{
+				Namespace:  namespace,
+				Name:       name,
+				APIVersion: "serving.knative.dev/v1",
+				Kind:       "Service",
+			},
+		})
+	}
+}
+
+func withRequestHeader(name, value string) domainMappingOption {
+	return func(dm *v1beta1.DomainMapping) {
+		dm.Spec.Headers = &v1beta1.DomainMappingHeaders{
+			Request: &v1beta1.HeaderOperations{Set: map[string]string{name: value}},
+		}
+	}
+}
+
+func withWildcard(dm *v1beta1.DomainMapping) {
+	dm.Spec.Wildcard = true
+}
+
 func withAPIVersionKind(apiVersion, kind string) refOption {
 	return func(ref *duckv1.KReference) {
 		ref.APIVersion = apiVersion
@@ -1418,6 +1803,24 @@ func withTLSSecret(secretName string) domainMappingOption {
 	}
 }
 
+func withCertificateNotAfter(notAfter time.Time) domainMappingOption {
+	return func(dm *v1beta1.DomainMapping) {
+		dm.Status.CertificateNotAfter = &metav1.Time{Time: notAfter}
+	}
+}
+
+func withCertificateInvalid(reason, message string) domainMappingOption {
+	return func(dm *v1beta1.DomainMapping) {
+		dm.Status.MarkCertificateInvalid(reason, message)
+	}
+}
+
+func withCertificateExpiringSoon(notAfter time.Time) domainMappingOption {
+	return func(dm *v1beta1.DomainMapping) {
+		dm.Status.MarkCertificateExpiringSoon(notAfter)
+	}
+}
+
 func withCertificateNotRequired(dm *v1beta1.DomainMapping) {
 	dm.Status.MarkCertificateNotRequired(v1beta1.TLSCertificateProvidedExternally)
 }
@@ -1454,6 +1857,12 @@ func withDomainClaimNotOwned(dm *v1beta1.DomainMapping) {
 	dm.Status.MarkDomainClaimNotOwned()
 }
 
+func withDomainClaimOverlaps(domain string) domainMappingOption {
+	return func(dm *v1beta1.DomainMapping) {
+		dm.Status.MarkDomainClaimOverlaps(domain)
+	}
+}
+
 func withDomainClaimed(dm *v1beta1.DomainMapping) {
 	dm.Status.MarkDomainClaimed()
 }
@@ -1493,7 +1902,10 @@ func ingress(dm *v1beta1.DomainMapping, ingressClass string, opt ...IngressOptio
 }
 
 func ingressWithChallenges(dm *v1beta1.DomainMapping, ingressClass string, challenges []netv1alpha1.HTTP01Challenge, opt ...IngressOption) *netv1alpha1.Ingress {
-	ing := resources.MakeIngress(dm, dm.Spec.Ref.Name, dm.Spec.Ref.Name+"."+dm.Spec.Ref.Namespace+".svc.cluster.local", ingressClass, netv1alpha1.HTTPOptionEnabled, nil /* tls */, challenges...)
+	ing := resources.MakeIngress(dm, []resources.Backend{{
+		ServiceName: dm.Spec.Ref.Name,
+		Host:        dm.Spec.Ref.Name + "." + dm.Spec.Ref.Namespace + ".svc.cluster.local",
+	}}, ingressClass, netv1alpha1.HTTPOptionEnabled, nil /* tls */, challenges...)
 	for _, o := range opt {
 		o(ing)
 	}
@@ -1585,6 +1997,18 @@ func patchRemoveFinalizerAction(namespace, name string) clientgotesting.PatchAct
 	}
 }
 
+// listerSecrets gets the Secrets of the DomainMappings from the lister of
+// the test objects.
+type listerSecrets struct {
+	lister corev1listers.SecretLister
+}
+
+func (l listerSecrets) Get(_ context.Context, _, secret types.NamespacedName) (*corev1.Secret, error) {
+	return l.lister.Secrets(secret.Namespace).Get(secret.Name)
+}
+
+func (listerSecrets) Forget(types.NamespacedName) {}
+
 type testConfigStore struct {
 	config *config.Config
 }
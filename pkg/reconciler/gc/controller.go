@@ -20,6 +20,8 @@ import (
 	"context"
 
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/clock"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
@@ -45,7 +47,9 @@ func NewController(
 
 	c := &reconciler{
 		client:         servingclient.Get(ctx),
+		kubeclient:     kubeclient.Get(ctx),
 		revisionLister: revisionInformer.Lister(),
+		clock:          clock.RealClock{},
 	}
 	return configreconciler.NewImpl(ctx, c, func(impl *controller.Impl) controller.Options {
 		// Since the gc controller came from the configuration controller, having event handlers
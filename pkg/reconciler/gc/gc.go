@@ -18,13 +18,23 @@ package gc
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"time"
 
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/clock"
+	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
 	pkgreconciler "knative.dev/pkg/reconciler"
 	"knative.dev/serving/pkg/apis/serving"
@@ -33,37 +43,91 @@ import (
 	listers "knative.dev/serving/pkg/client/listers/serving/v1"
 	"knative.dev/serving/pkg/gc"
 	configns "knative.dev/serving/pkg/reconciler/gc/config"
+	"knative.dev/serving/pkg/reconciler/gc/resources"
 )
 
 // collect deletes stale revisions if they are sufficiently old
 func collect(
 	ctx context.Context,
 	client clientset.Interface,
+	kubeclient kubernetes.Interface,
 	revisionLister listers.RevisionLister,
+	clock clock.PassiveClock,
 	config *v1.Configuration,
 ) pkgreconciler.Event {
-	cfg := configns.FromContext(ctx).RevisionGC
+	cfg, err := configns.FromContext(ctx).RevisionGC.WithOverrides(config.Annotations)
+	if err != nil {
+		return err
+	}
 	logger := logging.FromContext(ctx)
 
+	if err := restoreRevisions(ctx, client, kubeclient, revisionLister, config); err != nil {
+		return err
+	}
+
+	revs, err := collectableRevisions(cfg, revisionLister, config, clock.Now(), logger)
+	if err != nil {
+		return err
+	}
+	if cfg.DryRun {
+		return reportDryRun(ctx, client, config, revs)
+	}
+	// Clear the revisions reported in dry-run mode.
+	if err := reportDryRun(ctx, client, config, nil); err != nil {
+		return err
+	}
+
+	archived := 0
+	for _, rev := range revs {
+		if cfg.Archive {
+			if err := archiveRevision(ctx, kubeclient, config, rev); err != nil {
+				// Keep the revision until it is archived.
+				logger.Errorw("Failed to archive revision: "+rev.Name, zap.Error(err))
+				continue
+			}
+			archived++
+		}
+		logger.Info("Deleting revision: ", rev.ObjectMeta.Name)
+		if err := client.ServingV1().Revisions(rev.Namespace).Delete(ctx, rev.Name, metav1.DeleteOptions{}); err != nil {
+			logger.Errorw("Failed to GC revision: "+rev.Name, zap.Error(err))
+		}
+	}
+	if archived > 0 && cfg.MaxArchivedRevisions != gc.Disabled {
+		if err := pruneArchives(ctx, kubeclient, config, int(cfg.MaxArchivedRevisions)); err != nil {
+			logger.Errorw("Failed to prune the archives", zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// collectableRevisions returns the revisions of the configuration to collect,
+// in the order they should be deleted.
+func collectableRevisions(
+	cfg *gc.Config,
+	revisionLister listers.RevisionLister,
+	config *v1.Configuration,
+	now time.Time,
+	logger *zap.SugaredLogger,
+) ([]*v1.Revision, error) {
 	min, max := int(cfg.MinNonActiveRevisions), int(cfg.MaxNonActiveRevisions)
 	if max == gc.Disabled && cfg.RetainSinceCreateTime == gc.Disabled && cfg.RetainSinceLastActiveTime == gc.Disabled {
-		return nil // all deletion settings are disabled
+		return nil, nil // all deletion settings are disabled
 	}
 
 	selector := labels.SelectorFromSet(labels.Set{serving.ConfigurationLabelKey: config.Name})
 	revs, err := revisionLister.Revisions(config.Namespace).List(selector)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if len(revs) <= min {
-		return nil // not enough total revs
+		return nil, nil // not enough total revs
 	}
 
 	// Filter out active revs
 	revs = nonactiveRevisions(revs, config)
 
 	if len(revs) <= min {
-		return nil // not enough non-active revs
+		return nil, nil // not enough non-active revs
 	}
 
 	// Sort by last active ascending (oldest first)
@@ -72,34 +136,37 @@ func collect(
 		return a.Before(b)
 	})
 
+	var collected []*v1.Revision
 	count := len(revs)
 	// If we need `min` to remain, this is the max count of rev can delete.
 	maxIdx := len(revs) - min
+
+	// Filter out the revs retained by the retention rules. They are never
+	// collected, but still count towards min and max as non-active revs.
+	revs = unretainedRevisions(cfg.RetentionRules, revs, now)
+
 	staleCount := 0
-	for i := range count {
+	for i := range revs {
 		rev := revs[i]
-		if !isRevisionStale(cfg, rev, logger) {
+		if !isRevisionStale(cfg, rev, now, logger) {
 			continue
 		}
-		logger.Info("Deleting stale revision: ", rev.ObjectMeta.Name)
-		if err := client.ServingV1().Revisions(rev.Namespace).Delete(ctx, rev.Name, metav1.DeleteOptions{}); err != nil {
-			logger.Errorw("Failed to GC revision: "+rev.Name, zap.Error(err))
-		}
+		collected = append(collected, rev)
 		revs[i] = nil
 		staleCount++
 		if staleCount >= maxIdx {
-			return nil // Reaches max revs to delete
+			return collected, nil // Reaches max revs to delete
 		}
 	}
 
 	nonStaleCount := count - staleCount
 	if max == gc.Disabled || nonStaleCount <= max {
-		return nil
+		return collected, nil
 	}
 	needsDeleteCount := nonStaleCount - max
 
-	// Stale revisions has been deleted, delete extra revisions past max.
-	logger.Infof("Maximum number of revisions (%d) reached, deleting oldest non-active (%d) revisions",
+	// Stale revisions are collected first, then extra revisions past max.
+	logger.Infof("Maximum number of revisions (%d) reached, collecting oldest non-active (%d) revisions",
 		max, needsDeleteCount)
 	deletedCount := 0
 	for _, rev := range revs {
@@ -109,15 +176,197 @@ func collect(
 		if rev == nil {
 			continue
 		}
-		logger.Info("Deleting non-active revision: ", rev.ObjectMeta.Name)
-		if err := client.ServingV1().Revisions(rev.Namespace).Delete(ctx, rev.Name, metav1.DeleteOptions{}); err != nil {
-			logger.Errorw("Failed to GC revision: "+rev.Name, zap.Error(err))
-		}
+		collected = append(collected, rev)
 		deletedCount++
 	}
+	return collected, nil
+}
+
+// archiveRevision snapshots the revision into its archive, replacing any
+// previous snapshot.
+func archiveRevision(ctx context.Context, kubeclient kubernetes.Interface, config *v1.Configuration, rev *v1.Revision) error {
+	desired, err := resources.MakeArchive(config, rev)
+	if err != nil {
+		return err
+	}
+	archives := kubeclient.CoreV1().ConfigMaps(rev.Namespace)
+	existing, err := archives.Get(ctx, desired.Name, metav1.GetOptions{})
+	switch {
+	case apierrs.IsNotFound(err):
+		_, err = archives.Create(ctx, desired, metav1.CreateOptions{})
+		return err
+	case err != nil:
+		return err
+	case !metav1.IsControlledBy(existing, config):
+		return fmt.Errorf("configmap %s is not owned by configuration %s", existing.Name, config.Name)
+	}
+	existing = existing.DeepCopy()
+	existing.Labels = desired.Labels
+	existing.Data = desired.Data
+	_, err = archives.Update(ctx, existing, metav1.UpdateOptions{})
+	return err
+}
+
+// pruneArchives deletes the oldest archives of the configuration past max.
+func pruneArchives(ctx context.Context, kubeclient kubernetes.Interface, config *v1.Configuration, max int) error {
+	archives := kubeclient.CoreV1().ConfigMaps(config.Namespace)
+	list, err := archives.List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s,%s", serving.ConfigurationLabelKey, config.Name, serving.ArchivedRevisionLabelKey),
+	})
+	if err != nil {
+		return err
+	}
+	owned := make([]*corev1.ConfigMap, 0, len(list.Items))
+	for i := range list.Items {
+		if metav1.IsControlledBy(&list.Items[i], config) {
+			owned = append(owned, &list.Items[i])
+		}
+	}
+	if len(owned) <= max {
+		return nil
+	}
+
+	// Sort by creation ascending (oldest first)
+	sort.Slice(owned, func(i, j int) bool {
+		a, b := owned[i].CreationTimestamp, owned[j].CreationTimestamp
+		if a.Equal(&b) {
+			return owned[i].Name < owned[j].Name
+		}
+		return a.Before(&b)
+	})
+	for _, archive := range owned[:len(owned)-max] {
+		logging.FromContext(ctx).Info("Deleting archive: ", archive.Name)
+		if err := archives.Delete(ctx, archive.Name, metav1.DeleteOptions{}); err != nil && !apierrs.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// restoreRevisions recreates the revisions listed for restoration which
+// don't exist from their archive, which is then deleted.
+func restoreRevisions(
+	ctx context.Context,
+	client clientset.Interface,
+	kubeclient kubernetes.Interface,
+	revisionLister listers.RevisionLister,
+	config *v1.Configuration,
+) error {
+	recorder := controller.GetEventRecorder(ctx)
+	for _, name := range config.RestoreRevisions() {
+		if _, err := revisionLister.Revisions(config.Namespace).Get(name); err == nil {
+			continue
+		} else if !apierrs.IsNotFound(err) {
+			return err
+		}
+
+		archives := kubeclient.CoreV1().ConfigMaps(config.Namespace)
+		archive, err := archives.Get(ctx, resources.ArchiveName(name), metav1.GetOptions{})
+		if apierrs.IsNotFound(err) {
+			recorder.Eventf(config, corev1.EventTypeWarning, "RestoreFailed",
+				"Revision %q has no archive to restore it from", name)
+			continue
+		} else if err != nil {
+			return err
+		}
+		if !metav1.IsControlledBy(archive, config) {
+			recorder.Eventf(config, corev1.EventTypeWarning, "RestoreFailed",
+				"Archive %q is not owned by the configuration", archive.Name)
+			continue
+		}
+		rev, err := resources.RestoreRevision(config, archive)
+		if err != nil {
+			recorder.Eventf(config, corev1.EventTypeWarning, "RestoreFailed",
+				"Failed to restore revision %q: %v", name, err)
+			continue
+		}
+		if _, err := client.ServingV1().Revisions(config.Namespace).Create(ctx, rev, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to restore revision %s: %w", name, err)
+		}
+		recorder.Eventf(config, corev1.EventTypeNormal, "Restored",
+			"Restored revision %q from its archive", name)
+		if err := archives.Delete(ctx, archive.Name, metav1.DeleteOptions{}); err != nil && !apierrs.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// reportDryRun reports the revisions which would be collected through the
+// status of the configuration, and an event for each of those which wasn't
+// reported yet.
+func reportDryRun(ctx context.Context, client clientset.Interface, config *v1.Configuration, revs []*v1.Revision) error {
+	names := make([]string, 0, len(revs))
+	for _, rev := range revs {
+		names = append(names, rev.Name)
+	}
+	sort.Strings(names)
+	prev := config.Status.RevisionsToCollect
+	if slices.Equal(prev, names) {
+		return nil
+	}
+
+	var value interface{} // nil removes the field.
+	if len(names) > 0 {
+		value = names
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"revisionsToCollect": value,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := client.ServingV1().Configurations(config.Namespace).Patch(ctx, config.Name,
+		types.MergePatchType, patch, metav1.PatchOptions{}, "status"); err != nil {
+		return fmt.Errorf("failed to report the revisions to collect: %w", err)
+	}
+
+	reported := sets.New(prev...)
+	recorder := controller.GetEventRecorder(ctx)
+	for _, name := range names {
+		if !reported.Has(name) {
+			recorder.Eventf(config, corev1.EventTypeNormal, "DryRunCollect",
+				"Revision %q would be garbage collected", name)
+		}
+	}
 	return nil
 }
 
+// unretainedRevisions keeps only the revisions which are not retained by any
+// of the rules. The revisions are sorted by last active ascending.
+func unretainedRevisions(rules []gc.RetentionRule, revs []*v1.Revision, now time.Time) []*v1.Revision {
+	if len(rules) == 0 {
+		return revs
+	}
+	retained := sets.New[string]()
+	for _, rule := range rules {
+		selector := labels.SelectorFromSet(rule.Selector)
+		keep := rule.KeepLast
+		// Latest active first.
+		for i := len(revs) - 1; i >= 0; i-- {
+			rev := revs[i]
+			if !selector.Matches(labels.Set(rev.Labels)) {
+				continue
+			}
+			if keep > 0 {
+				keep--
+				retained.Insert(rev.Name)
+			} else if rule.RetainSinceCreateTime > 0 && now.Sub(rev.CreationTimestamp.Time) < rule.RetainSinceCreateTime {
+				retained.Insert(rev.Name)
+			}
+		}
+	}
+	ret := revs[:0]
+	for _, rev := range revs {
+		if !retained.Has(rev.Name) {
+			ret = append(ret, rev)
+		}
+	}
+	return ret
+}
+
 // nonactiveRevisions swaps keeps only non active revisions.
 func nonactiveRevisions(revs []*v1.Revision, config *v1.Configuration) []*v1.Revision {
 	swap := len(revs)
@@ -140,24 +389,28 @@ func isRevisionActive(rev *v1.Revision, config *v1.Configuration) bool {
 	if strings.EqualFold(rev.Annotations[serving.RevisionPreservedAnnotationKey], "true") {
 		return true
 	}
+
+	if slices.Contains(config.RestoreRevisions(), rev.Name) {
+		return true // restored revisions are kept while listed.
+	}
 	// Anything that the labeler hasn't explicitly labelled as inactive.
 	// Revisions which do not yet have any annotation are not eligible for deletion.
 	return rev.GetRoutingState() != v1.RoutingStateReserve
 }
 
-func isRevisionStale(cfg *gc.Config, rev *v1.Revision, logger *zap.SugaredLogger) bool {
+func isRevisionStale(cfg *gc.Config, rev *v1.Revision, now time.Time, logger *zap.SugaredLogger) bool {
 	sinceCreate, sinceActive := cfg.RetainSinceCreateTime, cfg.RetainSinceLastActiveTime
 	if sinceCreate == gc.Disabled && sinceActive == gc.Disabled {
 		return false // Time checks are both disabled. Not stale.
 	}
 
 	createTime := rev.ObjectMeta.CreationTimestamp.Time
-	if sinceCreate != gc.Disabled && time.Since(createTime) < sinceCreate {
+	if sinceCreate != gc.Disabled && now.Sub(createTime) < sinceCreate {
 		return false // Revision was created sooner than RetainSinceCreateTime. Not stale.
 	}
 
 	active := revisionLastActiveTime(rev)
-	if sinceActive != gc.Disabled && time.Since(active) < sinceActive {
+	if sinceActive != gc.Disabled && now.Sub(active) < sinceActive {
 		return false // Revision was recently active. Not stale.
 	}
 
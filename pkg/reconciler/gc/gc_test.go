@@ -17,21 +17,30 @@ limitations under the License.
 package gc
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 
 	clientgotesting "k8s.io/client-go/testing"
+	"k8s.io/utils/clock"
 	clocktest "k8s.io/utils/clock/testing"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
+	"knative.dev/pkg/controller"
 	"knative.dev/pkg/ptr"
+	"knative.dev/serving/pkg/apis/serving"
 	v1 "knative.dev/serving/pkg/apis/serving/v1"
 	fakeservingclient "knative.dev/serving/pkg/client/injection/client/fake"
 	fakerevisioninformer "knative.dev/serving/pkg/client/injection/informers/serving/v1/revision/fake"
 	"knative.dev/serving/pkg/gc"
 	"knative.dev/serving/pkg/reconciler/gc/config"
+	"knative.dev/serving/pkg/reconciler/gc/resources"
 
 	_ "knative.dev/serving/pkg/client/injection/informers/serving/v1/configuration/fake"
 
@@ -208,7 +217,7 @@ func TestCollectMin(t *testing.T) {
 
 	for _, test := range table {
 		t.Run(test.name, func(t *testing.T) {
-			runTest(t, cfgMap, test.revs, test.cfg, test.wantDeletes)
+			runTest(t, fc, cfgMap, test.revs, test.cfg, test.wantDeletes)
 		})
 	}
 }
@@ -317,7 +326,7 @@ func TestCollectMax(t *testing.T) {
 
 	for _, test := range table {
 		t.Run(test.name, func(t *testing.T) {
-			runTest(t, cfgMap, test.revs, test.cfg, test.wantDeletes)
+			runTest(t, fc, cfgMap, test.revs, test.cfg, test.wantDeletes)
 		})
 	}
 }
@@ -400,7 +409,7 @@ func TestCollectSettings(t *testing.T) {
 			cfgMap := &config.Config{
 				RevisionGC: &test.gc,
 			}
-			runTest(t, cfgMap, revs, cfg, test.wantDeletes)
+			runTest(t, fc, cfgMap, revs, cfg, test.wantDeletes)
 		})
 	}
 }
@@ -513,13 +522,372 @@ func TestGCInOrder(t *testing.T) {
 			cfgMap := &config.Config{
 				RevisionGC: &test.gc,
 			}
-			runTest(t, cfgMap, revs, cfg, test.wantDeletes)
+			runTest(t, fc, cfgMap, revs, cfg, test.wantDeletes)
+		})
+	}
+}
+
+func TestCollectRetentionRules(t *testing.T) {
+	now := time.Now()
+	old1 := now.Add(-11 * time.Minute)
+	old2 := now.Add(-12 * time.Minute)
+	old3 := now.Add(-13 * time.Minute)
+	old4 := now.Add(-14 * time.Minute)
+	old5 := now.Add(-15 * time.Minute)
+	fc := clocktest.NewFakePassiveClock(now)
+
+	revs := []*v1.Revision{
+		rev("retention-test", "foo", 5553, MarkRevisionReady,
+			WithRevName("5553"),
+			WithRevisionLabel("release", "true"),
+			WithCreationTimestamp(old5),
+			WithRoutingState(v1.RoutingStateReserve, fc),
+			WithRoutingStateModified(old5)),
+		rev("retention-test", "foo", 5554, MarkRevisionReady,
+			WithRevName("5554"),
+			WithRevisionLabel("channel", "nightly"),
+			WithRoutingState(v1.RoutingStateReserve, fc),
+			WithRoutingStateModified(old4)),
+		rev("retention-test", "foo", 5555, MarkRevisionReady,
+			WithRevName("5555"),
+			WithRevisionLabel("channel", "nightly"),
+			WithRoutingState(v1.RoutingStateReserve, fc),
+			WithRoutingStateModified(old3)),
+		rev("retention-test", "foo", 5556, MarkRevisionReady,
+			WithRevName("5556"),
+			WithRevisionLabel("channel", "nightly"),
+			WithRoutingState(v1.RoutingStateReserve, fc),
+			WithRoutingStateModified(old2)),
+		rev("retention-test", "foo", 5557, MarkRevisionReady,
+			WithRevName("5557"),
+			WithRoutingState(v1.RoutingStateActive, fc),
+			WithRoutingStateModified(old1)),
+	}
+
+	deletes := func(names ...string) []clientgotesting.DeleteActionImpl {
+		ret := make([]clientgotesting.DeleteActionImpl, 0, len(names))
+		for _, name := range names {
+			ret = append(ret, clientgotesting.DeleteActionImpl{
+				ActionImpl: clientgotesting.ActionImpl{
+					Namespace: "foo",
+					Verb:      "delete",
+					Resource:  v1.SchemeGroupVersion.WithResource("revisions"),
+				},
+				Name: name,
+			})
+		}
+		return ret
+	}
+
+	table := []struct {
+		name        string
+		rules       []gc.RetentionRule
+		cfgOpts     []ConfigOption
+		wantDeletes []clientgotesting.DeleteActionImpl
+	}{{
+		name:        "no rules",
+		wantDeletes: deletes("5553", "5554", "5555", "5556"),
+	}, {
+		name: "keep the last nightlies",
+		rules: []gc.RetentionRule{{
+			Selector: map[string]string{"channel": "nightly"},
+			KeepLast: 2,
+		}},
+		wantDeletes: deletes("5553", "5554"),
+	}, {
+		name: "retain the releases",
+		rules: []gc.RetentionRule{{
+			Selector:              map[string]string{"release": "true"},
+			RetainSinceCreateTime: time.Hour,
+		}},
+		wantDeletes: deletes("5554", "5555", "5556"),
+	}, {
+		name: "release retention expired",
+		rules: []gc.RetentionRule{{
+			Selector:              map[string]string{"release": "true"},
+			RetainSinceCreateTime: 10 * time.Minute,
+		}},
+		wantDeletes: deletes("5553", "5554", "5555", "5556"),
+	}, {
+		name: "configuration overrides",
+		rules: []gc.RetentionRule{{
+			Selector: map[string]string{"channel": "nightly"},
+			KeepLast: 1,
+		}},
+		cfgOpts: []ConfigOption{
+			WithConfigAnn(serving.GCMaxNonActiveRevisionsKey, "2"),
+		},
+		// The retained nightly still counts towards max.
+		wantDeletes: deletes("5553", "5554"),
+	}, {
+		name: "retained revisions count towards min",
+		rules: []gc.RetentionRule{{
+			Selector: map[string]string{"channel": "nightly"},
+			KeepLast: 2,
+		}},
+		cfgOpts: []ConfigOption{
+			WithConfigAnn(serving.GCRetainSinceLastActiveTimeKey, "1m"),
+			WithConfigAnn(serving.GCMinNonActiveRevisionsKey, "3"),
+			WithConfigAnn(serving.GCMaxNonActiveRevisionsKey, "disabled"),
+		},
+		wantDeletes: deletes("5553"),
+	}, {
+		name: "configuration dry-run",
+		cfgOpts: []ConfigOption{
+			WithConfigAnn(serving.GCDryRunKey, "true"),
+		},
+	}}
+
+	for _, test := range table {
+		t.Run(test.name, func(t *testing.T) {
+			cfgMap := &config.Config{
+				RevisionGC: &gc.Config{
+					RetainSinceCreateTime:     time.Duration(gc.Disabled),
+					RetainSinceLastActiveTime: time.Duration(gc.Disabled),
+					MinNonActiveRevisions:     0,
+					MaxNonActiveRevisions:     0,
+					RetentionRules:            test.rules,
+				},
+			}
+			cfg := cfg("retention-test", "foo", 5557, append([]ConfigOption{
+				WithLatestCreated("5557"),
+				WithLatestReady("5557"),
+				WithConfigObservedGen,
+			}, test.cfgOpts...)...)
+			runTest(t, fc, cfgMap, revs, cfg, test.wantDeletes)
+		})
+	}
+}
+
+func TestReportDryRun(t *testing.T) {
+	revs := func(names ...string) []*v1.Revision {
+		ret := make([]*v1.Revision, 0, len(names))
+		for _, name := range names {
+			ret = append(ret, rev("dry-run", "foo", 5554, WithRevName(name)))
+		}
+		return ret
+	}
+	event := func(name string) string {
+		return fmt.Sprintf("Normal DryRunCollect Revision %q would be garbage collected", name)
+	}
+	patch := func(value string) string {
+		return `{"status":{"revisionsToCollect":` + value + `}}`
+	}
+
+	tests := []struct {
+		name       string
+		reported   []string
+		revs       []*v1.Revision
+		wantPatch  string
+		wantEvents []string
+	}{{
+		name:       "first report",
+		revs:       revs("5555", "5554"),
+		wantPatch:  patch(`["5554","5555"]`),
+		wantEvents: []string{event("5554"), event("5555")},
+	}, {
+		name:     "already reported",
+		reported: []string{"5554"},
+		revs:     revs("5554"),
+	}, {
+		name:       "newly collectable",
+		reported:   []string{"5554"},
+		revs:       revs("5554", "5555"),
+		wantPatch:  patch(`["5554","5555"]`),
+		wantEvents: []string{event("5555")},
+	}, {
+		name:      "no longer collectable",
+		reported:  []string{"5554", "5555"},
+		revs:      revs("5555"),
+		wantPatch: patch(`["5555"]`),
+	}, {
+		name:      "nothing to collect",
+		reported:  []string{"5555"},
+		wantPatch: patch("null"),
+	}, {
+		name: "nothing reported",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := cfg("dry-run", "foo", 5556, WithRevisionsToCollect(test.reported...))
+
+			ctx, _ := SetupFakeContext(t)
+			client := fakeservingclient.Get(ctx)
+			client.ServingV1().Configurations("foo").Create(ctx, config, metav1.CreateOptions{})
+			client.ClearActions()
+			recorder := record.NewFakeRecorder(10)
+			ctx = controller.WithEventRecorder(ctx, recorder)
+
+			if err := reportDryRun(ctx, client, config, test.revs); err != nil {
+				t.Fatal("reportDryRun() =", err)
+			}
+
+			var gotPatch string
+			for _, a := range client.Actions() {
+				if p, ok := a.(clientgotesting.PatchAction); ok {
+					gotPatch = string(p.GetPatch())
+				}
+			}
+			if gotPatch != test.wantPatch {
+				t.Errorf("Patch = %s, want: %s", gotPatch, test.wantPatch)
+			}
+			var gotEvents []string
+			for len(recorder.Events) > 0 {
+				gotEvents = append(gotEvents, <-recorder.Events)
+			}
+			if !cmp.Equal(gotEvents, test.wantEvents) {
+				t.Errorf("Events = %v, want: %v", gotEvents, test.wantEvents)
+			}
+		})
+	}
+}
+
+func TestCollectArchive(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-11 * time.Minute)
+	older := now.Add(-12 * time.Minute)
+	oldest := now.Add(-13 * time.Minute)
+	fc := clocktest.NewFakePassiveClock(now)
+
+	cfgMap := &config.Config{
+		RevisionGC: &gc.Config{
+			RetainSinceCreateTime:     time.Duration(gc.Disabled),
+			RetainSinceLastActiveTime: time.Duration(gc.Disabled),
+			MinNonActiveRevisions:     0,
+			MaxNonActiveRevisions:     1,
+			Archive:                   true,
+			MaxArchivedRevisions:      1,
+		},
+	}
+	revs := []*v1.Revision{
+		rev("archive-test", "foo", 5554, MarkRevisionReady,
+			WithRevName("5554"),
+			WithRoutingState(v1.RoutingStateReserve, fc),
+			WithRoutingStateModified(oldest)),
+		rev("archive-test", "foo", 5555, MarkRevisionReady,
+			WithRevName("5555"),
+			WithRoutingState(v1.RoutingStateReserve, fc),
+			WithRoutingStateModified(older)),
+		rev("archive-test", "foo", 5556, MarkRevisionReady,
+			WithRevName("5556"),
+			WithRoutingState(v1.RoutingStateActive, fc),
+			WithRoutingStateModified(old)),
+	}
+	newCfg := func(co ...ConfigOption) *v1.Configuration {
+		return cfg("archive-test", "foo", 5556, append([]ConfigOption{
+			WithLatestCreated("5556"),
+			WithLatestReady("5556"),
+			WithConfigObservedGen,
+		}, co...)...)
+	}
+	archiveOf := func(cfg *v1.Configuration, rev *v1.Revision) *corev1.ConfigMap {
+		cm, err := resources.MakeArchive(cfg, rev)
+		if err != nil {
+			t.Fatal("MakeArchive() =", err)
+		}
+		return cm
+	}
+	archive := func(cfg *v1.Configuration) *corev1.ConfigMap {
+		return archiveOf(cfg, revs[0])
+	}
+	stale := func(cm *corev1.ConfigMap) *corev1.ConfigMap {
+		cm.Data = map[string]string{"revision": "{}"}
+		return cm
+	}
+
+	tests := []struct {
+		name        string
+		cfg         *v1.Configuration
+		existing    []runtime.Object
+		wantArchive bool
+		wantDeletes []string
+		wantPrunes  []string
+	}{{
+		name:        "archive before delete",
+		cfg:         newCfg(),
+		wantArchive: true,
+		wantDeletes: []string{"5554"},
+	}, {
+		name:        "archive replaced",
+		cfg:         newCfg(),
+		existing:    []runtime.Object{stale(archive(newCfg()))},
+		wantArchive: true,
+		wantDeletes: []string{"5554"},
+	}, {
+		// The fake client doesn't set the creation timestamps, so the archives
+		// are ordered by name.
+		name: "oldest archives pruned",
+		cfg:  newCfg(),
+		existing: []runtime.Object{
+			archiveOf(newCfg(), rev("archive-test", "foo", 5550, WithRevName("5550"))),
+		},
+		wantArchive: true,
+		wantDeletes: []string{"5554"},
+		wantPrunes:  []string{resources.ArchiveName("5550")},
+	}, {
+		name: "archive not owned",
+		cfg:  newCfg(),
+		existing: []runtime.Object{stale(archive(cfg("other", "foo", 1, func(c *v1.Configuration) {
+			c.UID = "other"
+		})))},
+	}, {
+		name: "restored revisions are kept",
+		cfg:  newCfg(WithConfigAnn(serving.RestoreRevisionsKey, "5554")),
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, _ := SetupFakeContext(t)
+			ctx = config.ToContext(ctx, cfgMap)
+			client := fakeservingclient.Get(ctx)
+			ctx, kubeclient := fakekubeclient.With(ctx, test.existing...)
+
+			ri := fakerevisioninformer.Get(ctx)
+			for _, rev := range revs {
+				ri.Informer().GetIndexer().Add(rev)
+			}
+
+			if err := collect(ctx, client, kubeclient, ri.Lister(), fc, test.cfg); err != nil {
+				t.Fatal("collect() =", err)
+			}
+
+			got, err := kubeclient.CoreV1().ConfigMaps("foo").Get(ctx, resources.ArchiveName("5554"), metav1.GetOptions{})
+			if test.wantArchive {
+				if err != nil {
+					t.Fatal("Failed to get the archive:", err)
+				}
+				if want := archive(test.cfg); !cmp.Equal(got.Data, want.Data) {
+					t.Error("Archive (-want, +got):", cmp.Diff(want.Data, got.Data))
+				}
+			}
+
+			var deletes []string
+			for _, a := range client.Actions() {
+				if d, ok := a.(clientgotesting.DeleteAction); ok {
+					deletes = append(deletes, d.GetName())
+				}
+			}
+			if !cmp.Equal(deletes, test.wantDeletes) {
+				t.Error("Deletes (-want, +got):", cmp.Diff(test.wantDeletes, deletes))
+			}
+
+			var prunes []string
+			for _, a := range kubeclient.Actions() {
+				if d, ok := a.(clientgotesting.DeleteAction); ok {
+					prunes = append(prunes, d.GetName())
+				}
+			}
+			if !cmp.Equal(prunes, test.wantPrunes) {
+				t.Error("Pruned archives (-want, +got):", cmp.Diff(test.wantPrunes, prunes))
+			}
 		})
 	}
 }
 
 func runTest(
 	t *testing.T,
+	clock clock.PassiveClock,
 	cfgMap *config.Config,
 	revs []*v1.Revision,
 	cfg *v1.Configuration,
@@ -537,7 +905,7 @@ func runTest(
 
 	recorderList := ActionRecorderList{client}
 
-	collect(ctx, client, ri.Lister(), cfg)
+	collect(ctx, client, fakekubeclient.Get(ctx), ri.Lister(), clock, cfg)
 
 	actions, err := recorderList.ActionsByVerb()
 	if err != nil {
@@ -638,7 +1006,7 @@ func TestIsRevisionStale(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			got := isRevisionStale(cfg, test.rev, TestLogger(t))
+			got := isRevisionStale(cfg, test.rev, curTime, TestLogger(t))
 
 			if got != test.want {
 				t.Errorf("IsRevisionStale want %v got %v", test.want, got)
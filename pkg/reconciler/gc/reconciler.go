@@ -19,6 +19,8 @@ package gc
 import (
 	"context"
 
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/clock"
 	pkgreconciler "knative.dev/pkg/reconciler"
 	v1 "knative.dev/serving/pkg/apis/serving/v1"
 	clientset "knative.dev/serving/pkg/client/clientset/versioned"
@@ -28,10 +30,13 @@ import (
 
 // reconciler implements controller.Reconciler for garbage collected resources.
 type reconciler struct {
-	client clientset.Interface
+	client     clientset.Interface
+	kubeclient kubernetes.Interface
 
 	// listers index properties about resources
 	revisionLister listers.RevisionLister
+
+	clock clock.PassiveClock
 }
 
 // Check that our reconciler implements configreconciler.Interface
@@ -42,5 +47,5 @@ func (c *reconciler) ReconcileKind(ctx context.Context, config *v1.Configuration
 	ctx, cancel := context.WithTimeout(ctx, pkgreconciler.DefaultTimeout)
 	defer cancel()
 
-	return collect(ctx, c.client, c.revisionLister, config)
+	return collect(ctx, c.client, c.kubeclient, c.revisionLister, c.clock, config)
 }
@@ -21,21 +21,26 @@ import (
 	"testing"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	clientgotesting "k8s.io/client-go/testing"
 	clocktest "k8s.io/utils/clock/testing"
 
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
 	pkgrec "knative.dev/pkg/reconciler"
+	"knative.dev/serving/pkg/apis/serving"
 	v1 "knative.dev/serving/pkg/apis/serving/v1"
 	servingclient "knative.dev/serving/pkg/client/injection/client/fake"
 	configreconciler "knative.dev/serving/pkg/client/injection/reconciler/serving/v1/configuration"
 	"knative.dev/serving/pkg/gc"
 	"knative.dev/serving/pkg/reconciler/configuration/resources"
 	"knative.dev/serving/pkg/reconciler/gc/config"
+	gcresources "knative.dev/serving/pkg/reconciler/gc/resources"
 
 	_ "knative.dev/serving/pkg/client/injection/informers/serving/v1/configuration/fake"
 	_ "knative.dev/serving/pkg/client/injection/informers/serving/v1/revision/fake"
@@ -65,7 +70,7 @@ func TestGCReconcile(t *testing.T) {
 		},
 	}
 
-	fc := clocktest.NewFakePassiveClock(time.Now())
+	fc := clocktest.NewFakePassiveClock(now)
 	table := TableTest{{
 		Name: "delete oldest, keep two V2",
 		Objects: []runtime.Object{
@@ -95,12 +100,119 @@ func TestGCReconcile(t *testing.T) {
 			Name: "5554",
 		}},
 		Key: "foo/keep-two",
+	}, {
+		Name: "dry-run reports the oldest",
+		Objects: []runtime.Object{
+			cfg("dry-run", "foo", 5556,
+				WithConfigAnn(serving.GCDryRunKey, "true"),
+				WithLatestCreated("5556"),
+				WithLatestReady("5556"),
+				WithConfigObservedGen),
+			rev("dry-run", "foo", 5554, MarkRevisionReady,
+				WithRevName("5554"),
+				WithRoutingState(v1.RoutingStateReserve, fc),
+				WithRoutingStateModified(oldest)),
+			rev("dry-run", "foo", 5555, MarkRevisionReady,
+				WithRevName("5555"),
+				WithRoutingState(v1.RoutingStateReserve, fc),
+				WithRoutingStateModified(older)),
+			rev("dry-run", "foo", 5556, MarkRevisionReady,
+				WithRevName("5556"),
+				WithRoutingState(v1.RoutingStateActive, fc),
+				WithRoutingStateModified(old)),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{{
+			ActionImpl: clientgotesting.ActionImpl{
+				Namespace:   "foo",
+				Subresource: "status",
+			},
+			Name:      "dry-run",
+			PatchType: types.MergePatchType,
+			Patch:     []byte(`{"status":{"revisionsToCollect":["5554"]}}`),
+		}},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "DryRunCollect", "Revision %q would be garbage collected", "5554"),
+		},
+		Key: "foo/dry-run",
+	}, {
+		Name: "dry-run already reported",
+		Objects: []runtime.Object{
+			cfg("dry-run", "foo", 5556,
+				WithConfigAnn(serving.GCDryRunKey, "true"),
+				WithRevisionsToCollect("5554"),
+				WithLatestCreated("5556"),
+				WithLatestReady("5556"),
+				WithConfigObservedGen),
+			rev("dry-run", "foo", 5554, MarkRevisionReady,
+				WithRevName("5554"),
+				WithRoutingState(v1.RoutingStateReserve, fc),
+				WithRoutingStateModified(oldest)),
+			rev("dry-run", "foo", 5555, MarkRevisionReady,
+				WithRevName("5555"),
+				WithRoutingState(v1.RoutingStateReserve, fc),
+				WithRoutingStateModified(older)),
+			rev("dry-run", "foo", 5556, MarkRevisionReady,
+				WithRevName("5556"),
+				WithRoutingState(v1.RoutingStateActive, fc),
+				WithRoutingStateModified(old)),
+		},
+		Key: "foo/dry-run",
+	}, {
+		Name: "restore archived revision",
+		Objects: []runtime.Object{
+			cfg("restore", "foo", 5556,
+				WithConfigAnn(serving.RestoreRevisionsKey, "restore-5554"),
+				WithLatestCreated("5556"),
+				WithLatestReady("5556"),
+				WithConfigObservedGen),
+			archive(cfg("restore", "foo", 5556),
+				rev("restore", "foo", 5554, WithRevName("restore-5554"))),
+			rev("restore", "foo", 5556, MarkRevisionReady,
+				WithRevName("5556"),
+				WithRoutingState(v1.RoutingStateActive, fc),
+				WithRoutingStateModified(old)),
+		},
+		WantCreates: []runtime.Object{
+			restored(cfg("restore", "foo", 5556),
+				rev("restore", "foo", 5554, WithRevName("restore-5554"))),
+		},
+		WantDeletes: []clientgotesting.DeleteActionImpl{{
+			ActionImpl: clientgotesting.ActionImpl{
+				Namespace: "foo",
+				Verb:      "delete",
+				Resource:  corev1.SchemeGroupVersion.WithResource("configmaps"),
+			},
+			Name: "restore-5554-archive",
+		}},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "Restored", "Restored revision %q from its archive", "restore-5554"),
+		},
+		Key: "foo/restore",
+	}, {
+		Name: "restore without archive",
+		Objects: []runtime.Object{
+			cfg("restore", "foo", 5556,
+				WithConfigAnn(serving.RestoreRevisionsKey, "restore-5554"),
+				WithLatestCreated("5556"),
+				WithLatestReady("5556"),
+				WithConfigObservedGen),
+			rev("restore", "foo", 5556, MarkRevisionReady,
+				WithRevName("5556"),
+				WithRoutingState(v1.RoutingStateActive, fc),
+				WithRoutingStateModified(old)),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeWarning, "RestoreFailed", "Revision %q has no archive to restore it from", "restore-5554"),
+		},
+		Key: "foo/restore",
 	}}
 
 	table.Test(t, MakeFactory(func(ctx context.Context, listers *Listers, cmw configmap.Watcher) controller.Reconciler {
 		r := &reconciler{
 			client:         servingclient.Get(ctx),
+			kubeclient:     kubeclient.Get(ctx),
 			revisionLister: listers.GetRevisionLister(),
+			clock:          fc,
 		}
 		return configreconciler.NewReconciler(ctx, logging.FromContext(ctx),
 			servingclient.Get(ctx), listers.GetConfigurationLister(),
@@ -108,6 +220,22 @@ func TestGCReconcile(t *testing.T) {
 	}))
 }
 
+func archive(config *v1.Configuration, rev *v1.Revision) *corev1.ConfigMap {
+	cm, err := gcresources.MakeArchive(config, rev)
+	if err != nil {
+		panic(err)
+	}
+	return cm
+}
+
+func restored(config *v1.Configuration, rev *v1.Revision) *v1.Revision {
+	r, err := gcresources.RestoreRevision(config, archive(config, rev))
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
 func cfg(name, namespace string, generation int64, co ...ConfigOption) *v1.Configuration {
 	c := &v1.Configuration{
 		ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,110 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/serving/pkg/apis/serving"
+	v1 "knative.dev/serving/pkg/apis/serving/v1"
+)
+
+// archiveKey is the key of the archived Revision in the archive data.
+const archiveKey = "revision"
+
+// ArchiveName returns the name of the archive of the named revision.
+func ArchiveName(revision string) string {
+	return kmeta.ChildName(revision, "-archive")
+}
+
+// MakeArchive snapshots the revision into an archive owned by the
+// configuration, so that deletes cascade. The images of the snapshot are
+// pinned to the digests they were resolved to.
+func MakeArchive(config *v1.Configuration, rev *v1.Revision) (*corev1.ConfigMap, error) {
+	snapshot := &v1.Revision{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1.SchemeGroupVersion.String(),
+			Kind:       "Revision",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        rev.Name,
+			Namespace:   rev.Namespace,
+			Labels:      rev.Labels,
+			Annotations: rev.Annotations,
+		},
+		Spec: *rev.Spec.DeepCopy(),
+	}
+	pinImages(snapshot.Spec.Containers, rev.Status.ContainerStatuses)
+	pinImages(snapshot.Spec.InitContainers, rev.Status.InitContainerStatuses)
+
+	b, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize revision %s: %w", rev.Name, err)
+	}
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ArchiveName(rev.Name),
+			Namespace: rev.Namespace,
+			Labels: map[string]string{
+				serving.ConfigurationLabelKey:    config.Name,
+				serving.ArchivedRevisionLabelKey: rev.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(config)},
+		},
+		Data: map[string]string{
+			archiveKey: string(b),
+		},
+	}, nil
+}
+
+// RestoreRevision recreates the revision snapshotted in the archive, owned by
+// the configuration. The routing state the revision had when it was archived
+// is dropped, for the labeler to set its current one.
+func RestoreRevision(config *v1.Configuration, archive *corev1.ConfigMap) (*v1.Revision, error) {
+	rev := &v1.Revision{}
+	if err := json.Unmarshal([]byte(archive.Data[archiveKey]), rev); err != nil {
+		return nil, fmt.Errorf("failed to deserialize archive %s: %w", archive.Name, err)
+	}
+	if rev.Name == "" {
+		return nil, fmt.Errorf("archive %s holds no revision", archive.Name)
+	}
+	rev.TypeMeta = metav1.TypeMeta{}
+	delete(rev.Labels, serving.RoutingStateLabelKey)
+	delete(rev.Annotations, serving.RoutingStateModifiedAnnotationKey)
+	delete(rev.Annotations, serving.RoutesAnnotationKey)
+	rev.Namespace = config.Namespace
+	rev.OwnerReferences = []metav1.OwnerReference{*kmeta.NewControllerRef(config)}
+	return rev, nil
+}
+
+// pinImages replaces the images of the containers with the digests they were
+// resolved to, if any.
+func pinImages(containers []corev1.Container, statuses []v1.ContainerStatus) {
+	digests := make(map[string]string, len(statuses))
+	for _, s := range statuses {
+		digests[s.Name] = s.ImageDigest
+	}
+	for i := range containers {
+		if d := digests[containers[i].Name]; d != "" {
+			containers[i].Image = d
+		}
+	}
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/ptr"
+	"knative.dev/serving/pkg/apis/serving"
+	v1 "knative.dev/serving/pkg/apis/serving/v1"
+)
+
+const digest = "gcr.io/repo/image@sha256:deadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef"
+
+func TestArchiveRoundTrip(t *testing.T) {
+	config := &v1.Configuration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "config",
+			Namespace: "ns",
+			UID:       "1234",
+		},
+	}
+	rev := &v1.Revision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "config-00001",
+			Namespace:       "ns",
+			UID:             "5678",
+			ResourceVersion: "42",
+			Labels: map[string]string{
+				serving.ConfigurationLabelKey: "config",
+				serving.RoutingStateLabelKey:  "reserve",
+			},
+			Annotations: map[string]string{
+				serving.CreatorAnnotation:                 "someone",
+				serving.RoutesAnnotationKey:               "route",
+				serving.RoutingStateModifiedAnnotationKey: "2026-01-01T00:00:00Z",
+			},
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(config)},
+		},
+		Spec: v1.RevisionSpec{
+			PodSpec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{
+					Name:  "init",
+					Image: "busybox",
+				}},
+				Containers: []corev1.Container{{
+					Name:  "user-container",
+					Image: "gcr.io/repo/image:latest",
+				}, {
+					Name:  "sidecar",
+					Image: "sidecar",
+				}},
+			},
+			TimeoutSeconds: ptr.Int64(60),
+		},
+		Status: v1.RevisionStatus{
+			ContainerStatuses: []v1.ContainerStatus{{
+				Name:        "user-container",
+				ImageDigest: digest,
+			}, {
+				Name: "sidecar",
+			}},
+			InitContainerStatuses: []v1.ContainerStatus{{
+				Name:        "init",
+				ImageDigest: "busybox@sha256:cafe",
+			}},
+		},
+	}
+
+	archive, err := MakeArchive(config, rev)
+	if err != nil {
+		t.Fatal("MakeArchive() =", err)
+	}
+	if got, want := archive.Name, "config-00001-archive"; got != want {
+		t.Errorf("Name = %q, want: %q", got, want)
+	}
+	if got, want := archive.Labels, map[string]string{
+		serving.ConfigurationLabelKey:    "config",
+		serving.ArchivedRevisionLabelKey: "config-00001",
+	}; !cmp.Equal(got, want) {
+		t.Error("Labels (-want, +got):", cmp.Diff(want, got))
+	}
+	if !metav1.IsControlledBy(archive, config) {
+		t.Error("Archive is not controlled by the configuration")
+	}
+
+	// The restored revision is owned by the configuration restoring it, even
+	// if it was recreated since.
+	config.UID = "9999"
+	got, err := RestoreRevision(config, archive)
+	if err != nil {
+		t.Fatal("RestoreRevision() =", err)
+	}
+	want := &v1.Revision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "config-00001",
+			Namespace: "ns",
+			Labels: map[string]string{
+				serving.ConfigurationLabelKey: "config",
+			},
+			Annotations: map[string]string{
+				serving.CreatorAnnotation: "someone",
+			},
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(config)},
+		},
+		Spec: v1.RevisionSpec{
+			PodSpec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{
+					Name:  "init",
+					Image: "busybox@sha256:cafe",
+				}},
+				Containers: []corev1.Container{{
+					Name:  "user-container",
+					Image: digest,
+				}, {
+					Name:  "sidecar",
+					Image: "sidecar",
+				}},
+			},
+			TimeoutSeconds: ptr.Int64(60),
+		},
+	}
+	if !cmp.Equal(got, want) {
+		t.Error("RestoreRevision (-want, +got):", cmp.Diff(want, got))
+	}
+
+	// The snapshot doesn't alias the revision.
+	if rev.Spec.Containers[0].Image != "gcr.io/repo/image:latest" {
+		t.Error("MakeArchive mutated the revision")
+	}
+}
+
+func TestRestoreRevisionErrors(t *testing.T) {
+	config := &v1.Configuration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "config",
+			Namespace: "ns",
+		},
+	}
+	for name, data := range map[string]string{
+		"malformed": "{",
+		"empty":     "{}",
+		"missing":   "",
+	} {
+		t.Run(name, func(t *testing.T) {
+			archive := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "archive"},
+				Data:       map[string]string{archiveKey: data},
+			}
+			if _, err := RestoreRevision(config, archive); err == nil {
+				t.Error("RestoreRevision() = nil, wanted an error")
+			}
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 	metricinformer "knative.dev/serving/pkg/client/injection/informers/autoscaling/v1alpha1/metric"
 	metricreconciler "knative.dev/serving/pkg/client/injection/reconciler/autoscaling/v1alpha1/metric"
 
+	"k8s.io/utils/clock"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 )
@@ -38,8 +39,10 @@ func NewController(
 
 	c := &reconciler{
 		collector: collector,
+		clock:     clock.RealClock{},
 	}
 	impl := metricreconciler.NewImpl(ctx, c)
+	c.enqueueAfter = impl.EnqueueAfter
 
 	// Watch all the Metric objects.
 	metricInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
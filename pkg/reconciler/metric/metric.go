@@ -19,8 +19,12 @@ package metric
 import (
 	"context"
 	"errors"
+	"math"
+	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
 	autoscalingv1alpha1 "knative.dev/serving/pkg/apis/autoscaling/v1alpha1"
 	"knative.dev/serving/pkg/autoscaler/metrics"
 
@@ -31,6 +35,9 @@ import (
 // reconciler implements controller.Reconciler for Metric resources.
 type reconciler struct {
 	collector metrics.Collector
+	clock     clock.PassiveClock
+
+	enqueueAfter func(interface{}, time.Duration)
 }
 
 // Check that our Reconciler implements the necessary interfaces.
@@ -47,6 +54,8 @@ func (r *reconciler) ReconcileKind(_ context.Context, metric *autoscalingv1alpha
 			metric.Status.MarkMetricNotReady("NoEndpoints", err.Error())
 		case errors.Is(err, metrics.ErrDidNotReceiveStat):
 			metric.Status.MarkMetricFailed("DidNotReceiveStat", err.Error())
+		case errors.Is(err, metrics.ErrCustomMetricNeedsPodScraping):
+			metric.Status.MarkMetricFailed("CustomMetricNeedsPodScraping", err.Error())
 		default:
 			metric.Status.MarkMetricFailed("CollectionFailed",
 				"Failed to reconcile metric collection: "+err.Error())
@@ -57,9 +66,39 @@ func (r *reconciler) ReconcileKind(_ context.Context, metric *autoscalingv1alpha
 	}
 
 	metric.Status.MarkMetricReady()
+	r.publishRequests(metric)
+	// Publish the requests again once the window has moved on by half.
+	r.enqueueAfter(metric, metric.Spec.StableWindow/2)
 	return nil
 }
 
+// publishRequests summarizes the requests served over the stable window in
+// the status of the metric, unless they were published within the last half
+// window. Publishing twice per window keeps a summary covering any period
+// that ended within half a window, however short, without updating the
+// status on every scrape.
+func (r *reconciler) publishRequests(metric *autoscalingv1alpha1.Metric) {
+	now := r.clock.Now()
+	if s := metric.Status.Requests; s != nil && now.Sub(s.ObservedTime.Time) < metric.Spec.StableWindow/2 {
+		return
+	}
+	requests, serverErrors, latency, err := r.collector.StableRequests(
+		types.NamespacedName{Namespace: metric.Namespace, Name: metric.Name}, now)
+	if err != nil || math.Round(requests) < 1 {
+		metric.Status.Requests = nil
+		return
+	}
+	summary := &autoscalingv1alpha1.RequestSummary{
+		ObservedTime: metav1.NewTime(now),
+		Count:        int64(math.Round(requests)),
+		ServerErrors: int64(math.Round(serverErrors)),
+	}
+	for _, c := range latency.GetCounts() {
+		summary.LatencyBuckets = append(summary.LatencyBuckets, int64(c)) //nolint:gosec // G115: the counts are far below the int64 limit
+	}
+	metric.Status.Requests = summary
+}
+
 func (r *reconciler) ObserveDeletion(ctx context.Context, key types.NamespacedName) error {
 	r.collector.Delete(key.Namespace, key.Name)
 	return nil
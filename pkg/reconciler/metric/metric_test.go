@@ -32,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	clientgotesting "k8s.io/client-go/testing"
+	clocktest "k8s.io/utils/clock/testing"
 
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
@@ -59,6 +60,10 @@ func TestNewController(t *testing.T) {
 
 func TestReconcile(t *testing.T) {
 	retryAttempted := false
+	now := time.Unix(1e9, 0)
+	latency := &metrics.LatencySketch{}
+	latency.Record(time.Millisecond)
+	latency.Record(time.Second)
 	table := TableTest{{
 		Name: "bad workqueue key, Part I",
 		Key:  "too/many/parts",
@@ -164,6 +169,61 @@ func TestReconcile(t *testing.T) {
 			Object: metric("bad", "collector", failed("DidNotReceiveStat",
 				metrics.ErrDidNotReceiveStat.Error())),
 		}},
+	}, {
+		Name: "custom metric in mesh mode",
+		Ctx: context.WithValue(context.Background(), collectorKey{},
+			&testCollector{createOrUpdateError: metrics.ErrCustomMetricNeedsPodScraping},
+		),
+		Key: "bad/collector",
+		Objects: []runtime.Object{
+			metric("bad", "collector"),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: metric("bad", "collector", failed("CustomMetricNeedsPodScraping",
+				metrics.ErrCustomMetricNeedsPodScraping.Error())),
+		}},
+	}, {
+		Name: "publish the requests",
+		Ctx: context.WithValue(context.Background(), collectorKey{},
+			&testCollector{requests: 120.4, serverErrors: 6, latency: latency},
+		),
+		Key: "status/requests",
+		Objects: []runtime.Object{
+			metric("status", "requests", ready),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: metric("status", "requests", ready, withRequests(now, 120, 6, latency)),
+		}},
+	}, {
+		Name: "requests published within half the window",
+		Ctx: context.WithValue(context.Background(), collectorKey{},
+			&testCollector{requests: 120, serverErrors: 6, latency: latency},
+		),
+		Key: "status/requests",
+		Objects: []runtime.Object{
+			metric("status", "requests", ready, withRequests(now.Add(-29*time.Second), 60, 0, nil)),
+		},
+	}, {
+		Name: "requests republished after half the window",
+		Ctx: context.WithValue(context.Background(), collectorKey{},
+			&testCollector{requests: 120, serverErrors: 6, latency: latency},
+		),
+		Key: "status/requests",
+		Objects: []runtime.Object{
+			metric("status", "requests", ready, withRequests(now.Add(-30*time.Second), 60, 0, nil)),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: metric("status", "requests", ready, withRequests(now, 120, 6, latency)),
+		}},
+	}, {
+		Name: "no more requests",
+		Key:  "status/requests",
+		Objects: []runtime.Object{
+			metric("status", "requests", ready, withRequests(now.Add(-time.Minute), 60, 0, nil)),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: metric("status", "requests", ready),
+		}},
 	}}
 
 	table.Test(t, MakeFactory(func(ctx context.Context, listers *Listers, cmw configmap.Watcher) controller.Reconciler {
@@ -173,7 +233,9 @@ func TestReconcile(t *testing.T) {
 			col = c.(*testCollector)
 		}
 		r := &reconciler{
-			collector: col,
+			collector:    col,
+			clock:        clocktest.NewFakePassiveClock(now),
+			enqueueAfter: func(interface{}, time.Duration) {},
 		}
 
 		return metricreconciler.NewReconciler(ctx, logging.FromContext(ctx),
@@ -242,6 +304,19 @@ func ready(m *autoscalingv1alpha1.Metric) {
 	m.Status.MarkMetricReady()
 }
 
+func withRequests(now time.Time, count, serverErrors int64, latency *metrics.LatencySketch) metricOption {
+	return func(m *autoscalingv1alpha1.Metric) {
+		m.Status.Requests = &autoscalingv1alpha1.RequestSummary{
+			ObservedTime: metav1.NewTime(now),
+			Count:        count,
+			ServerErrors: serverErrors,
+		}
+		for _, c := range latency.GetCounts() {
+			m.Status.Requests.LatencyBuckets = append(m.Status.Requests.LatencyBuckets, int64(c))
+		}
+	}
+}
+
 func metric(namespace, name string, opts ...metricOption) *autoscalingv1alpha1.Metric {
 	m := &autoscalingv1alpha1.Metric{
 		ObjectMeta: metav1.ObjectMeta{
@@ -266,6 +341,9 @@ type testCollector struct {
 	createOrUpdateError error
 
 	deleteCalls atomic.Int32
+
+	requests, serverErrors float64
+	latency                *metrics.LatencySketch
 }
 
 func (c *testCollector) CreateOrUpdate(metric *autoscalingv1alpha1.Metric) error {
@@ -278,3 +356,10 @@ func (c *testCollector) Delete(namespace, name string) {
 }
 
 func (c *testCollector) Watch(func(types.NamespacedName)) {}
+
+func (c *testCollector) StableRequests(types.NamespacedName, time.Time) (float64, float64, *metrics.LatencySketch, error) {
+	if c.requests == 0 {
+		return 0, 0, nil, metrics.ErrNoData
+	}
+	return c.requests, c.serverErrors, c.latency, nil
+}
@@ -38,6 +38,7 @@ import (
 	namespacereconciler "knative.dev/pkg/client/injection/kube/reconciler/core/v1/namespace"
 	"knative.dev/pkg/controller"
 	pkgreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/serving/pkg/apis/serving"
 	"knative.dev/serving/pkg/reconciler/nscert/config"
 	"knative.dev/serving/pkg/reconciler/nscert/resources"
 	domaincfg "knative.dev/serving/pkg/reconciler/route/config"
@@ -126,9 +127,10 @@ func (c *reconciler) reconcileWildcardCert(
 			cfg.Network.DomainTemplate, domain, ns.Name, err)
 	}
 
-	// If any labeled cert has been issued for our DNSName then there's nothing to do
+	// If any labeled cert has been issued for our DNSName then there's nothing to do,
+	// unless it is ours and the namespace changed its issuer.
 	matchingCert := findMatchingCert(dnsName, existingCerts)
-	if matchingCert != nil {
+	if matchingCert != nil && (!metav1.IsControlledBy(matchingCert, ns) || !issuerChanged(matchingCert, ns)) {
 		return nil
 	}
 	recorder := controller.GetEventRecorder(ctx)
@@ -152,10 +154,18 @@ func (c *reconciler) reconcileWildcardCert(
 		return fmt.Errorf("failed to get namespace certificate: %w", err)
 	} else if !metav1.IsControlledBy(existingCert, ns) {
 		return fmt.Errorf("namespace %s does not own Knative Certificate: %s", ns.Name, existingCert.Name)
-	} else if !equality.Semantic.DeepEqual(existingCert.Spec, desiredCert.Spec) {
+	} else if !equality.Semantic.DeepEqual(existingCert.Spec, desiredCert.Spec) || issuerChanged(existingCert, ns) {
 		copy := existingCert.DeepCopy()
 		copy.Spec = desiredCert.Spec
 		copy.Labels[networking.WildcardCertDomainLabelKey] = desiredCert.Labels[networking.WildcardCertDomainLabelKey]
+		if issuer, ok := desiredCert.Annotations[serving.CertificateIssuerKey]; ok {
+			if copy.Annotations == nil {
+				copy.Annotations = make(map[string]string, 1)
+			}
+			copy.Annotations[serving.CertificateIssuerKey] = issuer
+		} else {
+			delete(copy.Annotations, serving.CertificateIssuerKey)
+		}
 
 		if _, err := c.client.NetworkingV1alpha1().Certificates(copy.Namespace).Update(ctx, copy, metav1.UpdateOptions{}); err != nil {
 			recorder.Eventf(existingCert, corev1.EventTypeWarning, "UpdateFailed",
@@ -215,6 +225,12 @@ func wildcardDomain(tmpl, domain, namespace string) (string, error) {
 	return dom, nil
 }
 
+// issuerChanged returns whether the certificate-issuer annotation of the
+// namespace differs from the one of its certificate.
+func issuerChanged(cert *v1alpha1.Certificate, ns *corev1.Namespace) bool {
+	return cert.Annotations[serving.CertificateIssuerKey] != ns.Annotations[serving.CertificateIssuerKey]
+}
+
 func findMatchingCert(domain string, certs []*v1alpha1.Certificate) *v1alpha1.Certificate {
 	for _, cert := range certs {
 		if dnsNames := sets.New(cert.Spec.DNSNames...); dnsNames.Has(domain) {
@@ -45,6 +45,7 @@ import (
 	pkgreconciler "knative.dev/pkg/reconciler"
 	. "knative.dev/pkg/reconciler/testing"
 	"knative.dev/pkg/system"
+	"knative.dev/serving/pkg/apis/serving"
 	"knative.dev/serving/pkg/reconciler/nscert/config"
 	"knative.dev/serving/pkg/reconciler/nscert/resources/names"
 	routecfg "knative.dev/serving/pkg/reconciler/route/config"
@@ -271,6 +272,20 @@ func TestReconcile(t *testing.T) {
 			Eventf(corev1.EventTypeWarning, "CreationFailed", "Failed to create Knative certificate %s/%s: inducing failure for create certificates", "foo", defaultCertName),
 			Eventf(corev1.EventTypeWarning, "InternalError", "failed to create namespace certificate: inducing failure for create certificates"),
 		},
+	}, {
+		Name:                    "changing the certificate issuer of the namespace updates the cert",
+		Key:                     "foo",
+		SkipNamespaceValidation: true,
+		Objects: []runtime.Object{
+			kubeNamespaceWithIssuer("foo", "dns01"),
+			knCert(kubeNamespace("foo")),
+		},
+		WantUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: knCert(kubeNamespaceWithIssuer("foo", "dns01")),
+		}},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "Updated", "Updated Spec for Knative Certificate %s/%s", "foo", defaultCertName),
+		},
 	}, {
 		Name: "disabling namespace cert feature deletes the cert",
 		Key:  "foo",
@@ -589,7 +604,7 @@ func knCert(namespace *corev1.Namespace) *netv1alpha1.Certificate {
 }
 
 func knCertWithStatus(namespace *corev1.Namespace, status *netv1alpha1.CertificateStatus) *netv1alpha1.Certificate {
-	return &netv1alpha1.Certificate{
+	cert := &netv1alpha1.Certificate{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            defaultCertName,
 			Namespace:       namespace.Name,
@@ -607,6 +622,10 @@ func knCertWithStatus(namespace *corev1.Namespace, status *netv1alpha1.Certifica
 		},
 		Status: *status,
 	}
+	if issuer, ok := namespace.Annotations[serving.CertificateIssuerKey]; ok {
+		cert.Annotations[serving.CertificateIssuerKey] = issuer
+	}
+	return cert
 }
 
 func kubeNamespace(name string) *corev1.Namespace {
@@ -617,6 +636,17 @@ func kubeNamespace(name string) *corev1.Namespace {
 	}
 }
 
+func kubeNamespaceWithIssuer(name, issuer string) *corev1.Namespace {
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Annotations: map[string]string{
+				serving.CertificateIssuerKey: issuer,
+			},
+		},
+	}
+}
+
 func kubeNamespaceWithLabelValue(name string, labels map[string]string) *corev1.Namespace {
 	return &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
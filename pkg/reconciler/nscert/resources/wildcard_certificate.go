@@ -21,19 +21,25 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/networking/pkg/apis/networking"
 	"knative.dev/networking/pkg/apis/networking/v1alpha1"
+	"knative.dev/serving/pkg/apis/serving"
 	"knative.dev/serving/pkg/reconciler/nscert/resources/names"
 )
 
-// MakeWildcardCertificate creates a Knative certificate
+// MakeWildcardCertificate creates a Knative certificate, inheriting the
+// certificate-issuer annotation of the namespace.
 func MakeWildcardCertificate(namespace *corev1.Namespace, dnsName, domain, certClass string) *v1alpha1.Certificate {
+	annotations := map[string]string{
+		networking.CertificateClassAnnotationKey: certClass,
+	}
+	if issuer, ok := namespace.Annotations[serving.CertificateIssuerKey]; ok {
+		annotations[serving.CertificateIssuerKey] = issuer
+	}
 	return &v1alpha1.Certificate{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            names.WildcardCertificate(dnsName),
 			Namespace:       namespace.Name,
 			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(namespace, corev1.SchemeGroupVersion.WithKind("Namespace"))},
-			Annotations: map[string]string{
-				networking.CertificateClassAnnotationKey: certClass,
-			},
+			Annotations:     annotations,
 			Labels: map[string]string{
 				networking.WildcardCertDomainLabelKey: domain,
 			},
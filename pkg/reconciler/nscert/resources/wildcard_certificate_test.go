@@ -24,6 +24,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/networking/pkg/apis/networking"
 	"knative.dev/networking/pkg/apis/networking/v1alpha1"
+	"knative.dev/serving/pkg/apis/serving"
 	"knative.dev/serving/pkg/reconciler/nscert/resources/names"
 )
 
@@ -62,3 +63,20 @@ func TestMakeWildcardCertificate(t *testing.T) {
 		t.Error("MakeWildcardCertificate (-want, +got) =", diff)
 	}
 }
+
+func TestMakeWildcardCertificateIssuer(t *testing.T) {
+	ns := namespace.DeepCopy()
+	ns.Annotations = map[string]string{
+		serving.CertificateIssuerKey: "dns01",
+		"unrelated":                  "annotation",
+	}
+
+	got := MakeWildcardCertificate(ns, dnsName, domain, "dns-01.rocks")
+	want := map[string]string{
+		networking.CertificateClassAnnotationKey: "dns-01.rocks",
+		serving.CertificateIssuerKey:             "dns01",
+	}
+	if diff := cmp.Diff(want, got.Annotations); diff != "" {
+		t.Error("Annotations (-want, +got) =", diff)
+	}
+}
@@ -18,6 +18,7 @@ package revision
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -32,6 +33,7 @@ import (
 
 // imageResolver is an interface used mostly to mock digestResolver for tests.
 type imageResolver interface {
+	signatureVerifier
 	Resolve(ctx context.Context, image string, opt k8schain.Options, registriesToSkip sets.Set[string]) (string, error)
 }
 
@@ -54,6 +56,7 @@ type resolveResult struct {
 	// these fields are immutable after creation, so can be accessed without a lock.
 	opt                k8schain.Options
 	registriesToSkip   sets.Set[string]
+	policy             *imagePolicy
 	completionCallback func()
 	workItems          []workItem
 
@@ -143,7 +146,8 @@ func (r *backgroundResolver) Start(stop <-chan struct{}, maxInFlight int) (done
 // If this method returns `nil, nil` this implies a resolve was triggered or is
 // already in progress, so the reconciler should exit and wait for the revision
 // to be re-enqueued when the result is ready.
-func (r *backgroundResolver) Resolve(logger *zap.SugaredLogger, rev *v1.Revision, opt k8schain.Options, registriesToSkip sets.Set[string], timeout time.Duration) (initContainerStatuses []v1.ContainerStatus, statuses []v1.ContainerStatus, error error) {
+// The resolved digests are checked against the policy, if any.
+func (r *backgroundResolver) Resolve(logger *zap.SugaredLogger, rev *v1.Revision, opt k8schain.Options, registriesToSkip sets.Set[string], policy *imagePolicy, timeout time.Duration) (initContainerStatuses []v1.ContainerStatus, statuses []v1.ContainerStatus, error error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -155,7 +159,7 @@ func (r *backgroundResolver) Resolve(logger *zap.SugaredLogger, rev *v1.Revision
 	result, inFlight := r.results[name]
 	if !inFlight {
 		logger.Debugf("Adding Resolve request to queue (depth: %d)", r.queue.Len())
-		r.addWorkItems(rev, name, opt, registriesToSkip, timeout)
+		r.addWorkItems(rev, name, opt, registriesToSkip, policy, timeout)
 		return nil, nil, nil
 	}
 
@@ -194,11 +198,12 @@ func (r *backgroundResolver) Resolve(logger *zap.SugaredLogger, rev *v1.Revision
 
 // addWorkItems adds a digest resolve item to the queue for each container in the revision.
 // This is expected to be called with the mutex locked.
-func (r *backgroundResolver) addWorkItems(rev *v1.Revision, name types.NamespacedName, opt k8schain.Options, registriesToSkip sets.Set[string], timeout time.Duration) {
+func (r *backgroundResolver) addWorkItems(rev *v1.Revision, name types.NamespacedName, opt k8schain.Options, registriesToSkip sets.Set[string], policy *imagePolicy, timeout time.Duration) {
 	totalNumOfContainers := len(rev.Spec.Containers) + len(rev.Spec.InitContainers)
 	r.results[name] = &resolveResult{
 		opt:                opt,
 		registriesToSkip:   registriesToSkip,
+		policy:             policy,
 		imagesResolved:     make(map[string]string),
 		imagesToBeResolved: sets.Set[string]{},
 		workItems:          make([]workItem, 0, totalNumOfContainers),
@@ -245,6 +250,9 @@ func (r *backgroundResolver) processWorkItem(item workItem) {
 	r.logger.Debugf("Resolving image %q from revision %q to digest", item.image, item.revision)
 	resolvedDigest, resolveErr := r.resolver.Resolve(ctx, item.image, result.opt, result.registriesToSkip)
 	r.logger.Debugf("Resolved image %q from revision %q to digest %q, %v", item.image, item.revision, resolvedDigest, resolveErr)
+	if resolveErr == nil {
+		resolveErr = result.policy.check(ctx, r.resolver, item.image, resolvedDigest, result.opt)
+	}
 
 	// lock after the resolve because we don't want to block parallel resolves,
 	// just storing the result.
@@ -263,7 +271,12 @@ func (r *backgroundResolver) processWorkItem(item workItem) {
 		return
 	}
 
-	if resolveErr != nil {
+	var policyErr *imagePolicyError
+	if errors.As(resolveErr, &policyErr) {
+		result.err = resolveErr
+		result.completionCallback()
+		return
+	} else if resolveErr != nil {
 		result.err = fmt.Errorf("%s: %w", v1.RevisionContainerMissingMessage(item.image, "failed to resolve image to digest"), resolveErr)
 		result.completionCallback()
 		return
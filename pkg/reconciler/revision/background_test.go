@@ -18,6 +18,7 @@ package revision
 
 import (
 	"context"
+	"crypto"
 	"errors"
 	"fmt"
 	"math"
@@ -29,6 +30,7 @@ import (
 	"knative.dev/pkg/ptr"
 
 	"github.com/google/go-containerregistry/pkg/authn/k8schain"
+	"github.com/google/go-containerregistry/pkg/name"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -161,7 +163,7 @@ func TestResolveInBackground(t *testing.T) {
 			for i := range 2 {
 				t.Run(fmt.Sprint("iteration", i), func(t *testing.T) {
 					logger := logtesting.TestLogger(t)
-					initContainerStatuses, statuses, err := subject.Resolve(logger, fakeRevision, k8schain.Options{ServiceAccountName: "san"}, sets.New("skip"), timeout)
+					initContainerStatuses, statuses, err := subject.Resolve(logger, fakeRevision, k8schain.Options{ServiceAccountName: "san"}, sets.New("skip"), nil, timeout)
 					if err != nil || statuses != nil || initContainerStatuses != nil {
 						// Initial result should be nil, nil, nil since we have nothing in cache.
 						t.Errorf("Resolve() = %v, %v %v, wanted nil, nil, nil", statuses, initContainerStatuses, err)
@@ -176,7 +178,7 @@ func TestResolveInBackground(t *testing.T) {
 						t.Fatalf("Resolver did not report ready")
 					}
 
-					initContainerStatuses, statuses, err = subject.Resolve(logger, fakeRevision, k8schain.Options{}, nil, timeout)
+					initContainerStatuses, statuses, err = subject.Resolve(logger, fakeRevision, k8schain.Options{}, nil, nil, timeout)
 					if got, want := err, tt.wantError; !errors.Is(got, want) {
 						t.Errorf("Resolve() = _, %q, wanted %q", got, want)
 					}
@@ -198,6 +200,115 @@ func TestResolveInBackground(t *testing.T) {
 	}
 }
 
+func TestResolveInBackgroundWithPolicy(t *testing.T) {
+	const sha = "sha256:deadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef"
+	toDigest := resolveFunc(func(_ context.Context, img string, _ k8schain.Options, _ sets.Set[string]) (string, error) {
+		return img + "@" + sha, nil
+	})
+	// Registries skipping tag resolution resolve the tags to no digest.
+	skipped := resolveFunc(func(context.Context, string, k8schain.Options, sets.Set[string]) (string, error) {
+		return "", nil
+	})
+	signed := func(name.Digest) error { return nil }
+	unsigned := func(name.Digest) error { return errNoValidSignature }
+	unavailable := func(name.Digest) error { return errDigest }
+
+	tests := []struct {
+		name       string
+		policy     *imagePolicy
+		resolve    resolveFunc
+		verify     func(name.Digest) error
+		wantReason string
+		wantError  error
+	}{{
+		name:   "allowed digest",
+		policy: &imagePolicy{allowedDigests: sets.New(sha)},
+		verify: unsigned,
+	}, {
+		name:       "digest not allowed",
+		policy:     &imagePolicy{allowedDigests: sets.New("sha256:cafe")},
+		verify:     signed,
+		wantReason: v1.ReasonDigestNotAllowed,
+	}, {
+		name:   "signed digest",
+		policy: &imagePolicy{keys: []crypto.PublicKey{"key"}},
+		verify: signed,
+	}, {
+		name:       "unsigned digest",
+		policy:     &imagePolicy{keys: []crypto.PublicKey{"key"}},
+		verify:     unsigned,
+		wantReason: v1.ReasonSignatureVerificationFailed,
+	}, {
+		name:      "signatures unavailable",
+		policy:    &imagePolicy{keys: []crypto.PublicKey{"key"}},
+		verify:    unavailable,
+		wantError: errDigest,
+	}, {
+		name:       "skipped registry with allowed digests",
+		policy:     &imagePolicy{allowedDigests: sets.New(sha)},
+		resolve:    skipped,
+		verify:     signed,
+		wantReason: v1.ReasonDigestNotResolved,
+	}, {
+		name:       "skipped registry with signature keys",
+		policy:     &imagePolicy{keys: []crypto.PublicKey{"key"}},
+		resolve:    skipped,
+		verify:     signed,
+		wantReason: v1.ReasonDigestNotResolved,
+	}, {
+		name:    "skipped registry without policy",
+		resolve: skipped,
+		verify:  unsigned,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ready := make(chan types.NamespacedName, 1)
+			logger := logtesting.TestLogger(t)
+			resolve := toDigest
+			if tt.resolve != nil {
+				resolve = tt.resolve
+			}
+			subject := newBackgroundResolver(logger, verifyingResolver{resolve, tt.verify},
+				workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[any]()),
+				func(rev types.NamespacedName) { ready <- rev })
+
+			stop := make(chan struct{})
+			done := subject.Start(stop, 10)
+			defer func() {
+				close(stop)
+				<-done
+			}()
+
+			if _, _, err := subject.Resolve(logger, fakeRevision, k8schain.Options{}, nil, tt.policy, 5*time.Second); err != nil {
+				t.Fatal("Resolve() =", err)
+			}
+			select {
+			case <-ready:
+			case <-time.After(2 * time.Second):
+				t.Fatal("Resolver did not report ready")
+			}
+
+			_, statuses, err := subject.Resolve(logger, fakeRevision, k8schain.Options{}, nil, tt.policy, 5*time.Second)
+			var policyErr *imagePolicyError
+			switch {
+			case tt.wantReason != "":
+				if !errors.As(err, &policyErr) || policyErr.reason != tt.wantReason {
+					t.Errorf("Resolve() = %v, wanted a policy error with reason %s", err, tt.wantReason)
+				}
+			case tt.wantError != nil:
+				if !errors.Is(err, tt.wantError) || errors.As(err, &policyErr) {
+					t.Errorf("Resolve() = %v, wanted %v", err, tt.wantError)
+				}
+			case err != nil:
+				t.Error("Resolve() =", err)
+			case len(statuses) != 2:
+				t.Errorf("Resolve() = %v, wanted 2 statuses", statuses)
+			}
+		})
+	}
+}
+
 func TestRateLimitPerItem(t *testing.T) {
 	logger := logtesting.TestLogger(t)
 
@@ -229,14 +340,14 @@ func TestRateLimitPerItem(t *testing.T) {
 	for i := range 3 {
 		subject.Clear(types.NamespacedName{Name: revision.Name, Namespace: revision.Namespace})
 		start := time.Now()
-		initResolution, resolution, err := subject.Resolve(logger, revision, k8schain.Options{ServiceAccountName: "san"}, sets.New("skip"), 0)
+		initResolution, resolution, err := subject.Resolve(logger, revision, k8schain.Options{ServiceAccountName: "san"}, sets.New("skip"), nil, 0)
 		if err != nil || resolution != nil || initResolution != nil {
 			t.Fatalf("Expected Resolve to be nil, nil, nil but got %v, %v, %v", resolution, initResolution, err)
 		}
 
 		<-enqueue
 
-		_, _, err = subject.Resolve(logger, revision, k8schain.Options{ServiceAccountName: "san"}, sets.New("skip"), 0)
+		_, _, err = subject.Resolve(logger, revision, k8schain.Options{ServiceAccountName: "san"}, sets.New("skip"), nil, 0)
 		if err == nil {
 			t.Fatalf("Expected Resolve to fail")
 		}
@@ -251,7 +362,7 @@ func TestRateLimitPerItem(t *testing.T) {
 
 	t.Run("Does not affect other revisions", func(t *testing.T) {
 		start := time.Now()
-		_, resolution, err := subject.Resolve(logger, rev("another-revision", "img1", "img2"), k8schain.Options{ServiceAccountName: "san"}, sets.New("skip"), 0)
+		_, resolution, err := subject.Resolve(logger, rev("another-revision", "img1", "img2"), k8schain.Options{ServiceAccountName: "san"}, sets.New("skip"), nil, 0)
 		if err != nil || resolution != nil {
 			t.Fatalf("Expected Resolve to be nil, nil but got %v, %v", resolution, err)
 		}
@@ -266,7 +377,7 @@ func TestRateLimitPerItem(t *testing.T) {
 		subject.Forget(types.NamespacedName{Name: revision.Name, Namespace: revision.Namespace})
 
 		start := time.Now()
-		_, resolution, err := subject.Resolve(logger, revision, k8schain.Options{ServiceAccountName: "san"}, sets.New("skip"), 0)
+		_, resolution, err := subject.Resolve(logger, revision, k8schain.Options{ServiceAccountName: "san"}, sets.New("skip"), nil, 0)
 		if err != nil || resolution != nil {
 			t.Fatalf("Expected Resolve to be nil, nil but got %v, %v", resolution, err)
 		}
@@ -285,6 +396,21 @@ func (r resolveFunc) Resolve(c context.Context, s string, o k8schain.Options, t
 	return r(c, s, o, t)
 }
 
+func (r resolveFunc) Verify(context.Context, name.Digest, k8schain.Options, []crypto.PublicKey) error {
+	return nil
+}
+
+// verifyingResolver resolves the images to a digest, and verifies the
+// signatures with verify.
+type verifyingResolver struct {
+	resolveFunc
+	verify func(name.Digest) error
+}
+
+func (r verifyingResolver) Verify(_ context.Context, d name.Digest, _ k8schain.Options, _ []crypto.PublicKey) error {
+	return r.verify(d)
+}
+
 func rev(name, firstImage, secondImage string) *v1.Revision {
 	return &v1.Revision{
 		ObjectMeta: metav1.ObjectMeta{
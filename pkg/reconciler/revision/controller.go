@@ -32,6 +32,7 @@ import (
 	"knative.dev/pkg/changeset"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	deploymentinformer "knative.dev/pkg/client/injection/kube/informers/apps/v1/deployment"
+	nssecretinformer "knative.dev/pkg/injection/clients/namespacedkube/informers/core/v1/secret"
 	servingclient "knative.dev/serving/pkg/client/injection/client"
 	painformer "knative.dev/serving/pkg/client/injection/informers/autoscaling/v1alpha1/podautoscaler"
 	revisioninformer "knative.dev/serving/pkg/client/injection/informers/serving/v1/revision"
@@ -40,6 +41,7 @@ import (
 
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
 	netcfg "knative.dev/networking/pkg/config"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
@@ -77,6 +79,7 @@ func newControllerWithOptions(
 	imageInformer := imageinformer.Get(ctx)
 	paInformer := painformer.Get(ctx)
 	certificateInformer := certificateinformer.Get(ctx)
+	secretInformer := nssecretinformer.Get(ctx)
 
 	c := &Reconciler{
 		kubeclient:       kubeclient.Get(ctx),
@@ -88,8 +91,12 @@ func newControllerWithOptions(
 		imageLister:         imageInformer.Lister(),
 		deploymentLister:    deploymentInformer.Lister(),
 		certificateLister:   certificateInformer.Lister(),
+		secretLister:        secretInformer.Lister(),
+
+		drift: newDriftTracker(clock.RealClock{}),
 	}
 
+	var configStore *config.Store
 	impl := revisionreconciler.NewImpl(ctx, c, func(impl *controller.Impl) controller.Options {
 		configsToResync := []interface{}{
 			&netcfg.Config{},
@@ -104,12 +111,13 @@ func newControllerWithOptions(
 			impl.GlobalResync(revisionInformer.Informer())
 		})
 
-		configStore := config.NewStore(logger.Named("config-store"), resync)
+		configStore = config.NewStore(logger.Named("config-store"), resync)
 		configStore.WatchConfigs(cmw)
 		return controller.Options{ConfigStore: configStore}
 	})
 
 	c.tracker = impl.Tracker
+	c.enqueueAfter = impl.EnqueueAfter
 
 	transport := http.DefaultTransport
 	if rt, err := newResolverTransport(k8sCertPath, digestResolutionWorkers, digestResolutionWorkers); err != nil {
@@ -149,6 +157,19 @@ func newControllerWithOptions(
 		),
 	))
 
+	// Check the images of the revisions failing their signature verification
+	// again when the image signature keys change.
+	secretInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			cfg := configStore.Load().Deployment
+			return cfg != nil && cfg.ImageSignatureKeysSecret != "" &&
+				controller.FilterWithName(cfg.ImageSignatureKeysSecret)(obj)
+		},
+		Handler: controller.HandleAll(func(interface{}) {
+			impl.GlobalResync(revisionInformer.Informer())
+		}),
+	})
+
 	// We don't watch for changes to Image because we don't incorporate any of its
 	// properties into our own status and should work completely in the absence of
 	// a functioning Image controller.
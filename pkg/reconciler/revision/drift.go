@@ -0,0 +1,96 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package revision
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+	v1 "knative.dev/serving/pkg/apis/serving/v1"
+)
+
+// driftTracker records when the tags of the revisions were last resolved
+// again, to space their re-resolutions by the configured interval.
+type driftTracker struct {
+	clock clock.PassiveClock
+	// jitter returns the random delay of the first re-resolution of a
+	// revision within the interval, so that the revisions tracked since the
+	// controller started don't all resolve their tags at once.
+	jitter func(interval time.Duration) time.Duration
+
+	mu          sync.Mutex
+	lastChecked map[types.NamespacedName]time.Time
+}
+
+func newDriftTracker(clock clock.PassiveClock) *driftTracker {
+	return &driftTracker{
+		clock:       clock,
+		jitter:      rand.N[time.Duration],
+		lastChecked: make(map[types.NamespacedName]time.Time),
+	}
+}
+
+// due returns the time left until the tags of the revision are due to be
+// resolved again, or zero if they are. The first re-resolution of a revision
+// is due at a random time within the interval.
+func (d *driftTracker) due(name types.NamespacedName, interval time.Duration) time.Duration {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	last, ok := d.lastChecked[name]
+	if !ok {
+		last = d.clock.Now().Add(d.jitter(interval) - interval)
+		d.lastChecked[name] = last
+	}
+	return max(last.Add(interval).Sub(d.clock.Now()), 0)
+}
+
+// checked records that the tags of the revision were just resolved again.
+func (d *driftTracker) checked(name types.NamespacedName) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.lastChecked[name] = d.clock.Now()
+}
+
+// forget removes the revision from the tracker.
+func (d *driftTracker) forget(name types.NamespacedName) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.lastChecked, name)
+}
+
+// driftedImages returns a description of the images of the revision whose
+// tags now resolve to other digests than the ones the revision runs.
+func driftedImages(rev *v1.Revision, initContainerStatuses, statuses []v1.ContainerStatus) []string {
+	var drifted []string
+	check := func(containers []corev1.Container, pinned, resolved []v1.ContainerStatus) {
+		// The statuses are in the order of the containers.
+		for i := range min(len(containers), len(pinned), len(resolved)) {
+			was, now := pinned[i].ImageDigest, resolved[i].ImageDigest
+			if was != "" && now != "" && was != now {
+				drifted = append(drifted, fmt.Sprintf("%s now resolves to %s", containers[i].Image, now))
+			}
+		}
+	}
+	check(rev.Spec.InitContainers, rev.Status.InitContainerStatuses, initContainerStatuses)
+	check(rev.Spec.Containers, rev.Status.ContainerStatuses, statuses)
+	return drifted
+}
@@ -0,0 +1,274 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package revision
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-containerregistry/pkg/authn/k8schain"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clocktest "k8s.io/utils/clock/testing"
+
+	"knative.dev/serving/pkg/apis/serving"
+	v1 "knative.dev/serving/pkg/apis/serving/v1"
+	"knative.dev/serving/pkg/deployment"
+	"knative.dev/serving/pkg/reconciler/revision/config"
+)
+
+func TestDriftTracker(t *testing.T) {
+	clock := clocktest.NewFakePassiveClock(time.Now())
+	tracker := newDriftTracker(clock)
+	tracker.jitter = func(interval time.Duration) time.Duration { return interval / 3 }
+	name := types.NamespacedName{Namespace: "foo", Name: "bar"}
+
+	// The first check is due within the interval, however often asked.
+	if got, want := tracker.due(name, time.Hour), 20*time.Minute; got != want {
+		t.Errorf("due() = %v before the first check, wanted %v", got, want)
+	}
+	clock.SetTime(clock.Now().Add(5 * time.Minute))
+	if got, want := tracker.due(name, time.Hour), 15*time.Minute; got != want {
+		t.Errorf("due() = %v before the first check, wanted %v", got, want)
+	}
+	clock.SetTime(clock.Now().Add(15 * time.Minute))
+	if got := tracker.due(name, time.Hour); got != 0 {
+		t.Errorf("due() = %v once the first check is due, wanted 0", got)
+	}
+	tracker.checked(name)
+	if got, want := tracker.due(name, time.Hour), time.Hour; got != want {
+		t.Errorf("due() = %v, wanted %v", got, want)
+	}
+	clock.SetTime(clock.Now().Add(45 * time.Minute))
+	if got, want := tracker.due(name, time.Hour), 15*time.Minute; got != want {
+		t.Errorf("due() = %v, wanted %v", got, want)
+	}
+	clock.SetTime(clock.Now().Add(time.Hour))
+	if got := tracker.due(name, time.Hour); got != 0 {
+		t.Errorf("due() = %v after the interval, wanted 0", got)
+	}
+	tracker.checked(name)
+	tracker.forget(name)
+	if got, want := tracker.due(name, time.Hour), 20*time.Minute; got != want {
+		t.Errorf("due() = %v after forget, wanted %v", got, want)
+	}
+
+	for range 100 {
+		if got := newDriftTracker(clock).jitter(time.Hour); got < 0 || got >= time.Hour {
+			t.Fatalf("jitter() = %v, wanted within [0, %v)", got, time.Hour)
+		}
+	}
+}
+
+func TestDriftedImages(t *testing.T) {
+	rev := &v1.Revision{
+		Spec: v1.RevisionSpec{PodSpec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Image: "init:latest"}},
+			Containers:     []corev1.Container{{Image: "app:latest"}, {Image: "sidecar:latest"}},
+		}},
+		Status: v1.RevisionStatus{
+			InitContainerStatuses: []v1.ContainerStatus{{ImageDigest: "init@sha256:1"}},
+			ContainerStatuses:     []v1.ContainerStatus{{ImageDigest: "app@sha256:1"}, {ImageDigest: "sidecar@sha256:1"}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		init     []v1.ContainerStatus
+		statuses []v1.ContainerStatus
+		want     []string
+	}{{
+		name:     "no drift",
+		init:     []v1.ContainerStatus{{ImageDigest: "init@sha256:1"}},
+		statuses: []v1.ContainerStatus{{ImageDigest: "app@sha256:1"}, {ImageDigest: "sidecar@sha256:1"}},
+	}, {
+		name:     "drifted",
+		init:     []v1.ContainerStatus{{ImageDigest: "init@sha256:2"}},
+		statuses: []v1.ContainerStatus{{ImageDigest: "app@sha256:1"}, {ImageDigest: "sidecar@sha256:2"}},
+		want:     []string{"init:latest now resolves to init@sha256:2", "sidecar:latest now resolves to sidecar@sha256:2"},
+	}, {
+		name:     "skipped registry",
+		statuses: []v1.ContainerStatus{{ImageDigest: ""}, {ImageDigest: "sidecar@sha256:1"}},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := driftedImages(rev, tt.init, tt.statuses); !cmp.Equal(got, tt.want) {
+				t.Errorf("driftedImages() = %v, wanted %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// driftResolver resolves the images of the revisions to digests.
+type driftResolver struct {
+	digest  string
+	err     error
+	resolve int
+	policy  *imagePolicy
+}
+
+func (r *driftResolver) Resolve(_ *zap.SugaredLogger, rev *v1.Revision, _ k8schain.Options, _ sets.Set[string], policy *imagePolicy, _ time.Duration) ([]v1.ContainerStatus, []v1.ContainerStatus, error) {
+	r.resolve++
+	r.policy = policy
+	if r.err != nil {
+		return nil, nil, r.err
+	}
+	return nil, []v1.ContainerStatus{{Name: rev.Spec.Containers[0].Name, ImageDigest: r.digest}}, nil
+}
+
+func (r *driftResolver) Clear(types.NamespacedName)  {}
+func (r *driftResolver) Forget(types.NamespacedName) {}
+
+func TestReconcileTagDrift(t *testing.T) {
+	tests := []struct {
+		name       string
+		resolver   *driftResolver
+		allowed    sets.Set[string]
+		wantStatus corev1.ConditionStatus
+		wantReason string
+	}{{
+		name:       "no drift",
+		resolver:   &driftResolver{digest: "app@sha256:1"},
+		wantStatus: corev1.ConditionTrue,
+	}, {
+		name:       "drifted",
+		resolver:   &driftResolver{digest: "app@sha256:2"},
+		wantStatus: corev1.ConditionFalse,
+		wantReason: v1.ReasonTagDrifted,
+	}, {
+		name:    "policy violated",
+		allowed: sets.New("sha256:1"),
+		resolver: &driftResolver{err: &imagePolicyError{
+			reason:  v1.ReasonSignatureVerificationFailed,
+			message: `Image "app:latest" resolved to digest sha256:1, which has no valid signature`,
+		}},
+		wantStatus: corev1.ConditionFalse,
+		wantReason: v1.ReasonSignatureVerificationFailed,
+	}, {
+		name:     "resolution failed",
+		resolver: &driftResolver{err: errors.New("registry unavailable")},
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rev := &v1.Revision{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "bar"},
+				Spec: v1.RevisionSpec{PodSpec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "app", Image: "app:latest"}},
+				}},
+				Status: v1.RevisionStatus{
+					ContainerStatuses: []v1.ContainerStatus{{Name: "app", ImageDigest: "app@sha256:1"}},
+				},
+			}
+			var enqueued time.Duration
+			c := &Reconciler{
+				resolver:     tt.resolver,
+				drift:        newDriftTracker(clocktest.NewFakePassiveClock(time.Now())),
+				enqueueAfter: func(_ interface{}, d time.Duration) { enqueued = d },
+			}
+			c.drift.jitter = func(time.Duration) time.Duration { return 0 }
+			ctx := config.ToContext(context.Background(), &config.Config{
+				Deployment: &deployment.Config{
+					DigestReResolutionInterval: time.Hour,
+					AllowedImageDigests:        tt.allowed,
+				},
+			})
+
+			c.reconcileTagDrift(ctx, rev)
+			if enqueued != time.Hour {
+				t.Errorf("Enqueued after %v, wanted %v", enqueued, time.Hour)
+			}
+			if got, want := tt.resolver.policy != nil, tt.allowed != nil; got != want {
+				t.Errorf("Resolved with a policy = %t, wanted %t", got, want)
+			}
+			cond := rev.Status.GetCondition(v1.RevisionConditionImagePolicySatisfied)
+			if tt.wantStatus == "" {
+				if cond != nil {
+					t.Errorf("ImagePolicySatisfied = %v, wanted none", cond)
+				}
+			} else if cond == nil || cond.Status != tt.wantStatus || cond.Reason != tt.wantReason {
+				t.Errorf("ImagePolicySatisfied = %v, wanted status %s and reason %q", cond, tt.wantStatus, tt.wantReason)
+			}
+
+			// The tags aren't resolved again before the interval elapses.
+			c.reconcileTagDrift(ctx, rev)
+			if got := tt.resolver.resolve; got != 1 {
+				t.Errorf("Resolved %d times, wanted once", got)
+			}
+		})
+	}
+}
+
+func TestReconcileDigestDriftOfRoutedRevisions(t *testing.T) {
+	tests := []struct {
+		name        string
+		state       v1.RoutingState
+		wantResolve int
+	}{{
+		name:        "routed",
+		state:       v1.RoutingStateActive,
+		wantResolve: 1,
+	}, {
+		name:  "reserved",
+		state: v1.RoutingStateReserve,
+	}, {
+		name:  "pending",
+		state: v1.RoutingStatePending,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rev := &v1.Revision{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "foo",
+					Name:      "bar",
+					Labels:    map[string]string{serving.RoutingStateLabelKey: string(tt.state)},
+				},
+				Spec: v1.RevisionSpec{PodSpec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "app", Image: "app:latest"}},
+				}},
+				Status: v1.RevisionStatus{
+					ContainerStatuses: []v1.ContainerStatus{{Name: "app", ImageDigest: "app@sha256:1"}},
+				},
+			}
+			resolver := &driftResolver{digest: "app@sha256:1"}
+			c := &Reconciler{
+				resolver:     resolver,
+				drift:        newDriftTracker(clocktest.NewFakePassiveClock(time.Now())),
+				enqueueAfter: func(interface{}, time.Duration) {},
+			}
+			c.drift.jitter = func(time.Duration) time.Duration { return 0 }
+			ctx := config.ToContext(context.Background(), &config.Config{
+				Deployment: &deployment.Config{DigestReResolutionInterval: time.Hour},
+			})
+
+			if done, err := c.reconcileDigest(ctx, rev); !done || err != nil {
+				t.Fatalf("reconcileDigest() = %t, %v", done, err)
+			}
+			if got := resolver.resolve; got != tt.wantResolve {
+				t.Errorf("Resolved %d times, wanted %d", got, tt.wantResolve)
+			}
+		})
+	}
+}
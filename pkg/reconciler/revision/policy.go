@@ -0,0 +1,209 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package revision
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/authn/k8schain"
+	"github.com/google/go-containerregistry/pkg/name"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"knative.dev/pkg/system"
+	v1 "knative.dev/serving/pkg/apis/serving/v1"
+	"knative.dev/serving/pkg/deployment"
+)
+
+// errNoValidSignature is returned when a digest isn't signed by any of the
+// trusted keys.
+var errNoValidSignature = errors.New("no valid signature by a trusted key")
+
+// signatureVerifier verifies the signatures of the image digests.
+type signatureVerifier interface {
+	Verify(ctx context.Context, digest name.Digest, opt k8schain.Options, keys []crypto.PublicKey) error
+}
+
+// imagePolicy holds the checks the resolved digests of a revision must pass.
+type imagePolicy struct {
+	// allowedDigests is the set of digests the images may resolve to, or any
+	// digest if empty.
+	allowedDigests sets.Set[string]
+
+	// keys are the public keys one of which must have signed the digests, if
+	// any.
+	keys []crypto.PublicKey
+}
+
+// imagePolicyError is returned when a resolved digest violates the image
+// policy.
+type imagePolicyError struct {
+	// reason is the reason of the revision condition reporting the violation.
+	reason  string
+	message string
+}
+
+func (e *imagePolicyError) Error() string {
+	return e.message
+}
+
+// newImagePolicy returns the image policy of the deployment config for the
+// revisions of the namespace, or nil if none is configured.
+func newImagePolicy(secretLister corev1listers.SecretLister, cfg *deployment.Config, namespace string) (*imagePolicy, error) {
+	verifiesSignatures := cfg.VerifiesImageSignatures(namespace)
+	if len(cfg.AllowedImageDigests) == 0 && !verifiesSignatures {
+		return nil, nil
+	}
+	policy := &imagePolicy{allowedDigests: cfg.AllowedImageDigests}
+	if !verifiesSignatures {
+		return policy, nil
+	}
+
+	// The config map is validated when loaded.
+	keys, err := parsePublicKeys([]byte(cfg.ImageSignaturePublicKeys))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the image signature public keys: %w", err)
+	}
+	policy.keys = keys
+	if cfg.ImageSignatureKeysSecret != "" {
+		secret, err := secretLister.Secrets(system.Namespace()).Get(cfg.ImageSignatureKeysSecret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the image signature keys: %w", err)
+		}
+		for k, data := range secret.Data {
+			keys, err := parsePublicKeys(data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse the image signature keys of %q: %w", k, err)
+			}
+			policy.keys = append(policy.keys, keys...)
+		}
+	}
+	if len(policy.keys) == 0 {
+		return nil, errors.New("no image signature keys are configured")
+	}
+	return policy, nil
+}
+
+// parsePublicKeys parses the PEM encoded public keys.
+func parsePublicKeys(data []byte) ([]crypto.PublicKey, error) {
+	var keys []crypto.PublicKey
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return keys, nil
+		}
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+}
+
+// check verifies that the resolved image satisfies the policy. It returns an
+// imagePolicyError if it doesn't.
+func (p *imagePolicy) check(ctx context.Context, verifier signatureVerifier, image, resolved string, opt k8schain.Options) error {
+	if p == nil {
+		return nil
+	}
+	if resolved == "" {
+		// The image is tagged in a registry skipping tag resolution, so
+		// neither its digest nor its signatures can be checked.
+		return &imagePolicyError{
+			reason:  v1.ReasonDigestNotResolved,
+			message: fmt.Sprintf("Image %q is from a registry skipping tag resolution and must be referenced by digest", image),
+		}
+	}
+	digest, err := name.NewDigest(resolved, name.WeakValidation)
+	if err != nil {
+		return fmt.Errorf("failed to parse digest %q: %w", resolved, err)
+	}
+	if len(p.allowedDigests) > 0 && !p.allowedDigests.Has(digest.DigestStr()) {
+		return &imagePolicyError{
+			reason:  v1.ReasonDigestNotAllowed,
+			message: fmt.Sprintf("Image %q resolved to digest %s, which is not allowed", image, digest.DigestStr()),
+		}
+	}
+	if len(p.keys) > 0 {
+		if err := verifier.Verify(ctx, digest, opt, p.keys); errors.Is(err, errNoValidSignature) {
+			return &imagePolicyError{
+				reason:  v1.ReasonSignatureVerificationFailed,
+				message: fmt.Sprintf("Image %q resolved to digest %s, which has %v", image, digest.DigestStr(), err),
+			}
+		} else if err != nil {
+			return fmt.Errorf("failed to verify the signatures of %s: %w", digest, err)
+		}
+	}
+	return nil
+}
+
+// signedPayload is a signed payload stored in the registry alongside the image,
+// the way cosign does.
+type signedPayload struct {
+	payload   []byte
+	signature []byte
+}
+
+// simpleSigning is the subset of the simple signing payload, signed by
+// cosign, which binds the signature to the image digest.
+type simpleSigning struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// verifySignatures returns nil if one of the payloads is for the digest and
+// signed by one of the keys, and errNoValidSignature otherwise.
+func verifySignatures(payloads []signedPayload, digest string, keys []crypto.PublicKey) error {
+	for _, p := range payloads {
+		var ss simpleSigning
+		if err := json.Unmarshal(p.payload, &ss); err != nil || ss.Critical.Image.DockerManifestDigest != digest {
+			continue
+		}
+		hashed := sha256.Sum256(p.payload)
+		for _, key := range keys {
+			if verifySignature(key, p.payload, hashed[:], p.signature) {
+				return nil
+			}
+		}
+	}
+	return errNoValidSignature
+}
+
+func verifySignature(key crypto.PublicKey, payload, hashed, signature []byte) bool {
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(k, hashed, signature)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, hashed, signature) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(k, payload, signature)
+	}
+	return false
+}
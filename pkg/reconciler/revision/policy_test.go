@@ -0,0 +1,262 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package revision
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/system"
+
+	"knative.dev/serving/pkg/deployment"
+	. "knative.dev/serving/pkg/reconciler/testing/v1"
+)
+
+const testDigest = "sha256:deadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef"
+
+func generateKey(t *testing.T) (*ecdsa.PrivateKey, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal("Failed to generate key:", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal("Failed to marshal key:", err)
+	}
+	return key, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func sign(t *testing.T, key *ecdsa.PrivateKey, digest string) signedPayload {
+	t.Helper()
+	payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"img"},"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"}}`, digest))
+	hashed := sha256.Sum256(payload)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, hashed[:])
+	if err != nil {
+		t.Fatal("Failed to sign payload:", err)
+	}
+	return signedPayload{payload: payload, signature: signature}
+}
+
+func TestParsePublicKeys(t *testing.T) {
+	_, pem1 := generateKey(t)
+	_, pem2 := generateKey(t)
+
+	keys, err := parsePublicKeys(append(pem1, pem2...))
+	if err != nil {
+		t.Fatal("parsePublicKeys() =", err)
+	}
+	if len(keys) != 2 {
+		t.Errorf("parsePublicKeys() = %d keys, wanted 2", len(keys))
+	}
+
+	if _, err := parsePublicKeys([]byte("-----BEGIN PUBLIC KEY-----\nZm9v\n-----END PUBLIC KEY-----\n")); err == nil {
+		t.Error("parsePublicKeys() = nil, wanted an error for an invalid key")
+	}
+}
+
+func TestVerifySignatures(t *testing.T) {
+	key, _ := generateKey(t)
+	other, _ := generateKey(t)
+	_, cosigned, cosignKey := readCosignSignature(t)
+	tampered := signedPayload{
+		payload:   bytes.Replace(cosigned.payload, []byte("helloworld-go"), []byte("helloworld-js"), 1),
+		signature: cosigned.signature,
+	}
+
+	tests := []struct {
+		name     string
+		digest   string
+		payloads []signedPayload
+		keys     []crypto.PublicKey
+		wantErr  bool
+	}{{
+		name:     "signed",
+		payloads: []signedPayload{sign(t, key, testDigest)},
+		keys:     []crypto.PublicKey{&other.PublicKey, &key.PublicKey},
+	}, {
+		name:     "signed among others",
+		payloads: []signedPayload{sign(t, other, testDigest), sign(t, key, testDigest)},
+		keys:     []crypto.PublicKey{&key.PublicKey},
+	}, {
+		name:     "signed by an untrusted key",
+		payloads: []signedPayload{sign(t, other, testDigest)},
+		keys:     []crypto.PublicKey{&key.PublicKey},
+		wantErr:  true,
+	}, {
+		name:     "signed for another digest",
+		payloads: []signedPayload{sign(t, key, "sha256:cafe")},
+		keys:     []crypto.PublicKey{&key.PublicKey},
+		wantErr:  true,
+	}, {
+		name:    "not signed",
+		keys:    []crypto.PublicKey{&key.PublicKey},
+		wantErr: true,
+	}, {
+		name:     "invalid payload",
+		payloads: []signedPayload{{payload: []byte("not json"), signature: []byte("sig")}},
+		keys:     []crypto.PublicKey{&key.PublicKey},
+		wantErr:  true,
+	}, {
+		name:     "signed by cosign",
+		digest:   cosignDigest,
+		payloads: []signedPayload{cosigned},
+		keys:     []crypto.PublicKey{cosignKey},
+	}, {
+		name:     "signed by cosign for another digest",
+		payloads: []signedPayload{cosigned},
+		keys:     []crypto.PublicKey{cosignKey},
+		wantErr:  true,
+	}, {
+		name:     "tampered cosign payload",
+		digest:   cosignDigest,
+		payloads: []signedPayload{tampered},
+		keys:     []crypto.PublicKey{cosignKey},
+		wantErr:  true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			digest := testDigest
+			if tt.digest != "" {
+				digest = tt.digest
+			}
+			err := verifySignatures(tt.payloads, digest, tt.keys)
+			if tt.wantErr && !errors.Is(err, errNoValidSignature) {
+				t.Errorf("verifySignatures() = %v, wanted %v", err, errNoValidSignature)
+			} else if !tt.wantErr && err != nil {
+				t.Error("verifySignatures() =", err)
+			}
+		})
+	}
+}
+
+func TestNewImagePolicy(t *testing.T) {
+	_, pem1 := generateKey(t)
+	_, pem2 := generateKey(t)
+	secret := func(name string, data map[string][]byte) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: name},
+			Data:       data,
+		}
+	}
+	listers := NewListers([]runtime.Object{
+		secret("keys", map[string][]byte{"a.pub": pem1, "b.pub": pem2}),
+		secret("empty", nil),
+		secret("invalid", map[string][]byte{"a.pub": []byte("-----BEGIN PUBLIC KEY-----\nZm9v\n-----END PUBLIC KEY-----\n")}),
+	})
+
+	tests := []struct {
+		name        string
+		cfg         *deployment.Config
+		wantNil     bool
+		wantDigests int
+		wantKeys    int
+		wantErr     bool
+	}{{
+		name:    "no policy",
+		cfg:     &deployment.Config{},
+		wantNil: true,
+	}, {
+		name:        "allowed digests",
+		cfg:         &deployment.Config{AllowedImageDigests: sets.New(testDigest)},
+		wantDigests: 1,
+	}, {
+		name:     "signature keys",
+		cfg:      &deployment.Config{ImageSignatureKeysSecret: "keys"},
+		wantKeys: 2,
+	}, {
+		name: "signature keys in the config map",
+		cfg: &deployment.Config{
+			ImageSignatureKeysSecret: "keys",
+			ImageSignaturePublicKeys: string(pem1),
+		},
+		wantKeys: 3,
+	}, {
+		name: "enforced in the namespace",
+		cfg: &deployment.Config{
+			ImageSignaturePublicKeys: string(pem1),
+			ImageSignatureNamespaces: sets.New("other", "foo"),
+		},
+		wantKeys: 1,
+	}, {
+		name: "not enforced in the namespace",
+		cfg: &deployment.Config{
+			ImageSignaturePublicKeys: string(pem1),
+			ImageSignatureNamespaces: sets.New("other"),
+		},
+		wantNil: true,
+	}, {
+		name: "allowed digests, signatures not enforced in the namespace",
+		cfg: &deployment.Config{
+			AllowedImageDigests:      sets.New(testDigest),
+			ImageSignaturePublicKeys: string(pem1),
+			ImageSignatureNamespaces: sets.New("other"),
+		},
+		wantDigests: 1,
+	}, {
+		name:    "missing secret",
+		cfg:     &deployment.Config{ImageSignatureKeysSecret: "missing"},
+		wantErr: true,
+	}, {
+		name:    "no keys",
+		cfg:     &deployment.Config{ImageSignatureKeysSecret: "empty"},
+		wantErr: true,
+	}, {
+		name:    "invalid keys",
+		cfg:     &deployment.Config{ImageSignatureKeysSecret: "invalid"},
+		wantErr: true,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := newImagePolicy(listers.GetSecretLister(), tt.cfg, "foo")
+			switch {
+			case tt.wantErr:
+				if err == nil {
+					t.Error("newImagePolicy() = nil, wanted an error")
+				}
+			case err != nil:
+				t.Error("newImagePolicy() =", err)
+			case tt.wantNil:
+				if policy != nil {
+					t.Errorf("newImagePolicy() = %v, wanted nil", policy)
+				}
+			case policy == nil:
+				t.Error("newImagePolicy() = nil, wanted a policy")
+			default:
+				if len(policy.allowedDigests) != tt.wantDigests || len(policy.keys) != tt.wantKeys {
+					t.Errorf("newImagePolicy() = %d digests and %d keys, wanted %d and %d",
+						len(policy.allowedDigests), len(policy.keys), tt.wantDigests, tt.wantKeys)
+				}
+			}
+		})
+	}
+}
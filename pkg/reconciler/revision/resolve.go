@@ -18,15 +18,21 @@ package revision
 
 import (
 	"context"
+	"crypto"
 	"crypto/x509"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"strings"
 
 	"github.com/google/go-containerregistry/pkg/authn/k8schain"
 	"github.com/google/go-containerregistry/pkg/name"
+	ggcrv1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
 	knativetls "knative.dev/pkg/network/tls"
@@ -44,6 +50,14 @@ const (
 	k8sCertPath = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
 
 	tlsEnvPrefix = "TAG_TO_DIGEST_"
+
+	// cosignSignatureAnnotation is the annotation of the layers of a cosign
+	// signature image holding the base64 encoded signature of the layer.
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+
+	// maxSignedPayloadSize bounds the size of the signed payloads read from
+	// the registry.
+	maxSignedPayloadSize = 1 << 20
 )
 
 // newResolverTransport returns an http.Transport that appends the certs bundle
@@ -108,3 +122,59 @@ func (r *digestResolver) Resolve(
 	}
 	return fmt.Sprintf("%s@%s", tag.Repository.String(), desc.Digest), nil
 }
+
+// Verify verifies that the digest is signed by one of the keys, with the
+// signatures stored in the registry alongside the image the way cosign does.
+func (r *digestResolver) Verify(
+	ctx context.Context,
+	digest name.Digest,
+	opt k8schain.Options,
+	keys []crypto.PublicKey,
+) error {
+	kc, err := k8schain.New(ctx, r.client, opt)
+	if err != nil {
+		return fmt.Errorf("failed to initialize authentication: %w", err)
+	}
+
+	// Cosign stores the signatures of sha256:abc under the tag sha256-abc.sig.
+	tag := digest.Context().Tag(strings.Replace(digest.DigestStr(), ":", "-", 1) + ".sig")
+	img, err := remote.Image(tag, remote.WithContext(ctx), remote.WithTransport(r.transport), remote.WithAuthFromKeychain(kc), remote.WithUserAgent(r.userAgent))
+	var terr *transport.Error
+	if errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound {
+		return errNoValidSignature
+	} else if err != nil {
+		return err
+	}
+
+	manifest, err := img.Manifest()
+	if err != nil {
+		return err
+	}
+	payloads := make([]signedPayload, 0, len(manifest.Layers))
+	for _, desc := range manifest.Layers {
+		signature, err := base64.StdEncoding.DecodeString(desc.Annotations[cosignSignatureAnnotation])
+		if err != nil || len(signature) == 0 || desc.Size > maxSignedPayloadSize {
+			continue
+		}
+		layer, err := img.LayerByDigest(desc.Digest)
+		if err != nil {
+			return err
+		}
+		payload, err := readLayer(layer)
+		if err != nil {
+			return err
+		}
+		payloads = append(payloads, signedPayload{payload: payload, signature: signature})
+	}
+	return verifySignatures(payloads, digest.DigestStr(), keys)
+}
+
+// readLayer reads the raw content of the layer.
+func readLayer(layer ggcrv1.Layer) ([]byte, error) {
+	rc, err := layer.Compressed()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(io.LimitReader(rc, maxSignedPayloadSize))
+}
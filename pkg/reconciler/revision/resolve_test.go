@@ -19,9 +19,11 @@ package revision
 import (
 	"bytes"
 	"context"
+	"crypto"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/base64"
+	"encoding/json"
 	"encoding/pem"
 	"errors"
 	"fmt"
@@ -40,6 +42,7 @@ import (
 	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -450,6 +453,233 @@ func TestResolveSkippingRegistry(t *testing.T) {
 	}
 }
 
+// fakeSignatureRegistry serves the signatures of the digest of the repo the
+// way cosign stores them, or none if there are no payloads.
+func fakeSignatureRegistry(t *testing.T, repo, digest string, payloads []signedPayload) *httptest.Server {
+	blobs := make(map[string][]byte, len(payloads))
+	manifest := v1.Manifest{
+		SchemaVersion: 2,
+		MediaType:     types.OCIManifestSchema1,
+		Config: v1.Descriptor{
+			MediaType: types.OCIConfigJSON,
+			Size:      2,
+			Digest:    addBlob(t, blobs, []byte("{}")),
+		},
+	}
+	for _, p := range payloads {
+		manifest.Layers = append(manifest.Layers, v1.Descriptor{
+			MediaType: "application/vnd.dev.cosign.simplesigning.v1+json",
+			Size:      int64(len(p.payload)),
+			Digest:    addBlob(t, blobs, p.payload),
+			Annotations: map[string]string{
+				cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(p.signature),
+			},
+		})
+	}
+	rawManifest, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal("json.Marshal() =", err)
+	}
+
+	manifestPath := fmt.Sprintf("/v2/%s/manifests/%s.sig", repo, strings.Replace(digest, ":", "-", 1))
+	blobsPath := fmt.Sprintf("/v2/%s/blobs/", repo)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v2/":
+		case r.URL.Path == manifestPath && len(payloads) > 0:
+			w.Header().Set("Content-Type", string(types.OCIManifestSchema1))
+			w.Write(rawManifest)
+		case strings.HasPrefix(r.URL.Path, blobsPath) && blobs[strings.TrimPrefix(r.URL.Path, blobsPath)] != nil:
+			w.Write(blobs[strings.TrimPrefix(r.URL.Path, blobsPath)])
+		default:
+			http.Error(w, `{"errors":[{"code":"MANIFEST_UNKNOWN"}]}`, http.StatusNotFound)
+		}
+	}))
+}
+
+func addBlob(t *testing.T, blobs map[string][]byte, blob []byte) v1.Hash {
+	h, _, err := v1.SHA256(bytes.NewReader(blob))
+	if err != nil {
+		t.Fatal("SHA256() =", err)
+	}
+	blobs[h.String()] = blob
+	return h
+}
+
+func TestVerify(t *testing.T) {
+	const (
+		ns      = "foo"
+		svcacct = "default"
+		repo    = "booger/nose"
+	)
+	key, _ := generateKey(t)
+	other, _ := generateKey(t)
+
+	tests := []struct {
+		name     string
+		payloads []signedPayload
+		keys     []crypto.PublicKey
+		wantErr  error
+	}{{
+		name:     "signed",
+		payloads: []signedPayload{sign(t, other, testDigest), sign(t, key, testDigest)},
+		keys:     []crypto.PublicKey{&key.PublicKey},
+	}, {
+		name:     "signed by an untrusted key",
+		payloads: []signedPayload{sign(t, other, testDigest)},
+		keys:     []crypto.PublicKey{&key.PublicKey},
+		wantErr:  errNoValidSignature,
+	}, {
+		name:    "not signed",
+		keys:    []crypto.PublicKey{&key.PublicKey},
+		wantErr: errNoValidSignature,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := fakeSignatureRegistry(t, repo, testDigest, tt.payloads)
+			defer server.Close()
+			u, err := url.Parse(server.URL)
+			if err != nil {
+				t.Fatal("url.Parse() =", err)
+			}
+			digest, err := name.NewDigest(fmt.Sprintf("%s/%s@%s", u.Host, repo, testDigest))
+			if err != nil {
+				t.Fatal("NewDigest() =", err)
+			}
+
+			client := fakeclient.NewSimpleClientset(&corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      svcacct,
+					Namespace: ns,
+				},
+			})
+			dr := &digestResolver{client: client, transport: http.DefaultTransport}
+			opt := k8schain.Options{
+				Namespace:          ns,
+				ServiceAccountName: svcacct,
+			}
+			if err := dr.Verify(context.Background(), digest, opt, tt.keys); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() = %v, wanted %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// cosignDigest is the digest of the image cosign signed in testdata/cosign.
+const cosignDigest = "sha256:f9a74f112bd0653e87eb97c1a33d0a6eb575dee77fe84a72a5cd20dfe6fa3768"
+
+// readCosignSignature returns the signature cosign pushed for cosignDigest,
+// and the key it signed it with.
+func readCosignSignature(t *testing.T) (*v1.Manifest, signedPayload, crypto.PublicKey) {
+	t.Helper()
+	rawManifest, err := os.ReadFile(filepath.Join("testdata", "cosign", "manifest.json"))
+	if err != nil {
+		t.Fatal("ReadFile() =", err)
+	}
+	manifest, err := v1.ParseManifest(bytes.NewReader(rawManifest))
+	if err != nil {
+		t.Fatal("ParseManifest() =", err)
+	}
+	payload, err := os.ReadFile(filepath.Join("testdata", "cosign", "payload.json"))
+	if err != nil {
+		t.Fatal("ReadFile() =", err)
+	}
+	signature, err := base64.StdEncoding.DecodeString(manifest.Layers[0].Annotations[cosignSignatureAnnotation])
+	if err != nil {
+		t.Fatal("DecodeString() =", err)
+	}
+	pub, err := os.ReadFile(filepath.Join("testdata", "cosign", "cosign.pub"))
+	if err != nil {
+		t.Fatal("ReadFile() =", err)
+	}
+	keys, err := parsePublicKeys(pub)
+	if err != nil || len(keys) != 1 {
+		t.Fatalf("parsePublicKeys() = %d keys, %v", len(keys), err)
+	}
+	return manifest, signedPayload{payload: payload, signature: signature}, keys[0]
+}
+
+func TestVerifyCosignSignature(t *testing.T) {
+	const (
+		ns      = "foo"
+		svcacct = "default"
+		repo    = "knative/helloworld-go"
+	)
+	_, _, key := readCosignSignature(t)
+	other, _ := generateKey(t)
+
+	// Serve the signature the way the registry cosign pushed it to does.
+	files := map[string]string{
+		fmt.Sprintf("/v2/%s/manifests/%s.sig", repo, strings.Replace(cosignDigest, ":", "-", 1)):                  "manifest.json",
+		fmt.Sprintf("/v2/%s/blobs/sha256:5a3b53167a120e3330094279737e1bc7af49d6e737ecf39280fac1a98f6d77d7", repo): "payload.json",
+		fmt.Sprintf("/v2/%s/blobs/sha256:514d8d673b3d22fd3d4451de7e6fdfab8fc9747de9c059778ad283c03072b83b", repo): "config.json",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/" {
+			return
+		}
+		file, ok := files[r.URL.Path]
+		if !ok {
+			http.Error(w, `{"errors":[{"code":"MANIFEST_UNKNOWN"}]}`, http.StatusNotFound)
+			return
+		}
+		if strings.Contains(r.URL.Path, "/manifests/") {
+			w.Header().Set("Content-Type", string(types.OCIManifestSchema1))
+		}
+		http.ServeFile(w, r, filepath.Join("testdata", "cosign", file))
+	}))
+	defer server.Close()
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal("url.Parse() =", err)
+	}
+
+	tests := []struct {
+		name    string
+		digest  string
+		keys    []crypto.PublicKey
+		wantErr error
+	}{{
+		name:   "signed",
+		digest: cosignDigest,
+		keys:   []crypto.PublicKey{&other.PublicKey, key},
+	}, {
+		name:    "signed by an untrusted key",
+		digest:  cosignDigest,
+		keys:    []crypto.PublicKey{&other.PublicKey},
+		wantErr: errNoValidSignature,
+	}, {
+		name:    "not signed",
+		digest:  testDigest,
+		keys:    []crypto.PublicKey{key},
+		wantErr: errNoValidSignature,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			digest, err := name.NewDigest(fmt.Sprintf("%s/%s@%s", u.Host, repo, tt.digest))
+			if err != nil {
+				t.Fatal("NewDigest() =", err)
+			}
+			client := fakeclient.NewSimpleClientset(&corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      svcacct,
+					Namespace: ns,
+				},
+			})
+			dr := &digestResolver{client: client, transport: http.DefaultTransport}
+			opt := k8schain.Options{
+				Namespace:          ns,
+				ServiceAccountName: svcacct,
+			}
+			if err := dr.Verify(context.Background(), digest, opt, tt.keys); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() = %v, wanted %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestNewResolverTransport(t *testing.T) {
 	cases := []struct {
 		name               string
@@ -82,7 +82,16 @@ func imageCacheAnnotations(r *v1.Revision) map[string]string {
 }
 
 func podAutoscalerAnnotations(r *v1.Revision) map[string]string {
-	return kmap.Filter(r.GetAnnotations(), excludeAnnotations.Has)
+	ann := kmap.Filter(r.GetAnnotations(), excludeAnnotations.Has)
+
+	// Custom metrics are scraped from the user container, so unless told
+	// otherwise look for them on the port the user container serves on.
+	if _, m, _ := autoscaling.MetricAnnotation.Get(ann); m == autoscaling.Custom {
+		if _, _, ok := autoscaling.CustomMetricPortAnnotation.Get(ann); !ok {
+			ann[autoscaling.CustomMetricPortAnnotationKey] = strconv.Itoa(int(getUserPort(r)))
+		}
+	}
+	return ann
 }
 
 func podAnnotations(r *v1.Revision) map[string]string {
@@ -164,6 +164,20 @@ func TestMakeAnnotations(t *testing.T) {
 			autoscaling.MinScaleAnnotationKey: "1",
 			"keep":                            "keep me",
 		},
+	}, {
+		name: "default custom metric port",
+		buildFuncs: buildFuncs{
+			podAutoscalerAnnotations,
+		},
+		revAnn: map[string]string{
+			autoscaling.MetricAnnotationKey:           autoscaling.Custom,
+			autoscaling.CustomMetricNameAnnotationKey: "queue_length",
+		},
+		want: map[string]string{
+			autoscaling.MetricAnnotationKey:           autoscaling.Custom,
+			autoscaling.CustomMetricNameAnnotationKey: "queue_length",
+			autoscaling.CustomMetricPortAnnotationKey: "8080",
+		},
 	}}
 
 	for _, test := range tests {
@@ -448,6 +448,18 @@ func makeQueueContainer(rev *v1.Revision, cfg *config.Config) (*corev1.Container
 			Value: string(o11yConfig),
 		}},
 	}
+	if _, classes, ok := serving.PriorityClassesAnnotation.Get(rev.Annotations); ok {
+		c.Env = append(c.Env, corev1.EnvVar{
+			Name:  "PRIORITY_CLASSES",
+			Value: classes,
+		})
+	}
+	if _, algorithm, ok := serving.AdaptiveConcurrencyAnnotation.Get(rev.Annotations); ok {
+		c.Env = append(c.Env, corev1.EnvVar{
+			Name:  "ADAPTIVE_CONCURRENCY",
+			Value: algorithm,
+		})
+	}
 
 	return c, nil
 }
@@ -404,6 +404,26 @@ func TestMakeQueueContainer(t *testing.T) {
 				"ENABLE_HTTP_FULL_DUPLEX": "true",
 			})
 		}),
+	}, {
+		name: "priority classes",
+		rev: revision("bar", "foo",
+			withContainers(containers),
+			WithRevisionAnnotations(map[string]string{serving.PriorityClassesAnnotationKey: "premium:20:8,bulk"})),
+		want: queueContainer(func(c *corev1.Container) {
+			c.Env = env(map[string]string{
+				"PRIORITY_CLASSES": "premium:20:8,bulk",
+			})
+		}),
+	}, {
+		name: "adaptive concurrency",
+		rev: revision("bar", "foo",
+			withContainers(containers),
+			WithRevisionAnnotations(map[string]string{serving.AdaptiveConcurrencyAnnotationKey: serving.AdaptiveConcurrencyAIMD})),
+		want: queueContainer(func(c *corev1.Container) {
+			c.Env = env(map[string]string{
+				"ADAPTIVE_CONCURRENCY": "aimd",
+			})
+		}),
 	}, {
 		name: "set root ca",
 		rev: revision("bar", "foo",
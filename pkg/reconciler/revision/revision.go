@@ -18,6 +18,7 @@ package revision
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"time"
 
@@ -30,6 +31,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
 	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	cachingclientset "knative.dev/caching/pkg/client/clientset/versioned"
 	networkingclientset "knative.dev/networking/pkg/client/clientset/versioned"
 	"knative.dev/pkg/tracker"
@@ -49,7 +51,7 @@ import (
 )
 
 type resolver interface {
-	Resolve(*zap.SugaredLogger, *v1.Revision, k8schain.Options, sets.Set[string], time.Duration) ([]v1.ContainerStatus, []v1.ContainerStatus, error)
+	Resolve(*zap.SugaredLogger, *v1.Revision, k8schain.Options, sets.Set[string], *imagePolicy, time.Duration) ([]v1.ContainerStatus, []v1.ContainerStatus, error)
 	Clear(types.NamespacedName)
 	Forget(types.NamespacedName)
 }
@@ -66,9 +68,14 @@ type Reconciler struct {
 	imageLister         cachinglisters.ImageLister
 	deploymentLister    appsv1listers.DeploymentLister
 	certificateLister   networkinglisters.CertificateLister
+	// secretLister indexes the secrets of the system namespace.
+	secretLister corev1listers.SecretLister
 
 	tracker  tracker.Interface
 	resolver resolver
+	drift    *driftTracker
+
+	enqueueAfter func(interface{}, time.Duration)
 }
 
 // Check that our Reconciler implements the necessary interfaces.
@@ -79,31 +86,40 @@ var (
 
 func (c *Reconciler) reconcileDigest(ctx context.Context, rev *v1.Revision) (bool, error) {
 	totalNumOfContainers := len(rev.Spec.Containers) + len(rev.Spec.InitContainers)
+	cfgs := config.FromContext(ctx)
 
 	// The image digest has already been resolved.
 	// No need to check for init containers feature flag here because rev.Spec has been validated already
 	if len(rev.Status.ContainerStatuses)+len(rev.Status.InitContainerStatuses) == totalNumOfContainers {
-		c.resolver.Clear(types.NamespacedName{Namespace: rev.Namespace, Name: rev.Name})
+		// Only the tags of the revisions serving traffic are resolved again.
+		if cfgs.Deployment.DigestReResolutionInterval > 0 && rev.GetRoutingState() == v1.RoutingStateActive {
+			c.reconcileTagDrift(ctx, rev)
+		} else {
+			c.resolver.Clear(types.NamespacedName{Namespace: rev.Namespace, Name: rev.Name})
+			c.drift.forget(types.NamespacedName{Namespace: rev.Namespace, Name: rev.Name})
+		}
 		return true, nil
 	}
 
-	imagePullSecrets := make([]string, 0, len(rev.Spec.ImagePullSecrets))
-	for _, s := range rev.Spec.ImagePullSecrets {
-		imagePullSecrets = append(imagePullSecrets, s.Name)
-	}
-	cfgs := config.FromContext(ctx)
-	opt := k8schain.Options{
-		Namespace:          rev.Namespace,
-		ServiceAccountName: rev.Spec.ServiceAccountName,
-		ImagePullSecrets:   imagePullSecrets,
+	policy, err := newImagePolicy(c.secretLister, cfgs.Deployment, rev.Namespace)
+	if err != nil {
+		return true, err
 	}
 
 	logger := logging.FromContext(ctx)
-	initContainerStatuses, statuses, err := c.resolver.Resolve(logger, rev, opt, cfgs.Deployment.RegistriesSkippingTagResolving, cfgs.Deployment.DigestResolutionTimeout)
+	initContainerStatuses, statuses, err := c.resolver.Resolve(logger, rev, resolveOptions(rev),
+		cfgs.Deployment.RegistriesSkippingTagResolving, policy, cfgs.Deployment.DigestResolutionTimeout)
 	if err != nil {
 		// Clear the resolver so we can retry the digest resolution rather than
 		// being stuck with this error.
 		c.resolver.Clear(types.NamespacedName{Namespace: rev.Namespace, Name: rev.Name})
+		var policyErr *imagePolicyError
+		if errors.As(err, &policyErr) {
+			// The digests are checked again when the policy changes.
+			rev.Status.MarkImagePolicySatisfiedFalse(policyErr.reason, policyErr.Error())
+			rev.Status.MarkContainerHealthyFalse(policyErr.reason, policyErr.Error())
+			return true, controller.NewPermanentError(err)
+		}
 		rev.Status.MarkContainerHealthyFalse(v1.ReasonContainerMissing, err.Error())
 		return true, err
 	}
@@ -111,6 +127,12 @@ func (c *Reconciler) reconcileDigest(ctx context.Context, rev *v1.Revision) (boo
 	if len(statuses) > 0 || len(initContainerStatuses) > 0 {
 		rev.Status.ContainerStatuses = statuses
 		rev.Status.InitContainerStatuses = initContainerStatuses
+		if policy != nil {
+			rev.Status.MarkImagePolicySatisfiedTrue()
+		} else {
+			// The revision may have violated a policy which was removed since.
+			rev.Status.ClearImagePolicySatisfied()
+		}
 		return true, nil
 	}
 
@@ -118,6 +140,71 @@ func (c *Reconciler) reconcileDigest(ctx context.Context, rev *v1.Revision) (boo
 	return false, nil
 }
 
+// reconcileTagDrift resolves the tags of the images of the revision again once
+// per re-resolution interval, and reports whether they drifted from the
+// digests the revision runs or violate the image policy, if any. The revision
+// keeps running its digests.
+func (c *Reconciler) reconcileTagDrift(ctx context.Context, rev *v1.Revision) {
+	name := types.NamespacedName{Namespace: rev.Namespace, Name: rev.Name}
+	cfgs := config.FromContext(ctx)
+	interval := cfgs.Deployment.DigestReResolutionInterval
+	if due := c.drift.due(name, interval); due > 0 {
+		c.resolver.Clear(name)
+		c.enqueueAfter(rev, due)
+		return
+	}
+
+	logger := logging.FromContext(ctx)
+	policy, err := newImagePolicy(c.secretLister, cfgs.Deployment, rev.Namespace)
+	if err != nil {
+		// Don't report a violation we can't tell, try again at the next interval.
+		logger.Warnw("Failed to load the image policy", zap.Error(err))
+		c.drift.checked(name)
+		c.enqueueAfter(rev, interval)
+		return
+	}
+	initContainerStatuses, statuses, err := c.resolver.Resolve(logger, rev, resolveOptions(rev),
+		cfgs.Deployment.RegistriesSkippingTagResolving, policy, cfgs.Deployment.DigestResolutionTimeout)
+	if err == nil && len(statuses) == 0 && len(initContainerStatuses) == 0 {
+		// Wait for re-enqueue when resolution is done.
+		return
+	}
+	c.resolver.Clear(name)
+	c.drift.checked(name)
+	c.enqueueAfter(rev, interval)
+
+	var policyErr *imagePolicyError
+	if errors.As(err, &policyErr) {
+		rev.Status.MarkImagePolicySatisfiedFalse(policyErr.reason, policyErr.Error())
+		return
+	}
+	if err != nil {
+		// Don't report a drift we can't tell, try again at the next interval.
+		logger.Warnw("Failed to resolve the image tags again", zap.Error(err))
+		return
+	}
+	if drifted := driftedImages(rev, initContainerStatuses, statuses); len(drifted) > 0 {
+		rev.Status.MarkImagePolicySatisfiedFalse(v1.ReasonTagDrifted,
+			"Image tags moved since the revision was created: "+strings.Join(drifted, ", "))
+	} else {
+		rev.Status.MarkImagePolicySatisfiedTrue()
+	}
+}
+
+// resolveOptions returns the options to authenticate to the registries of the
+// images of the revision.
+func resolveOptions(rev *v1.Revision) k8schain.Options {
+	imagePullSecrets := make([]string, 0, len(rev.Spec.ImagePullSecrets))
+	for _, s := range rev.Spec.ImagePullSecrets {
+		imagePullSecrets = append(imagePullSecrets, s.Name)
+	}
+	return k8schain.Options{
+		Namespace:          rev.Namespace,
+		ServiceAccountName: rev.Spec.ServiceAccountName,
+		ImagePullSecrets:   imagePullSecrets,
+	}
+}
+
 // ReconcileKind implements Interface.ReconcileKind.
 func (c *Reconciler) ReconcileKind(ctx context.Context, rev *v1.Revision) pkgreconciler.Event {
 	ctx, cancel := context.WithTimeout(ctx, pkgreconciler.DefaultTimeout)
@@ -189,6 +276,7 @@ func (c *Reconciler) updateRevisionLoggingURL(ctx context.Context, rev *v1.Revis
 // ObserveDeletion implements OnDeletionInterface.ObserveDeletion.
 func (c *Reconciler) ObserveDeletion(ctx context.Context, key types.NamespacedName) error {
 	c.resolver.Forget(key)
+	c.drift.forget(key)
 	return nil
 }
 
@@ -31,6 +31,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -68,6 +69,7 @@ import (
 	_ "knative.dev/networking/pkg/client/injection/informers/networking/v1alpha1/certificate/fake"
 	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap/fake"
 	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/service/fake"
+	_ "knative.dev/pkg/injection/clients/namespacedkube/informers/core/v1/secret/fake"
 )
 
 const (
@@ -217,7 +219,7 @@ func addResourcesToInformers(t *testing.T, ctx context.Context, rev *v1.Revision
 
 type nopResolver struct{}
 
-func (r *nopResolver) Resolve(_ *zap.SugaredLogger, rev *v1.Revision, _ k8schain.Options, _ sets.Set[string], _ time.Duration) ([]v1.ContainerStatus, []v1.ContainerStatus, error) {
+func (r *nopResolver) Resolve(_ *zap.SugaredLogger, rev *v1.Revision, _ k8schain.Options, _ sets.Set[string], _ *imagePolicy, _ time.Duration) ([]v1.ContainerStatus, []v1.ContainerStatus, error) {
 	status := []v1.ContainerStatus{{
 		Name: rev.Spec.Containers[0].Name,
 	}}
@@ -328,7 +330,7 @@ func testDefaultsCM() *corev1.ConfigMap {
 
 type notResolvedYetResolver struct{}
 
-func (r *notResolvedYetResolver) Resolve(_ *zap.SugaredLogger, _ *v1.Revision, _ k8schain.Options, _ sets.Set[string], _ time.Duration) ([]v1.ContainerStatus, []v1.ContainerStatus, error) {
+func (r *notResolvedYetResolver) Resolve(_ *zap.SugaredLogger, _ *v1.Revision, _ k8schain.Options, _ sets.Set[string], _ *imagePolicy, _ time.Duration) ([]v1.ContainerStatus, []v1.ContainerStatus, error) {
 	return nil, nil, nil
 }
 
@@ -340,7 +342,7 @@ type errorResolver struct {
 	cleared bool
 }
 
-func (r *errorResolver) Resolve(_ *zap.SugaredLogger, _ *v1.Revision, _ k8schain.Options, _ sets.Set[string], _ time.Duration) ([]v1.ContainerStatus, []v1.ContainerStatus, error) {
+func (r *errorResolver) Resolve(_ *zap.SugaredLogger, _ *v1.Revision, _ k8schain.Options, _ sets.Set[string], _ *imagePolicy, _ time.Duration) ([]v1.ContainerStatus, []v1.ContainerStatus, error) {
 	return nil, nil, r.err
 }
 
@@ -387,6 +389,65 @@ func TestResolutionFailed(t *testing.T) {
 	}
 }
 
+func TestImagePolicyViolated(t *testing.T) {
+	policyErr := &imagePolicyError{
+		reason:  v1.ReasonDigestNotAllowed,
+		message: `Image "gcr.io/repo/image" resolved to digest sha256:cafe, which is not allowed`,
+	}
+	resolver := &errorResolver{err: policyErr}
+	ctx, _, _, ctrl, _ := newTestController(t, nil /*additional CMs*/, func(r *Reconciler) {
+		r.resolver = resolver
+	})
+
+	rev := testRevision(testPodSpec())
+	if _, err := fakeservingclient.Get(ctx).ServingV1().Revisions(rev.Namespace).Create(ctx, rev, metav1.CreateOptions{}); err != nil {
+		t.Fatal("Failed to create revision:", err)
+	}
+	fakerevisioninformer.Get(ctx).Informer().GetIndexer().Add(rev)
+	if err := ctrl.Reconciler.Reconcile(ctx, KeyOrDie(rev)); !controller.IsPermanentError(err) {
+		t.Errorf("Reconcile() = %v, wanted a permanent error", err)
+	}
+
+	rev, err := fakeservingclient.Get(ctx).ServingV1().Revisions(testNamespace).Get(ctx, rev.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal("Couldn't get revision:", err)
+	}
+	for _, ct := range []apis.ConditionType{v1.RevisionConditionImagePolicySatisfied, v1.RevisionConditionContainerHealthy} {
+		if got := rev.Status.GetCondition(ct); got == nil || !got.IsFalse() || got.Reason != v1.ReasonDigestNotAllowed || got.Message != policyErr.message {
+			t.Errorf("Condition %s = %v, wanted False with reason %s", ct, got, v1.ReasonDigestNotAllowed)
+		}
+	}
+	if !rev.Status.GetCondition(v1.RevisionConditionReady).IsFalse() {
+		t.Error("Revision is not failed")
+	}
+	if !resolver.cleared {
+		t.Error("Expected resolver.Clear() to have been called")
+	}
+	if _, err := fakekubeclient.Get(ctx).AppsV1().Deployments(rev.Namespace).Get(ctx, names.Deployment(rev), metav1.GetOptions{}); !apierrs.IsNotFound(err) {
+		t.Errorf("Deployments.Get() = %v, wanted the deployment not to be created", err)
+	}
+}
+
+func TestImagePolicyRemoved(t *testing.T) {
+	ctx, _, _, ctrl, _ := newTestController(t, nil /*additional CMs*/)
+
+	// The revision violated a policy which was removed since.
+	rev := testRevision(testPodSpec())
+	rev.Status.MarkImagePolicySatisfiedFalse(v1.ReasonDigestNotAllowed, "not allowed")
+	createRevision(t, ctx, ctrl, rev)
+
+	rev, err := fakeservingclient.Get(ctx).ServingV1().Revisions(testNamespace).Get(ctx, rev.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal("Couldn't get revision:", err)
+	}
+	if got := rev.Status.GetCondition(v1.RevisionConditionImagePolicySatisfied); got != nil {
+		t.Errorf("ImagePolicySatisfied = %v, wanted none", got)
+	}
+	if len(rev.Status.ContainerStatuses) == 0 {
+		t.Error("The digests were not resolved")
+	}
+}
+
 func TestUpdateRevWithWithUpdatedLoggingURL(t *testing.T) {
 	ctx, _, _, controller, watcher := newTestController(t, []*corev1.ConfigMap{
 		testDeploymentCM(),
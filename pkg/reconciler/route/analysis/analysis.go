@@ -0,0 +1,135 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package analysis tells whether the revisions being rolled out by a Route
+// are healthy enough for their rollout to advance.
+package analysis
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"knative.dev/serving/pkg/autoscaler/metrics"
+	listers "knative.dev/serving/pkg/client/listers/autoscaling/v1alpha1"
+)
+
+// ErrNoData is returned when the revision served no requests to tell
+// whether it is healthy.
+var ErrNoData = errors.New("no requests to analyze")
+
+// Result is the health of a revision, as observed over a recent period.
+type Result struct {
+	// Requests is the number of requests served.
+	Requests float64
+	// ServerErrors is the number of those requests that failed with a 5xx status.
+	ServerErrors float64
+	// Latency is the requested percentile of the latency of the requests,
+	// or 0 if unknown.
+	Latency time.Duration
+}
+
+// Provider measures the health of revisions.
+type Provider interface {
+	// Analyze returns the health of the revision last measured since the
+	// given time, with the latency at the given percentile, as a fraction.
+	// It returns ErrNoData if the revision wasn't measured since then.
+	Analyze(rev types.NamespacedName, since time.Time, percentile float64) (Result, error)
+}
+
+// Gates are the thresholds the health of a revision must stay within.
+type Gates struct {
+	// MaxErrorRate is the highest fraction of requests which may fail, if
+	// ErrorRateGated is set.
+	MaxErrorRate   float64
+	ErrorRateGated bool
+	// MaxLatency is the highest latency at the Percentile, if not zero.
+	MaxLatency time.Duration
+	Percentile float64
+	// MinRequests is the fewest requests the revision must have served for
+	// its health to tell.
+	MinRequests float64
+}
+
+// Enabled returns whether any threshold is set.
+func (g Gates) Enabled() bool {
+	return g.ErrorRateGated || g.MaxLatency > 0
+}
+
+// Check returns an error describing the threshold the result is beyond, if
+// any, or ErrNoData if the result doesn't tell.
+func (g Gates) Check(res Result) error {
+	if res.Requests <= 0 || res.Requests < g.MinRequests {
+		return ErrNoData
+	}
+	if rate := res.ServerErrors / res.Requests; g.ErrorRateGated && rate > g.MaxErrorRate {
+		return fmt.Errorf("error rate %.3f is above %.3f", rate, g.MaxErrorRate)
+	}
+	if g.MaxLatency > 0 && res.Latency > g.MaxLatency {
+		return fmt.Errorf("p%g latency %v is above %v", g.Percentile*100, res.Latency, g.MaxLatency)
+	}
+	return nil
+}
+
+// MetricProvider is a Provider reading the requests the autoscaler
+// summarizes in the status of the Metric of each revision. The summary
+// covers the stable window of the revision up to its observation and is
+// republished every half window, so the analysis spans the longest of the
+// analyzed period and the stable window.
+type MetricProvider struct {
+	lister listers.MetricLister
+}
+
+var _ Provider = (*MetricProvider)(nil)
+
+// NewMetricProvider returns a MetricProvider reading the Metrics listed by
+// the lister.
+func NewMetricProvider(lister listers.MetricLister) *MetricProvider {
+	return &MetricProvider{lister: lister}
+}
+
+// Analyze implements Provider.
+func (p *MetricProvider) Analyze(rev types.NamespacedName, since time.Time, percentile float64) (Result, error) {
+	// The Metric of a revision is named after it.
+	m, err := p.lister.Metrics(rev.Namespace).Get(rev.Name)
+	if apierrs.IsNotFound(err) {
+		return Result{}, ErrNoData
+	} else if err != nil {
+		return Result{}, err
+	}
+	// Any summary whose window reaches into the analyzed period is fresh,
+	// which holds for periods shorter than the publishing interval.
+	s := m.Status.Requests
+	if s == nil || !s.ObservedTime.Time.Add(m.Spec.StableWindow).After(since) {
+		return Result{}, ErrNoData
+	}
+
+	res := Result{
+		Requests:     float64(s.Count),
+		ServerErrors: float64(s.ServerErrors),
+	}
+	latency := metrics.LatencySketch{Counts: make([]uint64, 0, len(s.LatencyBuckets))}
+	for _, c := range s.LatencyBuckets {
+		latency.Counts = append(latency.Counts, uint64(max(c, 0)))
+	}
+	if latency.Valid() && latency.Count() > 0 {
+		res.Latency = latency.Quantile(percentile)
+	}
+	return res, nil
+}
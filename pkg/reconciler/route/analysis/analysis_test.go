@@ -0,0 +1,191 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analysis
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+
+	autoscalingv1alpha1 "knative.dev/serving/pkg/apis/autoscaling/v1alpha1"
+	"knative.dev/serving/pkg/autoscaler/metrics"
+	listers "knative.dev/serving/pkg/client/listers/autoscaling/v1alpha1"
+)
+
+func TestGatesCheck(t *testing.T) {
+	gates := Gates{
+		MaxErrorRate:   0.05,
+		ErrorRateGated: true,
+		MaxLatency:     100 * time.Millisecond,
+		Percentile:     0.95,
+	}
+	for _, tc := range []struct {
+		name    string
+		gates   Gates
+		res     Result
+		wantErr bool
+		noData  bool
+	}{{
+		name:  "healthy",
+		gates: gates,
+		res:   Result{Requests: 100, ServerErrors: 5, Latency: 100 * time.Millisecond},
+	}, {
+		name:    "errors",
+		gates:   gates,
+		res:     Result{Requests: 100, ServerErrors: 6},
+		wantErr: true,
+	}, {
+		name:    "slow",
+		gates:   gates,
+		res:     Result{Requests: 100, Latency: time.Second},
+		wantErr: true,
+	}, {
+		name:    "no requests",
+		gates:   gates,
+		wantErr: true,
+		noData:  true,
+	}, {
+		name:    "too few requests",
+		gates:   Gates{ErrorRateGated: true, MinRequests: 20},
+		res:     Result{Requests: 19, ServerErrors: 19},
+		wantErr: true,
+		noData:  true,
+	}, {
+		name:  "zero error rate allowed",
+		gates: Gates{ErrorRateGated: true},
+		res:   Result{Requests: 100, Latency: time.Hour},
+	}, {
+		name:    "no errors allowed",
+		gates:   Gates{ErrorRateGated: true},
+		res:     Result{Requests: 100, ServerErrors: 1},
+		wantErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.gates.Check(tc.res)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Check() = %v, wantErr: %v", err, tc.wantErr)
+			}
+			if got := errors.Is(err, ErrNoData); got != tc.noData {
+				t.Errorf("Check() = %v, want ErrNoData: %v", err, tc.noData)
+			}
+		})
+	}
+}
+
+func TestGatesEnabled(t *testing.T) {
+	if (Gates{Percentile: 0.95}).Enabled() {
+		t.Error("Gates without thresholds are enabled")
+	}
+	if !(Gates{ErrorRateGated: true}).Enabled() {
+		t.Error("Gates with an error rate are not enabled")
+	}
+	if !(Gates{MaxLatency: time.Second}).Enabled() {
+		t.Error("Gates with a latency are not enabled")
+	}
+}
+
+func TestMetricProvider(t *testing.T) {
+	now := time.Unix(1e9, 0)
+	latency := &metrics.LatencySketch{}
+	for range 9 {
+		latency.Record(time.Millisecond)
+	}
+	latency.Record(time.Second)
+	var buckets []int64
+	for _, c := range latency.Counts {
+		buckets = append(buckets, int64(c))
+	}
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, m := range []*autoscalingv1alpha1.Metric{{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "served"},
+		Spec:       autoscalingv1alpha1.MetricSpec{StableWindow: time.Minute},
+		Status: autoscalingv1alpha1.MetricStatus{
+			Requests: &autoscalingv1alpha1.RequestSummary{
+				ObservedTime:   metav1.NewTime(now),
+				Count:          10,
+				ServerErrors:   1,
+				LatencyBuckets: buckets,
+			},
+		},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "idle"},
+	}} {
+		indexer.Add(m)
+	}
+	p := NewMetricProvider(listers.NewMetricLister(indexer))
+
+	for _, tc := range []struct {
+		name       string
+		rev        string
+		since      time.Time
+		percentile float64
+		want       Result
+		wantErr    error
+	}{{
+		name:       "served",
+		rev:        "served",
+		since:      now.Add(-time.Minute),
+		percentile: 0.9,
+		want:       Result{Requests: 10, ServerErrors: 1, Latency: time.Millisecond},
+	}, {
+		name:       "slowest",
+		rev:        "served",
+		since:      now,
+		percentile: 1,
+		// 1s falls into the bucket bounded by 1.024s.
+		want: Result{Requests: 10, ServerErrors: 1, Latency: 1024 * time.Millisecond},
+	}, {
+		// A 5s step starting after the summary, which is republished every
+		// 30s, is analyzed over the stable window reaching into it.
+		name:       "step shorter than the window",
+		rev:        "served",
+		since:      now.Add(25 * time.Second),
+		percentile: 0.9,
+		want:       Result{Requests: 10, ServerErrors: 1, Latency: time.Millisecond},
+	}, {
+		name:    "stale",
+		rev:     "served",
+		since:   now.Add(time.Minute),
+		wantErr: ErrNoData,
+	}, {
+		name:    "idle",
+		rev:     "idle",
+		since:   now.Add(-time.Minute),
+		wantErr: ErrNoData,
+	}, {
+		name:    "missing",
+		rev:     "missing",
+		since:   now.Add(-time.Minute),
+		wantErr: ErrNoData,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := p.Analyze(types.NamespacedName{Namespace: "ns", Name: tc.rev}, tc.since, tc.percentile)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Analyze() = %v, want: %v", err, tc.wantErr)
+			}
+			if !cmp.Equal(got, tc.want) {
+				t.Error("Analyze (-want, +got):", cmp.Diff(tc.want, got))
+			}
+		})
+	}
+}
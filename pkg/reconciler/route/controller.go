@@ -26,11 +26,13 @@ import (
 	endpointsinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/endpoints"
 	serviceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/service"
 	servingclient "knative.dev/serving/pkg/client/injection/client"
+	metricinformer "knative.dev/serving/pkg/client/injection/informers/autoscaling/v1alpha1/metric"
 	configurationinformer "knative.dev/serving/pkg/client/injection/informers/serving/v1/configuration"
 	revisioninformer "knative.dev/serving/pkg/client/injection/informers/serving/v1/revision"
 	routeinformer "knative.dev/serving/pkg/client/injection/informers/serving/v1/route"
 	routereconciler "knative.dev/serving/pkg/client/injection/reconciler/serving/v1/route"
 
+	"go.opentelemetry.io/otel"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/clock"
 	netcfg "knative.dev/networking/pkg/config"
@@ -38,6 +40,7 @@ import (
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
 	v1 "knative.dev/serving/pkg/apis/serving/v1"
+	"knative.dev/serving/pkg/reconciler/route/analysis"
 	"knative.dev/serving/pkg/reconciler/route/config"
 )
 
@@ -66,6 +69,7 @@ func newController(
 	revisionInformer := revisioninformer.Get(ctx)
 	ingressInformer := ingressinformer.Get(ctx)
 	certificateInformer := certificateinformer.Get(ctx)
+	metricInformer := metricinformer.Get(ctx)
 
 	c := &Reconciler{
 		kubeclient:          kubeclient.Get(ctx),
@@ -78,6 +82,8 @@ func newController(
 		ingressLister:       ingressInformer.Lister(),
 		certificateLister:   certificateInformer.Lister(),
 		clock:               clock,
+		analysis:            analysis.NewMetricProvider(metricInformer.Lister()),
+		metrics:             newRolloutMetrics(otel.GetMeterProvider()),
 	}
 	impl := routereconciler.NewImpl(ctx, c, func(impl *controller.Impl) controller.Options {
 		configsToResync := []interface{}{
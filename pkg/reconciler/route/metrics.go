@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package route
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+
+	v1 "knative.dev/serving/pkg/apis/serving/v1"
+	"knative.dev/serving/pkg/metrics"
+)
+
+const scopeName = "knative.dev/serving/pkg/reconciler/route"
+
+type rolloutMetrics struct {
+	duration otelmetric.Float64Histogram
+	steps    otelmetric.Int64Counter
+}
+
+func newRolloutMetrics(mp otelmetric.MeterProvider) *rolloutMetrics {
+	p := mp
+	if p == nil {
+		p = otel.GetMeterProvider()
+	}
+
+	meter := p.Meter(scopeName)
+
+	return &rolloutMetrics{
+		duration: must(meter.Float64Histogram(
+			"kn.route.rollout.duration",
+			otelmetric.WithDescription("Duration of the completed rollouts of the latest revisions"),
+			otelmetric.WithUnit("s"),
+		)),
+		steps: must(meter.Int64Counter(
+			"kn.route.rollout.steps",
+			otelmetric.WithDescription("Number of steps shifting traffic to the revisions being rolled out"),
+			otelmetric.WithUnit("{step}"),
+		)),
+	}
+}
+
+func rolloutAttributes(r *v1.Route, config, tag string) otelmetric.MeasurementOption {
+	return otelmetric.WithAttributeSet(attribute.NewSet(
+		metrics.K8sNamespaceKey.With(r.Namespace),
+		metrics.RouteNameKey.With(r.Name),
+		metrics.ConfigurationNameKey.With(config),
+		metrics.RouteTagNameKey.With(tag),
+	))
+}
+
+func (m *rolloutMetrics) recordStep(ctx context.Context, r *v1.Route, config, tag string) {
+	if m == nil {
+		return
+	}
+	m.steps.Add(ctx, 1, rolloutAttributes(r, config, tag))
+}
+
+func (m *rolloutMetrics) recordCompletion(ctx context.Context, r *v1.Route, config, tag string, d time.Duration) {
+	if m == nil {
+		return
+	}
+	m.duration.Record(ctx, d.Seconds(), rolloutAttributes(r, config, tag))
+}
+
+func must[T any](t T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
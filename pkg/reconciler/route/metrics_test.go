@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package route
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/metric"
+	"k8s.io/client-go/tools/record"
+
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/observability/metrics/metricstest"
+	"knative.dev/serving/pkg/metrics"
+	"knative.dev/serving/pkg/reconciler/route/traffic"
+	. "knative.dev/serving/pkg/testing/v1"
+)
+
+func TestRolloutMetrics(t *testing.T) {
+	reader := metric.NewManualReader()
+	mp := metric.NewMeterProvider(metric.WithReader(reader))
+	recorder := record.NewFakeRecorder(10)
+	ctx := controller.WithEventRecorder(context.Background(), recorder)
+	c := &Reconciler{metrics: newRolloutMetrics(mp)}
+	r := Route("default", "route")
+
+	prev := &traffic.Rollout{
+		Configurations: []*traffic.ConfigurationRollout{{
+			ConfigurationName: "config",
+			Tag:               "tag",
+			Percent:           100,
+			Revisions: []traffic.RevisionRollout{
+				{RevisionName: "config-00001", Percent: 50},
+				{RevisionName: "config-00002", Percent: 50},
+			},
+			StepParams: traffic.RolloutParams{StartTime: fakeCurTime.UnixNano()},
+		}},
+	}
+	cur := &traffic.Rollout{
+		Configurations: []*traffic.ConfigurationRollout{{
+			ConfigurationName: "config",
+			Tag:               "tag",
+			Percent:           100,
+			Revisions:         []traffic.RevisionRollout{{RevisionName: "config-00002", Percent: 100}},
+		}},
+	}
+	c.reportRolloutProgress(ctx, r, rolloutsInFlight(prev), cur, fakeCurTime.Add(time.Minute).UnixNano())
+
+	if got, want := <-recorder.Events, `Normal RolloutCompleted Rolled out revision "config-00002" of configuration "config" in 1m0s`; got != want {
+		t.Errorf("Event = %q, want: %q", got, want)
+	}
+	metricstest.AssertMetrics(t, reader,
+		metricstest.MetricsPresent(scopeName, "kn.route.rollout.duration", "kn.route.rollout.steps"),
+		metricstest.HasAttributes(scopeName, "kn.route.rollout",
+			metrics.K8sNamespaceKey.With("default"),
+			metrics.RouteNameKey.With("route"),
+			metrics.ConfigurationNameKey.With("config"),
+			metrics.RouteTagNameKey.With("tag"),
+		),
+	)
+}
@@ -19,6 +19,7 @@ package route
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -32,6 +33,7 @@ import (
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	"knative.dev/networking/pkg/apis/networking"
@@ -41,6 +43,7 @@ import (
 	"knative.dev/pkg/logging"
 	"knative.dev/serving/pkg/apis/serving"
 	v1 "knative.dev/serving/pkg/apis/serving/v1"
+	"knative.dev/serving/pkg/reconciler/route/analysis"
 	"knative.dev/serving/pkg/reconciler/route/config"
 	"knative.dev/serving/pkg/reconciler/route/resources"
 	"knative.dev/serving/pkg/reconciler/route/resources/names"
@@ -328,6 +331,10 @@ func deserializeRollout(ctx context.Context, ro string) *traffic.Rollout {
 	return r
 }
 
+// minRolloutRequeueDelay is the shortest delay to reconcile the route again
+// for the next step of its rollout.
+const minRolloutRequeueDelay = time.Second
+
 func (c *Reconciler) reconcileRollout(
 	ctx context.Context, r *v1.Route, tc *traffic.Config,
 	ingress *netv1alpha1.Ingress,
@@ -340,9 +347,11 @@ func (c *Reconciler) reconcileRollout(
 		// If not, check if there's a cluster-wide default.
 		rd = cfg.Network.RolloutDurationSecs
 	}
+	plan := r.RolloutPlan()
 	curRO := tc.BuildRollout()
 	// When rollout is disabled just create the baseline annotation.
-	if rd <= 0 {
+	// A rollout plan enables the rollout on its own.
+	if rd <= 0 && len(plan) == 0 {
 		return curRO
 	}
 	// Get the current rollout state as described by the traffic.
@@ -362,12 +371,27 @@ func (c *Reconciler) reconcileRollout(
 	rtView := r.Status.GetCondition(v1.RouteConditionIngressReady)
 	if prevRO != nil && ingress.IsReady() && !rtView.IsTrue() {
 		logger.Debug("Observing Ingress not-ready to ready switch condition for rollout")
-		prevRO.ObserveReady(ctx, now, float64(rd))
+		prevRO.ObserveReadyWithPlan(ctx, now, float64(rd), plan)
+	}
+	// Resume the rollouts paused at the approved stage of their plan.
+	if rev, stage, ok := r.RolloutApproval(); ok && prevRO != nil {
+		prevRO.Approve(rev, stage)
 	}
 
-	effectiveRO, nextStepTime := curRO.Step(ctx, prevRO, now)
+	// Stepping shifts the traffic of the previous rollout in place,
+	// so take note of its progress beforehand.
+	prevProgress := rolloutsInFlight(prevRO)
+	var rollbacks []rollback
+	effectiveRO, nextStepTime := curRO.StepGated(ctx, prevRO, now, c.rolloutGate(ctx, r, &rollbacks))
+	for _, rb := range rollbacks {
+		r.Status.MarkRolledBack(rb.revision, rb.reason.Error())
+		controller.GetEventRecorder(ctx).Eventf(r, corev1.EventTypeWarning, "RolledBack",
+			"Rolled back revision %q: %v", rb.revision, rb.reason)
+	}
+	c.reportRolloutProgress(ctx, r, prevProgress, effectiveRO, now)
 	if nextStepTime > 0 {
-		nextStepTime -= now
+		// Never requeue right away, which would spin while the step is due.
+		nextStepTime = max(nextStepTime-now, int64(minRolloutRequeueDelay))
 		c.enqueueAfter(r, time.Duration(nextStepTime))
 		logger.Debug("Re-enqueuing after", zap.Duration("nextStepTime", time.Duration(nextStepTime)))
 	}
@@ -381,3 +405,128 @@ func (c *Reconciler) reconcileRollout(
 	}
 	return effectiveRO
 }
+
+// rolloutKey identifies the rollout of a configuration for a tag.
+type rolloutKey struct {
+	tag, config string
+}
+
+// rolloutProgress is the progress of a rollout in flight.
+type rolloutProgress struct {
+	revision  string
+	percent   int
+	startTime int64
+}
+
+// rolloutsInFlight returns the progress of the configuration rollouts in
+// flight in the given rollout.
+func rolloutsInFlight(ro *traffic.Rollout) map[rolloutKey]rolloutProgress {
+	if ro == nil {
+		return nil
+	}
+	ret := make(map[rolloutKey]rolloutProgress, len(ro.Configurations))
+	for _, c := range ro.Configurations {
+		if len(c.Revisions) < 2 {
+			continue
+		}
+		latest := c.Revisions[len(c.Revisions)-1]
+		ret[rolloutKey{tag: c.Tag, config: c.ConfigurationName}] = rolloutProgress{
+			revision:  latest.RevisionName,
+			percent:   latest.Percent,
+			startTime: c.StepParams.StartTime,
+		}
+	}
+	return ret
+}
+
+// reportRolloutProgress emits an event and records the metrics for each
+// rollout that started, stepped or completed since the previous state.
+// Rollbacks are reported as they are decided.
+func (c *Reconciler) reportRolloutProgress(ctx context.Context, r *v1.Route,
+	prev map[rolloutKey]rolloutProgress, cur *traffic.Rollout, now int64,
+) {
+	recorder := controller.GetEventRecorder(ctx)
+	for _, cr := range cur.Configurations {
+		if len(cr.Revisions) == 0 {
+			continue
+		}
+		latest := cr.Revisions[len(cr.Revisions)-1]
+		p, wasInFlight := prev[rolloutKey{tag: cr.Tag, config: cr.ConfigurationName}]
+		switch {
+		case len(cr.Revisions) > 1 && (!wasInFlight || p.revision != latest.RevisionName):
+			recorder.Eventf(r, corev1.EventTypeNormal, "RolloutStarted",
+				"Started rolling out revision %q of configuration %q: %d%% of the traffic",
+				latest.RevisionName, cr.ConfigurationName, latest.Percent)
+			c.metrics.recordStep(ctx, r, cr.ConfigurationName, cr.Tag)
+		case len(cr.Revisions) > 1 && p.percent != latest.Percent:
+			recorder.Eventf(r, corev1.EventTypeNormal, "RolloutStep",
+				"Rolling out revision %q of configuration %q: %d%% of the traffic",
+				latest.RevisionName, cr.ConfigurationName, latest.Percent)
+			c.metrics.recordStep(ctx, r, cr.ConfigurationName, cr.Tag)
+		case len(cr.Revisions) == 1 && wasInFlight && p.revision == latest.RevisionName:
+			d := time.Duration(now - p.startTime)
+			recorder.Eventf(r, corev1.EventTypeNormal, "RolloutCompleted",
+				"Rolled out revision %q of configuration %q in %v",
+				latest.RevisionName, cr.ConfigurationName, d.Round(time.Second))
+			c.metrics.recordStep(ctx, r, cr.ConfigurationName, cr.Tag)
+			c.metrics.recordCompletion(ctx, r, cr.ConfigurationName, cr.Tag, d)
+		}
+	}
+}
+
+// rollback is the rollback of a revision decided by the rollout gate.
+type rollback struct {
+	revision string
+	reason   error
+}
+
+// rolloutGate returns the gate analysing the latest revisions before each
+// step of their rollout, or nil if the route sets no analysis thresholds.
+// The revisions failing the analysis are rolled back, and appended to
+// rollbacks, while those without stats to analyze are held at their step.
+func (c *Reconciler) rolloutGate(ctx context.Context, r *v1.Route, rollbacks *[]rollback) traffic.Gate {
+	gates := rolloutGates(r)
+	if !gates.Enabled() || c.analysis == nil {
+		return nil
+	}
+
+	logger := logging.FromContext(ctx)
+	return func(cr *traffic.ConfigurationRollout) traffic.GateDecision {
+		rev := cr.Revisions[len(cr.Revisions)-1].RevisionName
+		// The gate runs at the end of the step, so analyze the whole step.
+		since := time.Unix(0, cr.StepParams.NextStepTime-cr.StepParams.StepDuration)
+		res, err := c.analysis.Analyze(types.NamespacedName{Namespace: r.Namespace, Name: rev}, since, gates.Percentile)
+		inconclusive := err != nil
+		if err == nil {
+			err = gates.Check(res)
+			inconclusive = errors.Is(err, analysis.ErrNoData)
+		}
+		switch {
+		case err == nil:
+			return traffic.GateAdvance
+		case inconclusive:
+			// Missing stats, e.g. while the revision serves too few requests,
+			// tell nothing about its health, so the rollout holds until they
+			// do.
+			logger.Infow(fmt.Sprintf("Holding the rollout of revision %s (%d steps)", rev, cr.StepParams.Pauses+1), zap.Error(err))
+			controller.GetEventRecorder(ctx).Eventf(r, corev1.EventTypeWarning, "RolloutPaused",
+				"Paused the rollout of revision %q: %v", rev, err)
+			return traffic.GatePause
+		}
+		*rollbacks = append(*rollbacks, rollback{revision: rev, reason: err})
+		return traffic.GateRollback
+	}
+}
+
+// rolloutGates returns the thresholds the route sets for the analysis of
+// its rollouts.
+func rolloutGates(r *v1.Route) analysis.Gates {
+	maxErrorRate, errorRateGated := r.RolloutMaxErrorRate()
+	return analysis.Gates{
+		MaxErrorRate:   maxErrorRate,
+		ErrorRateGated: errorRateGated,
+		MaxLatency:     r.RolloutMaxLatency(),
+		Percentile:     r.RolloutLatencyPercentile(),
+		MinRequests:    float64(r.RolloutMinRequests()),
+	}
+}
@@ -20,6 +20,8 @@ import (
 	"context"
 	"encoding/json"
 	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/davecgh/go-spew/spew"
 	"go.uber.org/zap"
@@ -33,6 +35,7 @@ import (
 	netheader "knative.dev/networking/pkg/http/header"
 	"knative.dev/pkg/kmeta"
 	"knative.dev/pkg/logging"
+	"knative.dev/pkg/system"
 	"knative.dev/serving/pkg/activator"
 	apicfg "knative.dev/serving/pkg/apis/config"
 	"knative.dev/serving/pkg/apis/serving"
@@ -215,6 +218,46 @@ func makeIngressSpec(
 					}
 				}
 
+				// Route the requests matching the rules of the targets ahead of
+				// the percentage based split, which is always the last path.
+				if name == traffic.DefaultTarget {
+					if matchPaths := makeMatchIngressPaths(r.Namespace, tc.Targets[name], networkConfig.SystemInternalTLSEnabled()); len(matchPaths) > 0 {
+						last := len(rule.HTTP.Paths) - 1
+						paths := make([]netv1alpha1.HTTPIngressPath, 0, len(rule.HTTP.Paths)+len(matchPaths))
+						paths = append(paths, rule.HTTP.Paths[:last]...)
+						paths = append(paths, matchPaths...)
+						rule.HTTP.Paths = append(paths, rule.HTTP.Paths[last])
+					}
+				}
+
+				// The ingress can't split by key, so let the activator do it.
+				if affinity := r.SplitAffinity(); affinity != "" {
+					for j := range rule.HTTP.Paths {
+						if path := &rule.HTTP.Paths[j]; len(path.Splits) > 1 {
+							activatorSplit(r.Namespace, path).AppendHeaders[activator.SplitAffinityHeaderName] = affinity
+						}
+					}
+				}
+
+				// The ingress can't match a cookie among the others, so let the
+				// activator route the requests matching the cookie rules ahead
+				// of the percentage based split.
+				if name == traffic.DefaultTarget {
+					if matches := makeCookieMatches(tc.Targets[name]); matches != "" {
+						path := &rule.HTTP.Paths[len(rule.HTTP.Paths)-1]
+						activatorSplit(r.Namespace, path).AppendHeaders[activator.SplitMatchHeaderName] = matches
+					}
+				}
+
+				// The ingress can't mirror the requests, so let the activator
+				// do it, routing the percentage based split through it.
+				if name == traffic.DefaultTarget && tc.Mirror != nil {
+					path := &rule.HTTP.Paths[len(rule.HTTP.Paths)-1]
+					split := activatorSplit(r.Namespace, path)
+					split.AppendHeaders[activator.MirrorHeaderName] = tc.Mirror.RevisionName
+					split.AppendHeaders[activator.MirrorPercentHeaderName] = strconv.FormatInt(*tc.Mirror.Mirror, 10)
+				}
+
 				// Merge ACME paths for ExternalIP single-host rules
 				// Note: ACME challenges without matching traffic rules are silently ignored
 				if visibility == netv1alpha1.IngressVisibilityExternalIP && len(rule.Hosts) == 1 {
@@ -306,6 +349,100 @@ func makeTagBasedRoutingIngressPaths(ns string, tc *traffic.Config, ro *traffic.
 	return paths
 }
 
+// makeMatchIngressPaths returns the ingress paths routing the requests
+// matching the rules of the targets to their revisions.
+func makeMatchIngressPaths(ns string, targets traffic.RevisionTargets, encryption bool) []netv1alpha1.HTTPIngressPath {
+	var paths []netv1alpha1.HTTPIngressPath
+	for _, t := range targets {
+		for _, m := range t.Match {
+			if m.Header == "" {
+				// Cookie rules are matched by the activator.
+				continue
+			}
+			paths = append(paths, netv1alpha1.HTTPIngressPath{
+				Headers: map[string]netv1alpha1.HeaderMatch{m.Header: {Exact: m.Value}},
+				Splits:  []netv1alpha1.IngressBackendSplit{makeRevisionSplit(ns, t, 100, encryption)},
+			})
+		}
+	}
+	return paths
+}
+
+// makeCookieMatches returns the cookie rules of the targets the activator
+// matches, as `<revision>=<cookie>=<value>,...`.
+func makeCookieMatches(targets traffic.RevisionTargets) string {
+	var matches []string
+	for _, t := range targets {
+		for _, m := range t.Match {
+			if m.Cookie != "" {
+				matches = append(matches, t.RevisionName+"="+m.Cookie+"="+m.Value)
+			}
+		}
+	}
+	return strings.Join(matches, ",")
+}
+
+// activatorSplit returns the split of the path through the activator, first
+// routing all the requests of the path through it when they aren't already.
+func activatorSplit(ns string, path *netv1alpha1.HTTPIngressPath) *netv1alpha1.IngressBackendSplit {
+	if len(path.Splits) != 1 || path.Splits[0].AppendHeaders[activator.SplitHeaderName] == "" {
+		path.Splits = []netv1alpha1.IngressBackendSplit{makeActivatorSplit(ns, path.Splits)}
+	}
+	return &path.Splits[0]
+}
+
+// makeActivatorSplit returns the split sending all the requests to the
+// activator, which assigns each of them to a revision of the given splits.
+// The headers the activator acts on are set to activator.UnsetHeaderValue,
+// overwriting the values sent by the clients, until the Route uses them.
+func makeActivatorSplit(ns string, splits []netv1alpha1.IngressBackendSplit) netv1alpha1.IngressBackendSplit {
+	weights := make([]string, 0, len(splits))
+	for _, s := range splits {
+		weights = append(weights, s.ServiceName+"="+strconv.Itoa(s.Percent))
+	}
+	return netv1alpha1.IngressBackendSplit{
+		IngressBackend: netv1alpha1.IngressBackend{
+			ServiceNamespace: system.Namespace(),
+			ServiceName:      servingnetworking.ActivatorServiceName,
+			// The activator service exposes the same ports as the revision services.
+			ServicePort: splits[0].ServicePort,
+		},
+		Percent: 100,
+		AppendHeaders: map[string]string{
+			activator.RevisionHeaderNamespace: ns,
+			activator.RevisionHeaderName:      activator.UnsetHeaderValue,
+			activator.SplitHeaderName:         strings.Join(weights, ","),
+			activator.SplitAffinityHeaderName: activator.UnsetHeaderValue,
+			activator.SplitMatchHeaderName:    activator.UnsetHeaderValue,
+			activator.MirrorHeaderName:        activator.UnsetHeaderValue,
+		},
+	}
+}
+
+// makeRevisionSplit returns the split of the given percent to the target revision.
+func makeRevisionSplit(ns string, t traffic.RevisionTarget, percent int, encryption bool) netv1alpha1.IngressBackendSplit {
+	return netv1alpha1.IngressBackendSplit{
+		IngressBackend: netv1alpha1.IngressBackend{
+			ServiceNamespace: ns,
+			ServiceName:      t.RevisionName,
+			ServicePort:      revisionServicePort(t, encryption),
+		},
+		Percent: percent,
+		AppendHeaders: map[string]string{
+			activator.RevisionHeaderName:      t.RevisionName,
+			activator.RevisionHeaderNamespace: ns,
+		},
+	}
+}
+
+// revisionServicePort returns the port of the target revision service.
+func revisionServicePort(t traffic.RevisionTarget, encryption bool) intstr.IntOrString {
+	if encryption {
+		return intstr.FromInt(networking.ServiceHTTPSPort)
+	}
+	return intstr.FromInt(networking.ServicePort(t.Protocol))
+}
+
 func rolloutConfig(cfgName string, ros []*traffic.ConfigurationRollout) *traffic.ConfigurationRollout {
 	idx := sort.Search(len(ros), func(i int) bool {
 		return ros[i].ConfigurationName >= cfgName
@@ -331,13 +468,9 @@ func makeBaseIngressPath(ns string, targets traffic.RevisionTargets,
 		if t.LatestRevision != nil && *t.LatestRevision {
 			cfg = rolloutConfig(t.ConfigurationName, roCfgs)
 		}
-		var servicePort intstr.IntOrString
-		if encryption {
-			servicePort = intstr.FromInt(networking.ServiceHTTPSPort)
-		} else {
-			servicePort = intstr.FromInt(networking.ServicePort(t.Protocol))
-		}
-		if cfg == nil || len(cfg.Revisions) < 2 {
+		servicePort := revisionServicePort(t, encryption)
+		// A rolled back rollout keeps serving the previous revision, rather than the target.
+		if cfg == nil || (len(cfg.Revisions) < 2 && cfg.RolledBack == "") {
 			// No rollout in progress.
 			splits = append(splits, netv1alpha1.IngressBackendSplit{
 				IngressBackend: netv1alpha1.IngressBackend{
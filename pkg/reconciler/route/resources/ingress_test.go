@@ -599,6 +599,299 @@ func TestMakeIngressSpecCorrectRules(t *testing.T) {
 	}
 }
 
+func TestMakeIngressSpecCorrectRulesWithMatch(t *testing.T) {
+	targets := map[string]traffic.RevisionTargets{
+		traffic.DefaultTarget: {{
+			TrafficTarget: v1.TrafficTarget{
+				ConfigurationName: "config",
+				RevisionName:      "v2",
+				Percent:           ptr.Int64(100),
+			},
+		}, {
+			TrafficTarget: v1.TrafficTarget{
+				Tag:          "v1",
+				RevisionName: "v1",
+				Percent:      ptr.Int64(0),
+				Match: []v1.TrafficMatch{{
+					Header: "X-User-Group",
+					Value:  "beta",
+				}, {
+					Header: "X-Canary",
+					Value:  "always",
+				}},
+			},
+		}},
+		"v1": {{
+			TrafficTarget: v1.TrafficTarget{
+				Tag:          "v1",
+				RevisionName: "v1",
+				Percent:      ptr.Int64(100),
+			},
+		}},
+	}
+
+	r := Route(ns, "test-route", WithURL)
+
+	split := func(rev string) []netv1alpha1.IngressBackendSplit {
+		return []netv1alpha1.IngressBackendSplit{{
+			IngressBackend: netv1alpha1.IngressBackend{
+				ServiceNamespace: ns,
+				ServiceName:      rev,
+				ServicePort:      intstr.FromInt(80),
+			},
+			Percent: 100,
+			AppendHeaders: map[string]string{
+				"Knative-Serving-Revision":  rev,
+				"Knative-Serving-Namespace": ns,
+			},
+		}}
+	}
+	// The matching requests are routed ahead of the split, on the default hosts only.
+	defaultPaths := []netv1alpha1.HTTPIngressPath{{
+		Headers: map[string]netv1alpha1.HeaderMatch{"X-User-Group": {Exact: "beta"}},
+		Splits:  split("v1"),
+	}, {
+		Headers: map[string]netv1alpha1.HeaderMatch{"X-Canary": {Exact: "always"}},
+		Splits:  split("v1"),
+	}, {
+		Splits: split("v2"),
+	}}
+	tagPaths := []netv1alpha1.HTTPIngressPath{{
+		Splits: split("v1"),
+	}}
+
+	tc := &traffic.Config{Targets: targets}
+	ro := tc.BuildRollout()
+	ci, _, err := makeIngressSpec(testContext(), r, nil /*tls*/, tc, ro)
+	if err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	if len(ci.Rules) != 4 {
+		t.Fatalf("Expected 4 rules, got %d", len(ci.Rules))
+	}
+	for i, want := range [][]netv1alpha1.HTTPIngressPath{defaultPaths, defaultPaths, tagPaths, tagPaths} {
+		if got := ci.Rules[i].HTTP.Paths; !cmp.Equal(want, got) {
+			t.Errorf("Unexpected paths of rule %d (-want, +got): %s", i, cmp.Diff(want, got))
+		}
+	}
+}
+
+func TestMakeIngressWithCookieMatch(t *testing.T) {
+	tc := &traffic.Config{
+		Targets: map[string]traffic.RevisionTargets{
+			traffic.DefaultTarget: {{
+				TrafficTarget: v1.TrafficTarget{
+					ConfigurationName: "config",
+					RevisionName:      "v2",
+					Percent:           ptr.Int64(100),
+				},
+			}, {
+				TrafficTarget: v1.TrafficTarget{
+					RevisionName: "v1",
+					Percent:      ptr.Int64(0),
+					Match: []v1.TrafficMatch{{
+						Header: "X-Canary",
+						Value:  "always",
+					}, {
+						Cookie: "beta",
+						Value:  "1",
+					}},
+				},
+			}},
+		},
+	}
+	r := Route(ns, "test-route", WithURL)
+
+	ing, err := MakeIngress(testContext(), r, tc, nil /*tls*/, "foo-ingress")
+	if err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	// The header rule is matched by the ingress, the cookie rule by the
+	// activator, ahead of the split.
+	want := []netv1alpha1.HTTPIngressPath{{
+		Headers: map[string]netv1alpha1.HeaderMatch{"X-Canary": {Exact: "always"}},
+		Splits: []netv1alpha1.IngressBackendSplit{{
+			IngressBackend: netv1alpha1.IngressBackend{
+				ServiceNamespace: ns,
+				ServiceName:      "v1",
+				ServicePort:      intstr.FromInt(80),
+			},
+			Percent: 100,
+			AppendHeaders: map[string]string{
+				"Knative-Serving-Revision":  "v1",
+				"Knative-Serving-Namespace": ns,
+			},
+		}},
+	}, {
+		Splits: []netv1alpha1.IngressBackendSplit{{
+			IngressBackend: netv1alpha1.IngressBackend{
+				ServiceNamespace: system.Namespace(),
+				ServiceName:      "activator-service",
+				ServicePort:      intstr.FromInt(80),
+			},
+			Percent: 100,
+			AppendHeaders: map[string]string{
+				"Knative-Serving-Mirror":         "-",
+				"Knative-Serving-Namespace":      ns,
+				"Knative-Serving-Revision":       "-",
+				"Knative-Serving-Split":          "v2=100",
+				"Knative-Serving-Split-Affinity": "-",
+				"Knative-Serving-Split-Match":    "v1=beta=1",
+			},
+		}},
+	}}
+	for _, rule := range ing.Spec.Rules {
+		if got := rule.HTTP.Paths; !cmp.Equal(want, got) {
+			t.Errorf("Unexpected paths of %v (-want, +got): %s", rule.Hosts, cmp.Diff(want, got))
+		}
+	}
+}
+
+func TestMakeIngressWithMirror(t *testing.T) {
+	mirror := traffic.RevisionTarget{
+		TrafficTarget: v1.TrafficTarget{
+			RevisionName: "v1",
+			Percent:      ptr.Int64(0),
+			Mirror:       ptr.Int64(10),
+		},
+	}
+	tc := &traffic.Config{
+		Targets: map[string]traffic.RevisionTargets{
+			traffic.DefaultTarget: {{
+				TrafficTarget: v1.TrafficTarget{
+					ConfigurationName: "config",
+					RevisionName:      "v2",
+					Percent:           ptr.Int64(100),
+				},
+			}},
+		},
+		Mirror: &mirror,
+	}
+	r := Route(ns, "test-route", WithURL)
+
+	ing, err := MakeIngress(testContext(), r, tc, nil /*tls*/, "foo-ingress")
+	if err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	// The split of the default target goes through the activator, which
+	// mirrors the requests.
+	want := []netv1alpha1.IngressBackendSplit{{
+		IngressBackend: netv1alpha1.IngressBackend{
+			ServiceNamespace: system.Namespace(),
+			ServiceName:      "activator-service",
+			ServicePort:      intstr.FromInt(80),
+		},
+		Percent: 100,
+		AppendHeaders: map[string]string{
+			"Knative-Serving-Mirror":         "v1",
+			"Knative-Serving-Mirror-Percent": "10",
+			"Knative-Serving-Namespace":      ns,
+			"Knative-Serving-Revision":       "-",
+			"Knative-Serving-Split":          "v2=100",
+			"Knative-Serving-Split-Affinity": "-",
+			"Knative-Serving-Split-Match":    "-",
+		},
+	}}
+	for _, rule := range ing.Spec.Rules {
+		if got := rule.HTTP.Paths[0].Splits; !cmp.Equal(want, got) {
+			t.Errorf("Unexpected splits of %v (-want, +got): %s", rule.Hosts, cmp.Diff(want, got))
+		}
+	}
+}
+
+func TestMakeIngressWithSplitAffinity(t *testing.T) {
+	tc := &traffic.Config{
+		Targets: map[string]traffic.RevisionTargets{
+			traffic.DefaultTarget: {{
+				TrafficTarget: v1.TrafficTarget{
+					ConfigurationName: "config",
+					RevisionName:      "v1",
+					Percent:           ptr.Int64(90),
+				},
+			}, {
+				TrafficTarget: v1.TrafficTarget{
+					ConfigurationName: "config",
+					RevisionName:      "v2",
+					Percent:           ptr.Int64(10),
+				},
+			}},
+			"v2": {{
+				TrafficTarget: v1.TrafficTarget{
+					Tag:          "v2",
+					RevisionName: "v2",
+					Percent:      ptr.Int64(100),
+				},
+			}},
+		},
+	}
+	r := Route(ns, "test-route", WithURL, WithRouteAnnotation(map[string]string{
+		serving.SplitAffinityKey: "header:X-User-Id",
+	}))
+
+	ing, err := MakeIngress(testContext(), r, tc, nil /*tls*/, "foo-ingress")
+	if err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+
+	// The split of the default target is left to the activator.
+	want := []netv1alpha1.IngressBackendSplit{{
+		IngressBackend: netv1alpha1.IngressBackend{
+			ServiceNamespace: system.Namespace(),
+			ServiceName:      "activator-service",
+			ServicePort:      intstr.FromInt(80),
+		},
+		Percent: 100,
+		AppendHeaders: map[string]string{
+			"Knative-Serving-Mirror":         "-",
+			"Knative-Serving-Namespace":      ns,
+			"Knative-Serving-Revision":       "-",
+			"Knative-Serving-Split":          "v1=90,v2=10",
+			"Knative-Serving-Split-Affinity": "header:X-User-Id",
+			"Knative-Serving-Split-Match":    "-",
+		},
+	}}
+	for _, rule := range ing.Spec.Rules {
+		got := rule.HTTP.Paths[0].Splits
+		if strings.HasPrefix(rule.Hosts[0], "v2-") {
+			// The tag targets a single revision.
+			if len(got) != 1 || got[0].ServiceName != "v2" {
+				t.Errorf("Unexpected splits of %v: %v", rule.Hosts, got)
+			}
+		} else if !cmp.Equal(want, got) {
+			t.Errorf("Unexpected splits of %v (-want, +got): %s", rule.Hosts, cmp.Diff(want, got))
+		}
+	}
+
+	// Without the affinity, the ingress splits the requests directly.
+	ing, err = MakeIngress(testContext(), Route(ns, "test-route", WithURL), tc, nil /*tls*/, "foo-ingress")
+	if err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+	split := func(rev string, percent int) netv1alpha1.IngressBackendSplit {
+		return netv1alpha1.IngressBackendSplit{
+			IngressBackend: netv1alpha1.IngressBackend{
+				ServiceNamespace: ns,
+				ServiceName:      rev,
+				ServicePort:      intstr.FromInt(80),
+			},
+			Percent: percent,
+			AppendHeaders: map[string]string{
+				"Knative-Serving-Revision":  rev,
+				"Knative-Serving-Namespace": ns,
+			},
+		}
+	}
+	want = []netv1alpha1.IngressBackendSplit{split("v1", 90), split("v2", 10)}
+	for _, rule := range ing.Spec.Rules {
+		if got := rule.HTTP.Paths[0].Splits; !strings.HasPrefix(rule.Hosts[0], "v2-") && !cmp.Equal(want, got) {
+			t.Errorf("Unexpected splits of %v (-want, +got): %s", rule.Hosts, cmp.Diff(want, got))
+		}
+	}
+}
+
 func TestMakeIngressSpecCorrectRuleVisibility(t *testing.T) {
 	cases := []struct {
 		name               string
@@ -964,6 +1257,59 @@ func TestMakeIngressRuleZeroPercentTarget(t *testing.T) {
 	}
 }
 
+func TestMakeIngressRuleRolledBack(t *testing.T) {
+	targets := []traffic.RevisionTarget{{
+		TrafficTarget: v1.TrafficTarget{
+			ConfigurationName: "config",
+			RevisionName:      "revision-orca",
+			LatestRevision:    ptr.Bool(true),
+			Percent:           ptr.Int64(100),
+		},
+	}}
+	domains := sets.New("test.org")
+	ro := []*traffic.ConfigurationRollout{{
+		ConfigurationName: "config",
+		Percent:           100,
+		RolledBack:        "revision-orca",
+		Revisions: []traffic.RevisionRollout{{
+			RevisionName: "revision-dolphin",
+			Percent:      100,
+		}},
+	}}
+	rules := makeIngressRules(domains, ns,
+		netv1alpha1.IngressVisibilityExternalIP, targets, ro, false /* internal encryption */)
+
+	if len(rules) != 1 {
+		t.Fatalf("Expected 1 rule, got %d", len(rules))
+	}
+
+	// The rolled back revision must not receive traffic.
+	expected := netv1alpha1.IngressRule{
+		Hosts: []string{"test.org"},
+		HTTP: &netv1alpha1.HTTPIngressRuleValue{
+			Paths: []netv1alpha1.HTTPIngressPath{{
+				Splits: []netv1alpha1.IngressBackendSplit{{
+					IngressBackend: netv1alpha1.IngressBackend{
+						ServiceNamespace: ns,
+						ServiceName:      "revision-dolphin",
+						ServicePort:      intstr.FromInt(80),
+					},
+					Percent: 100,
+					AppendHeaders: map[string]string{
+						"Knative-Serving-Revision":  "revision-dolphin",
+						"Knative-Serving-Namespace": ns,
+					},
+				}},
+			}},
+		},
+		Visibility: netv1alpha1.IngressVisibilityExternalIP,
+	}
+
+	if !cmp.Equal(expected, rules[0]) {
+		t.Error("Unexpected rule (-want, +got):", cmp.Diff(expected, rules[0]))
+	}
+}
+
 // Two active targets.
 func TestMakeIngressRuleTwoTargets(t *testing.T) {
 	targets := []traffic.RevisionTarget{{
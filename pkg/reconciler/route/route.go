@@ -52,6 +52,7 @@ import (
 	listers "knative.dev/serving/pkg/client/listers/serving/v1"
 	kaccessor "knative.dev/serving/pkg/reconciler/accessor"
 	networkaccessor "knative.dev/serving/pkg/reconciler/accessor/networking"
+	"knative.dev/serving/pkg/reconciler/route/analysis"
 	"knative.dev/serving/pkg/reconciler/route/config"
 	"knative.dev/serving/pkg/reconciler/route/domains"
 	"knative.dev/serving/pkg/reconciler/route/resources"
@@ -78,6 +79,12 @@ type Reconciler struct {
 
 	clock        clock.PassiveClock
 	enqueueAfter func(interface{}, time.Duration)
+
+	// analysis measures the health of the revisions being rolled out.
+	analysis analysis.Provider
+
+	// metrics records the progress of the rollouts.
+	metrics *rolloutMetrics
 }
 
 const errorConfigMsg = "ErrorConfig"
@@ -168,6 +175,11 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, r *v1.Route) pkgreconcil
 	}
 
 	roInProgress := !effectiveRO.Done()
+	r.Status.Rollouts = effectiveRO.Status()
+	rolledBack := len(effectiveRO.RolledBackRevisions()) > 0
+	if !rolledBack {
+		r.Status.ClearRolledBack()
+	}
 	if ingress.GetObjectMeta().GetGeneration() != ingress.Status.ObservedGeneration {
 		r.Status.MarkIngressNotConfigured()
 	} else if !roInProgress {
@@ -195,6 +207,14 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, r *v1.Route) pkgreconcil
 		}
 		return nil
 	}
+	if rolledBack {
+		// The traffic of the rolled back revisions is still served by the
+		// previous ones.
+		r.Status.Traffic, err = traffic.GetRevisionTrafficTargets(ctx, r, effectiveRO)
+		if err != nil {
+			return err
+		}
+	}
 
 	logger.Info("Route successfully synced")
 	return nil
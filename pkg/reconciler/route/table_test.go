@@ -27,6 +27,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	clientgotesting "k8s.io/client-go/testing"
 	clocktest "k8s.io/utils/clock/testing"
@@ -52,6 +53,7 @@ import (
 	servingclient "knative.dev/serving/pkg/client/injection/client/fake"
 	routereconciler "knative.dev/serving/pkg/client/injection/reconciler/serving/v1/route"
 	kaccessor "knative.dev/serving/pkg/reconciler/accessor"
+	"knative.dev/serving/pkg/reconciler/route/analysis"
 	"knative.dev/serving/pkg/reconciler/route/config"
 	"knative.dev/serving/pkg/reconciler/route/domains"
 	"knative.dev/serving/pkg/reconciler/route/resources"
@@ -74,8 +76,38 @@ const (
 	externalSchemeKey
 	enableExternalDomainTLSKey
 	domainConfigKey
+	analysisKey
 )
 
+// withMaxErrorRate gates the route rollouts on the error rate of the revisions.
+var withMaxErrorRate = WithRouteAnnotation(map[string]string{serving.RolloutMaxErrorRateKey: "0.05"})
+
+// rolloutPlan is the rollout plan set by withRolloutPlan, approved at its
+// pause stage.
+var rolloutPlan = []serving.RolloutStage{
+	{Percent: 10, Duration: time.Minute},
+	{Pause: true},
+	{Percent: 50, Duration: time.Minute},
+}
+
+var withRolloutPlan = WithRouteAnnotation(map[string]string{
+	serving.RolloutPlanKey:    "10%:1m,pause,50%:1m",
+	serving.RolloutApproveKey: "config-00001:1",
+})
+
+// rolloutTime returns the rollout status time of t.
+func rolloutTime(t time.Time) *metav1.Time {
+	mt := metav1.NewTime(t)
+	return &mt
+}
+
+// fakeAnalysis always reports the same result for every revision.
+type fakeAnalysis analysis.Result
+
+func (f fakeAnalysis) Analyze(types.NamespacedName, time.Time, float64) (analysis.Result, error) {
+	return analysis.Result(f), nil
+}
+
 // This is heavily based on the way the OpenShift Ingress controller tests its reconciliation method.
 func TestReconcile(t *testing.T) {
 	table := TableTest{{
@@ -477,7 +509,20 @@ func TestReconcile(t *testing.T) {
 				// Populated by reconciliation when the route becomes ready.
 				WithURL, WithAddress, WithRouteConditionsExternalDomainTLSDisabled,
 				WithRouteGeneration(2009), WithRouteObservedGeneration,
-				MarkTrafficAssigned, MarkInRollout, WithStatusTraffic(
+				MarkTrafficAssigned, MarkInRollout, func(r *v1.Route) {
+					r.Status.Rollouts = []v1.RolloutStatus{{
+						ConfigurationName: "config",
+						RevisionName:      "config-00001",
+						Revisions: []v1.RolloutRevisionStatus{
+							{RevisionName: "config-00000", Percent: 99},
+							{RevisionName: "config-00001", Percent: 1},
+						},
+						StartTime:    rolloutTime(fakeCurTime.Add(-3 * time.Second)),
+						NextStepTime: rolloutTime(fakeCurTime.Add(3 * time.Second)),
+						// 33 steps of 3% every 3s.
+						EstimatedCompletionTime: rolloutTime(fakeCurTime.Add(99 * time.Second)),
+					}}
+				}, WithStatusTraffic(
 					v1.TrafficTarget{
 						RevisionName:   "config-00000",
 						Percent:        ptr.Int64(99),
@@ -570,6 +615,337 @@ func TestReconcile(t *testing.T) {
 		}},
 		WantEvents: []string{
 			Eventf(corev1.EventTypeNormal, "Created", "Created placeholder service %q", "becomes-ready"),
+			Eventf(corev1.EventTypeNormal, "RolloutCompleted", "Rolled out revision %q of configuration %q in %v",
+				"config-00001", "config", time.Hour),
+		},
+		Key: "default/becomes-ready",
+	}, {
+		Name: "simple route rollout is rolled back",
+		Ctx: context.WithValue(context.WithValue(context.Background(), rolloutDurationKey, 120),
+			analysisKey, fakeAnalysis{Requests: 100, ServerErrors: 50}),
+		Objects: []runtime.Object{
+			Route("default", "becomes-ready", WithConfigTarget("config"),
+				WithRouteGeneration(2009), MarkInRollout, withMaxErrorRate),
+			cfg("default", "config",
+				WithConfigGeneration(1), WithLatestCreated("config-00001"), WithLatestReady("config-00001")),
+			rev("default", "config", 0, MarkRevisionReady, WithRevName("config-00000")),
+			rev("default", "config", 1, MarkRevisionReady, WithRevName("config-00001")),
+			simpleIngress(
+				Route("default", "becomes-ready", WithConfigTarget("config"), withMaxErrorRate, WithURL),
+				&traffic.Config{
+					Targets: map[string]traffic.RevisionTargets{
+						traffic.DefaultTarget: {{
+							TrafficTarget: v1.TrafficTarget{
+								ConfigurationName: "config",
+								RevisionName:      "config-00001",
+								Percent:           ptr.Int64(100),
+								LatestRevision:    ptr.Bool(true),
+							},
+						}},
+					},
+				},
+				simpleRollout("config", []traffic.RevisionRollout{{
+					RevisionName: "config-00000", Percent: 90,
+				}, {
+					RevisionName: "config-00001", Percent: 10,
+				}}, fakeCurTime.Add(-3*time.Second),
+					withStepParams(traffic.RolloutParams{
+						NextStepTime: fakeCurTime.Add(-time.Second).UnixNano(),
+						StepSize:     10,
+						StartTime:    fakeCurTime.Add(-time.Minute).UnixNano(),
+						StepDuration: int64(12 * time.Second),
+					})),
+				withReadyIngress,
+			),
+		},
+		WantCreates: []runtime.Object{
+			simplePlaceholderK8sService(getContext(), Route("default", "becomes-ready", WithConfigTarget("config"), withMaxErrorRate), ""),
+		},
+		WantUpdates: []clientgotesting.UpdateActionImpl{{
+			// The previous revision takes back all the traffic.
+			Object: ingressWithRollout(
+				Route("default", "becomes-ready", WithConfigTarget("config"), withMaxErrorRate, WithURL),
+				&traffic.Config{
+					Targets: map[string]traffic.RevisionTargets{
+						traffic.DefaultTarget: {{
+							TrafficTarget: v1.TrafficTarget{
+								ConfigurationName: "config",
+								RevisionName:      "config-00001",
+								Percent:           ptr.Int64(100),
+								LatestRevision:    ptr.Bool(true),
+							},
+						}},
+					},
+				},
+				&traffic.Rollout{
+					Configurations: []*traffic.ConfigurationRollout{{
+						ConfigurationName: "config",
+						Percent:           100,
+						RolledBack:        "config-00001",
+						Revisions: []traffic.RevisionRollout{{
+							RevisionName: "config-00000", Percent: 100,
+						}},
+					}},
+				},
+				withReadyIngress,
+			),
+		}, {
+			Object: simpleK8sService(
+				Route("default", "becomes-ready", WithConfigTarget("config"), withMaxErrorRate),
+			),
+		}},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: Route("default", "becomes-ready", WithConfigTarget("config"), withMaxErrorRate,
+				WithURL, WithAddress, WithRouteConditionsExternalDomainTLSDisabled,
+				WithRouteGeneration(2009), WithRouteObservedGeneration,
+				MarkTrafficAssigned, MarkIngressReady, func(r *v1.Route) {
+					r.Status.MarkRolledBack("config-00001", "error rate 0.500 is above 0.050")
+				}, WithStatusTraffic(
+					v1.TrafficTarget{
+						RevisionName:   "config-00000",
+						Percent:        ptr.Int64(100),
+						LatestRevision: ptr.Bool(true),
+					})),
+		}},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "Created", "Created placeholder service %q", "becomes-ready"),
+			Eventf(corev1.EventTypeWarning, "RolledBack", "Rolled back revision %q: %s",
+				"config-00001", "error rate 0.500 is above 0.050"),
+		},
+		Key: "default/becomes-ready",
+	}, {
+		Name: "simple route rollout is held without stats",
+		Ctx: context.WithValue(context.WithValue(context.Background(), rolloutDurationKey, 120),
+			analysisKey, fakeAnalysis{}),
+		Objects: []runtime.Object{
+			Route("default", "becomes-ready", WithConfigTarget("config"),
+				WithRouteGeneration(2009), MarkInRollout, withMaxErrorRate),
+			cfg("default", "config",
+				WithConfigGeneration(1), WithLatestCreated("config-00001"), WithLatestReady("config-00001")),
+			rev("default", "config", 0, MarkRevisionReady, WithRevName("config-00000")),
+			rev("default", "config", 1, MarkRevisionReady, WithRevName("config-00001")),
+			simpleIngress(
+				Route("default", "becomes-ready", WithConfigTarget("config"), withMaxErrorRate, WithURL),
+				&traffic.Config{
+					Targets: map[string]traffic.RevisionTargets{
+						traffic.DefaultTarget: {{
+							TrafficTarget: v1.TrafficTarget{
+								ConfigurationName: "config",
+								RevisionName:      "config-00001",
+								Percent:           ptr.Int64(100),
+								LatestRevision:    ptr.Bool(true),
+							},
+						}},
+					},
+				},
+				simpleRollout("config", []traffic.RevisionRollout{{
+					RevisionName: "config-00000", Percent: 90,
+				}, {
+					RevisionName: "config-00001", Percent: 10,
+				}}, fakeCurTime.Add(-3*time.Second),
+					withStepParams(traffic.RolloutParams{
+						NextStepTime: fakeCurTime.Add(-time.Second).UnixNano(),
+						StepSize:     10,
+						StartTime:    fakeCurTime.Add(-time.Minute).UnixNano(),
+						StepDuration: int64(12 * time.Second),
+						// However long the revision goes without stats.
+						Pauses: 10,
+					})),
+				withReadyIngress,
+			),
+		},
+		WantCreates: []runtime.Object{
+			simplePlaceholderK8sService(getContext(), Route("default", "becomes-ready", WithConfigTarget("config"), withMaxErrorRate), ""),
+		},
+		WantUpdates: []clientgotesting.UpdateActionImpl{{
+			// The traffic stays at the step until the next analysis.
+			Object: ingressWithRollout(
+				Route("default", "becomes-ready", WithConfigTarget("config"), withMaxErrorRate, WithURL),
+				&traffic.Config{
+					Targets: map[string]traffic.RevisionTargets{
+						traffic.DefaultTarget: {{
+							TrafficTarget: v1.TrafficTarget{
+								ConfigurationName: "config",
+								RevisionName:      "config-00001",
+								Percent:           ptr.Int64(100),
+								LatestRevision:    ptr.Bool(true),
+							},
+						}},
+					},
+				},
+				&traffic.Rollout{
+					Configurations: []*traffic.ConfigurationRollout{{
+						ConfigurationName: "config",
+						Percent:           100,
+						Revisions: []traffic.RevisionRollout{{
+							RevisionName: "config-00000", Percent: 90,
+						}, {
+							RevisionName: "config-00001", Percent: 10,
+						}},
+						StepParams: traffic.RolloutParams{
+							NextStepTime: fakeCurTime.Add(12 * time.Second).UnixNano(),
+							StepSize:     10,
+							StartTime:    fakeCurTime.Add(-time.Minute).UnixNano(),
+							StepDuration: int64(12 * time.Second),
+							Pauses:       11,
+						},
+					}},
+				},
+				withReadyIngress,
+			),
+		}, {
+			Object: simpleK8sService(
+				Route("default", "becomes-ready", WithConfigTarget("config"), withMaxErrorRate),
+			),
+		}},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: Route("default", "becomes-ready", WithConfigTarget("config"), withMaxErrorRate,
+				WithURL, WithAddress, WithRouteConditionsExternalDomainTLSDisabled,
+				WithRouteGeneration(2009), WithRouteObservedGeneration,
+				MarkTrafficAssigned, MarkInRollout, func(r *v1.Route) {
+					r.Status.Rollouts = []v1.RolloutStatus{{
+						ConfigurationName: "config",
+						RevisionName:      "config-00001",
+						Revisions: []v1.RolloutRevisionStatus{
+							{RevisionName: "config-00000", Percent: 90},
+							{RevisionName: "config-00001", Percent: 10},
+						},
+						StartTime:               rolloutTime(fakeCurTime.Add(-time.Minute)),
+						NextStepTime:            rolloutTime(fakeCurTime.Add(12 * time.Second)),
+						EstimatedCompletionTime: rolloutTime(fakeCurTime.Add(108 * time.Second)),
+					}}
+				}, WithStatusTraffic(
+					v1.TrafficTarget{
+						RevisionName:   "config-00000",
+						Percent:        ptr.Int64(90),
+						LatestRevision: ptr.Bool(true),
+					},
+					v1.TrafficTarget{
+						RevisionName:   "config-00001",
+						Percent:        ptr.Int64(10),
+						LatestRevision: ptr.Bool(true),
+					})),
+		}},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "Created", "Created placeholder service %q", "becomes-ready"),
+			Eventf(corev1.EventTypeWarning, "RolloutPaused", "Paused the rollout of revision %q: %s",
+				"config-00001", "no requests to analyze"),
+		},
+		Key: "default/becomes-ready",
+	}, {
+		Name: "route rollout plan resumed when approved",
+		Objects: []runtime.Object{
+			Route("default", "becomes-ready", WithConfigTarget("config"),
+				WithRouteGeneration(2009), MarkInRollout, withRolloutPlan),
+			cfg("default", "config",
+				WithConfigGeneration(1), WithLatestCreated("config-00001"), WithLatestReady("config-00001")),
+			rev("default", "config", 0, MarkRevisionReady, WithRevName("config-00000")),
+			rev("default", "config", 1, MarkRevisionReady, WithRevName("config-00001")),
+			simpleIngress(
+				Route("default", "becomes-ready", WithConfigTarget("config"), withRolloutPlan, WithURL),
+				&traffic.Config{
+					Targets: map[string]traffic.RevisionTargets{
+						traffic.DefaultTarget: {{
+							TrafficTarget: v1.TrafficTarget{
+								ConfigurationName: "config",
+								RevisionName:      "config-00001",
+								Percent:           ptr.Int64(100),
+								LatestRevision:    ptr.Bool(true),
+							},
+						}},
+					},
+				},
+				simpleRollout("config", []traffic.RevisionRollout{{
+					RevisionName: "config-00000", Percent: 90,
+				}, {
+					RevisionName: "config-00001", Percent: 10,
+				}}, fakeCurTime.Add(-3*time.Second),
+					withStepParams(traffic.RolloutParams{
+						StartTime:        fakeCurTime.Add(-time.Hour).UnixNano(),
+						Stages:           rolloutPlan,
+						Stage:            1,
+						AwaitingApproval: true,
+					})),
+				withReadyIngress,
+			),
+		},
+		WantCreates: []runtime.Object{
+			simplePlaceholderK8sService(getContext(), Route("default", "becomes-ready", WithConfigTarget("config"), withRolloutPlan), ""),
+		},
+		WantUpdates: []clientgotesting.UpdateActionImpl{{
+			// The rollout moves on to the next stage.
+			Object: ingressWithRollout(
+				Route("default", "becomes-ready", WithConfigTarget("config"), withRolloutPlan, WithURL),
+				&traffic.Config{
+					Targets: map[string]traffic.RevisionTargets{
+						traffic.DefaultTarget: {{
+							TrafficTarget: v1.TrafficTarget{
+								ConfigurationName: "config",
+								RevisionName:      "config-00001",
+								Percent:           ptr.Int64(100),
+								LatestRevision:    ptr.Bool(true),
+							},
+						}},
+					},
+				},
+				&traffic.Rollout{
+					Configurations: []*traffic.ConfigurationRollout{{
+						ConfigurationName: "config",
+						Percent:           100,
+						Revisions: []traffic.RevisionRollout{{
+							RevisionName: "config-00000", Percent: 50,
+						}, {
+							RevisionName: "config-00001", Percent: 50,
+						}},
+						StepParams: traffic.RolloutParams{
+							StartTime:    fakeCurTime.Add(-time.Hour).UnixNano(),
+							Stages:       rolloutPlan,
+							Stage:        2,
+							StepDuration: int64(time.Minute),
+							NextStepTime: fakeCurTime.Add(time.Minute).UnixNano(),
+						},
+					}},
+				},
+				withReadyIngress,
+			),
+		}, {
+			Object: simpleK8sService(
+				Route("default", "becomes-ready", WithConfigTarget("config"), withRolloutPlan),
+			),
+		}},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: Route("default", "becomes-ready", WithConfigTarget("config"), withRolloutPlan,
+				WithURL, WithAddress, WithRouteConditionsExternalDomainTLSDisabled,
+				WithRouteGeneration(2009), WithRouteObservedGeneration,
+				MarkTrafficAssigned, MarkInRollout, func(r *v1.Route) {
+					r.Status.Rollouts = []v1.RolloutStatus{{
+						ConfigurationName: "config",
+						RevisionName:      "config-00001",
+						Revisions: []v1.RolloutRevisionStatus{
+							{RevisionName: "config-00000", Percent: 50},
+							{RevisionName: "config-00001", Percent: 50},
+						},
+						StartTime:               rolloutTime(fakeCurTime.Add(-time.Hour)),
+						NextStepTime:            rolloutTime(fakeCurTime.Add(time.Minute)),
+						EstimatedCompletionTime: rolloutTime(fakeCurTime.Add(time.Minute)),
+						Stage:                   ptr.Int32(2),
+					}}
+				}, WithStatusTraffic(
+					v1.TrafficTarget{
+						RevisionName:   "config-00000",
+						Percent:        ptr.Int64(50),
+						LatestRevision: ptr.Bool(true),
+					},
+					v1.TrafficTarget{
+						RevisionName:   "config-00001",
+						Percent:        ptr.Int64(50),
+						LatestRevision: ptr.Bool(true),
+					})),
+		}},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "Created", "Created placeholder service %q", "becomes-ready"),
+			Eventf(corev1.EventTypeNormal, "RolloutStep", "Rolling out revision %q of configuration %q: %d%% of the traffic",
+				"config-00001", "config", 50),
 		},
 		Key: "default/becomes-ready",
 	}, {
@@ -924,7 +1300,18 @@ func TestReconcile(t *testing.T) {
 		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
 			Object: Route("default", "new-latest-ready", WithConfigTarget("config"),
 				WithURL, WithAddress, WithRouteConditionsExternalDomainTLSDisabled, WithRouteGeneration(1),
-				MarkTrafficAssigned, MarkInRollout, WithRouteObservedGeneration, WithRouteFinalizer, WithStatusTraffic(
+				MarkTrafficAssigned, MarkInRollout, WithRouteObservedGeneration, WithRouteFinalizer, func(r *v1.Route) {
+					// The first step is scheduled once the ingress is ready.
+					r.Status.Rollouts = []v1.RolloutStatus{{
+						ConfigurationName: "config",
+						RevisionName:      "config-00002",
+						Revisions: []v1.RolloutRevisionStatus{
+							{RevisionName: "config-00001", Percent: 99},
+							{RevisionName: "config-00002", Percent: 1},
+						},
+						StartTime: rolloutTime(fakeCurTime),
+					}}
+				}, WithStatusTraffic(
 					v1.TrafficTarget{
 						RevisionName:   "config-00001",
 						Percent:        ptr.Int64(99),
@@ -936,6 +1323,10 @@ func TestReconcile(t *testing.T) {
 						LatestRevision: ptr.Bool(true),
 					})),
 		}},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "RolloutStarted", "Started rolling out revision %q of configuration %q: %d%% of the traffic",
+				"config-00002", "config", 1),
+		},
 		Key: "default/new-latest-ready",
 	}, {
 		Name: "new latest ready revision, rollout disabled",
@@ -3387,6 +3778,9 @@ func NewTestReconciler(ctx context.Context, listers *Listers, cmw configmap.Watc
 	if v := ctx.Value(domainConfigKey); v != nil {
 		cfg.Domain = v.(*config.Domain)
 	}
+	if v := ctx.Value(analysisKey); v != nil {
+		r.analysis = v.(analysis.Provider)
+	}
 
 	return routereconciler.NewReconciler(ctx,
 		logging.FromContext(ctx),
@@ -28,7 +28,11 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/logging"
+	"knative.dev/pkg/ptr"
+	"knative.dev/serving/pkg/apis/serving"
+	v1 "knative.dev/serving/pkg/apis/serving/v1"
 )
 
 // Rollout encapsulates the current rollout state of the system.
@@ -64,8 +68,30 @@ type ConfigurationRollout struct {
 
 	// StepParams describes rollout params for the configuration.
 	StepParams RolloutParams `json:"stepParams"`
+
+	// RolledBack is the name of the latest revision, if its rollout was
+	// rolled back for failing the analysis gates. The revisions above keep
+	// receiving the traffic until a newer revision is rolled out.
+	RolledBack string `json:"rolledBack,omitempty"`
 }
 
+// GateDecision is the outcome of the analysis of a rollout step.
+type GateDecision int
+
+const (
+	// GateAdvance lets the rollout move on to the next step.
+	GateAdvance GateDecision = iota
+	// GatePause holds the rollout at the current step until the next analysis.
+	GatePause
+	// GateRollback moves all the traffic of the latest revision back to the
+	// previous one.
+	GateRollback
+)
+
+// Gate analyses the latest revision of the configuration rollout before each
+// step of the rollout.
+type Gate func(cr *ConfigurationRollout) GateDecision
+
 // RolloutParams contains the timing and sizing parameters for the
 // ConfigurationRollout.
 type RolloutParams struct {
@@ -84,6 +110,22 @@ type RolloutParams struct {
 
 	// How much traffic to move in a single step.
 	StepSize int `json:"stepSize,omitempty"`
+
+	// Stages is the rollout plan, when the rollout follows explicit stages
+	// rather than equal steps. StepDuration is then the duration of the
+	// current stage.
+	Stages []serving.RolloutStage `json:"stages,omitempty"`
+
+	// Stage is the index of the current stage of the rollout plan.
+	Stage int `json:"stage,omitempty"`
+
+	// AwaitingApproval is set when the rollout is paused at a pause stage
+	// of the rollout plan, until approved.
+	AwaitingApproval bool `json:"awaitingApproval,omitempty"`
+
+	// Pauses is the number of consecutive analyses which paused the rollout
+	// at the current step, for lack of stats.
+	Pauses int `json:"pauses,omitempty"`
 }
 
 // RevisionRollout describes the revision in the config rollout.
@@ -122,6 +164,99 @@ func (cur *Rollout) Done() bool {
 	return true
 }
 
+// RolledBackRevisions returns the names of the revisions whose rollout
+// was rolled back.
+func (cur *Rollout) RolledBackRevisions() []string {
+	var ret []string
+	for _, c := range cur.Configurations {
+		if c.RolledBack != "" {
+			ret = append(ret, c.RolledBack)
+		}
+	}
+	return ret
+}
+
+// Approve resumes the rollouts of the revision paused awaiting approval at
+// the given stage of their plan.
+func (cur *Rollout) Approve(rev string, stage int) {
+	for _, c := range cur.Configurations {
+		if p := &c.StepParams; p.AwaitingApproval && p.Stage == stage &&
+			!c.done() && c.Revisions[len(c.Revisions)-1].RevisionName == rev {
+			p.AwaitingApproval = false
+		}
+	}
+}
+
+// Status returns the progress of the configuration rollouts in flight.
+func (cur *Rollout) Status() []v1.RolloutStatus {
+	var ret []v1.RolloutStatus
+	for _, c := range cur.Configurations {
+		if c.done() {
+			continue
+		}
+		rs := v1.RolloutStatus{
+			ConfigurationName:       c.ConfigurationName,
+			Tag:                     c.Tag,
+			RevisionName:            c.Revisions[len(c.Revisions)-1].RevisionName,
+			Revisions:               make([]v1.RolloutRevisionStatus, 0, len(c.Revisions)),
+			StartTime:               statusTime(c.StepParams.StartTime),
+			NextStepTime:            statusTime(c.StepParams.NextStepTime),
+			EstimatedCompletionTime: statusTime(c.completionTime()),
+			AwaitingApproval:        c.StepParams.AwaitingApproval,
+		}
+		for _, r := range c.Revisions {
+			rs.Revisions = append(rs.Revisions, v1.RolloutRevisionStatus{
+				RevisionName: r.RevisionName,
+				Percent:      int64(r.Percent),
+			})
+		}
+		if len(c.StepParams.Stages) > 0 {
+			rs.Stage = ptr.Int32(int32(c.StepParams.Stage))
+		}
+		ret = append(ret, rs)
+	}
+	return ret
+}
+
+// completionTime returns the Unix timestamp in ns by when the rollout is
+// expected to complete, or 0 if it can't be estimated: before the rollout
+// has been scheduled, or when it is going to pause for approval.
+func (cur *ConfigurationRollout) completionTime() int64 {
+	p := cur.StepParams
+	if p.NextStepTime == 0 || p.AwaitingApproval {
+		return 0
+	}
+	if len(p.Stages) > 0 {
+		// The next step starts the next stage, and the rollout completes
+		// at the end of the last one.
+		ts := p.NextStepTime
+		for _, s := range p.Stages[p.Stage+1:] {
+			if s.Pause {
+				return 0
+			}
+			ts += int64(s.Duration)
+		}
+		return ts
+	}
+	if p.StepSize <= 0 {
+		return 0
+	}
+	// The next step is the first of the remaining ones.
+	remaining := cur.Percent - cur.Revisions[len(cur.Revisions)-1].Percent
+	steps := (remaining + p.StepSize - 1) / p.StepSize
+	return p.NextStepTime + int64(max(steps-1, 0))*p.StepDuration
+}
+
+// statusTime converts the Unix timestamp in ns to a status time. The time is
+// truncated to the second, which is the precision it is persisted with.
+func statusTime(ts int64) *metav1.Time {
+	if ts == 0 {
+		return nil
+	}
+	t := metav1.NewTime(time.Unix(0, ts).Truncate(time.Second))
+	return &t
+}
+
 // done returns true if there is no active rollout going on
 // for the configuration.
 func (cur *ConfigurationRollout) done() bool {
@@ -142,6 +277,10 @@ func (cur *Rollout) Validate() bool {
 		if c.StepParams.StepSize < 0 || c.StepParams.StepSize > c.Percent {
 			return false
 		}
+		// Ensure the current stage is in the plan.
+		if c.StepParams.Stage < 0 || (len(c.StepParams.Stages) > 0 && c.StepParams.Stage >= len(c.StepParams.Stages)) {
+			return false
+		}
 		// If total % values in the revision do not add up — discard.
 		tot := 0
 		for _, r := range c.Revisions {
@@ -158,10 +297,23 @@ func (cur *Rollout) Validate() bool {
 // but have not observed step time yet, will have it set, to
 // max(1, nowTS-cfg.StartTime).
 func (cur *Rollout) ObserveReady(ctx context.Context, nowTS int64, durationSecs float64) {
+	cur.ObserveReadyWithPlan(ctx, nowTS, durationSecs, nil)
+}
+
+// ObserveReadyWithPlan is like ObserveReady, but the configs follow the
+// stages of the plan, if not empty, rather than equal steps over the
+// duration. The plan is fixed for the whole rollout of a revision.
+func (cur *Rollout) ObserveReadyWithPlan(ctx context.Context, nowTS int64, durationSecs float64, plan []serving.RolloutStage) {
 	logger := logging.FromContext(ctx)
 	for i := range cur.Configurations {
 		c := cur.Configurations[i]
-		if c.StepParams.StepDuration == 0 && c.StepParams.StartTime > 0 {
+		if c.StepParams.StepDuration == 0 && c.StepParams.StartTime > 0 && len(c.StepParams.Stages) == 0 {
+			if len(plan) > 0 && !c.done() {
+				c.StepParams.Stages = plan
+				startStage(c, nowTS)
+				logger.Debugf("Started rollout plan for %s: %#v", c.ConfigurationName, c.StepParams)
+				continue
+			}
 			// In really ceil(nowTS-params.StartTime) should always give 1s, but
 			// given possible time drift, we'll ensure that at least 1s is returned.
 			minStepSec := math.Max(1, math.Ceil(time.Duration(nowTS-c.StepParams.StartTime).Seconds()))
@@ -181,6 +333,12 @@ func (cur *Rollout) ObserveReady(ctx context.Context, nowTS int64, durationSecs
 // Second return value is the Unix timestamp in ns of the closest
 // rollout action to take or 0, if no rollout is currently scheduled.
 func (cur *Rollout) Step(ctx context.Context, prev *Rollout, nowTS int64) (*Rollout, int64) {
+	return cur.StepGated(ctx, prev, nowTS, nil)
+}
+
+// StepGated is like Step, but consults the gate, if not nil, before moving
+// more traffic to the latest revision of a configuration.
+func (cur *Rollout) StepGated(ctx context.Context, prev *Rollout, nowTS int64, gate Gate) (*Rollout, int64) {
 	logger := logging.FromContext(ctx)
 	if prev == nil || len(prev.Configurations) == 0 {
 		logger.Debug("No previous Rollout to Step")
@@ -227,7 +385,7 @@ func (cur *Rollout) Step(ctx context.Context, prev *Rollout, nowTS int64) (*Roll
 				// altogether.
 				switch p := ccfgs[i].Percent; {
 				case p > 1:
-					sc := stepConfig(ccfgs[i], pcfgs[j], nowTS, logger)
+					sc := stepConfig(ccfgs[i], pcfgs[j], nowTS, gate, logger)
 					ret = append(ret, sc)
 					// Keep the minimum value if it is not 0.
 					if nst := sc.StepParams.NextStepTime; nst > 0 && nst < returnTS {
@@ -310,9 +468,62 @@ func stepRevisions(goal *ConfigurationRollout, nowTS int64) {
 	if nowTS < goal.StepParams.NextStepTime || len(goal.Revisions) < 2 {
 		return
 	}
+	if len(goal.StepParams.Stages) > 0 {
+		stepStages(goal, nowTS)
+		return
+	}
+
+	shiftTraffic(goal, goal.StepParams.StepSize)
+	// Also set the next time.
+	if len(goal.Revisions) > 1 {
+		goal.StepParams.NextStepTime = nowTS + goal.StepParams.StepDuration
+	} else {
+		// This is the last step, we're done! Clear the params out.
+		goal.StepParams = RolloutParams{}
+	}
+}
+
+// stepStages moves the rollout to the next stage of its plan, unless it is
+// awaiting approval.
+func stepStages(goal *ConfigurationRollout, nowTS int64) {
+	if goal.StepParams.AwaitingApproval {
+		return
+	}
+	goal.StepParams.Stage++
+	startStage(goal, nowTS)
+}
+
+// startStage shifts the traffic to the latest revision as required by the
+// current stage of the plan, and schedules the next one. Past the last
+// stage the latest revision receives all the traffic.
+func startStage(goal *ConfigurationRollout, nowTS int64) {
+	p := &goal.StepParams
+	if p.Stage >= len(p.Stages) {
+		shiftTraffic(goal, goal.Percent)
+		goal.StepParams = RolloutParams{}
+		return
+	}
+	stage := p.Stages[p.Stage]
+	if stage.Pause {
+		p.AwaitingApproval = true
+		p.StepDuration, p.NextStepTime = 0, 0
+		return
+	}
+	// The stage percent is the share of the configuration traffic, but at
+	// least the 1% the rollout started with.
+	share := max(1, stage.Percent*goal.Percent/100)
+	if d := share - goal.Revisions[len(goal.Revisions)-1].Percent; d > 0 {
+		shiftTraffic(goal, d)
+	}
+	p.StepDuration = int64(stage.Duration)
+	p.NextStepTime = nowTS + p.StepDuration
+}
 
+// shiftTraffic moves size percent of traffic from the older revisions to the
+// latest one, culling the revisions left without traffic.
+func shiftTraffic(goal *ConfigurationRollout, size int) {
 	revLen := len(goal.Revisions)
-	remaining := goal.StepParams.StepSize
+	remaining := size
 	writePos := revLen - 1
 	// readPos is guaranteed to be >= 0, due to the check above.
 	readPos := revLen - 2
@@ -342,7 +553,7 @@ func stepRevisions(goal *ConfigurationRollout, nowTS int64) {
 	// Copy the last one to the write pos
 	goal.Revisions[writePos] = goal.Revisions[revLen-1]
 
-	goal.Revisions[writePos].Percent += goal.StepParams.StepSize
+	goal.Revisions[writePos].Percent += size
 	// This can happen if step is now larger than total allocation, see the
 	// note above.
 	// E.g. with example above R2 = 20, and ro we have to cap it at 15.
@@ -351,18 +562,72 @@ func stepRevisions(goal *ConfigurationRollout, nowTS int64) {
 	}
 	// And cull the tail portion of it.
 	goal.Revisions = goal.Revisions[:writePos+1]
-	// Also set the next time.
-	if len(goal.Revisions) > 1 {
-		goal.StepParams.NextStepTime = nowTS + goal.StepParams.StepDuration
-	} else {
-		// This is the last step, we're done! Clear the params out.
-		goal.StepParams = RolloutParams{}
+}
+
+// rollBack moves the traffic of the latest revision to the one before it,
+// ending the rollout.
+func rollBack(cr *ConfigurationRollout) {
+	last := len(cr.Revisions) - 1
+	cr.Revisions[last-1].Percent += cr.Revisions[last].Percent
+	cr.RolledBack = cr.Revisions[last].RevisionName
+	cr.Revisions = cr.Revisions[:last]
+	cr.StepParams = RolloutParams{}
+}
+
+// gateStep consults the gate before stepping the revisions of the rollout.
+func gateStep(cr *ConfigurationRollout, nowTS int64, gate Gate, logger *zap.SugaredLogger) {
+	p := &cr.StepParams
+	if gate != nil && len(p.Stages) > 0 && p.NextStepTime == 0 && !p.AwaitingApproval {
+		// The pause stage has just been approved: analyze the traffic held
+		// at the previous stage before moving on.
+		logger.Infof("Analyzing the approved rollout of config %s", cr.ConfigurationName)
+		p.StepDuration = approvedStageDuration(p)
+		p.NextStepTime = nowTS + p.StepDuration
+		return
+	}
+	if gate == nil || nowTS < p.NextStepTime || p.AwaitingApproval {
+		stepRevisions(cr, nowTS)
+		return
+	}
+	switch gate(cr) {
+	case GatePause:
+		logger.Infof("Pausing the rollout of config %s", cr.ConfigurationName)
+		p.Pauses++
+		p.NextStepTime = nowTS + p.StepDuration
+	case GateRollback:
+		logger.Infof("Rolling back revision %s of config %s",
+			cr.Revisions[len(cr.Revisions)-1].RevisionName, cr.ConfigurationName)
+		rollBack(cr)
+	default:
+		p.Pauses = 0
+		stepRevisions(cr, nowTS)
 	}
 }
 
+// approvedStageDuration returns the duration of the analysis of the rollout
+// once its pause stage is approved: that of the closest stage, preferring
+// the stages before the pause.
+func approvedStageDuration(p *RolloutParams) int64 {
+	for i := p.Stage - 1; i >= 0; i-- {
+		if s := p.Stages[i]; !s.Pause && s.Duration > 0 {
+			return int64(s.Duration)
+		}
+	}
+	for _, s := range p.Stages[p.Stage+1:] {
+		if !s.Pause && s.Duration > 0 {
+			return int64(s.Duration)
+		}
+	}
+	return int64(defaultApprovedStageDuration)
+}
+
+// defaultApprovedStageDuration is the duration of the analysis of an
+// approved rollout whose plan has no stage with a duration.
+const defaultApprovedStageDuration = time.Minute
+
 // stepConfig takes previous and goal configuration shapes and returns a new
 // config rollout, after computing the percentage allocations.
-func stepConfig(goal, prev *ConfigurationRollout, nowTS int64, logger *zap.SugaredLogger) *ConfigurationRollout {
+func stepConfig(goal, prev *ConfigurationRollout, nowTS int64, gate Gate, logger *zap.SugaredLogger) *ConfigurationRollout {
 	pc := len(prev.Revisions)
 	ret := &ConfigurationRollout{
 		ConfigurationName: goal.ConfigurationName,
@@ -377,6 +642,13 @@ func stepConfig(goal, prev *ConfigurationRollout, nowTS int64, logger *zap.Sugar
 	if len(prev.Revisions) > 0 {
 		adjustPercentage(goal.Percent, prev, logger)
 	}
+	// A rolled back revision is not rolled out again, until a newer one
+	// is created.
+	if prev.RolledBack != "" && goal.Revisions[0].RevisionName == prev.RolledBack {
+		ret.Revisions = prev.Revisions
+		ret.RolledBack = prev.RolledBack
+		return ret
+	}
 	// goal will always have just one revision in the list – the current desired revision.
 	// If it matches the last revision of the previous rollout state (or there were no revisions)
 	// then no new rollout has begun for this configuration.
@@ -396,11 +668,11 @@ func stepConfig(goal, prev *ConfigurationRollout, nowTS int64, logger *zap.Sugar
 			ret.StepParams = prev.StepParams
 			// We might end up here before `ObserveReady` is called.
 			// In that case don't step individual revisions just yet.
-			if ret.StepParams.StepSize > 0 {
+			if ret.StepParams.StepSize > 0 || len(ret.StepParams.Stages) > 0 {
 				// adjustPercentage above would've already accounted if target for the
 				// whole Configuration changed up or down. So here we should just redistribute
 				// the existing values.
-				stepRevisions(ret, nowTS)
+				gateStep(ret, nowTS, gate, logger)
 			}
 		}
 		return ret
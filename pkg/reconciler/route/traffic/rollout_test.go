@@ -28,8 +28,12 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	. "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/ptr"
+	"knative.dev/serving/pkg/apis/serving"
+	v1 "knative.dev/serving/pkg/apis/serving/v1"
 )
 
 func TestStep(t *testing.T) {
@@ -1007,6 +1011,429 @@ func TestStep(t *testing.T) {
 	}
 }
 
+func TestStepGated(t *testing.T) {
+	const now = 2020
+	rollout := func(ro RolloutParams, rolledBack string, revs ...RevisionRollout) *Rollout {
+		return &Rollout{
+			Configurations: []*ConfigurationRollout{{
+				ConfigurationName: "mick",
+				Percent:           100,
+				Revisions:         revs,
+				StepParams:        ro,
+				RolledBack:        rolledBack,
+			}},
+		}
+	}
+	inProgress := RolloutParams{
+		StartTime:    1000,
+		NextStepTime: 2000,
+		StepDuration: 100,
+		StepSize:     10,
+	}
+	tests := []struct {
+		name         string
+		decision     GateDecision
+		prev, cur    *Rollout
+		want         *Rollout
+		wantNextStep int64
+		wantGated    bool
+	}{{
+		name:     "advance",
+		decision: GateAdvance,
+		cur:      rollout(RolloutParams{}, "", RevisionRollout{RevisionName: "sticky-fingers", Percent: 100}),
+		prev: rollout(inProgress, "",
+			RevisionRollout{RevisionName: "let-it-bleed", Percent: 90},
+			RevisionRollout{RevisionName: "sticky-fingers", Percent: 10}),
+		want: rollout(RolloutParams{StartTime: 1000, NextStepTime: 2120, StepDuration: 100, StepSize: 10}, "",
+			RevisionRollout{RevisionName: "let-it-bleed", Percent: 80},
+			RevisionRollout{RevisionName: "sticky-fingers", Percent: 20}),
+		wantNextStep: 2120,
+		wantGated:    true,
+	}, {
+		name:     "pause",
+		decision: GatePause,
+		cur:      rollout(RolloutParams{}, "", RevisionRollout{RevisionName: "sticky-fingers", Percent: 100}),
+		prev: rollout(inProgress, "",
+			RevisionRollout{RevisionName: "let-it-bleed", Percent: 90},
+			RevisionRollout{RevisionName: "sticky-fingers", Percent: 10}),
+		want: rollout(RolloutParams{StartTime: 1000, NextStepTime: 2120, StepDuration: 100, StepSize: 10, Pauses: 1}, "",
+			RevisionRollout{RevisionName: "let-it-bleed", Percent: 90},
+			RevisionRollout{RevisionName: "sticky-fingers", Percent: 10}),
+		wantNextStep: 2120,
+		wantGated:    true,
+	}, {
+		name:     "advance after pauses",
+		decision: GateAdvance,
+		cur:      rollout(RolloutParams{}, "", RevisionRollout{RevisionName: "sticky-fingers", Percent: 100}),
+		prev: rollout(RolloutParams{StartTime: 1000, NextStepTime: 2000, StepDuration: 100, StepSize: 10, Pauses: 3}, "",
+			RevisionRollout{RevisionName: "let-it-bleed", Percent: 90},
+			RevisionRollout{RevisionName: "sticky-fingers", Percent: 10}),
+		want: rollout(RolloutParams{StartTime: 1000, NextStepTime: 2120, StepDuration: 100, StepSize: 10}, "",
+			RevisionRollout{RevisionName: "let-it-bleed", Percent: 80},
+			RevisionRollout{RevisionName: "sticky-fingers", Percent: 20}),
+		wantNextStep: 2120,
+		wantGated:    true,
+	}, {
+		name:     "roll back",
+		decision: GateRollback,
+		cur:      rollout(RolloutParams{}, "", RevisionRollout{RevisionName: "sticky-fingers", Percent: 100}),
+		prev: rollout(inProgress, "",
+			RevisionRollout{RevisionName: "beggars-banquet", Percent: 40},
+			RevisionRollout{RevisionName: "let-it-bleed", Percent: 50},
+			RevisionRollout{RevisionName: "sticky-fingers", Percent: 10}),
+		want: rollout(RolloutParams{}, "sticky-fingers",
+			RevisionRollout{RevisionName: "beggars-banquet", Percent: 40},
+			RevisionRollout{RevisionName: "let-it-bleed", Percent: 60}),
+		wantGated: true,
+	}, {
+		name:     "too soon to gate",
+		decision: GateRollback,
+		cur:      rollout(RolloutParams{}, "", RevisionRollout{RevisionName: "sticky-fingers", Percent: 100}),
+		prev: rollout(RolloutParams{StartTime: 1000, NextStepTime: 2100, StepDuration: 100, StepSize: 10}, "",
+			RevisionRollout{RevisionName: "let-it-bleed", Percent: 90},
+			RevisionRollout{RevisionName: "sticky-fingers", Percent: 10}),
+		want: rollout(RolloutParams{StartTime: 1000, NextStepTime: 2100, StepDuration: 100, StepSize: 10}, "",
+			RevisionRollout{RevisionName: "let-it-bleed", Percent: 90},
+			RevisionRollout{RevisionName: "sticky-fingers", Percent: 10}),
+		wantNextStep: 2100,
+	}, {
+		name:     "rolled back stays rolled back",
+		decision: GateAdvance,
+		cur:      rollout(RolloutParams{}, "", RevisionRollout{RevisionName: "sticky-fingers", Percent: 100}),
+		prev: rollout(RolloutParams{}, "sticky-fingers",
+			RevisionRollout{RevisionName: "let-it-bleed", Percent: 100}),
+		want: rollout(RolloutParams{}, "sticky-fingers",
+			RevisionRollout{RevisionName: "let-it-bleed", Percent: 100}),
+	}, {
+		name:     "newer revision after roll back",
+		decision: GateAdvance,
+		cur:      rollout(RolloutParams{}, "", RevisionRollout{RevisionName: "exile-on-main-st", Percent: 100}),
+		prev: rollout(RolloutParams{}, "sticky-fingers",
+			RevisionRollout{RevisionName: "let-it-bleed", Percent: 100}),
+		want: rollout(RolloutParams{StartTime: now}, "",
+			RevisionRollout{RevisionName: "let-it-bleed", Percent: 99},
+			RevisionRollout{RevisionName: "exile-on-main-st", Percent: 1}),
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gated := false
+			gate := func(cr *ConfigurationRollout) GateDecision {
+				gated = true
+				if got, want := cr.Revisions[len(cr.Revisions)-1].RevisionName, "sticky-fingers"; got != want {
+					t.Errorf("Gated revision = %s, want: %s", got, want)
+				}
+				return tc.decision
+			}
+			got, gotNS := tc.cur.StepGated(TestContextWithLogger(t), tc.prev, now, gate)
+			if want := tc.want; !cmp.Equal(got, want, cmpopts.EquateEmpty()) {
+				t.Errorf("Wrong rolled rollout, diff(-want,+got):\n%s", cmp.Diff(want, got))
+			}
+			if !got.Validate() {
+				t.Errorf("StepGated returned an invalid config:\n%#v", got)
+			}
+			if got, want := gotNS, tc.wantNextStep; got != want {
+				t.Errorf("Incorrect NextStepTime = %d, want: %d", got, want)
+			}
+			if gated != tc.wantGated {
+				t.Errorf("Gated = %v, want: %v", gated, tc.wantGated)
+			}
+		})
+	}
+}
+
+func TestRolloutPlan(t *testing.T) {
+	ctx := TestContextWithLogger(t)
+	plan := []serving.RolloutStage{
+		{Percent: 10, Duration: 100},
+		{Pause: true},
+		{Percent: 50, Duration: 200},
+	}
+	cur := &Rollout{
+		Configurations: []*ConfigurationRollout{{
+			ConfigurationName: "ronnie",
+			Percent:           80,
+			Revisions:         []RevisionRollout{{RevisionName: "some-girls", Percent: 80}},
+		}},
+	}
+	// The rollout of some-girls has started, with 1% of the traffic.
+	ro := &Rollout{
+		Configurations: []*ConfigurationRollout{{
+			ConfigurationName: "ronnie",
+			Percent:           80,
+			Revisions: []RevisionRollout{
+				{RevisionName: "black-and-blue", Percent: 79},
+				{RevisionName: "some-girls", Percent: 1},
+			},
+			StepParams: RolloutParams{StartTime: 1000},
+		}},
+	}
+	check := func(step string, wantRevs []RevisionRollout, wantStatus []v1.RolloutStatus) {
+		t.Helper()
+		if !ro.Validate() {
+			t.Errorf("%s: invalid rollout:\n%#v", step, ro)
+		}
+		if got := ro.Configurations[0].Revisions; !cmp.Equal(got, wantRevs) {
+			t.Errorf("%s: revisions diff(-want,+got):\n%s", step, cmp.Diff(wantRevs, got))
+		}
+		// The progress of the traffic and the times are covered by TestRolloutStatus.
+		ignore := cmpopts.IgnoreFields(v1.RolloutStatus{}, "Revisions", "StartTime", "NextStepTime", "EstimatedCompletionTime")
+		if got := ro.Status(); !cmp.Equal(got, wantStatus, ignore) {
+			t.Errorf("%s: status diff(-want,+got):\n%s", step, cmp.Diff(wantStatus, got, ignore))
+		}
+	}
+	status := func(stage int32, paused bool) []v1.RolloutStatus {
+		return []v1.RolloutStatus{{
+			ConfigurationName: "ronnie",
+			RevisionName:      "some-girls",
+			Stage:             ptr.Int32(stage),
+			AwaitingApproval:  paused,
+		}}
+	}
+
+	// The first stage starts when the ingress is ready.
+	ro.ObserveReadyWithPlan(ctx, 2000, 0, plan)
+	check("stage 0", []RevisionRollout{
+		{RevisionName: "black-and-blue", Percent: 72},
+		{RevisionName: "some-girls", Percent: 8},
+	}, status(0, false))
+	// Observing again doesn't restart the plan.
+	ro.ObserveReadyWithPlan(ctx, 2050, 0, plan)
+
+	var next int64
+	ro, next = cur.Step(ctx, ro, 2050)
+	if next != 2100 {
+		t.Errorf("stage 0: next step = %d, want: 2100", next)
+	}
+	ro, next = cur.Step(ctx, ro, 2100)
+	check("stage 1", []RevisionRollout{
+		{RevisionName: "black-and-blue", Percent: 72},
+		{RevisionName: "some-girls", Percent: 8},
+	}, status(1, true))
+	if next != 0 {
+		t.Errorf("stage 1: next step = %d, want: 0", next)
+	}
+
+	// Approvals of other stages or revisions don't resume the rollout.
+	ro.Approve("some-girls", 0)
+	ro.Approve("tattoo-you", 1)
+	ro, _ = cur.Step(ctx, ro, 5000)
+	check("stage 1, not approved", []RevisionRollout{
+		{RevisionName: "black-and-blue", Percent: 72},
+		{RevisionName: "some-girls", Percent: 8},
+	}, status(1, true))
+
+	ro.Approve("some-girls", 1)
+	ro, next = cur.Step(ctx, ro, 6000)
+	check("stage 2", []RevisionRollout{
+		{RevisionName: "black-and-blue", Percent: 40},
+		{RevisionName: "some-girls", Percent: 40},
+	}, status(2, false))
+	if next != 6200 {
+		t.Errorf("stage 2: next step = %d, want: 6200", next)
+	}
+
+	// After the last stage the revision gets all the traffic.
+	ro, next = cur.Step(ctx, ro, 6200)
+	check("done", []RevisionRollout{{RevisionName: "some-girls", Percent: 80}}, nil)
+	if next != 0 || ro.Configurations[0].StepParams.Stages != nil {
+		t.Errorf("done: next step = %d, params = %#v, want cleared", next, ro.Configurations[0].StepParams)
+	}
+}
+
+func TestRolloutPlanGatedApproval(t *testing.T) {
+	ctx := TestContextWithLogger(t)
+	plan := []serving.RolloutStage{
+		{Percent: 10, Duration: 100},
+		{Pause: true},
+		{Percent: 50, Duration: 200},
+	}
+	cur := &Rollout{
+		Configurations: []*ConfigurationRollout{{
+			ConfigurationName: "ronnie",
+			Percent:           100,
+			Revisions:         []RevisionRollout{{RevisionName: "some-girls", Percent: 100}},
+		}},
+	}
+	// The rollout of some-girls is paused at the pause stage.
+	ro := &Rollout{
+		Configurations: []*ConfigurationRollout{{
+			ConfigurationName: "ronnie",
+			Percent:           100,
+			Revisions: []RevisionRollout{
+				{RevisionName: "black-and-blue", Percent: 90},
+				{RevisionName: "some-girls", Percent: 10},
+			},
+			StepParams: RolloutParams{StartTime: 1000, Stages: plan, Stage: 1, AwaitingApproval: true},
+		}},
+	}
+	decision := GatePause
+	var windows [][2]int64
+	gate := func(cr *ConfigurationRollout) GateDecision {
+		p := cr.StepParams
+		windows = append(windows, [2]int64{p.NextStepTime - p.StepDuration, p.NextStepTime})
+		return decision
+	}
+
+	// Once approved, the traffic held at the previous stage is analyzed
+	// over its duration rather than right away.
+	ro.Approve("some-girls", 1)
+	ro, next := cur.StepGated(ctx, ro, 5000, gate)
+	if next != 5100 {
+		t.Errorf("Approved: next step = %d, want: 5100", next)
+	}
+	if len(windows) != 0 {
+		t.Errorf("Approved: gated over %v, want not gated", windows)
+	}
+
+	// Without data the gate pauses for another window, never right away.
+	ro, next = cur.StepGated(ctx, ro, 5100, gate)
+	if next != 5200 {
+		t.Errorf("Paused: next step = %d, want: 5200", next)
+	}
+	if want := [][2]int64{{5000, 5100}}; !cmp.Equal(windows, want) {
+		t.Errorf("Paused: gated windows = %v, want: %v", windows, want)
+	}
+
+	decision = GateAdvance
+	ro, next = cur.StepGated(ctx, ro, 5200, gate)
+	if got, want := ro.Configurations[0].Revisions, []RevisionRollout{
+		{RevisionName: "black-and-blue", Percent: 50},
+		{RevisionName: "some-girls", Percent: 50},
+	}; !cmp.Equal(got, want) {
+		t.Errorf("Advanced: revisions diff(-want,+got):\n%s", cmp.Diff(want, got))
+	}
+	if next != 5400 {
+		t.Errorf("Advanced: next step = %d, want: 5400", next)
+	}
+}
+
+func TestRolloutStatus(t *testing.T) {
+	sec := func(s int64) int64 { return s * int64(time.Second) }
+	at := func(s int64) *metav1.Time {
+		t := metav1.NewTime(time.Unix(s, 0))
+		return &t
+	}
+	revisions := []RevisionRollout{
+		{RevisionName: "beggars-banquet", Percent: 74},
+		{RevisionName: "let-it-bleed", Percent: 26},
+	}
+	revisionsStatus := []v1.RolloutRevisionStatus{
+		{RevisionName: "beggars-banquet", Percent: 74},
+		{RevisionName: "let-it-bleed", Percent: 26},
+	}
+	plan := []serving.RolloutStage{
+		{Percent: 10, Duration: 100 * time.Second},
+		{Percent: 50, Duration: 200 * time.Second},
+	}
+
+	tests := []struct {
+		name   string
+		params RolloutParams
+		want   v1.RolloutStatus
+	}{{
+		name: "awaiting ingress",
+		params: RolloutParams{
+			StartTime:    sec(10),
+			StepDuration: sec(60),
+			StepSize:     25,
+		},
+		want: v1.RolloutStatus{StartTime: at(10)},
+	}, {
+		name: "steps",
+		params: RolloutParams{
+			StartTime:    sec(10) + 500,
+			NextStepTime: sec(70) + 500,
+			StepDuration: sec(60),
+			StepSize:     25,
+		},
+		// 3 more steps to go.
+		want: v1.RolloutStatus{
+			StartTime:               at(10),
+			NextStepTime:            at(70),
+			EstimatedCompletionTime: at(190),
+		},
+	}, {
+		name: "plan",
+		params: RolloutParams{
+			StartTime:    sec(10),
+			NextStepTime: sec(110),
+			StepDuration: sec(100),
+			Stages:       plan,
+		},
+		want: v1.RolloutStatus{
+			StartTime:               at(10),
+			NextStepTime:            at(110),
+			EstimatedCompletionTime: at(310),
+			Stage:                   ptr.Int32(0),
+		},
+	}, {
+		name: "plan pausing",
+		params: RolloutParams{
+			StartTime:    sec(10),
+			NextStepTime: sec(110),
+			StepDuration: sec(100),
+			Stages:       append([]serving.RolloutStage{plan[0], {Pause: true}}, plan[1:]...),
+		},
+		want: v1.RolloutStatus{
+			StartTime:    at(10),
+			NextStepTime: at(110),
+			Stage:        ptr.Int32(0),
+		},
+	}, {
+		name: "awaiting approval",
+		params: RolloutParams{
+			StartTime:        sec(10),
+			Stages:           append([]serving.RolloutStage{plan[0], {Pause: true}}, plan[1:]...),
+			Stage:            1,
+			AwaitingApproval: true,
+		},
+		want: v1.RolloutStatus{
+			StartTime:        at(10),
+			Stage:            ptr.Int32(1),
+			AwaitingApproval: true,
+		},
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ro := &Rollout{
+				Configurations: []*ConfigurationRollout{{
+					ConfigurationName: "mick",
+					Percent:           100,
+					Revisions:         []RevisionRollout{{RevisionName: "sticky-fingers", Percent: 100}},
+				}, {
+					ConfigurationName: "keith",
+					Tag:               "stones",
+					Percent:           100,
+					Revisions:         revisions,
+					StepParams:        tc.params,
+				}},
+			}
+			want := tc.want
+			want.ConfigurationName, want.Tag = "keith", "stones"
+			want.RevisionName, want.Revisions = "let-it-bleed", revisionsStatus
+			if got := ro.Status(); !cmp.Equal(got, []v1.RolloutStatus{want}) {
+				t.Errorf("Status diff(-want,+got):\n%s", cmp.Diff([]v1.RolloutStatus{want}, got))
+			}
+		})
+	}
+}
+
+func TestRolledBackRevisions(t *testing.T) {
+	ro := &Rollout{
+		Configurations: []*ConfigurationRollout{{
+			ConfigurationName: "keith",
+			RolledBack:        "sticky-fingers",
+		}, {
+			ConfigurationName: "mick",
+		}},
+	}
+	if got, want := ro.RolledBackRevisions(), []string{"sticky-fingers"}; !cmp.Equal(got, want) {
+		t.Errorf("RolledBackRevisions() = %v, want: %v", got, want)
+	}
+}
+
 func TestObserveReady(t *testing.T) {
 	const (
 		now         = 200620092020 + 1982
@@ -60,6 +60,10 @@ type Config struct {
 	// Visibility of the traffic targets.
 	Visibility map[string]netv1alpha1.IngressVisibility
 
+	// Mirror is the shadow target the requests of the default target are
+	// duplicated to, if any.  It is not part of the default target.
+	Mirror *RevisionTarget
+
 	// A list traffic targets, flattened to the Revision level.  This
 	// is used to populate the Route.Status.TrafficTarget field.
 	revisionTargets RevisionTargets
@@ -144,6 +148,12 @@ func (cfg *Config) targetToStatus(ctx context.Context, r *v1.Route, tt *Revision
 		if tt.Tag != "" {
 			result.URL = url
 		}
+		// The matching requests are routed to the target revision only,
+		// even during its rollout.
+		if rr.RevisionName == tt.RevisionName {
+			result.Match = tt.Match
+		}
+		result.Mirror = tt.Mirror
 		results = append(results, result)
 	}
 	return results, nil
@@ -160,7 +170,8 @@ func (cfg *Config) GetRevisionTrafficTargets(ctx context.Context, r *v1.Route, r
 			err   error
 		)
 
-		if tt.LatestRevision != nil && *tt.LatestRevision {
+		// The mirror target follows the latest revision without a rollout.
+		if tt.Mirror == nil && tt.LatestRevision != nil && *tt.LatestRevision {
 			cfgs := ro.RolloutsByTag(tt.Tag)
 			roCfg = rolloutConfig(tt.ConfigurationName, cfgs)
 		}
@@ -194,6 +205,9 @@ type configBuilder struct {
 	// revisionTargets is the original list of targets, at the Revision level.
 	revisionTargets RevisionTargets
 
+	// mirror is the shadow target, if any.
+	mirror *RevisionTarget
+
 	// configurations contains all the referred Configuration, keyed by their name.
 	configurations map[string]*v1.Configuration
 	// revisions contains all the referred Revision, keyed by their name.
@@ -418,6 +432,7 @@ func mergeIfNecessary(rts RevisionTargets, rt RevisionTarget) RevisionTargets {
 		if rts[i].Tag == rt.Tag && rts[i].RevisionName == rt.RevisionName &&
 			*rt.LatestRevision == *rts[i].LatestRevision {
 			rts[i].Percent = ptr.Int64(*rts[i].Percent + *rt.Percent)
+			rts[i].Match = append(rts[i].Match, rt.Match...)
 			return rts
 		}
 	}
@@ -426,6 +441,15 @@ func mergeIfNecessary(rts RevisionTargets, rt RevisionTarget) RevisionTargets {
 
 func (cb *configBuilder) addFlattenedTarget(target RevisionTarget) {
 	name := target.TrafficTarget.Tag
+	if target.Mirror != nil {
+		// The shadow is only reachable through its tag.
+		cb.mirror = &target
+		cb.revisionTargets = append(cb.revisionTargets, target)
+		if name != "" {
+			cb.targets[name] = append(cb.targets[name], target)
+		}
+		return
+	}
 	cb.revisionTargets = mergeIfNecessary(cb.revisionTargets, target)
 	cb.targets[DefaultTarget] = append(cb.targets[DefaultTarget], target)
 	if name != "" {
@@ -441,9 +465,11 @@ func (cb *configBuilder) build() (*Config, error) {
 	if cb.deferredTargetErr != nil {
 		cb.targets = nil
 		cb.revisionTargets = nil
+		cb.mirror = nil
 	}
 	return &Config{
 		Targets:         consolidateAll(cb.targets),
+		Mirror:          cb.mirror,
 		revisionTargets: cb.revisionTargets,
 		Configurations:  cb.configurations,
 		Revisions:       cb.revisions,
@@ -472,6 +498,7 @@ func consolidate(targets RevisionTargets) RevisionTargets {
 		}
 		cur.TrafficTarget.Percent = ptr.Int64(
 			*cur.TrafficTarget.Percent + *tt.TrafficTarget.Percent)
+		cur.TrafficTarget.Match = append(cur.TrafficTarget.Match, tt.TrafficTarget.Match...)
 		byName[name] = cur
 	}
 	consolidated := make([]RevisionTarget, len(names))
@@ -1416,6 +1416,88 @@ func TestRoundTripping(t *testing.T) {
 	}
 }
 
+func TestRoundTrippingWithMatch(t *testing.T) {
+	match := []v1.TrafficMatch{{
+		Header: "x-user-group",
+		Value:  "beta",
+	}}
+	expected := []v1.TrafficTarget{{
+		RevisionName:   goodOldRev.Name,
+		Percent:        ptr.Int64(100),
+		LatestRevision: ptr.Bool(false),
+	}, {
+		Tag:            "beta",
+		RevisionName:   goodNewRev.Name,
+		URL:            domains.URL(domains.HTTPScheme, "beta-test-route.test.example.com"),
+		LatestRevision: ptr.Bool(false),
+		Percent:        ptr.Int64(0),
+		Match:          match,
+	}}
+	route := testRouteWithTrafficTargets(WithSpecTraffic(v1.TrafficTarget{
+		RevisionName: goodOldRev.Name,
+		Percent:      ptr.Int64(100),
+	}, v1.TrafficTarget{
+		Tag:          "beta",
+		RevisionName: goodNewRev.Name,
+		Match:        match,
+	}))
+	tc, err := BuildTrafficConfiguration(configLister, revLister, route)
+	if err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+	if got, want := tc.Targets[DefaultTarget][1].Match, match; !cmp.Equal(want, got) {
+		t.Errorf("Unexpected match rules (-want +got):\n%s", cmp.Diff(want, got))
+	}
+	targets, err := tc.GetRevisionTrafficTargets(getContext(), route, &Rollout{})
+	if err != nil {
+		t.Error("Unexpected error:", err)
+	}
+	if got, want := targets, expected; !cmp.Equal(want, got) {
+		t.Errorf("Unexpected traffic diff (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestRoundTrippingWithMirror(t *testing.T) {
+	expected := []v1.TrafficTarget{{
+		RevisionName:   goodOldRev.Name,
+		Percent:        ptr.Int64(100),
+		LatestRevision: ptr.Bool(false),
+	}, {
+		Tag:            "shadow",
+		RevisionName:   niceNewRev.Name,
+		URL:            domains.URL(domains.HTTPScheme, "shadow-test-route.test.example.com"),
+		LatestRevision: ptr.Bool(true),
+		Percent:        ptr.Int64(0),
+		Mirror:         ptr.Int64(10),
+	}}
+	route := testRouteWithTrafficTargets(WithSpecTraffic(v1.TrafficTarget{
+		RevisionName: goodOldRev.Name,
+		Percent:      ptr.Int64(100),
+	}, v1.TrafficTarget{
+		Tag:               "shadow",
+		ConfigurationName: niceConfig.Name,
+		Mirror:            ptr.Int64(10),
+	}))
+	tc, err := BuildTrafficConfiguration(configLister, revLister, route)
+	if err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+	// The shadow receives no traffic of the default target.
+	if got, want := len(tc.Targets[DefaultTarget]), 1; got != want {
+		t.Errorf("len(Targets[DefaultTarget]) = %d, want: %d", got, want)
+	}
+	if tc.Mirror == nil || tc.Mirror.RevisionName != niceNewRev.Name {
+		t.Errorf("Mirror = %v, want revision %s", tc.Mirror, niceNewRev.Name)
+	}
+	targets, err := tc.GetRevisionTrafficTargets(getContext(), route, tc.BuildRollout())
+	if err != nil {
+		t.Error("Unexpected error:", err)
+	}
+	if got, want := targets, expected; !cmp.Equal(want, got) {
+		t.Errorf("Unexpected traffic diff (-want +got):\n%s", cmp.Diff(want, got))
+	}
+}
+
 func TestRoundTrippingWithRollout(t *testing.T) {
 	expected := []v1.TrafficTarget{{
 		RevisionName:   "older-rev",
@@ -78,6 +78,14 @@ func WithLatestReady(name string) ConfigOption {
 	}
 }
 
+// WithRevisionsToCollect sets the .status.revisionsToCollect reported by the
+// garbage collector in dry-run mode.
+func WithRevisionsToCollect(names ...string) ConfigOption {
+	return func(cfg *v1.Configuration) {
+		cfg.Status.RevisionsToCollect = names
+	}
+}
+
 // MarkRevisionCreationFailed calls .Status.MarkRevisionCreationFailed.
 func MarkRevisionCreationFailed(msg string) ConfigOption {
 	return func(cfg *v1.Configuration) {